@@ -19,6 +19,42 @@ type Admin struct {
 	FechaRegistro pgtype.Timestamptz
 }
 
+type AuditoriaEvento struct {
+	EventoID   int32
+	AdminID    pgtype.Int4
+	Actor      string
+	Metodo     string
+	Ruta       string
+	Entidad    string
+	EntidadID  pgtype.Int4
+	EstadoHttp int32
+	Antes      []byte
+	Despues    []byte
+	Ip         string
+	Fecha      pgtype.Timestamp
+}
+
+type CalendarioToken struct {
+	TipoUsuario   string
+	UsuarioID     int32
+	Token         string
+	FechaCreacion pgtype.Timestamp
+}
+
+type Certificado struct {
+	CertificadoID int32
+	Codigo        string
+	TutorID       pgtype.Int4
+	TutorNombre   string
+	Desde         pgtype.Date
+	Hasta         pgtype.Date
+	TotalTutorias int32
+	TotalMinutos  int32
+	Detalle       []byte
+	Firma         string
+	FechaEmision  pgtype.Timestamp
+}
+
 type Desempenotutore struct {
 	TutorID              int32
 	Tutor                interface{}
@@ -46,6 +82,15 @@ type Estudiante struct {
 	Semestre          pgtype.Int4
 	FechaRegistro     pgtype.Timestamp
 	Ti                pgtype.Int4
+	DeletedAt         pgtype.Timestamp
+}
+
+type Inscripcione struct {
+	InscripcionID    int32
+	EstudianteID     int32
+	MateriaID        int32
+	Periodo          string
+	FechaInscripcion pgtype.Timestamp
 }
 
 type Materia struct {
@@ -55,16 +100,81 @@ type Materia struct {
 	Facultad    string
 	Descripcion pgtype.Text
 	Creditos    int32
+	Activo      bool
+	DeletedAt   pgtype.Timestamp
+}
+
+type MateriaRequisito struct {
+	MateriaID          int32
+	SemestreMinimo     pgtype.Int4
+	Programas          []string
+	RequiereAprobacion bool
+	FechaActualizacion pgtype.Timestamp
 }
 
 type Reporte struct {
-	ReporteID       int32
-	TipoReporte     string
-	FechaGeneracion pgtype.Timestamp
-	PeriodoInicio   pgtype.Date
-	PeriodoFin      pgtype.Date
-	GeneradoPor     int32
-	Datos           []byte
+	ReporteID         int32
+	TipoReporte       string
+	FechaGeneracion   pgtype.Timestamp
+	PeriodoInicio     pgtype.Date
+	PeriodoFin        pgtype.Date
+	GeneradoPor       int32
+	Datos             []byte
+	Estado            string
+	Progreso          int32
+	Intentos          int32
+	UltimoError       pgtype.Text
+	ProximoIntento    pgtype.Timestamp
+	FechaInicio       pgtype.Timestamp
+	FechaFinalizacion pgtype.Timestamp
+	ProgramacionID    pgtype.Int4
+}
+
+type ReporteProgramacione struct {
+	ProgramacionID   int32
+	Nombre           string
+	TipoReporte      string
+	Cron             string
+	Periodo          string
+	Destinatarios    []string
+	AdjuntarPdf      bool
+	Activo           bool
+	CreadoPor        int32
+	ProximaEjecucion pgtype.Timestamp
+	UltimaEjecucion  pgtype.Timestamp
+	FechaCreacion    pgtype.Timestamp
+}
+
+type SolicitudesFallida struct {
+	SolicitudID  int32
+	EstudianteID pgtype.Int4
+	MateriaID    int32
+	TutorID      pgtype.Int4
+	Fecha        pgtype.Date
+	HoraInicio   pgtype.Time
+	HoraFin      pgtype.Time
+	Motivo       string
+	FechaIntento pgtype.Timestamp
+}
+
+type SolicitudesTutor struct {
+	SolicitudID    int32
+	EstudianteID   int32
+	Motivacion     string
+	Materias       []int32
+	Estado         string
+	MotivoRechazo  pgtype.Text
+	RevisadoPor    pgtype.Int4
+	TutorID        pgtype.Int4
+	FechaSolicitud pgtype.Timestamp
+	FechaRevision  pgtype.Timestamp
+}
+
+type TutorLimite struct {
+	TutorID            int32
+	HorasSemana        pgtype.Int4
+	HorasMes           pgtype.Int4
+	FechaActualizacion pgtype.Timestamp
 }
 
 type TutorMateria struct {
@@ -75,6 +185,18 @@ type TutorMateria struct {
 	Activo          bool
 }
 
+type TutorPostulacione struct {
+	PostulacionID    int32
+	TutorID          int32
+	MateriaID        int32
+	Comentario       pgtype.Text
+	Estado           string
+	MotivoRechazo    pgtype.Text
+	RevisadoPor      pgtype.Int4
+	FechaPostulacion pgtype.Timestamp
+	FechaRevision    pgtype.Timestamp
+}
+
 type Tutore struct {
 	TutorID           int32
 	Nombre            string
@@ -82,6 +204,7 @@ type Tutore struct {
 	Correo            string
 	ProgramaAcademico pgtype.Text
 	FechaRegistro     pgtype.Timestamp
+	DeletedAt         pgtype.Timestamp
 }
 
 type Tutoria struct {
@@ -100,6 +223,13 @@ type Tutoria struct {
 	Lugar                string
 }
 
+type TutoriaInvitacione struct {
+	TutoriaID  int32
+	Secuencia  int32
+	Metodo     string
+	FechaEnvio pgtype.Timestamp
+}
+
 type Tutoriasactiva struct {
 	TutoriaID          int32
 	NombreEstudiante   string
@@ -113,3 +243,27 @@ type Tutoriasactiva struct {
 	Lugar              string
 	Estado             string
 }
+
+type Webhook struct {
+	WebhookID     int32
+	Url           string
+	Secreto       string
+	Eventos       []string
+	Activo        bool
+	CreadoPor     pgtype.Int4
+	FechaCreacion pgtype.Timestamp
+}
+
+type WebhookEntrega struct {
+	EntregaID          int32
+	WebhookID          int32
+	Evento             string
+	Payload            []byte
+	Estado             string
+	Intentos           int32
+	UltimoCodigo       pgtype.Int4
+	UltimoError        pgtype.Text
+	FechaCreacion      pgtype.Timestamp
+	FechaUltimoIntento pgtype.Timestamp
+	ProximoIntento     pgtype.Timestamp
+}
@@ -11,32 +11,144 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const countEstudiantesByPrograma = `-- name: CountEstudiantesByPrograma :many
+const advanceReporteProgramacion = `-- name: AdvanceReporteProgramacion :exec
+UPDATE REPORTE_PROGRAMACIONES
+SET ultima_ejecucion = $2, proxima_ejecucion = $3
+WHERE programacion_id = $1
+`
 
-SELECT programa_academico, COUNT(*) as total_estudiantes
-FROM ESTUDIANTES
-GROUP BY programa_academico
-ORDER BY total_estudiantes DESC
+type AdvanceReporteProgramacionParams struct {
+	ProgramacionID   int32
+	UltimaEjecucion  pgtype.Timestamp
+	ProximaEjecucion pgtype.Timestamp
+}
+
+func (q *Queries) AdvanceReporteProgramacion(ctx context.Context, arg AdvanceReporteProgramacionParams) error {
+	_, err := q.db.Exec(ctx, advanceReporteProgramacion, arg.ProgramacionID, arg.UltimaEjecucion, arg.ProximaEjecucion)
+	return err
+}
+
+const analyticsAnticipacion = `-- name: AnalyticsAnticipacion :one
+SELECT
+    COUNT(*) AS tutorias,
+    COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY x.horas), 0)::float8 AS mediana_horas,
+    COALESCE(percentile_cont(0.25) WITHIN GROUP (ORDER BY x.horas), 0)::float8 AS p25_horas,
+    COALESCE(percentile_cont(0.75) WITHIN GROUP (ORDER BY x.horas), 0)::float8 AS p75_horas,
+    COALESCE(AVG(x.horas), 0)::float8 AS promedio_horas
+FROM (
+    SELECT (EXTRACT(EPOCH FROM (t.fecha + t.hora_inicio - t.fecha_solicitud)) / 3600)::float8 AS horas
+    FROM TUTORIAS t
+    WHERE t.fecha BETWEEN $1::date AND $2::date
+      AND ($3::int IS NULL OR t.materia_id = $3)
+) x
 `
 
-type CountEstudiantesByProgramaRow struct {
-	ProgramaAcademico string
-	TotalEstudiantes  int64
+type AnalyticsAnticipacionParams struct {
+	Desde     pgtype.Date
+	Hasta     pgtype.Date
+	MateriaID pgtype.Int4
 }
 
-// ========================================
-// ESTUDIANTES - MISSING QUERIES
-// ========================================
-func (q *Queries) CountEstudiantesByPrograma(ctx context.Context) ([]CountEstudiantesByProgramaRow, error) {
-	rows, err := q.db.Query(ctx, countEstudiantesByPrograma)
+type AnalyticsAnticipacionRow struct {
+	Tutorias      int64
+	MedianaHoras  float64
+	P25Horas      float64
+	P75Horas      float64
+	PromedioHoras float64
+}
+
+// Hours between the request of a tutoria and its start, for the tutorias dated within the period.
+func (q *Queries) AnalyticsAnticipacion(ctx context.Context, arg AnalyticsAnticipacionParams) (AnalyticsAnticipacionRow, error) {
+	row := q.db.QueryRow(ctx, analyticsAnticipacion, arg.Desde, arg.Hasta, arg.MateriaID)
+	var i AnalyticsAnticipacionRow
+	err := row.Scan(
+		&i.Tutorias,
+		&i.MedianaHoras,
+		&i.P25Horas,
+		&i.P75Horas,
+		&i.PromedioHoras,
+	)
+	return i, err
+}
+
+const analyticsDemandaOferta = `-- name: AnalyticsDemandaOferta :many
+WITH dias AS (
+    SELECT EXTRACT(ISODOW FROM d)::int AS dia_semana, COUNT(*) AS n
+    FROM generate_series($1::date, $2::date, '1 day'::interval) AS d
+    GROUP BY 1
+), oferta AS (
+    SELECT
+        tm.materia_id,
+        COUNT(DISTINCT tm.tutor_id) AS tutores,
+        SUM(EXTRACT(EPOCH FROM (dp.hora_fin - dp.hora_inicio)) / 3600 * dias.n) AS horas
+    FROM TUTOR_MATERIAS tm
+    JOIN TUTORES t ON t.tutor_id = tm.tutor_id
+    LEFT JOIN DISPONIBILIDAD dp ON dp.tutor_id = tm.tutor_id
+    LEFT JOIN dias ON dias.dia_semana = dp.dia_semana
+    WHERE tm.activo = true AND t.deleted_at IS NULL
+    GROUP BY tm.materia_id
+), demanda AS (
+    SELECT
+        materia_id,
+        COUNT(*) AS solicitudes,
+        SUM(EXTRACT(EPOCH FROM (hora_fin - hora_inicio)) / 3600) AS horas
+    FROM TUTORIAS
+    WHERE fecha BETWEEN $1::date AND $2::date
+    GROUP BY materia_id
+)
+SELECT
+    m.materia_id,
+    m.codigo,
+    m.nombre,
+    m.facultad,
+    COALESCE(d.solicitudes, 0)::bigint AS solicitudes,
+    COALESCE(d.horas, 0)::float8 AS horas_solicitadas,
+    COALESCE(o.tutores, 0)::bigint AS tutores,
+    COALESCE(o.horas, 0)::float8 AS horas_disponibles
+FROM MATERIAS m
+LEFT JOIN demanda d ON d.materia_id = m.materia_id
+LEFT JOIN oferta o ON o.materia_id = m.materia_id
+WHERE m.activo = true AND m.deleted_at IS NULL
+ORDER BY COALESCE(d.horas, 0) - COALESCE(o.horas, 0) DESC, m.codigo
+`
+
+type AnalyticsDemandaOfertaParams struct {
+	Desde pgtype.Date
+	Hasta pgtype.Date
+}
+
+type AnalyticsDemandaOfertaRow struct {
+	MateriaID        int32
+	Codigo           string
+	Nombre           string
+	Facultad         string
+	Solicitudes      int64
+	HorasSolicitadas float64
+	Tutores          int64
+	HorasDisponibles float64
+}
+
+// Requested hours of tutoring per active materia against the hours its active tutors are
+// available on the days of the period. A tutor's availability counts for every materia they teach.
+func (q *Queries) AnalyticsDemandaOferta(ctx context.Context, arg AnalyticsDemandaOfertaParams) ([]AnalyticsDemandaOfertaRow, error) {
+	rows, err := q.db.Query(ctx, analyticsDemandaOferta, arg.Desde, arg.Hasta)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []CountEstudiantesByProgramaRow
+	var items []AnalyticsDemandaOfertaRow
 	for rows.Next() {
-		var i CountEstudiantesByProgramaRow
-		if err := rows.Scan(&i.ProgramaAcademico, &i.TotalEstudiantes); err != nil {
+		var i AnalyticsDemandaOfertaRow
+		if err := rows.Scan(
+			&i.MateriaID,
+			&i.Codigo,
+			&i.Nombre,
+			&i.Facultad,
+			&i.Solicitudes,
+			&i.HorasSolicitadas,
+			&i.Tutores,
+			&i.HorasDisponibles,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -47,224 +159,330 @@ func (q *Queries) CountEstudiantesByPrograma(ctx context.Context) ([]CountEstudi
 	return items, nil
 }
 
-const countTutorsWithMaterias = `-- name: CountTutorsWithMaterias :one
-SELECT COUNT(DISTINCT tm.tutor_id) as count
-FROM TUTOR_MATERIAS tm
-WHERE tm.activo = true
-`
-
-func (q *Queries) CountTutorsWithMaterias(ctx context.Context) (int64, error) {
-	row := q.db.QueryRow(ctx, countTutorsWithMaterias)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
-}
-
-const createAdmin = `-- name: CreateAdmin :one
-
-INSERT INTO ADMINS (nombre, apellido, correo, password_hash, rol, activo)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING admin_id, nombre, apellido, correo, rol, activo, fecha_registro
+const analyticsHorasPico = `-- name: AnalyticsHorasPico :many
+SELECT
+    d.dia_semana::int AS dia_semana,
+    h.hora::int AS hora,
+    COUNT(t.tutoria_id) AS tutorias
+FROM generate_series(1, 7) AS d(dia_semana)
+CROSS JOIN generate_series(0, 23) AS h(hora)
+LEFT JOIN TUTORIAS t ON EXTRACT(ISODOW FROM t.fecha) = d.dia_semana
+    AND EXTRACT(HOUR FROM t.hora_inicio) = h.hora
+    AND t.fecha BETWEEN $1::date AND $2::date
+    AND t.estado <> 'cancelada'
+GROUP BY d.dia_semana, h.hora
+ORDER BY d.dia_semana, h.hora
 `
 
-type CreateAdminParams struct {
-	Nombre       string
-	Apellido     string
-	Correo       string
-	PasswordHash string
-	Rol          string
-	Activo       pgtype.Bool
+type AnalyticsHorasPicoParams struct {
+	Desde pgtype.Date
+	Hasta pgtype.Date
 }
 
-type CreateAdminRow struct {
-	AdminID       int32
-	Nombre        string
-	Apellido      string
-	Correo        string
-	Rol           string
-	Activo        pgtype.Bool
-	FechaRegistro pgtype.Timestamptz
+type AnalyticsHorasPicoRow struct {
+	DiaSemana int32
+	Hora      int32
+	Tutorias  int64
 }
 
-// ========================================
-// ADMINS QUERIES
-// ========================================
-func (q *Queries) CreateAdmin(ctx context.Context, arg CreateAdminParams) (CreateAdminRow, error) {
-	row := q.db.QueryRow(ctx, createAdmin,
-		arg.Nombre,
-		arg.Apellido,
-		arg.Correo,
-		arg.PasswordHash,
-		arg.Rol,
-		arg.Activo,
-	)
-	var i CreateAdminRow
-	err := row.Scan(
-		&i.AdminID,
-		&i.Nombre,
-		&i.Apellido,
-		&i.Correo,
-		&i.Rol,
-		&i.Activo,
-		&i.FechaRegistro,
-	)
-	return i, err
+// Non-cancelled tutorias per day of the week (1 = Monday) and starting hour, every cell included.
+func (q *Queries) AnalyticsHorasPico(ctx context.Context, arg AnalyticsHorasPicoParams) ([]AnalyticsHorasPicoRow, error) {
+	rows, err := q.db.Query(ctx, analyticsHorasPico, arg.Desde, arg.Hasta)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AnalyticsHorasPicoRow
+	for rows.Next() {
+		var i AnalyticsHorasPicoRow
+		if err := rows.Scan(&i.DiaSemana, &i.Hora, &i.Tutorias); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const createDisponibilidad = `-- name: CreateDisponibilidad :one
-
-INSERT INTO DISPONIBILIDAD (tutor_id, dia_semana, hora_inicio, hora_fin)
-VALUES ($1, $2, $3, $4)
-RETURNING disponibilidad_id, tutor_id, dia_semana, hora_inicio, hora_fin
+const analyticsLatenciaConfirmacion = `-- name: AnalyticsLatenciaConfirmacion :one
+SELECT
+    COUNT(*) AS tutorias,
+    COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY x.horas), 0)::float8 AS mediana_horas,
+    COALESCE(percentile_cont(0.25) WITHIN GROUP (ORDER BY x.horas), 0)::float8 AS p25_horas,
+    COALESCE(percentile_cont(0.75) WITHIN GROUP (ORDER BY x.horas), 0)::float8 AS p75_horas,
+    COALESCE(AVG(x.horas), 0)::float8 AS promedio_horas
+FROM (
+    SELECT (EXTRACT(EPOCH FROM (t.fecha_confirmacion - t.fecha_solicitud)) / 3600)::float8 AS horas
+    FROM TUTORIAS t
+    WHERE t.fecha BETWEEN $1::date AND $2::date
+      AND t.fecha_confirmacion IS NOT NULL
+      AND ($3::int IS NULL OR t.materia_id = $3)
+) x
 `
 
-type CreateDisponibilidadParams struct {
-	TutorID    int32
-	DiaSemana  int32
-	HoraInicio pgtype.Time
-	HoraFin    pgtype.Time
+type AnalyticsLatenciaConfirmacionParams struct {
+	Desde     pgtype.Date
+	Hasta     pgtype.Date
+	MateriaID pgtype.Int4
 }
 
-// ========================================
-// DISPONIBILIDAD QUERIES
-// ========================================
-func (q *Queries) CreateDisponibilidad(ctx context.Context, arg CreateDisponibilidadParams) (Disponibilidad, error) {
-	row := q.db.QueryRow(ctx, createDisponibilidad,
-		arg.TutorID,
-		arg.DiaSemana,
-		arg.HoraInicio,
-		arg.HoraFin,
-	)
-	var i Disponibilidad
+type AnalyticsLatenciaConfirmacionRow struct {
+	Tutorias      int64
+	MedianaHoras  float64
+	P25Horas      float64
+	P75Horas      float64
+	PromedioHoras float64
+}
+
+// Hours between the request of a tutoria and its confirmation by the tutor, for the confirmed
+// tutorias dated within the period.
+func (q *Queries) AnalyticsLatenciaConfirmacion(ctx context.Context, arg AnalyticsLatenciaConfirmacionParams) (AnalyticsLatenciaConfirmacionRow, error) {
+	row := q.db.QueryRow(ctx, analyticsLatenciaConfirmacion, arg.Desde, arg.Hasta, arg.MateriaID)
+	var i AnalyticsLatenciaConfirmacionRow
 	err := row.Scan(
-		&i.DisponibilidadID,
-		&i.TutorID,
-		&i.DiaSemana,
-		&i.HoraInicio,
-		&i.HoraFin,
+		&i.Tutorias,
+		&i.MedianaHoras,
+		&i.P25Horas,
+		&i.P75Horas,
+		&i.PromedioHoras,
 	)
 	return i, err
 }
 
-const createEstudiante = `-- name: CreateEstudiante :one
-
-INSERT INTO ESTUDIANTES (nombre, apellido, correo, programa_academico, semestre, ti)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING estudiante_id, nombre, apellido, correo, programa_academico, semestre, ti, fecha_registro
+const analyticsSesiones = `-- name: AnalyticsSesiones :many
+
+SELECT
+    s.periodo::date AS periodo,
+    COUNT(t.tutoria_id) AS total,
+    COUNT(t.tutoria_id) FILTER (WHERE t.estado = 'solicitada') AS solicitadas,
+    COUNT(t.tutoria_id) FILTER (WHERE t.estado = 'confirmada') AS confirmadas,
+    COUNT(t.tutoria_id) FILTER (WHERE t.estado = 'cancelada') AS canceladas,
+    COUNT(t.tutoria_id) FILTER (WHERE t.estado = 'completada') AS completadas
+FROM generate_series(
+    date_trunc($1::text, $2::date::timestamp),
+    $3::date::timestamp,
+    ('1 ' || $1::text)::interval
+) AS s(periodo)
+LEFT JOIN TUTORIAS t ON date_trunc($1::text, t.fecha::timestamp) = s.periodo
+    AND t.fecha BETWEEN $2::date AND $3::date
+GROUP BY s.periodo
+ORDER BY s.periodo
 `
 
-type CreateEstudianteParams struct {
-	Nombre            string
-	Apellido          string
-	Correo            string
-	ProgramaAcademico string
-	Semestre          pgtype.Int4
-	Ti                pgtype.Int4
+type AnalyticsSesionesParams struct {
+	Agrupacion string
+	Desde      pgtype.Date
+	Hasta      pgtype.Date
 }
 
-type CreateEstudianteRow struct {
-	EstudianteID      int32
-	Nombre            string
-	Apellido          string
-	Correo            string
-	ProgramaAcademico string
-	Semestre          pgtype.Int4
-	Ti                pgtype.Int4
-	FechaRegistro     pgtype.Timestamp
+type AnalyticsSesionesRow struct {
+	Periodo     pgtype.Date
+	Total       int64
+	Solicitadas int64
+	Confirmadas int64
+	Canceladas  int64
+	Completadas int64
 }
 
 // ========================================
-// ESTUDIANTES QUERIES
+// ANALYTICS QUERIES
 // ========================================
-func (q *Queries) CreateEstudiante(ctx context.Context, arg CreateEstudianteParams) (CreateEstudianteRow, error) {
-	row := q.db.QueryRow(ctx, createEstudiante,
-		arg.Nombre,
-		arg.Apellido,
-		arg.Correo,
-		arg.ProgramaAcademico,
-		arg.Semestre,
-		arg.Ti,
-	)
-	var i CreateEstudianteRow
-	err := row.Scan(
-		&i.EstudianteID,
-		&i.Nombre,
-		&i.Apellido,
-		&i.Correo,
-		&i.ProgramaAcademico,
-		&i.Semestre,
-		&i.Ti,
-		&i.FechaRegistro,
-	)
-	return i, err
+// Tutorias per day or week (agrupacion is 'day' or 'week'), including periods without tutorias.
+// Weeks start on Monday.
+func (q *Queries) AnalyticsSesiones(ctx context.Context, arg AnalyticsSesionesParams) ([]AnalyticsSesionesRow, error) {
+	rows, err := q.db.Query(ctx, analyticsSesiones, arg.Agrupacion, arg.Desde, arg.Hasta)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AnalyticsSesionesRow
+	for rows.Next() {
+		var i AnalyticsSesionesRow
+		if err := rows.Scan(
+			&i.Periodo,
+			&i.Total,
+			&i.Solicitadas,
+			&i.Confirmadas,
+			&i.Canceladas,
+			&i.Completadas,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const createMateria = `-- name: CreateMateria :one
-
-INSERT INTO MATERIAS (nombre, codigo, facultad, descripcion, creditos)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING materia_id, nombre, codigo, facultad, descripcion, creditos
+const buscarMaterias = `-- name: BuscarMaterias :many
+WITH candidatas AS (
+    SELECT materia_id FROM MATERIAS
+    WHERE f_unaccent(lower($1::text)) <% f_unaccent(lower(nombre || ' ' || codigo || ' ' || facultad || ' ' || COALESCE(descripcion, '')))
+    UNION
+    SELECT materia_id FROM MATERIAS
+    WHERE to_tsvector('spanish', f_unaccent(lower(nombre || ' ' || codigo || ' ' || facultad || ' ' || COALESCE(descripcion, ''))))
+          @@ plainto_tsquery('spanish', f_unaccent(lower($1::text)))
+)
+SELECT m.materia_id, m.nombre, m.codigo, m.facultad, m.descripcion, m.creditos,
+       (GREATEST(
+           word_similarity(b.q, f_unaccent(lower(m.nombre))),
+           word_similarity(b.q, lower(m.codigo)),
+           word_similarity(b.q, f_unaccent(lower(m.facultad))) * 0.8,
+           word_similarity(b.q, f_unaccent(lower(COALESCE(m.descripcion, '')))) * 0.6
+       ) + CASE WHEN to_tsvector('spanish', b.doc) @@ plainto_tsquery('spanish', b.q) THEN 0.5 ELSE 0 END)::real AS rank
+FROM candidatas c
+JOIN MATERIAS m ON m.materia_id = c.materia_id
+CROSS JOIN LATERAL (
+    SELECT f_unaccent(lower($1::text)) AS q,
+           f_unaccent(lower(m.nombre || ' ' || m.codigo || ' ' || m.facultad || ' ' || COALESCE(m.descripcion, ''))) AS doc
+) b
+WHERE m.activo AND m.deleted_at IS NULL
+ORDER BY rank DESC, m.nombre
+LIMIT $2
 `
 
-type CreateMateriaParams struct {
+type BuscarMateriasParams struct {
+	Q        string
+	RowLimit int32
+}
+
+type BuscarMateriasRow struct {
+	MateriaID   int32
 	Nombre      string
 	Codigo      string
 	Facultad    string
 	Descripcion pgtype.Text
 	Creditos    int32
+	Rank        float32
 }
 
 // ========================================
-// MATERIAS QUERIES
+// BUSQUEDA QUERIES
 // ========================================
-func (q *Queries) CreateMateria(ctx context.Context, arg CreateMateriaParams) (Materia, error) {
-	row := q.db.QueryRow(ctx, createMateria,
-		arg.Nombre,
-		arg.Codigo,
-		arg.Facultad,
-		arg.Descripcion,
-		arg.Creditos,
-	)
-	var i Materia
-	err := row.Scan(
-		&i.MateriaID,
-		&i.Nombre,
-		&i.Codigo,
-		&i.Facultad,
-		&i.Descripcion,
-		&i.Creditos,
-	)
-	return i, err
+// Ranks active materias by trigram word similarity of q against nombre, codigo, facultad and
+// descripcion, ignoring case and accents, with a bonus for Spanish full-text matches.
+// Candidates are the union of a trigram branch and a full-text branch, each written to match
+// its index (idx_materias_busqueda and idx_materias_busqueda_texto).
+func (q *Queries) BuscarMaterias(ctx context.Context, arg BuscarMateriasParams) ([]BuscarMateriasRow, error) {
+	rows, err := q.db.Query(ctx, buscarMaterias, arg.Q, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BuscarMateriasRow
+	for rows.Next() {
+		var i BuscarMateriasRow
+		if err := rows.Scan(
+			&i.MateriaID,
+			&i.Nombre,
+			&i.Codigo,
+			&i.Facultad,
+			&i.Descripcion,
+			&i.Creditos,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const createReporte = `-- name: CreateReporte :one
-
-INSERT INTO REPORTES (tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos
+const buscarTutores = `-- name: BuscarTutores :many
+WITH candidatos AS (
+    SELECT tutor_id FROM TUTORES
+    WHERE f_unaccent(lower($1::text)) <% f_unaccent(lower(nombre || ' ' || apellido))
+    UNION
+    SELECT tm.tutor_id
+    FROM MATERIAS m
+    JOIN TUTOR_MATERIAS tm ON tm.materia_id = m.materia_id
+    WHERE f_unaccent(lower($1::text)) <% f_unaccent(lower(m.nombre))
+      AND tm.activo AND m.activo AND m.deleted_at IS NULL
+)
+SELECT t.tutor_id, t.nombre, t.apellido, t.correo, t.programa_academico,
+       COALESCE(mt.materias, '{}')::text[] AS materias,
+       GREATEST(
+           word_similarity(b.q, f_unaccent(lower(t.nombre || ' ' || t.apellido))),
+           word_similarity(b.q, f_unaccent(lower(COALESCE(array_to_string(mt.materias, ' '), '')))) * 0.8
+       )::real AS rank
+FROM candidatos c
+JOIN TUTORES t ON t.tutor_id = c.tutor_id
+LEFT JOIN LATERAL (
+    SELECT array_agg(m.nombre ORDER BY m.nombre) AS materias
+    FROM TUTOR_MATERIAS tm
+    JOIN MATERIAS m ON tm.materia_id = m.materia_id
+    WHERE tm.tutor_id = t.tutor_id AND tm.activo AND m.activo AND m.deleted_at IS NULL
+) mt ON true
+CROSS JOIN LATERAL (
+    SELECT f_unaccent(lower($1::text)) AS q
+) b
+WHERE t.deleted_at IS NULL
+ORDER BY rank DESC, t.apellido, t.nombre
+LIMIT $2
 `
 
-type CreateReporteParams struct {
-	TipoReporte     string
-	FechaGeneracion pgtype.Timestamp
-	PeriodoInicio   pgtype.Date
-	PeriodoFin      pgtype.Date
-	GeneradoPor     int32
-	Datos           []byte
+type BuscarTutoresParams struct {
+	Q        string
+	RowLimit int32
 }
 
-// ========================================
-// REPORTES QUERIES
-// ========================================
-func (q *Queries) CreateReporte(ctx context.Context, arg CreateReporteParams) (Reporte, error) {
-	row := q.db.QueryRow(ctx, createReporte,
-		arg.TipoReporte,
-		arg.FechaGeneracion,
-		arg.PeriodoInicio,
-		arg.PeriodoFin,
-		arg.GeneradoPor,
-		arg.Datos,
-	)
+type BuscarTutoresRow struct {
+	TutorID           int32
+	Nombre            string
+	Apellido          string
+	Correo            string
+	ProgramaAcademico pgtype.Text
+	Materias          []string
+	Rank              float32
+}
+
+// Ranks tutors by word similarity of q against their name and the materias they teach,
+// ignoring case and accents. Candidates are the union of the tutors whose name matches
+// (idx_tutores_busqueda) and those teaching a materia whose nombre matches (idx_materias_nombre_busqueda).
+func (q *Queries) BuscarTutores(ctx context.Context, arg BuscarTutoresParams) ([]BuscarTutoresRow, error) {
+	rows, err := q.db.Query(ctx, buscarTutores, arg.Q, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BuscarTutoresRow
+	for rows.Next() {
+		var i BuscarTutoresRow
+		if err := rows.Scan(
+			&i.TutorID,
+			&i.Nombre,
+			&i.Apellido,
+			&i.Correo,
+			&i.ProgramaAcademico,
+			&i.Materias,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const cancelReporte = `-- name: CancelReporte :one
+UPDATE REPORTES
+SET estado = 'cancelado', fecha_finalizacion = CURRENT_TIMESTAMP
+WHERE reporte_id = $1 AND estado IN ('pendiente', 'en_progreso')
+RETURNING reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos, estado, progreso, intentos, ultimo_error, proximo_intento, fecha_inicio, fecha_finalizacion, programacion_id
+`
+
+func (q *Queries) CancelReporte(ctx context.Context, reporteID int32) (Reporte, error) {
+	row := q.db.QueryRow(ctx, cancelReporte, reporteID)
 	var i Reporte
 	err := row.Scan(
 		&i.ReporteID,
@@ -274,251 +492,2070 @@ func (q *Queries) CreateReporte(ctx context.Context, arg CreateReporteParams) (R
 		&i.PeriodoFin,
 		&i.GeneradoPor,
 		&i.Datos,
+		&i.Estado,
+		&i.Progreso,
+		&i.Intentos,
+		&i.UltimoError,
+		&i.ProximoIntento,
+		&i.FechaInicio,
+		&i.FechaFinalizacion,
+		&i.ProgramacionID,
 	)
 	return i, err
 }
 
-const createTutor = `-- name: CreateTutor :one
-
-INSERT INTO TUTORES (nombre, apellido, correo, programa_academico)
-VALUES ($1, $2, $3, $4)
-RETURNING tutor_id, nombre, apellido, correo, programa_academico, fecha_registro
+const claimReportePendiente = `-- name: ClaimReportePendiente :one
+UPDATE REPORTES
+SET estado = 'en_progreso', progreso = 0, intentos = intentos + 1, fecha_inicio = CURRENT_TIMESTAMP
+WHERE reporte_id = (
+    SELECT reporte_id FROM REPORTES
+    WHERE estado = 'pendiente' AND proximo_intento <= CURRENT_TIMESTAMP
+    ORDER BY proximo_intento, reporte_id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos, estado, progreso, intentos, ultimo_error, proximo_intento, fecha_inicio, fecha_finalizacion, programacion_id
 `
 
-type CreateTutorParams struct {
-	Nombre            string
-	Apellido          string
-	Correo            string
-	ProgramaAcademico pgtype.Text
-}
-
-// ========================================
-// TUTORES QUERIES
-// ========================================
-func (q *Queries) CreateTutor(ctx context.Context, arg CreateTutorParams) (Tutore, error) {
-	row := q.db.QueryRow(ctx, createTutor,
-		arg.Nombre,
-		arg.Apellido,
-		arg.Correo,
-		arg.ProgramaAcademico,
-	)
-	var i Tutore
+// Takes the next due job; SKIP LOCKED lets several workers (and servers) share the queue.
+func (q *Queries) ClaimReportePendiente(ctx context.Context) (Reporte, error) {
+	row := q.db.QueryRow(ctx, claimReportePendiente)
+	var i Reporte
 	err := row.Scan(
-		&i.TutorID,
-		&i.Nombre,
-		&i.Apellido,
-		&i.Correo,
-		&i.ProgramaAcademico,
-		&i.FechaRegistro,
+		&i.ReporteID,
+		&i.TipoReporte,
+		&i.FechaGeneracion,
+		&i.PeriodoInicio,
+		&i.PeriodoFin,
+		&i.GeneradoPor,
+		&i.Datos,
+		&i.Estado,
+		&i.Progreso,
+		&i.Intentos,
+		&i.UltimoError,
+		&i.ProximoIntento,
+		&i.FechaInicio,
+		&i.FechaFinalizacion,
+		&i.ProgramacionID,
 	)
 	return i, err
 }
 
-const createTutorMateria = `-- name: CreateTutorMateria :one
-
-INSERT INTO TUTOR_MATERIAS (tutor_id, materia_id, fecha_asignacion, activo)
-VALUES ($1, $2, $3, $4)
-RETURNING asignacion_id, tutor_id, materia_id, fecha_asignacion, activo
+const completeReporte = `-- name: CompleteReporte :execrows
+UPDATE REPORTES
+SET estado = 'listo', progreso = 100, datos = $2, ultimo_error = NULL, fecha_finalizacion = CURRENT_TIMESTAMP
+WHERE reporte_id = $1 AND estado = 'en_progreso'
 `
 
-type CreateTutorMateriaParams struct {
-	TutorID         int32
-	MateriaID       int32
-	FechaAsignacion pgtype.Date
-	Activo          bool
+type CompleteReporteParams struct {
+	ReporteID int32
+	Datos     []byte
 }
 
-// ========================================
-// TUTOR_MATERIAS QUERIES
-// ========================================
-func (q *Queries) CreateTutorMateria(ctx context.Context, arg CreateTutorMateriaParams) (TutorMateria, error) {
-	row := q.db.QueryRow(ctx, createTutorMateria,
-		arg.TutorID,
-		arg.MateriaID,
-		arg.FechaAsignacion,
-		arg.Activo,
-	)
-	var i TutorMateria
-	err := row.Scan(
-		&i.AsignacionID,
-		&i.TutorID,
-		&i.MateriaID,
-		&i.FechaAsignacion,
-		&i.Activo,
-	)
-	return i, err
+func (q *Queries) CompleteReporte(ctx context.Context, arg CompleteReporteParams) (int64, error) {
+	result, err := q.db.Exec(ctx, completeReporte, arg.ReporteID, arg.Datos)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const createTutoria = `-- name: CreateTutoria :one
+const countEstudiantes = `-- name: CountEstudiantes :one
+SELECT COUNT(*) FROM ESTUDIANTES WHERE deleted_at IS NULL
+`
 
-INSERT INTO TUTORIAS (estudiante_id, tutor_id, materia_id, fecha, hora_inicio, hora_fin, estado, fecha_solicitud, lugar)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-RETURNING tutoria_id, estudiante_id, tutor_id, materia_id, fecha, hora_inicio, hora_fin, estado, fecha_solicitud, fecha_confirmacion, temas_tratados, asistencia_confirmada, lugar
+func (q *Queries) CountEstudiantes(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countEstudiantes)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countEstudiantesByPrograma = `-- name: CountEstudiantesByPrograma :many
+
+SELECT programa_academico, COUNT(*) as total_estudiantes
+FROM ESTUDIANTES
+WHERE deleted_at IS NULL
+GROUP BY programa_academico
+ORDER BY total_estudiantes DESC
 `
 
-type CreateTutoriaParams struct {
-	EstudianteID   int32
-	TutorID        int32
-	MateriaID      int32
-	Fecha          pgtype.Date
-	HoraInicio     pgtype.Time
-	HoraFin        pgtype.Time
-	Estado         string
-	FechaSolicitud pgtype.Timestamp
-	Lugar          string
+type CountEstudiantesByProgramaRow struct {
+	ProgramaAcademico string
+	TotalEstudiantes  int64
 }
 
 // ========================================
-// TUTORIAS QUERIES
+// ESTUDIANTES - MISSING QUERIES
 // ========================================
-func (q *Queries) CreateTutoria(ctx context.Context, arg CreateTutoriaParams) (Tutoria, error) {
-	row := q.db.QueryRow(ctx, createTutoria,
-		arg.EstudianteID,
-		arg.TutorID,
-		arg.MateriaID,
-		arg.Fecha,
-		arg.HoraInicio,
-		arg.HoraFin,
-		arg.Estado,
-		arg.FechaSolicitud,
-		arg.Lugar,
-	)
-	var i Tutoria
-	err := row.Scan(
-		&i.TutoriaID,
-		&i.EstudianteID,
-		&i.TutorID,
-		&i.MateriaID,
-		&i.Fecha,
-		&i.HoraInicio,
-		&i.HoraFin,
-		&i.Estado,
-		&i.FechaSolicitud,
-		&i.FechaConfirmacion,
-		&i.TemasTratados,
-		&i.AsistenciaConfirmada,
-		&i.Lugar,
-	)
-	return i, err
+func (q *Queries) CountEstudiantesByPrograma(ctx context.Context) ([]CountEstudiantesByProgramaRow, error) {
+	rows, err := q.db.Query(ctx, countEstudiantesByPrograma)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountEstudiantesByProgramaRow
+	for rows.Next() {
+		var i CountEstudiantesByProgramaRow
+		if err := rows.Scan(&i.ProgramaAcademico, &i.TotalEstudiantes); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const deleteAdmin = `-- name: DeleteAdmin :exec
-DELETE FROM ADMINS WHERE admin_id = $1
+const countMaterias = `-- name: CountMaterias :one
+SELECT COUNT(*) FROM MATERIAS WHERE deleted_at IS NULL
 `
 
-func (q *Queries) DeleteAdmin(ctx context.Context, adminID int32) error {
-	_, err := q.db.Exec(ctx, deleteAdmin, adminID)
-	return err
+func (q *Queries) CountMaterias(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countMaterias)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const deleteDisponibilidad = `-- name: DeleteDisponibilidad :exec
-DELETE FROM DISPONIBILIDAD WHERE disponibilidad_id = $1
+const countReportes = `-- name: CountReportes :one
+SELECT COUNT(*) FROM REPORTES
 `
 
-func (q *Queries) DeleteDisponibilidad(ctx context.Context, disponibilidadID int32) error {
-	_, err := q.db.Exec(ctx, deleteDisponibilidad, disponibilidadID)
-	return err
+func (q *Queries) CountReportes(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countReportes)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const deleteEstudiante = `-- name: DeleteEstudiante :exec
-DELETE FROM ESTUDIANTES WHERE estudiante_id = $1
+const countTutores = `-- name: CountTutores :one
+SELECT COUNT(*) FROM TUTORES WHERE deleted_at IS NULL
 `
 
-func (q *Queries) DeleteEstudiante(ctx context.Context, estudianteID int32) error {
-	_, err := q.db.Exec(ctx, deleteEstudiante, estudianteID)
-	return err
+func (q *Queries) CountTutores(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countTutores)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const deleteMateria = `-- name: DeleteMateria :exec
-DELETE FROM MATERIAS WHERE materia_id = $1
+const countTutorias = `-- name: CountTutorias :one
+SELECT COUNT(*) FROM TUTORIAS t
+WHERE ($1::int IS NULL OR t.estudiante_id = $1::int)
+  AND ($2::int IS NULL OR t.tutor_id = $2::int)
+  AND ($3::int IS NULL OR t.materia_id = $3::int)
+  AND ($4::text[] IS NULL OR t.estado = ANY($4::text[]))
+  AND ($5::date IS NULL OR t.fecha >= $5::date)
+  AND ($6::date IS NULL OR t.fecha <= $6::date)
+  AND ($7::text IS NULL OR t.lugar ILIKE '%' || replace(replace(replace($7::text, '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND ($8::bool IS NULL OR t.asistencia_confirmada = $8::bool)
 `
 
-func (q *Queries) DeleteMateria(ctx context.Context, materiaID int32) error {
-	_, err := q.db.Exec(ctx, deleteMateria, materiaID)
-	return err
+type CountTutoriasParams struct {
+	EstudianteID pgtype.Int4
+	TutorID      pgtype.Int4
+	MateriaID    pgtype.Int4
+	Estados      []string
+	FechaDesde   pgtype.Date
+	FechaHasta   pgtype.Date
+	Lugar        pgtype.Text
+	Asistencia   pgtype.Bool
 }
 
-const deleteReporte = `-- name: DeleteReporte :exec
-DELETE FROM REPORTES WHERE reporte_id = $1
-`
-
-func (q *Queries) DeleteReporte(ctx context.Context, reporteID int32) error {
-	_, err := q.db.Exec(ctx, deleteReporte, reporteID)
-	return err
+func (q *Queries) CountTutorias(ctx context.Context, arg CountTutoriasParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTutorias,
+		arg.EstudianteID,
+		arg.TutorID,
+		arg.MateriaID,
+		arg.Estados,
+		arg.FechaDesde,
+		arg.FechaHasta,
+		arg.Lugar,
+		arg.Asistencia,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const deleteTutor = `-- name: DeleteTutor :exec
-DELETE FROM TUTORES WHERE tutor_id = $1
+const countTutorsWithMaterias = `-- name: CountTutorsWithMaterias :one
+SELECT COUNT(DISTINCT tm.tutor_id) as count
+FROM TUTOR_MATERIAS tm
+JOIN TUTORES t ON t.tutor_id = tm.tutor_id
+WHERE tm.activo = true AND t.deleted_at IS NULL
 `
 
-func (q *Queries) DeleteTutor(ctx context.Context, tutorID int32) error {
-	_, err := q.db.Exec(ctx, deleteTutor, tutorID)
-	return err
+func (q *Queries) CountTutorsWithMaterias(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countTutorsWithMaterias)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-const deleteTutorMateria = `-- name: DeleteTutorMateria :exec
-DELETE FROM TUTOR_MATERIAS WHERE asignacion_id = $1
+const createAdmin = `-- name: CreateAdmin :one
+
+INSERT INTO ADMINS (nombre, apellido, correo, password_hash, rol, activo)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING admin_id, nombre, apellido, correo, rol, activo, fecha_registro
 `
 
-func (q *Queries) DeleteTutorMateria(ctx context.Context, asignacionID int32) error {
-	_, err := q.db.Exec(ctx, deleteTutorMateria, asignacionID)
-	return err
+type CreateAdminParams struct {
+	Nombre       string
+	Apellido     string
+	Correo       string
+	PasswordHash string
+	Rol          string
+	Activo       pgtype.Bool
+}
+
+type CreateAdminRow struct {
+	AdminID       int32
+	Nombre        string
+	Apellido      string
+	Correo        string
+	Rol           string
+	Activo        pgtype.Bool
+	FechaRegistro pgtype.Timestamptz
+}
+
+// ========================================
+// ADMINS QUERIES
+// ========================================
+func (q *Queries) CreateAdmin(ctx context.Context, arg CreateAdminParams) (CreateAdminRow, error) {
+	row := q.db.QueryRow(ctx, createAdmin,
+		arg.Nombre,
+		arg.Apellido,
+		arg.Correo,
+		arg.PasswordHash,
+		arg.Rol,
+		arg.Activo,
+	)
+	var i CreateAdminRow
+	err := row.Scan(
+		&i.AdminID,
+		&i.Nombre,
+		&i.Apellido,
+		&i.Correo,
+		&i.Rol,
+		&i.Activo,
+		&i.FechaRegistro,
+	)
+	return i, err
+}
+
+const createAuditoriaEvento = `-- name: CreateAuditoriaEvento :exec
+
+INSERT INTO AUDITORIA_EVENTOS (admin_id, actor, metodo, ruta, entidad, entidad_id, estado_http, antes, despues, ip)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+
+type CreateAuditoriaEventoParams struct {
+	AdminID    pgtype.Int4
+	Actor      string
+	Metodo     string
+	Ruta       string
+	Entidad    string
+	EntidadID  pgtype.Int4
+	EstadoHttp int32
+	Antes      []byte
+	Despues    []byte
+	Ip         string
+}
+
+// ========================================
+// AUDITORIA QUERIES
+// ========================================
+func (q *Queries) CreateAuditoriaEvento(ctx context.Context, arg CreateAuditoriaEventoParams) error {
+	_, err := q.db.Exec(ctx, createAuditoriaEvento,
+		arg.AdminID,
+		arg.Actor,
+		arg.Metodo,
+		arg.Ruta,
+		arg.Entidad,
+		arg.EntidadID,
+		arg.EstadoHttp,
+		arg.Antes,
+		arg.Despues,
+		arg.Ip,
+	)
+	return err
+}
+
+const createCertificado = `-- name: CreateCertificado :one
+INSERT INTO CERTIFICADOS (codigo, tutor_id, tutor_nombre, desde, hasta, total_tutorias, total_minutos, detalle, firma)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING certificado_id, codigo, tutor_id, tutor_nombre, desde, hasta, total_tutorias, total_minutos, detalle, firma, fecha_emision
+`
+
+type CreateCertificadoParams struct {
+	Codigo        string
+	TutorID       pgtype.Int4
+	TutorNombre   string
+	Desde         pgtype.Date
+	Hasta         pgtype.Date
+	TotalTutorias int32
+	TotalMinutos  int32
+	Detalle       []byte
+	Firma         string
+}
+
+func (q *Queries) CreateCertificado(ctx context.Context, arg CreateCertificadoParams) (Certificado, error) {
+	row := q.db.QueryRow(ctx, createCertificado,
+		arg.Codigo,
+		arg.TutorID,
+		arg.TutorNombre,
+		arg.Desde,
+		arg.Hasta,
+		arg.TotalTutorias,
+		arg.TotalMinutos,
+		arg.Detalle,
+		arg.Firma,
+	)
+	var i Certificado
+	err := row.Scan(
+		&i.CertificadoID,
+		&i.Codigo,
+		&i.TutorID,
+		&i.TutorNombre,
+		&i.Desde,
+		&i.Hasta,
+		&i.TotalTutorias,
+		&i.TotalMinutos,
+		&i.Detalle,
+		&i.Firma,
+		&i.FechaEmision,
+	)
+	return i, err
+}
+
+const createDisponibilidad = `-- name: CreateDisponibilidad :one
+
+INSERT INTO DISPONIBILIDAD (tutor_id, dia_semana, hora_inicio, hora_fin)
+VALUES ($1, $2, $3, $4)
+RETURNING disponibilidad_id, tutor_id, dia_semana, hora_inicio, hora_fin
+`
+
+type CreateDisponibilidadParams struct {
+	TutorID    int32
+	DiaSemana  int32
+	HoraInicio pgtype.Time
+	HoraFin    pgtype.Time
+}
+
+// ========================================
+// DISPONIBILIDAD QUERIES
+// ========================================
+func (q *Queries) CreateDisponibilidad(ctx context.Context, arg CreateDisponibilidadParams) (Disponibilidad, error) {
+	row := q.db.QueryRow(ctx, createDisponibilidad,
+		arg.TutorID,
+		arg.DiaSemana,
+		arg.HoraInicio,
+		arg.HoraFin,
+	)
+	var i Disponibilidad
+	err := row.Scan(
+		&i.DisponibilidadID,
+		&i.TutorID,
+		&i.DiaSemana,
+		&i.HoraInicio,
+		&i.HoraFin,
+	)
+	return i, err
+}
+
+const createEstudiante = `-- name: CreateEstudiante :one
+
+INSERT INTO ESTUDIANTES (nombre, apellido, correo, programa_academico, semestre, ti)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING estudiante_id, nombre, apellido, correo, programa_academico, semestre, ti, fecha_registro
+`
+
+type CreateEstudianteParams struct {
+	Nombre            string
+	Apellido          string
+	Correo            string
+	ProgramaAcademico string
+	Semestre          pgtype.Int4
+	Ti                pgtype.Int4
+}
+
+type CreateEstudianteRow struct {
+	EstudianteID      int32
+	Nombre            string
+	Apellido          string
+	Correo            string
+	ProgramaAcademico string
+	Semestre          pgtype.Int4
+	Ti                pgtype.Int4
+	FechaRegistro     pgtype.Timestamp
+}
+
+// ========================================
+// ESTUDIANTES QUERIES
+// ========================================
+func (q *Queries) CreateEstudiante(ctx context.Context, arg CreateEstudianteParams) (CreateEstudianteRow, error) {
+	row := q.db.QueryRow(ctx, createEstudiante,
+		arg.Nombre,
+		arg.Apellido,
+		arg.Correo,
+		arg.ProgramaAcademico,
+		arg.Semestre,
+		arg.Ti,
+	)
+	var i CreateEstudianteRow
+	err := row.Scan(
+		&i.EstudianteID,
+		&i.Nombre,
+		&i.Apellido,
+		&i.Correo,
+		&i.ProgramaAcademico,
+		&i.Semestre,
+		&i.Ti,
+		&i.FechaRegistro,
+	)
+	return i, err
+}
+
+const createInscripcion = `-- name: CreateInscripcion :one
+
+INSERT INTO INSCRIPCIONES (estudiante_id, materia_id, periodo)
+VALUES ($1, $2, $3)
+RETURNING inscripcion_id, estudiante_id, materia_id, periodo, fecha_inscripcion
+`
+
+type CreateInscripcionParams struct {
+	EstudianteID int32
+	MateriaID    int32
+	Periodo      string
+}
+
+// ========================================
+// INSCRIPCIONES QUERIES
+// ========================================
+func (q *Queries) CreateInscripcion(ctx context.Context, arg CreateInscripcionParams) (Inscripcione, error) {
+	row := q.db.QueryRow(ctx, createInscripcion, arg.EstudianteID, arg.MateriaID, arg.Periodo)
+	var i Inscripcione
+	err := row.Scan(
+		&i.InscripcionID,
+		&i.EstudianteID,
+		&i.MateriaID,
+		&i.Periodo,
+		&i.FechaInscripcion,
+	)
+	return i, err
+}
+
+const createMateria = `-- name: CreateMateria :one
+
+INSERT INTO MATERIAS (nombre, codigo, facultad, descripcion, creditos)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING materia_id, nombre, codigo, facultad, descripcion, creditos, activo, deleted_at
+`
+
+type CreateMateriaParams struct {
+	Nombre      string
+	Codigo      string
+	Facultad    string
+	Descripcion pgtype.Text
+	Creditos    int32
+}
+
+// ========================================
+// MATERIAS QUERIES
+// ========================================
+func (q *Queries) CreateMateria(ctx context.Context, arg CreateMateriaParams) (Materia, error) {
+	row := q.db.QueryRow(ctx, createMateria,
+		arg.Nombre,
+		arg.Codigo,
+		arg.Facultad,
+		arg.Descripcion,
+		arg.Creditos,
+	)
+	var i Materia
+	err := row.Scan(
+		&i.MateriaID,
+		&i.Nombre,
+		&i.Codigo,
+		&i.Facultad,
+		&i.Descripcion,
+		&i.Creditos,
+		&i.Activo,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const createReporte = `-- name: CreateReporte :one
+
+INSERT INTO REPORTES (tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos, estado, progreso, intentos, ultimo_error, proximo_intento, fecha_inicio, fecha_finalizacion, programacion_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos, estado, progreso, intentos, ultimo_error, proximo_intento, fecha_inicio, fecha_finalizacion, programacion_id
+`
+
+type CreateReporteParams struct {
+	TipoReporte     string
+	FechaGeneracion pgtype.Timestamp
+	PeriodoInicio   pgtype.Date
+	PeriodoFin      pgtype.Date
+	GeneradoPor     int32
+	Datos           []byte
+}
+
+// ========================================
+// REPORTES QUERIES
+// ========================================
+func (q *Queries) CreateReporte(ctx context.Context, arg CreateReporteParams) (Reporte, error) {
+	row := q.db.QueryRow(ctx, createReporte,
+		arg.TipoReporte,
+		arg.FechaGeneracion,
+		arg.PeriodoInicio,
+		arg.PeriodoFin,
+		arg.GeneradoPor,
+		arg.Datos,
+	)
+	var i Reporte
+	err := row.Scan(
+		&i.ReporteID,
+		&i.TipoReporte,
+		&i.FechaGeneracion,
+		&i.PeriodoInicio,
+		&i.PeriodoFin,
+		&i.GeneradoPor,
+		&i.Datos,
+		&i.Estado,
+		&i.Progreso,
+		&i.Intentos,
+		&i.UltimoError,
+		&i.ProximoIntento,
+		&i.FechaInicio,
+		&i.FechaFinalizacion,
+		&i.ProgramacionID,
+	)
+	return i, err
+}
+
+const createReporteProgramacion = `-- name: CreateReporteProgramacion :one
+
+INSERT INTO REPORTE_PROGRAMACIONES (nombre, tipo_reporte, cron, periodo, destinatarios, adjuntar_pdf, activo, creado_por, proxima_ejecucion)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING programacion_id, nombre, tipo_reporte, cron, periodo, destinatarios, adjuntar_pdf, activo, creado_por, proxima_ejecucion, ultima_ejecucion, fecha_creacion
+`
+
+type CreateReporteProgramacionParams struct {
+	Nombre           string
+	TipoReporte      string
+	Cron             string
+	Periodo          string
+	Destinatarios    []string
+	AdjuntarPdf      bool
+	Activo           bool
+	CreadoPor        int32
+	ProximaEjecucion pgtype.Timestamp
+}
+
+// ========================================
+// REPORTE PROGRAMACIONES QUERIES
+// ========================================
+func (q *Queries) CreateReporteProgramacion(ctx context.Context, arg CreateReporteProgramacionParams) (ReporteProgramacione, error) {
+	row := q.db.QueryRow(ctx, createReporteProgramacion,
+		arg.Nombre,
+		arg.TipoReporte,
+		arg.Cron,
+		arg.Periodo,
+		arg.Destinatarios,
+		arg.AdjuntarPdf,
+		arg.Activo,
+		arg.CreadoPor,
+		arg.ProximaEjecucion,
+	)
+	var i ReporteProgramacione
+	err := row.Scan(
+		&i.ProgramacionID,
+		&i.Nombre,
+		&i.TipoReporte,
+		&i.Cron,
+		&i.Periodo,
+		&i.Destinatarios,
+		&i.AdjuntarPdf,
+		&i.Activo,
+		&i.CreadoPor,
+		&i.ProximaEjecucion,
+		&i.UltimaEjecucion,
+		&i.FechaCreacion,
+	)
+	return i, err
+}
+
+const createSolicitudFallida = `-- name: CreateSolicitudFallida :exec
+
+INSERT INTO SOLICITUDES_FALLIDAS (estudiante_id, materia_id, tutor_id, fecha, hora_inicio, hora_fin, motivo)
+VALUES (
+    (SELECT estudiante_id FROM ESTUDIANTES WHERE estudiante_id = $1),
+    $2,
+    (SELECT tutor_id FROM TUTORES WHERE tutor_id = $3),
+    $4,
+    $5,
+    $6,
+    $7
+)
+`
+
+type CreateSolicitudFallidaParams struct {
+	EstudianteID int32
+	MateriaID    int32
+	TutorID      pgtype.Int4
+	Fecha        pgtype.Date
+	HoraInicio   pgtype.Time
+	HoraFin      pgtype.Time
+	Motivo       string
+}
+
+// ========================================
+// SOLICITUDES FALLIDAS QUERIES
+// ========================================
+// Unknown estudiante and tutor IDs are stored as NULL so that the attempt is always recorded.
+func (q *Queries) CreateSolicitudFallida(ctx context.Context, arg CreateSolicitudFallidaParams) error {
+	_, err := q.db.Exec(ctx, createSolicitudFallida,
+		arg.EstudianteID,
+		arg.MateriaID,
+		arg.TutorID,
+		arg.Fecha,
+		arg.HoraInicio,
+		arg.HoraFin,
+		arg.Motivo,
+	)
+	return err
+}
+
+const createSolicitudTutor = `-- name: CreateSolicitudTutor :one
+
+INSERT INTO SOLICITUDES_TUTOR (estudiante_id, motivacion, materias)
+VALUES ($1, $2, $3)
+RETURNING solicitud_id, estudiante_id, motivacion, materias, estado, motivo_rechazo, revisado_por, tutor_id, fecha_solicitud, fecha_revision
+`
+
+type CreateSolicitudTutorParams struct {
+	EstudianteID int32
+	Motivacion   string
+	Materias     []int32
+}
+
+// ========================================
+// SOLICITUDES TUTOR QUERIES
+// ========================================
+func (q *Queries) CreateSolicitudTutor(ctx context.Context, arg CreateSolicitudTutorParams) (SolicitudesTutor, error) {
+	row := q.db.QueryRow(ctx, createSolicitudTutor, arg.EstudianteID, arg.Motivacion, arg.Materias)
+	var i SolicitudesTutor
+	err := row.Scan(
+		&i.SolicitudID,
+		&i.EstudianteID,
+		&i.Motivacion,
+		&i.Materias,
+		&i.Estado,
+		&i.MotivoRechazo,
+		&i.RevisadoPor,
+		&i.TutorID,
+		&i.FechaSolicitud,
+		&i.FechaRevision,
+	)
+	return i, err
+}
+
+const createTutor = `-- name: CreateTutor :one
+
+INSERT INTO TUTORES (nombre, apellido, correo, programa_academico)
+VALUES ($1, $2, $3, $4)
+RETURNING tutor_id, nombre, apellido, correo, programa_academico, fecha_registro, deleted_at
+`
+
+type CreateTutorParams struct {
+	Nombre            string
+	Apellido          string
+	Correo            string
+	ProgramaAcademico pgtype.Text
+}
+
+// ========================================
+// TUTORES QUERIES
+// ========================================
+func (q *Queries) CreateTutor(ctx context.Context, arg CreateTutorParams) (Tutore, error) {
+	row := q.db.QueryRow(ctx, createTutor,
+		arg.Nombre,
+		arg.Apellido,
+		arg.Correo,
+		arg.ProgramaAcademico,
+	)
+	var i Tutore
+	err := row.Scan(
+		&i.TutorID,
+		&i.Nombre,
+		&i.Apellido,
+		&i.Correo,
+		&i.ProgramaAcademico,
+		&i.FechaRegistro,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const createTutorMateria = `-- name: CreateTutorMateria :one
+
+INSERT INTO TUTOR_MATERIAS (tutor_id, materia_id, fecha_asignacion, activo)
+VALUES ($1, $2, $3, $4)
+RETURNING asignacion_id, tutor_id, materia_id, fecha_asignacion, activo
+`
+
+type CreateTutorMateriaParams struct {
+	TutorID         int32
+	MateriaID       int32
+	FechaAsignacion pgtype.Date
+	Activo          bool
+}
+
+// ========================================
+// TUTOR_MATERIAS QUERIES
+// ========================================
+func (q *Queries) CreateTutorMateria(ctx context.Context, arg CreateTutorMateriaParams) (TutorMateria, error) {
+	row := q.db.QueryRow(ctx, createTutorMateria,
+		arg.TutorID,
+		arg.MateriaID,
+		arg.FechaAsignacion,
+		arg.Activo,
+	)
+	var i TutorMateria
+	err := row.Scan(
+		&i.AsignacionID,
+		&i.TutorID,
+		&i.MateriaID,
+		&i.FechaAsignacion,
+		&i.Activo,
+	)
+	return i, err
+}
+
+const createTutorPostulacion = `-- name: CreateTutorPostulacion :one
+INSERT INTO TUTOR_POSTULACIONES (tutor_id, materia_id, comentario)
+VALUES ($1, $2, $3)
+RETURNING postulacion_id, tutor_id, materia_id, comentario, estado, motivo_rechazo, revisado_por, fecha_postulacion, fecha_revision
+`
+
+type CreateTutorPostulacionParams struct {
+	TutorID    int32
+	MateriaID  int32
+	Comentario pgtype.Text
+}
+
+func (q *Queries) CreateTutorPostulacion(ctx context.Context, arg CreateTutorPostulacionParams) (TutorPostulacione, error) {
+	row := q.db.QueryRow(ctx, createTutorPostulacion, arg.TutorID, arg.MateriaID, arg.Comentario)
+	var i TutorPostulacione
+	err := row.Scan(
+		&i.PostulacionID,
+		&i.TutorID,
+		&i.MateriaID,
+		&i.Comentario,
+		&i.Estado,
+		&i.MotivoRechazo,
+		&i.RevisadoPor,
+		&i.FechaPostulacion,
+		&i.FechaRevision,
+	)
+	return i, err
+}
+
+const createTutoria = `-- name: CreateTutoria :one
+
+INSERT INTO TUTORIAS (estudiante_id, tutor_id, materia_id, fecha, hora_inicio, hora_fin, estado, fecha_solicitud, lugar)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING tutoria_id, estudiante_id, tutor_id, materia_id, fecha, hora_inicio, hora_fin, estado, fecha_solicitud, fecha_confirmacion, temas_tratados, asistencia_confirmada, lugar
+`
+
+type CreateTutoriaParams struct {
+	EstudianteID   int32
+	TutorID        int32
+	MateriaID      int32
+	Fecha          pgtype.Date
+	HoraInicio     pgtype.Time
+	HoraFin        pgtype.Time
+	Estado         string
+	FechaSolicitud pgtype.Timestamp
+	Lugar          string
+}
+
+// ========================================
+// TUTORIAS QUERIES
+// ========================================
+func (q *Queries) CreateTutoria(ctx context.Context, arg CreateTutoriaParams) (Tutoria, error) {
+	row := q.db.QueryRow(ctx, createTutoria,
+		arg.EstudianteID,
+		arg.TutorID,
+		arg.MateriaID,
+		arg.Fecha,
+		arg.HoraInicio,
+		arg.HoraFin,
+		arg.Estado,
+		arg.FechaSolicitud,
+		arg.Lugar,
+	)
+	var i Tutoria
+	err := row.Scan(
+		&i.TutoriaID,
+		&i.EstudianteID,
+		&i.TutorID,
+		&i.MateriaID,
+		&i.Fecha,
+		&i.HoraInicio,
+		&i.HoraFin,
+		&i.Estado,
+		&i.FechaSolicitud,
+		&i.FechaConfirmacion,
+		&i.TemasTratados,
+		&i.AsistenciaConfirmada,
+		&i.Lugar,
+	)
+	return i, err
+}
+
+const createWebhook = `-- name: CreateWebhook :one
+
+INSERT INTO WEBHOOKS (url, secreto, eventos, activo, creado_por)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING webhook_id, url, secreto, eventos, activo, creado_por, fecha_creacion
+`
+
+type CreateWebhookParams struct {
+	Url       string
+	Secreto   string
+	Eventos   []string
+	Activo    bool
+	CreadoPor pgtype.Int4
+}
+
+// ========================================
+// WEBHOOKS QUERIES
+// ========================================
+func (q *Queries) CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error) {
+	row := q.db.QueryRow(ctx, createWebhook,
+		arg.Url,
+		arg.Secreto,
+		arg.Eventos,
+		arg.Activo,
+		arg.CreadoPor,
+	)
+	var i Webhook
+	err := row.Scan(
+		&i.WebhookID,
+		&i.Url,
+		&i.Secreto,
+		&i.Eventos,
+		&i.Activo,
+		&i.CreadoPor,
+		&i.FechaCreacion,
+	)
+	return i, err
+}
+
+const createWebhookEntrega = `-- name: CreateWebhookEntrega :one
+INSERT INTO WEBHOOK_ENTREGAS (webhook_id, evento, payload)
+VALUES ($1, $2, $3)
+RETURNING entrega_id, webhook_id, evento, payload, estado, intentos, ultimo_codigo, ultimo_error, fecha_creacion, fecha_ultimo_intento, proximo_intento
+`
+
+type CreateWebhookEntregaParams struct {
+	WebhookID int32
+	Evento    string
+	Payload   []byte
+}
+
+func (q *Queries) CreateWebhookEntrega(ctx context.Context, arg CreateWebhookEntregaParams) (WebhookEntrega, error) {
+	row := q.db.QueryRow(ctx, createWebhookEntrega, arg.WebhookID, arg.Evento, arg.Payload)
+	var i WebhookEntrega
+	err := row.Scan(
+		&i.EntregaID,
+		&i.WebhookID,
+		&i.Evento,
+		&i.Payload,
+		&i.Estado,
+		&i.Intentos,
+		&i.UltimoCodigo,
+		&i.UltimoError,
+		&i.FechaCreacion,
+		&i.FechaUltimoIntento,
+		&i.ProximoIntento,
+	)
+	return i, err
+}
+
+const deleteAdmin = `-- name: DeleteAdmin :exec
+DELETE FROM ADMINS WHERE admin_id = $1
+`
+
+func (q *Queries) DeleteAdmin(ctx context.Context, adminID int32) error {
+	_, err := q.db.Exec(ctx, deleteAdmin, adminID)
+	return err
+}
+
+const deleteDisponibilidad = `-- name: DeleteDisponibilidad :exec
+DELETE FROM DISPONIBILIDAD WHERE disponibilidad_id = $1
+`
+
+func (q *Queries) DeleteDisponibilidad(ctx context.Context, disponibilidadID int32) error {
+	_, err := q.db.Exec(ctx, deleteDisponibilidad, disponibilidadID)
+	return err
+}
+
+const deleteInscripcion = `-- name: DeleteInscripcion :execrows
+DELETE FROM INSCRIPCIONES WHERE inscripcion_id = $1
+`
+
+func (q *Queries) DeleteInscripcion(ctx context.Context, inscripcionID int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteInscripcion, inscripcionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteReporte = `-- name: DeleteReporte :exec
+DELETE FROM REPORTES WHERE reporte_id = $1
+`
+
+func (q *Queries) DeleteReporte(ctx context.Context, reporteID int32) error {
+	_, err := q.db.Exec(ctx, deleteReporte, reporteID)
+	return err
+}
+
+const deleteReporteProgramacion = `-- name: DeleteReporteProgramacion :exec
+DELETE FROM REPORTE_PROGRAMACIONES WHERE programacion_id = $1
+`
+
+func (q *Queries) DeleteReporteProgramacion(ctx context.Context, programacionID int32) error {
+	_, err := q.db.Exec(ctx, deleteReporteProgramacion, programacionID)
+	return err
+}
+
+const deleteTutorMateria = `-- name: DeleteTutorMateria :exec
+DELETE FROM TUTOR_MATERIAS WHERE asignacion_id = $1
+`
+
+func (q *Queries) DeleteTutorMateria(ctx context.Context, asignacionID int32) error {
+	_, err := q.db.Exec(ctx, deleteTutorMateria, asignacionID)
+	return err
+}
+
+const deleteTutoria = `-- name: DeleteTutoria :exec
+DELETE FROM TUTORIAS WHERE tutoria_id = $1
+`
+
+func (q *Queries) DeleteTutoria(ctx context.Context, tutoriaID int32) error {
+	_, err := q.db.Exec(ctx, deleteTutoria, tutoriaID)
+	return err
+}
+
+const deleteWebhook = `-- name: DeleteWebhook :exec
+DELETE FROM WEBHOOKS WHERE webhook_id = $1
+`
+
+func (q *Queries) DeleteWebhook(ctx context.Context, webhookID int32) error {
+	_, err := q.db.Exec(ctx, deleteWebhook, webhookID)
+	return err
+}
+
+const enqueueReporte = `-- name: EnqueueReporte :one
+INSERT INTO REPORTES (tipo_reporte, periodo_inicio, periodo_fin, generado_por, estado, progreso)
+VALUES ($1, $2, $3, $4, 'pendiente', 0)
+RETURNING reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos, estado, progreso, intentos, ultimo_error, proximo_intento, fecha_inicio, fecha_finalizacion, programacion_id
+`
+
+type EnqueueReporteParams struct {
+	TipoReporte   string
+	PeriodoInicio pgtype.Date
+	PeriodoFin    pgtype.Date
+	GeneradoPor   int32
+}
+
+func (q *Queries) EnqueueReporte(ctx context.Context, arg EnqueueReporteParams) (Reporte, error) {
+	row := q.db.QueryRow(ctx, enqueueReporte, arg.TipoReporte, arg.PeriodoInicio, arg.PeriodoFin, arg.GeneradoPor)
+	var i Reporte
+	err := row.Scan(
+		&i.ReporteID,
+		&i.TipoReporte,
+		&i.FechaGeneracion,
+		&i.PeriodoInicio,
+		&i.PeriodoFin,
+		&i.GeneradoPor,
+		&i.Datos,
+		&i.Estado,
+		&i.Progreso,
+		&i.Intentos,
+		&i.UltimoError,
+		&i.ProximoIntento,
+		&i.FechaInicio,
+		&i.FechaFinalizacion,
+		&i.ProgramacionID,
+	)
+	return i, err
+}
+
+const enqueueReporteProgramado = `-- name: EnqueueReporteProgramado :one
+INSERT INTO REPORTES (tipo_reporte, periodo_inicio, periodo_fin, generado_por, programacion_id, estado, progreso)
+VALUES ($1, $2, $3, $4, $5, 'pendiente', 0)
+ON CONFLICT (programacion_id, periodo_inicio, periodo_fin) WHERE programacion_id IS NOT NULL DO NOTHING
+RETURNING reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos, estado, progreso, intentos, ultimo_error, proximo_intento, fecha_inicio, fecha_finalizacion, programacion_id
+`
+
+type EnqueueReporteProgramadoParams struct {
+	TipoReporte    string
+	PeriodoInicio  pgtype.Date
+	PeriodoFin     pgtype.Date
+	GeneradoPor    int32
+	ProgramacionID pgtype.Int4
+}
+
+// Returns no rows when the programacion already has a reporte for the period.
+func (q *Queries) EnqueueReporteProgramado(ctx context.Context, arg EnqueueReporteProgramadoParams) (Reporte, error) {
+	row := q.db.QueryRow(ctx, enqueueReporteProgramado, arg.TipoReporte, arg.PeriodoInicio, arg.PeriodoFin, arg.GeneradoPor, arg.ProgramacionID)
+	var i Reporte
+	err := row.Scan(
+		&i.ReporteID,
+		&i.TipoReporte,
+		&i.FechaGeneracion,
+		&i.PeriodoInicio,
+		&i.PeriodoFin,
+		&i.GeneradoPor,
+		&i.Datos,
+		&i.Estado,
+		&i.Progreso,
+		&i.Intentos,
+		&i.UltimoError,
+		&i.ProximoIntento,
+		&i.FechaInicio,
+		&i.FechaFinalizacion,
+		&i.ProgramacionID,
+	)
+	return i, err
+}
+
+const estudianteInscrito = `-- name: EstudianteInscrito :one
+SELECT EXISTS (
+    SELECT 1 FROM INSCRIPCIONES
+    WHERE estudiante_id = $1 AND materia_id = $2 AND periodo = $3
+)
+`
+
+type EstudianteInscritoParams struct {
+	EstudianteID int32
+	MateriaID    int32
+	Periodo      string
+}
+
+func (q *Queries) EstudianteInscrito(ctx context.Context, arg EstudianteInscritoParams) (bool, error) {
+	row := q.db.QueryRow(ctx, estudianteInscrito, arg.EstudianteID, arg.MateriaID, arg.Periodo)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const failReporte = `-- name: FailReporte :execrows
+UPDATE REPORTES
+SET estado = $1, ultimo_error = $2,
+    proximo_intento = CURRENT_TIMESTAMP + make_interval(secs => $3::float8),
+    fecha_finalizacion = CASE WHEN $1 = 'fallido' THEN CURRENT_TIMESTAMP END
+WHERE reporte_id = $4 AND estado = 'en_progreso'
+`
+
+type FailReporteParams struct {
+	Estado         string
+	UltimoError    pgtype.Text
+	EsperaSegundos float64
+	ReporteID      int32
+}
+
+// estado is 'pendiente' to retry espera_segundos from now, on the database clock, or 'fallido'
+// when attempts are exhausted.
+func (q *Queries) FailReporte(ctx context.Context, arg FailReporteParams) (int64, error) {
+	result, err := q.db.Exec(ctx, failReporte,
+		arg.Estado,
+		arg.UltimoError,
+		arg.EsperaSegundos,
+		arg.ReporteID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getMateriaIdByName = `-- name: GetMateriaIdByName :one
+SELECT materia_id FROM MATERIAS WHERE nombre = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetMateriaIdByName(ctx context.Context, nombre string) (int32, error) {
+	row := q.db.QueryRow(ctx, getMateriaIdByName, nombre)
+	var materia_id int32
+	err := row.Scan(&materia_id)
+	return materia_id, err
+}
+
+const getProximasTutoriasByEstudiante = `-- name: GetProximasTutoriasByEstudiante :many
+SELECT tutoria_id, estudiante_id, tutor_id, materia_id, fecha, hora_inicio, hora_fin, estado, fecha_solicitud, fecha_confirmacion, temas_tratados, asistencia_confirmada, lugar
+FROM TUTORIAS
+WHERE estudiante_id = $1
+  AND (
+    fecha > CURRENT_DATE OR
+    (fecha = CURRENT_DATE AND hora_inicio > CURRENT_TIME)
+  )
+ORDER BY fecha, hora_inicio
+`
+
+func (q *Queries) GetProximasTutoriasByEstudiante(ctx context.Context, estudianteID int32) ([]Tutoria, error) {
+	rows, err := q.db.Query(ctx, getProximasTutoriasByEstudiante, estudianteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tutoria
+	for rows.Next() {
+		var i Tutoria
+		if err := rows.Scan(
+			&i.TutoriaID,
+			&i.EstudianteID,
+			&i.TutorID,
+			&i.MateriaID,
+			&i.Fecha,
+			&i.HoraInicio,
+			&i.HoraFin,
+			&i.Estado,
+			&i.FechaSolicitud,
+			&i.FechaConfirmacion,
+			&i.TemasTratados,
+			&i.AsistenciaConfirmada,
+			&i.Lugar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTutorMaterias = `-- name: GetTutorMaterias :many
+SELECT m.materia_id, m.nombre, m.codigo, m.facultad, m.descripcion, m.creditos, m.activo, m.deleted_at
+FROM MATERIAS m
+JOIN TUTOR_MATERIAS tm ON m.materia_id = tm.materia_id
+WHERE tm.tutor_id = $1 AND tm.activo = true AND m.deleted_at IS NULL
+ORDER BY m.codigo
+`
+
+func (q *Queries) GetTutorMaterias(ctx context.Context, tutorID int32) ([]Materia, error) {
+	rows, err := q.db.Query(ctx, getTutorMaterias, tutorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Materia
+	for rows.Next() {
+		var i Materia
+		if err := rows.Scan(
+			&i.MateriaID,
+			&i.Nombre,
+			&i.Codigo,
+			&i.Facultad,
+			&i.Descripcion,
+			&i.Creditos,
+			&i.Activo,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTutorNameById = `-- name: GetTutorNameById :one
+SELECT nombre, apellido FROM TUTORES WHERE tutor_id = $1
+`
+
+type GetTutorNameByIdRow struct {
+	Nombre   string
+	Apellido string
+}
+
+func (q *Queries) GetTutorNameById(ctx context.Context, tutorID int32) (GetTutorNameByIdRow, error) {
+	row := q.db.QueryRow(ctx, getTutorNameById, tutorID)
+	var i GetTutorNameByIdRow
+	err := row.Scan(&i.Nombre, &i.Apellido)
+	return i, err
+}
+
+const listAdmins = `-- name: ListAdmins :many
+SELECT admin_id, nombre, apellido, correo, rol, activo, fecha_registro FROM ADMINS ORDER BY apellido, nombre
+`
+
+type ListAdminsRow struct {
+	AdminID       int32
+	Nombre        string
+	Apellido      string
+	Correo        string
+	Rol           string
+	Activo        pgtype.Bool
+	FechaRegistro pgtype.Timestamptz
+}
+
+func (q *Queries) ListAdmins(ctx context.Context) ([]ListAdminsRow, error) {
+	rows, err := q.db.Query(ctx, listAdmins)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAdminsRow
+	for rows.Next() {
+		var i ListAdminsRow
+		if err := rows.Scan(
+			&i.AdminID,
+			&i.Nombre,
+			&i.Apellido,
+			&i.Correo,
+			&i.Rol,
+			&i.Activo,
+			&i.FechaRegistro,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditoriaEventos = `-- name: ListAuditoriaEventos :many
+SELECT evento_id, admin_id, actor, metodo, ruta, entidad, entidad_id, estado_http, antes, despues, ip, fecha FROM AUDITORIA_EVENTOS
+WHERE ($1::text IS NULL OR entidad = $1)
+  AND ($2::int IS NULL OR entidad_id = $2)
+  AND ($3::int IS NULL OR admin_id = $3)
+  AND ($4::text IS NULL OR metodo = $4)
+  AND ($5::date IS NULL OR fecha >= $5)
+  AND ($6::date IS NULL OR fecha < $6::date + 1)
+ORDER BY fecha DESC, evento_id DESC
+LIMIT $7
+`
+
+type ListAuditoriaEventosParams struct {
+	Entidad   pgtype.Text
+	EntidadID pgtype.Int4
+	AdminID   pgtype.Int4
+	Metodo    pgtype.Text
+	Desde     pgtype.Date
+	Hasta     pgtype.Date
+	RowLimit  int32
+}
+
+func (q *Queries) ListAuditoriaEventos(ctx context.Context, arg ListAuditoriaEventosParams) ([]AuditoriaEvento, error) {
+	rows, err := q.db.Query(ctx, listAuditoriaEventos, arg.Entidad, arg.EntidadID, arg.AdminID, arg.Metodo, arg.Desde, arg.Hasta, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditoriaEvento
+	for rows.Next() {
+		var i AuditoriaEvento
+		if err := rows.Scan(
+			&i.EventoID,
+			&i.AdminID,
+			&i.Actor,
+			&i.Metodo,
+			&i.Ruta,
+			&i.Entidad,
+			&i.EntidadID,
+			&i.EstadoHttp,
+			&i.Antes,
+			&i.Despues,
+			&i.Ip,
+			&i.Fecha,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDisponibilidadByDia = `-- name: ListDisponibilidadByDia :many
+SELECT d.disponibilidad_id, d.tutor_id, d.dia_semana, d.hora_inicio, d.hora_fin, t.nombre as tutor_nombre, t.apellido as tutor_apellido
+FROM DISPONIBILIDAD d
+JOIN TUTORES t ON d.tutor_id = t.tutor_id
+WHERE d.dia_semana = $1
+ORDER BY d.hora_inicio
+`
+
+type ListDisponibilidadByDiaRow struct {
+	DisponibilidadID int32
+	TutorID          int32
+	DiaSemana        int32
+	HoraInicio       pgtype.Time
+	HoraFin          pgtype.Time
+	TutorNombre      string
+	TutorApellido    string
+}
+
+func (q *Queries) ListDisponibilidadByDia(ctx context.Context, diaSemana int32) ([]ListDisponibilidadByDiaRow, error) {
+	rows, err := q.db.Query(ctx, listDisponibilidadByDia, diaSemana)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDisponibilidadByDiaRow
+	for rows.Next() {
+		var i ListDisponibilidadByDiaRow
+		if err := rows.Scan(
+			&i.DisponibilidadID,
+			&i.TutorID,
+			&i.DiaSemana,
+			&i.HoraInicio,
+			&i.HoraFin,
+			&i.TutorNombre,
+			&i.TutorApellido,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDisponibilidadByTutor = `-- name: ListDisponibilidadByTutor :many
+SELECT disponibilidad_id, tutor_id, dia_semana, hora_inicio, hora_fin FROM DISPONIBILIDAD 
+WHERE tutor_id = $1 
+ORDER BY dia_semana, hora_inicio
+`
+
+func (q *Queries) ListDisponibilidadByTutor(ctx context.Context, tutorID int32) ([]Disponibilidad, error) {
+	rows, err := q.db.Query(ctx, listDisponibilidadByTutor, tutorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Disponibilidad
+	for rows.Next() {
+		var i Disponibilidad
+		if err := rows.Scan(
+			&i.DisponibilidadID,
+			&i.TutorID,
+			&i.DiaSemana,
+			&i.HoraInicio,
+			&i.HoraFin,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEstudianteCorreos = `-- name: ListEstudianteCorreos :many
+SELECT estudiante_id, correo FROM ESTUDIANTES WHERE deleted_at IS NULL
+`
+
+type ListEstudianteCorreosRow struct {
+	EstudianteID int32
+	Correo       string
+}
+
+func (q *Queries) ListEstudianteCorreos(ctx context.Context) ([]ListEstudianteCorreosRow, error) {
+	rows, err := q.db.Query(ctx, listEstudianteCorreos)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEstudianteCorreosRow
+	for rows.Next() {
+		var i ListEstudianteCorreosRow
+		if err := rows.Scan(&i.EstudianteID, &i.Correo); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEstudiantes = `-- name: ListEstudiantes :many
+SELECT e.estudiante_id, e.nombre, e.apellido, e.correo, e.programa_academico, e.semestre, e.fecha_registro, e.ti, e.deleted_at FROM ESTUDIANTES e
+CROSS JOIN LATERAL (
+    SELECT CASE $1::text
+        WHEN 'nombre' THEN e.nombre || ' ' || e.apellido
+        WHEN 'correo' THEN e.correo
+        WHEN 'programa_academico' THEN e.programa_academico
+        WHEN 'semestre' THEN lpad(COALESCE(e.semestre, 0)::text, 10, '0')
+        WHEN 'fecha_registro' THEN COALESCE(to_char(e.fecha_registro, 'YYYY-MM-DD HH24:MI:SS.US'), '')
+        WHEN 'id' THEN lpad(e.estudiante_id::text, 10, '0')
+        ELSE e.apellido || ' ' || e.nombre
+    END AS valor
+) k
+WHERE e.deleted_at IS NULL AND ($2::int IS NULL
+    OR (NOT $3::bool AND (k.valor, e.estudiante_id) > ($4::text, $2::int))
+    OR ($3::bool AND (k.valor, e.estudiante_id) < ($4::text, $2::int)))
+ORDER BY
+    CASE WHEN NOT $3::bool THEN k.valor END,
+    CASE WHEN $3::bool THEN k.valor END DESC,
+    CASE WHEN NOT $3::bool THEN e.estudiante_id END,
+    e.estudiante_id DESC
+LIMIT $5
+`
+
+type ListEstudiantesParams struct {
+	Sort       string
+	AfterID    pgtype.Int4
+	Descending bool
+	AfterValor pgtype.Text
+	RowLimit   int32
+}
+
+// Keyset pagination: k.valor is the sort key as text, and rows come after the
+// (after_valor, after_id) cursor in the requested direction.
+func (q *Queries) ListEstudiantes(ctx context.Context, arg ListEstudiantesParams) ([]Estudiante, error) {
+	rows, err := q.db.Query(ctx, listEstudiantes, arg.Sort, arg.AfterID, arg.Descending, arg.AfterValor, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Estudiante
+	for rows.Next() {
+		var i Estudiante
+		if err := rows.Scan(
+			&i.EstudianteID,
+			&i.Nombre,
+			&i.Apellido,
+			&i.Correo,
+			&i.ProgramaAcademico,
+			&i.Semestre,
+			&i.FechaRegistro,
+			&i.Ti,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEstudiantesByPrograma = `-- name: ListEstudiantesByPrograma :many
+SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti, deleted_at FROM ESTUDIANTES WHERE programa_academico = $1 AND deleted_at IS NULL ORDER BY apellido, nombre
+`
+
+func (q *Queries) ListEstudiantesByPrograma(ctx context.Context, programaAcademico string) ([]Estudiante, error) {
+	rows, err := q.db.Query(ctx, listEstudiantesByPrograma, programaAcademico)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Estudiante
+	for rows.Next() {
+		var i Estudiante
+		if err := rows.Scan(
+			&i.EstudianteID,
+			&i.Nombre,
+			&i.Apellido,
+			&i.Correo,
+			&i.ProgramaAcademico,
+			&i.Semestre,
+			&i.FechaRegistro,
+			&i.Ti,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEstudiantesBySemestre = `-- name: ListEstudiantesBySemestre :many
+SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti, deleted_at FROM ESTUDIANTES 
+WHERE semestre = $1 AND deleted_at IS NULL
+ORDER BY apellido, nombre
+`
+
+func (q *Queries) ListEstudiantesBySemestre(ctx context.Context, semestre pgtype.Int4) ([]Estudiante, error) {
+	rows, err := q.db.Query(ctx, listEstudiantesBySemestre, semestre)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Estudiante
+	for rows.Next() {
+		var i Estudiante
+		if err := rows.Scan(
+			&i.EstudianteID,
+			&i.Nombre,
+			&i.Apellido,
+			&i.Correo,
+			&i.ProgramaAcademico,
+			&i.Semestre,
+			&i.FechaRegistro,
+			&i.Ti,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInscripciones = `-- name: ListInscripciones :many
+SELECT i.inscripcion_id, i.estudiante_id, i.materia_id, i.periodo, i.fecha_inscripcion,
+    (e.nombre || ' ' || e.apellido)::text AS estudiante,
+    e.correo,
+    m.codigo AS materia_codigo,
+    m.nombre AS materia
+FROM INSCRIPCIONES i
+JOIN ESTUDIANTES e ON e.estudiante_id = i.estudiante_id
+JOIN MATERIAS m ON m.materia_id = i.materia_id
+WHERE ($1::int IS NULL OR i.estudiante_id = $1)
+  AND ($2::int IS NULL OR i.materia_id = $2)
+  AND ($3::text IS NULL OR i.periodo = $3)
+ORDER BY i.periodo DESC, m.codigo, e.apellido, e.nombre
+LIMIT $4
+`
+
+type ListInscripcionesParams struct {
+	EstudianteID pgtype.Int4
+	MateriaID    pgtype.Int4
+	Periodo      pgtype.Text
+	RowLimit     int32
+}
+
+type ListInscripcionesRow struct {
+	InscripcionID    int32
+	EstudianteID     int32
+	MateriaID        int32
+	Periodo          string
+	FechaInscripcion pgtype.Timestamp
+	Estudiante       string
+	Correo           string
+	MateriaCodigo    string
+	Materia          string
+}
+
+func (q *Queries) ListInscripciones(ctx context.Context, arg ListInscripcionesParams) ([]ListInscripcionesRow, error) {
+	rows, err := q.db.Query(ctx, listInscripciones, arg.EstudianteID, arg.MateriaID, arg.Periodo, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListInscripcionesRow
+	for rows.Next() {
+		var i ListInscripcionesRow
+		if err := rows.Scan(
+			&i.InscripcionID,
+			&i.EstudianteID,
+			&i.MateriaID,
+			&i.Periodo,
+			&i.FechaInscripcion,
+			&i.Estudiante,
+			&i.Correo,
+			&i.MateriaCodigo,
+			&i.Materia,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMateriaNames = `-- name: ListMateriaNames :many
+SELECT nombre 
+FROM MATERIAS
+WHERE activo = true AND deleted_at IS NULL
+ORDER BY nombre
+`
+
+func (q *Queries) ListMateriaNames(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, listMateriaNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var nombre string
+		if err := rows.Scan(&nombre); err != nil {
+			return nil, err
+		}
+		items = append(items, nombre)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMateriaNombres = `-- name: ListMateriaNombres :many
+SELECT materia_id, nombre, codigo FROM MATERIAS WHERE activo = true AND deleted_at IS NULL ORDER BY codigo
+`
+
+type ListMateriaNombresRow struct {
+	MateriaID int32
+	Nombre    string
+	Codigo    string
+}
+
+func (q *Queries) ListMateriaNombres(ctx context.Context) ([]ListMateriaNombresRow, error) {
+	rows, err := q.db.Query(ctx, listMateriaNombres)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMateriaNombresRow
+	for rows.Next() {
+		var i ListMateriaNombresRow
+		if err := rows.Scan(&i.MateriaID, &i.Nombre, &i.Codigo); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMaterias = `-- name: ListMaterias :many
+SELECT m.materia_id, m.nombre, m.codigo, m.facultad, m.descripcion, m.creditos, m.activo, m.deleted_at FROM MATERIAS m
+CROSS JOIN LATERAL (
+    SELECT CASE $1::text
+        WHEN 'nombre' THEN m.nombre
+        WHEN 'facultad' THEN m.facultad
+        WHEN 'creditos' THEN lpad(m.creditos::text, 10, '0')
+        WHEN 'id' THEN lpad(m.materia_id::text, 10, '0')
+        ELSE m.codigo
+    END AS valor
+) k
+WHERE m.deleted_at IS NULL AND ($2::int IS NULL
+    OR (NOT $3::bool AND (k.valor, m.materia_id) > ($4::text, $2::int))
+    OR ($3::bool AND (k.valor, m.materia_id) < ($4::text, $2::int)))
+ORDER BY
+    CASE WHEN NOT $3::bool THEN k.valor END,
+    CASE WHEN $3::bool THEN k.valor END DESC,
+    CASE WHEN NOT $3::bool THEN m.materia_id END,
+    m.materia_id DESC
+LIMIT $5
+`
+
+type ListMateriasParams struct {
+	Sort       string
+	AfterID    pgtype.Int4
+	Descending bool
+	AfterValor pgtype.Text
+	RowLimit   int32
+}
+
+func (q *Queries) ListMaterias(ctx context.Context, arg ListMateriasParams) ([]Materia, error) {
+	rows, err := q.db.Query(ctx, listMaterias, arg.Sort, arg.AfterID, arg.Descending, arg.AfterValor, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Materia
+	for rows.Next() {
+		var i Materia
+		if err := rows.Scan(
+			&i.MateriaID,
+			&i.Nombre,
+			&i.Codigo,
+			&i.Facultad,
+			&i.Descripcion,
+			&i.Creditos,
+			&i.Activo,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMateriasByFacultad = `-- name: ListMateriasByFacultad :many
+SELECT materia_id, nombre, codigo, facultad, descripcion, creditos, activo, deleted_at FROM MATERIAS WHERE facultad = $1 AND deleted_at IS NULL ORDER BY codigo
+`
+
+func (q *Queries) ListMateriasByFacultad(ctx context.Context, facultad string) ([]Materia, error) {
+	rows, err := q.db.Query(ctx, listMateriasByFacultad, facultad)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Materia
+	for rows.Next() {
+		var i Materia
+		if err := rows.Scan(
+			&i.MateriaID,
+			&i.Nombre,
+			&i.Codigo,
+			&i.Facultad,
+			&i.Descripcion,
+			&i.Creditos,
+			&i.Activo,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMateriasByTutor = `-- name: ListMateriasByTutor :many
+SELECT tm.asignacion_id, tm.tutor_id, tm.materia_id, tm.fecha_asignacion, tm.activo, m.nombre as materia_nombre, m.codigo as materia_codigo
+FROM TUTOR_MATERIAS tm
+JOIN MATERIAS m ON tm.materia_id = m.materia_id
+WHERE tm.tutor_id = $1 AND tm.activo = true AND m.deleted_at IS NULL
+ORDER BY m.codigo
+`
+
+type ListMateriasByTutorRow struct {
+	AsignacionID    int32
+	TutorID         int32
+	MateriaID       int32
+	FechaAsignacion pgtype.Date
+	Activo          bool
+	MateriaNombre   string
+	MateriaCodigo   string
+}
+
+func (q *Queries) ListMateriasByTutor(ctx context.Context, tutorID int32) ([]ListMateriasByTutorRow, error) {
+	rows, err := q.db.Query(ctx, listMateriasByTutor, tutorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMateriasByTutorRow
+	for rows.Next() {
+		var i ListMateriasByTutorRow
+		if err := rows.Scan(
+			&i.AsignacionID,
+			&i.TutorID,
+			&i.MateriaID,
+			&i.FechaAsignacion,
+			&i.Activo,
+			&i.MateriaNombre,
+			&i.MateriaCodigo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMateriasCatalogo = `-- name: ListMateriasCatalogo :many
+SELECT materia_id, nombre, codigo, facultad, descripcion, creditos, activo, deleted_at FROM MATERIAS WHERE deleted_at IS NULL ORDER BY codigo
+`
+
+// Whole catalog, active or not, for imports and onboarding. Deleted materias are left out.
+func (q *Queries) ListMateriasCatalogo(ctx context.Context) ([]Materia, error) {
+	rows, err := q.db.Query(ctx, listMateriasCatalogo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Materia
+	for rows.Next() {
+		var i Materia
+		if err := rows.Scan(
+			&i.MateriaID,
+			&i.Nombre,
+			&i.Codigo,
+			&i.Facultad,
+			&i.Descripcion,
+			&i.Creditos,
+			&i.Activo,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPapelera = `-- name: ListPapelera :many
+
+SELECT 'estudiante'::text AS tipo, estudiante_id AS id, (nombre || ' ' || apellido)::text AS nombre, correo AS detalle, deleted_at
+FROM ESTUDIANTES WHERE deleted_at IS NOT NULL
+UNION ALL
+SELECT 'tutor'::text, tutor_id, (nombre || ' ' || apellido)::text, correo, deleted_at
+FROM TUTORES WHERE deleted_at IS NOT NULL
+UNION ALL
+SELECT 'materia'::text, materia_id, nombre, codigo, deleted_at
+FROM MATERIAS WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, tipo, id
+`
+
+type ListPapeleraRow struct {
+	Tipo      string
+	ID        int32
+	Nombre    string
+	Detalle   string
+	DeletedAt pgtype.Timestamp
+}
+
+// ========================================
+// PAPELERA QUERIES
+// ========================================
+// Estudiantes, tutores and materias moved to the papelera, most recently deleted first.
+func (q *Queries) ListPapelera(ctx context.Context) ([]ListPapeleraRow, error) {
+	rows, err := q.db.Query(ctx, listPapelera)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPapeleraRow
+	for rows.Next() {
+		var i ListPapeleraRow
+		if err := rows.Scan(
+			&i.Tipo,
+			&i.ID,
+			&i.Nombre,
+			&i.Detalle,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReporteProgramaciones = `-- name: ListReporteProgramaciones :many
+SELECT programacion_id, nombre, tipo_reporte, cron, periodo, destinatarios, adjuntar_pdf, activo, creado_por, proxima_ejecucion, ultima_ejecucion, fecha_creacion FROM REPORTE_PROGRAMACIONES ORDER BY programacion_id
+`
+
+func (q *Queries) ListReporteProgramaciones(ctx context.Context) ([]ReporteProgramacione, error) {
+	rows, err := q.db.Query(ctx, listReporteProgramaciones)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReporteProgramacione
+	for rows.Next() {
+		var i ReporteProgramacione
+		if err := rows.Scan(
+			&i.ProgramacionID,
+			&i.Nombre,
+			&i.TipoReporte,
+			&i.Cron,
+			&i.Periodo,
+			&i.Destinatarios,
+			&i.AdjuntarPdf,
+			&i.Activo,
+			&i.CreadoPor,
+			&i.ProximaEjecucion,
+			&i.UltimaEjecucion,
+			&i.FechaCreacion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReporteProgramacionesPendientes = `-- name: ListReporteProgramacionesPendientes :many
+SELECT programacion_id, nombre, tipo_reporte, cron, periodo, destinatarios, adjuntar_pdf, activo, creado_por, proxima_ejecucion, ultima_ejecucion, fecha_creacion FROM REPORTE_PROGRAMACIONES
+WHERE activo = true AND proxima_ejecucion <= $1
+ORDER BY proxima_ejecucion, programacion_id
+`
+
+func (q *Queries) ListReporteProgramacionesPendientes(ctx context.Context, proximaEjecucion pgtype.Timestamp) ([]ReporteProgramacione, error) {
+	rows, err := q.db.Query(ctx, listReporteProgramacionesPendientes, proximaEjecucion)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReporteProgramacione
+	for rows.Next() {
+		var i ReporteProgramacione
+		if err := rows.Scan(
+			&i.ProgramacionID,
+			&i.Nombre,
+			&i.TipoReporte,
+			&i.Cron,
+			&i.Periodo,
+			&i.Destinatarios,
+			&i.AdjuntarPdf,
+			&i.Activo,
+			&i.CreadoPor,
+			&i.ProximaEjecucion,
+			&i.UltimaEjecucion,
+			&i.FechaCreacion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const deleteTutoria = `-- name: DeleteTutoria :exec
-DELETE FROM TUTORIAS WHERE tutoria_id = $1
+const listReportes = `-- name: ListReportes :many
+SELECT r.reporte_id, r.tipo_reporte, r.fecha_generacion, r.periodo_inicio, r.periodo_fin, r.generado_por, r.datos, r.estado, r.progreso, r.intentos, r.ultimo_error, r.proximo_intento, r.fecha_inicio, r.fecha_finalizacion, r.programacion_id FROM REPORTES r
+CROSS JOIN LATERAL (
+    SELECT CASE $1::text
+        WHEN 'tipo_reporte' THEN r.tipo_reporte
+        WHEN 'periodo_inicio' THEN COALESCE(to_char(r.periodo_inicio, 'YYYY-MM-DD'), '')
+        WHEN 'id' THEN lpad(r.reporte_id::text, 10, '0')
+        ELSE COALESCE(to_char(r.fecha_generacion, 'YYYY-MM-DD HH24:MI:SS.US'), '')
+    END AS valor
+) k
+WHERE $2::int IS NULL
+    OR (NOT $3::bool AND (k.valor, r.reporte_id) > ($4::text, $2::int))
+    OR ($3::bool AND (k.valor, r.reporte_id) < ($4::text, $2::int))
+ORDER BY
+    CASE WHEN NOT $3::bool THEN k.valor END,
+    CASE WHEN $3::bool THEN k.valor END DESC,
+    CASE WHEN NOT $3::bool THEN r.reporte_id END,
+    r.reporte_id DESC
+LIMIT $5
 `
 
-func (q *Queries) DeleteTutoria(ctx context.Context, tutoriaID int32) error {
-	_, err := q.db.Exec(ctx, deleteTutoria, tutoriaID)
-	return err
+type ListReportesParams struct {
+	Sort       string
+	AfterID    pgtype.Int4
+	Descending bool
+	AfterValor pgtype.Text
+	RowLimit   int32
 }
 
-const getMateriaIdByName = `-- name: GetMateriaIdByName :one
-SELECT materia_id FROM MATERIAS WHERE nombre = $1
-`
-
-func (q *Queries) GetMateriaIdByName(ctx context.Context, nombre string) (int32, error) {
-	row := q.db.QueryRow(ctx, getMateriaIdByName, nombre)
-	var materia_id int32
-	err := row.Scan(&materia_id)
-	return materia_id, err
+func (q *Queries) ListReportes(ctx context.Context, arg ListReportesParams) ([]Reporte, error) {
+	rows, err := q.db.Query(ctx, listReportes, arg.Sort, arg.AfterID, arg.Descending, arg.AfterValor, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Reporte
+	for rows.Next() {
+		var i Reporte
+		if err := rows.Scan(
+			&i.ReporteID,
+			&i.TipoReporte,
+			&i.FechaGeneracion,
+			&i.PeriodoInicio,
+			&i.PeriodoFin,
+			&i.GeneradoPor,
+			&i.Datos,
+			&i.Estado,
+			&i.Progreso,
+			&i.Intentos,
+			&i.UltimoError,
+			&i.ProximoIntento,
+			&i.FechaInicio,
+			&i.FechaFinalizacion,
+			&i.ProgramacionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getProximasTutoriasByEstudiante = `-- name: GetProximasTutoriasByEstudiante :many
-SELECT tutoria_id, estudiante_id, tutor_id, materia_id, fecha, hora_inicio, hora_fin, estado, fecha_solicitud, fecha_confirmacion, temas_tratados, asistencia_confirmada, lugar
-FROM TUTORIAS
-WHERE estudiante_id = $1
-  AND (
-    fecha > CURRENT_DATE OR
-    (fecha = CURRENT_DATE AND hora_inicio > CURRENT_TIME)
-  )
-ORDER BY fecha, hora_inicio
+const listReportesByPeriodo = `-- name: ListReportesByPeriodo :many
+SELECT reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos, estado, progreso, intentos, ultimo_error, proximo_intento, fecha_inicio, fecha_finalizacion, programacion_id FROM REPORTES 
+WHERE periodo_inicio >= $1 AND periodo_fin <= $2 
+ORDER BY fecha_generacion DESC
 `
 
-func (q *Queries) GetProximasTutoriasByEstudiante(ctx context.Context, estudianteID int32) ([]Tutoria, error) {
-	rows, err := q.db.Query(ctx, getProximasTutoriasByEstudiante, estudianteID)
+type ListReportesByPeriodoParams struct {
+	PeriodoInicio pgtype.Date
+	PeriodoFin    pgtype.Date
+}
+
+func (q *Queries) ListReportesByPeriodo(ctx context.Context, arg ListReportesByPeriodoParams) ([]Reporte, error) {
+	rows, err := q.db.Query(ctx, listReportesByPeriodo, arg.PeriodoInicio, arg.PeriodoFin)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Tutoria
+	var items []Reporte
 	for rows.Next() {
-		var i Tutoria
+		var i Reporte
 		if err := rows.Scan(
-			&i.TutoriaID,
-			&i.EstudianteID,
-			&i.TutorID,
-			&i.MateriaID,
-			&i.Fecha,
-			&i.HoraInicio,
-			&i.HoraFin,
+			&i.ReporteID,
+			&i.TipoReporte,
+			&i.FechaGeneracion,
+			&i.PeriodoInicio,
+			&i.PeriodoFin,
+			&i.GeneradoPor,
+			&i.Datos,
 			&i.Estado,
-			&i.FechaSolicitud,
-			&i.FechaConfirmacion,
-			&i.TemasTratados,
-			&i.AsistenciaConfirmada,
-			&i.Lugar,
+			&i.Progreso,
+			&i.Intentos,
+			&i.UltimoError,
+			&i.ProximoIntento,
+			&i.FechaInicio,
+			&i.FechaFinalizacion,
+			&i.ProgramacionID,
 		); err != nil {
 			return nil, err
 		}
@@ -530,30 +2567,35 @@ func (q *Queries) GetProximasTutoriasByEstudiante(ctx context.Context, estudiant
 	return items, nil
 }
 
-const getTutorMaterias = `-- name: GetTutorMaterias :many
-SELECT m.materia_id, m.nombre, m.codigo, m.facultad, m.descripcion, m.creditos
-FROM MATERIAS m
-JOIN TUTOR_MATERIAS tm ON m.materia_id = tm.materia_id
-WHERE tm.tutor_id = $1 AND tm.activo = true
-ORDER BY m.codigo
+const listReportesByTipo = `-- name: ListReportesByTipo :many
+SELECT reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos, estado, progreso, intentos, ultimo_error, proximo_intento, fecha_inicio, fecha_finalizacion, programacion_id FROM REPORTES WHERE tipo_reporte = $1 ORDER BY fecha_generacion DESC
 `
 
-func (q *Queries) GetTutorMaterias(ctx context.Context, tutorID int32) ([]Materia, error) {
-	rows, err := q.db.Query(ctx, getTutorMaterias, tutorID)
+func (q *Queries) ListReportesByTipo(ctx context.Context, tipoReporte string) ([]Reporte, error) {
+	rows, err := q.db.Query(ctx, listReportesByTipo, tipoReporte)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Materia
+	var items []Reporte
 	for rows.Next() {
-		var i Materia
+		var i Reporte
 		if err := rows.Scan(
-			&i.MateriaID,
-			&i.Nombre,
-			&i.Codigo,
-			&i.Facultad,
-			&i.Descripcion,
-			&i.Creditos,
+			&i.ReporteID,
+			&i.TipoReporte,
+			&i.FechaGeneracion,
+			&i.PeriodoInicio,
+			&i.PeriodoFin,
+			&i.GeneradoPor,
+			&i.Datos,
+			&i.Estado,
+			&i.Progreso,
+			&i.Intentos,
+			&i.UltimoError,
+			&i.ProximoIntento,
+			&i.FechaInicio,
+			&i.FechaFinalizacion,
+			&i.ProgramacionID,
 		); err != nil {
 			return nil, err
 		}
@@ -565,53 +2607,61 @@ func (q *Queries) GetTutorMaterias(ctx context.Context, tutorID int32) ([]Materi
 	return items, nil
 }
 
-const getTutorNameById = `-- name: GetTutorNameById :one
-SELECT nombre, apellido FROM TUTORES WHERE tutor_id = $1
+const listSolicitudesFallidas = `-- name: ListSolicitudesFallidas :many
+SELECT s.solicitud_id, s.estudiante_id, s.materia_id, s.tutor_id, s.fecha, s.hora_inicio, s.hora_fin, s.motivo, s.fecha_intento, m.codigo AS materia_codigo, m.nombre AS materia
+FROM SOLICITUDES_FALLIDAS s
+JOIN MATERIAS m ON m.materia_id = s.materia_id
+WHERE ($1::int IS NULL OR s.materia_id = $1)
+  AND ($2::text IS NULL OR s.motivo = $2)
+  AND ($3::date IS NULL OR s.fecha_intento >= $3)
+  AND ($4::date IS NULL OR s.fecha_intento < $4::date + 1)
+ORDER BY s.fecha_intento DESC, s.solicitud_id DESC
+LIMIT $5
 `
 
-type GetTutorNameByIdRow struct {
-	Nombre   string
-	Apellido string
-}
-
-func (q *Queries) GetTutorNameById(ctx context.Context, tutorID int32) (GetTutorNameByIdRow, error) {
-	row := q.db.QueryRow(ctx, getTutorNameById, tutorID)
-	var i GetTutorNameByIdRow
-	err := row.Scan(&i.Nombre, &i.Apellido)
-	return i, err
+type ListSolicitudesFallidasParams struct {
+	MateriaID pgtype.Int4
+	Motivo    pgtype.Text
+	Desde     pgtype.Date
+	Hasta     pgtype.Date
+	RowLimit  int32
 }
 
-const listAdmins = `-- name: ListAdmins :many
-SELECT admin_id, nombre, apellido, correo, rol, activo, fecha_registro FROM ADMINS ORDER BY apellido, nombre
-`
-
-type ListAdminsRow struct {
-	AdminID       int32
-	Nombre        string
-	Apellido      string
-	Correo        string
-	Rol           string
-	Activo        pgtype.Bool
-	FechaRegistro pgtype.Timestamptz
+type ListSolicitudesFallidasRow struct {
+	SolicitudID   int32
+	EstudianteID  pgtype.Int4
+	MateriaID     int32
+	TutorID       pgtype.Int4
+	Fecha         pgtype.Date
+	HoraInicio    pgtype.Time
+	HoraFin       pgtype.Time
+	Motivo        string
+	FechaIntento  pgtype.Timestamp
+	MateriaCodigo string
+	Materia       string
 }
 
-func (q *Queries) ListAdmins(ctx context.Context) ([]ListAdminsRow, error) {
-	rows, err := q.db.Query(ctx, listAdmins)
+func (q *Queries) ListSolicitudesFallidas(ctx context.Context, arg ListSolicitudesFallidasParams) ([]ListSolicitudesFallidasRow, error) {
+	rows, err := q.db.Query(ctx, listSolicitudesFallidas, arg.MateriaID, arg.Motivo, arg.Desde, arg.Hasta, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListAdminsRow
+	var items []ListSolicitudesFallidasRow
 	for rows.Next() {
-		var i ListAdminsRow
+		var i ListSolicitudesFallidasRow
 		if err := rows.Scan(
-			&i.AdminID,
-			&i.Nombre,
-			&i.Apellido,
-			&i.Correo,
-			&i.Rol,
-			&i.Activo,
-			&i.FechaRegistro,
+			&i.SolicitudID,
+			&i.EstudianteID,
+			&i.MateriaID,
+			&i.TutorID,
+			&i.Fecha,
+			&i.HoraInicio,
+			&i.HoraFin,
+			&i.Motivo,
+			&i.FechaIntento,
+			&i.MateriaCodigo,
+			&i.Materia,
 		); err != nil {
 			return nil, err
 		}
@@ -623,41 +2673,67 @@ func (q *Queries) ListAdmins(ctx context.Context) ([]ListAdminsRow, error) {
 	return items, nil
 }
 
-const listDisponibilidadByDia = `-- name: ListDisponibilidadByDia :many
-SELECT d.disponibilidad_id, d.tutor_id, d.dia_semana, d.hora_inicio, d.hora_fin, t.nombre as tutor_nombre, t.apellido as tutor_apellido
-FROM DISPONIBILIDAD d
-JOIN TUTORES t ON d.tutor_id = t.tutor_id
-WHERE d.dia_semana = $1
-ORDER BY d.hora_inicio
+const listSolicitudesTutor = `-- name: ListSolicitudesTutor :many
+SELECT s.solicitud_id, s.estudiante_id, s.motivacion, s.materias, s.estado, s.motivo_rechazo, s.revisado_por, s.tutor_id, s.fecha_solicitud, s.fecha_revision,
+    (e.nombre || ' ' || e.apellido)::text AS estudiante,
+    e.correo,
+    e.programa_academico,
+    e.semestre
+FROM SOLICITUDES_TUTOR s
+JOIN ESTUDIANTES e ON e.estudiante_id = s.estudiante_id
+WHERE ($1::text IS NULL OR s.estado = $1)
+  AND ($2::int IS NULL OR s.estudiante_id = $2)
+ORDER BY s.fecha_solicitud DESC, s.solicitud_id DESC
+LIMIT $3
 `
 
-type ListDisponibilidadByDiaRow struct {
-	DisponibilidadID int32
-	TutorID          int32
-	DiaSemana        int32
-	HoraInicio       pgtype.Time
-	HoraFin          pgtype.Time
-	TutorNombre      string
-	TutorApellido    string
+type ListSolicitudesTutorParams struct {
+	Estado       pgtype.Text
+	EstudianteID pgtype.Int4
+	RowLimit     int32
 }
 
-func (q *Queries) ListDisponibilidadByDia(ctx context.Context, diaSemana int32) ([]ListDisponibilidadByDiaRow, error) {
-	rows, err := q.db.Query(ctx, listDisponibilidadByDia, diaSemana)
+type ListSolicitudesTutorRow struct {
+	SolicitudID       int32
+	EstudianteID      int32
+	Motivacion        string
+	Materias          []int32
+	Estado            string
+	MotivoRechazo     pgtype.Text
+	RevisadoPor       pgtype.Int4
+	TutorID           pgtype.Int4
+	FechaSolicitud    pgtype.Timestamp
+	FechaRevision     pgtype.Timestamp
+	Estudiante        string
+	Correo            string
+	ProgramaAcademico string
+	Semestre          pgtype.Int4
+}
+
+func (q *Queries) ListSolicitudesTutor(ctx context.Context, arg ListSolicitudesTutorParams) ([]ListSolicitudesTutorRow, error) {
+	rows, err := q.db.Query(ctx, listSolicitudesTutor, arg.Estado, arg.EstudianteID, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListDisponibilidadByDiaRow
+	var items []ListSolicitudesTutorRow
 	for rows.Next() {
-		var i ListDisponibilidadByDiaRow
+		var i ListSolicitudesTutorRow
 		if err := rows.Scan(
-			&i.DisponibilidadID,
+			&i.SolicitudID,
+			&i.EstudianteID,
+			&i.Motivacion,
+			&i.Materias,
+			&i.Estado,
+			&i.MotivoRechazo,
+			&i.RevisadoPor,
 			&i.TutorID,
-			&i.DiaSemana,
-			&i.HoraInicio,
-			&i.HoraFin,
-			&i.TutorNombre,
-			&i.TutorApellido,
+			&i.FechaSolicitud,
+			&i.FechaRevision,
+			&i.Estudiante,
+			&i.Correo,
+			&i.ProgramaAcademico,
+			&i.Semestre,
 		); err != nil {
 			return nil, err
 		}
@@ -669,27 +2745,65 @@ func (q *Queries) ListDisponibilidadByDia(ctx context.Context, diaSemana int32)
 	return items, nil
 }
 
-const listDisponibilidadByTutor = `-- name: ListDisponibilidadByTutor :many
-SELECT disponibilidad_id, tutor_id, dia_semana, hora_inicio, hora_fin FROM DISPONIBILIDAD 
-WHERE tutor_id = $1 
-ORDER BY dia_semana, hora_inicio
+const listTutorPostulaciones = `-- name: ListTutorPostulaciones :many
+SELECT p.postulacion_id, p.tutor_id, p.materia_id, p.comentario, p.estado, p.motivo_rechazo, p.revisado_por, p.fecha_postulacion, p.fecha_revision,
+    (t.nombre || ' ' || t.apellido)::text AS tutor,
+    m.codigo AS materia_codigo,
+    m.nombre AS materia
+FROM TUTOR_POSTULACIONES p
+JOIN TUTORES t ON t.tutor_id = p.tutor_id
+JOIN MATERIAS m ON m.materia_id = p.materia_id
+WHERE ($1::text IS NULL OR p.estado = $1)
+  AND ($2::int IS NULL OR p.tutor_id = $2)
+  AND ($3::int IS NULL OR p.materia_id = $3)
+ORDER BY p.fecha_postulacion DESC, p.postulacion_id DESC
+LIMIT $4
 `
 
-func (q *Queries) ListDisponibilidadByTutor(ctx context.Context, tutorID int32) ([]Disponibilidad, error) {
-	rows, err := q.db.Query(ctx, listDisponibilidadByTutor, tutorID)
+type ListTutorPostulacionesParams struct {
+	Estado    pgtype.Text
+	TutorID   pgtype.Int4
+	MateriaID pgtype.Int4
+	RowLimit  int32
+}
+
+type ListTutorPostulacionesRow struct {
+	PostulacionID    int32
+	TutorID          int32
+	MateriaID        int32
+	Comentario       pgtype.Text
+	Estado           string
+	MotivoRechazo    pgtype.Text
+	RevisadoPor      pgtype.Int4
+	FechaPostulacion pgtype.Timestamp
+	FechaRevision    pgtype.Timestamp
+	Tutor            string
+	MateriaCodigo    string
+	Materia          string
+}
+
+func (q *Queries) ListTutorPostulaciones(ctx context.Context, arg ListTutorPostulacionesParams) ([]ListTutorPostulacionesRow, error) {
+	rows, err := q.db.Query(ctx, listTutorPostulaciones, arg.Estado, arg.TutorID, arg.MateriaID, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Disponibilidad
+	var items []ListTutorPostulacionesRow
 	for rows.Next() {
-		var i Disponibilidad
+		var i ListTutorPostulacionesRow
 		if err := rows.Scan(
-			&i.DisponibilidadID,
+			&i.PostulacionID,
 			&i.TutorID,
-			&i.DiaSemana,
-			&i.HoraInicio,
-			&i.HoraFin,
+			&i.MateriaID,
+			&i.Comentario,
+			&i.Estado,
+			&i.MotivoRechazo,
+			&i.RevisadoPor,
+			&i.FechaPostulacion,
+			&i.FechaRevision,
+			&i.Tutor,
+			&i.MateriaCodigo,
+			&i.Materia,
 		); err != nil {
 			return nil, err
 		}
@@ -701,28 +2815,54 @@ func (q *Queries) ListDisponibilidadByTutor(ctx context.Context, tutorID int32)
 	return items, nil
 }
 
-const listEstudiantes = `-- name: ListEstudiantes :many
-SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti FROM ESTUDIANTES ORDER BY apellido, nombre
+const listTutores = `-- name: ListTutores :many
+SELECT t.tutor_id, t.nombre, t.apellido, t.correo, t.programa_academico, t.fecha_registro, t.deleted_at FROM TUTORES t
+CROSS JOIN LATERAL (
+    SELECT CASE $1::text
+        WHEN 'nombre' THEN t.nombre || ' ' || t.apellido
+        WHEN 'correo' THEN t.correo
+        WHEN 'programa_academico' THEN COALESCE(t.programa_academico, '')
+        WHEN 'fecha_registro' THEN COALESCE(to_char(t.fecha_registro, 'YYYY-MM-DD HH24:MI:SS.US'), '')
+        WHEN 'id' THEN lpad(t.tutor_id::text, 10, '0')
+        ELSE t.apellido || ' ' || t.nombre
+    END AS valor
+) k
+WHERE t.deleted_at IS NULL AND ($2::int IS NULL
+    OR (NOT $3::bool AND (k.valor, t.tutor_id) > ($4::text, $2::int))
+    OR ($3::bool AND (k.valor, t.tutor_id) < ($4::text, $2::int)))
+ORDER BY
+    CASE WHEN NOT $3::bool THEN k.valor END,
+    CASE WHEN $3::bool THEN k.valor END DESC,
+    CASE WHEN NOT $3::bool THEN t.tutor_id END,
+    t.tutor_id DESC
+LIMIT $5
 `
 
-func (q *Queries) ListEstudiantes(ctx context.Context) ([]Estudiante, error) {
-	rows, err := q.db.Query(ctx, listEstudiantes)
+type ListTutoresParams struct {
+	Sort       string
+	AfterID    pgtype.Int4
+	Descending bool
+	AfterValor pgtype.Text
+	RowLimit   int32
+}
+
+func (q *Queries) ListTutores(ctx context.Context, arg ListTutoresParams) ([]Tutore, error) {
+	rows, err := q.db.Query(ctx, listTutores, arg.Sort, arg.AfterID, arg.Descending, arg.AfterValor, arg.RowLimit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Estudiante
+	var items []Tutore
 	for rows.Next() {
-		var i Estudiante
+		var i Tutore
 		if err := rows.Scan(
-			&i.EstudianteID,
+			&i.TutorID,
 			&i.Nombre,
 			&i.Apellido,
 			&i.Correo,
 			&i.ProgramaAcademico,
-			&i.Semestre,
 			&i.FechaRegistro,
-			&i.Ti,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -734,28 +2874,41 @@ func (q *Queries) ListEstudiantes(ctx context.Context) ([]Estudiante, error) {
 	return items, nil
 }
 
-const listEstudiantesByPrograma = `-- name: ListEstudiantesByPrograma :many
-SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti FROM ESTUDIANTES WHERE programa_academico = $1 ORDER BY apellido, nombre
+const listTutoresByMateria = `-- name: ListTutoresByMateria :many
+SELECT tm.asignacion_id, tm.tutor_id, tm.materia_id, tm.fecha_asignacion, tm.activo, t.nombre as tutor_nombre, t.apellido as tutor_apellido
+FROM TUTOR_MATERIAS tm
+JOIN TUTORES t ON tm.tutor_id = t.tutor_id
+WHERE tm.materia_id = $1 AND tm.activo = true AND t.deleted_at IS NULL
+ORDER BY t.apellido, t.nombre
 `
 
-func (q *Queries) ListEstudiantesByPrograma(ctx context.Context, programaAcademico string) ([]Estudiante, error) {
-	rows, err := q.db.Query(ctx, listEstudiantesByPrograma, programaAcademico)
+type ListTutoresByMateriaRow struct {
+	AsignacionID    int32
+	TutorID         int32
+	MateriaID       int32
+	FechaAsignacion pgtype.Date
+	Activo          bool
+	TutorNombre     string
+	TutorApellido   string
+}
+
+func (q *Queries) ListTutoresByMateria(ctx context.Context, materiaID int32) ([]ListTutoresByMateriaRow, error) {
+	rows, err := q.db.Query(ctx, listTutoresByMateria, materiaID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Estudiante
+	var items []ListTutoresByMateriaRow
 	for rows.Next() {
-		var i Estudiante
+		var i ListTutoresByMateriaRow
 		if err := rows.Scan(
-			&i.EstudianteID,
-			&i.Nombre,
-			&i.Apellido,
-			&i.Correo,
-			&i.ProgramaAcademico,
-			&i.Semestre,
-			&i.FechaRegistro,
-			&i.Ti,
+			&i.AsignacionID,
+			&i.TutorID,
+			&i.MateriaID,
+			&i.FechaAsignacion,
+			&i.Activo,
+			&i.TutorNombre,
+			&i.TutorApellido,
 		); err != nil {
 			return nil, err
 		}
@@ -767,30 +2920,53 @@ func (q *Queries) ListEstudiantesByPrograma(ctx context.Context, programaAcademi
 	return items, nil
 }
 
-const listEstudiantesBySemestre = `-- name: ListEstudiantesBySemestre :many
-SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti FROM ESTUDIANTES 
-WHERE semestre = $1 
-ORDER BY apellido, nombre
+const listTutoresDisponiblesByMateriaAndDia = `-- name: ListTutoresDisponiblesByMateriaAndDia :many
+SELECT DISTINCT t.tutor_id, t.nombre, t.apellido, t.correo, t.programa_academico, t.fecha_registro, t.deleted_at, d.dia_semana, d.hora_inicio, d.hora_fin
+FROM TUTORES t
+JOIN TUTOR_MATERIAS tm ON t.tutor_id = tm.tutor_id
+JOIN DISPONIBILIDAD d ON t.tutor_id = d.tutor_id
+WHERE tm.materia_id = $1 AND tm.activo = true AND d.dia_semana = $2 AND t.deleted_at IS NULL
+ORDER BY d.hora_inicio
 `
 
-func (q *Queries) ListEstudiantesBySemestre(ctx context.Context, semestre pgtype.Int4) ([]Estudiante, error) {
-	rows, err := q.db.Query(ctx, listEstudiantesBySemestre, semestre)
+type ListTutoresDisponiblesByMateriaAndDiaParams struct {
+	MateriaID int32
+	DiaSemana int32
+}
+
+type ListTutoresDisponiblesByMateriaAndDiaRow struct {
+	TutorID           int32
+	Nombre            string
+	Apellido          string
+	Correo            string
+	ProgramaAcademico pgtype.Text
+	FechaRegistro     pgtype.Timestamp
+	DeletedAt         pgtype.Timestamp
+	DiaSemana         int32
+	HoraInicio        pgtype.Time
+	HoraFin           pgtype.Time
+}
+
+func (q *Queries) ListTutoresDisponiblesByMateriaAndDia(ctx context.Context, arg ListTutoresDisponiblesByMateriaAndDiaParams) ([]ListTutoresDisponiblesByMateriaAndDiaRow, error) {
+	rows, err := q.db.Query(ctx, listTutoresDisponiblesByMateriaAndDia, arg.MateriaID, arg.DiaSemana)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Estudiante
+	var items []ListTutoresDisponiblesByMateriaAndDiaRow
 	for rows.Next() {
-		var i Estudiante
+		var i ListTutoresDisponiblesByMateriaAndDiaRow
 		if err := rows.Scan(
-			&i.EstudianteID,
+			&i.TutorID,
 			&i.Nombre,
 			&i.Apellido,
 			&i.Correo,
 			&i.ProgramaAcademico,
-			&i.Semestre,
 			&i.FechaRegistro,
-			&i.Ti,
+			&i.DeletedAt,
+			&i.DiaSemana,
+			&i.HoraInicio,
+			&i.HoraFin,
 		); err != nil {
 			return nil, err
 		}
@@ -802,25 +2978,61 @@ func (q *Queries) ListEstudiantesBySemestre(ctx context.Context, semestre pgtype
 	return items, nil
 }
 
-const listMateriaNames = `-- name: ListMateriaNames :many
-SELECT nombre 
-FROM MATERIAS
-ORDER BY nombre
+const listTutoresWithMaterias = `-- name: ListTutoresWithMaterias :many
+
+
+SELECT t.tutor_id, t.nombre, t.apellido, t.correo, t.programa_academico, t.fecha_registro, t.deleted_at, 
+       STRING_AGG(m.nombre, ', ') as materias_asignadas,
+       COUNT(tm.materia_id) as total_materias
+FROM TUTORES t
+LEFT JOIN TUTOR_MATERIAS tm ON t.tutor_id = tm.tutor_id AND tm.activo = true
+LEFT JOIN MATERIAS m ON tm.materia_id = m.materia_id AND m.deleted_at IS NULL
+WHERE t.deleted_at IS NULL
+GROUP BY t.tutor_id, t.nombre, t.apellido, t.correo, t.programa_academico, t.fecha_registro
+ORDER BY t.apellido, t.nombre
 `
 
-func (q *Queries) ListMateriaNames(ctx context.Context) ([]string, error) {
-	rows, err := q.db.Query(ctx, listMateriaNames)
+type ListTutoresWithMateriasRow struct {
+	TutorID           int32
+	Nombre            string
+	Apellido          string
+	Correo            string
+	ProgramaAcademico pgtype.Text
+	FechaRegistro     pgtype.Timestamp
+	DeletedAt         pgtype.Timestamp
+	MateriasAsignadas []byte
+	TotalMaterias     int64
+}
+
+// ========================================
+// ADDITIONAL USEFUL QUERIES FOR URTUTORIAS
+// ========================================
+// ========================================
+// TUTORES - MISSING QUERIES
+// ========================================
+func (q *Queries) ListTutoresWithMaterias(ctx context.Context) ([]ListTutoresWithMateriasRow, error) {
+	rows, err := q.db.Query(ctx, listTutoresWithMaterias)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []ListTutoresWithMateriasRow
 	for rows.Next() {
-		var nombre string
-		if err := rows.Scan(&nombre); err != nil {
+		var i ListTutoresWithMateriasRow
+		if err := rows.Scan(
+			&i.TutorID,
+			&i.Nombre,
+			&i.Apellido,
+			&i.Correo,
+			&i.ProgramaAcademico,
+			&i.FechaRegistro,
+			&i.DeletedAt,
+			&i.MateriasAsignadas,
+			&i.TotalMaterias,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, nombre)
+		items = append(items, i)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -828,26 +3040,32 @@ func (q *Queries) ListMateriaNames(ctx context.Context) ([]string, error) {
 	return items, nil
 }
 
-const listMateriaNombres = `-- name: ListMateriaNombres :many
-SELECT materia_id, nombre, codigo FROM MATERIAS ORDER BY codigo
+const listTutoriasActivas = `-- name: ListTutoriasActivas :many
+SELECT tutoria_id, nombre_estudiante, apellido_estudiante, nombre_tutor, apellido_tutor, materia, fecha, hora_inicio, hora_fin, lugar, estado FROM tutoriasActivas ORDER BY fecha, hora_inicio
 `
 
-type ListMateriaNombresRow struct {
-	MateriaID int32
-	Nombre    string
-	Codigo    string
-}
-
-func (q *Queries) ListMateriaNombres(ctx context.Context) ([]ListMateriaNombresRow, error) {
-	rows, err := q.db.Query(ctx, listMateriaNombres)
+func (q *Queries) ListTutoriasActivas(ctx context.Context) ([]Tutoriasactiva, error) {
+	rows, err := q.db.Query(ctx, listTutoriasActivas)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListMateriaNombresRow
+	var items []Tutoriasactiva
 	for rows.Next() {
-		var i ListMateriaNombresRow
-		if err := rows.Scan(&i.MateriaID, &i.Nombre, &i.Codigo); err != nil {
+		var i Tutoriasactiva
+		if err := rows.Scan(
+			&i.TutoriaID,
+			&i.NombreEstudiante,
+			&i.ApellidoEstudiante,
+			&i.NombreTutor,
+			&i.ApellidoTutor,
+			&i.Materia,
+			&i.Fecha,
+			&i.HoraInicio,
+			&i.HoraFin,
+			&i.Lugar,
+			&i.Estado,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -858,26 +3076,60 @@ func (q *Queries) ListMateriaNombres(ctx context.Context) ([]ListMateriaNombresR
 	return items, nil
 }
 
-const listMaterias = `-- name: ListMaterias :many
-SELECT materia_id, nombre, codigo, facultad, descripcion, creditos FROM MATERIAS ORDER BY codigo
+const listTutoriasByEstudiante = `-- name: ListTutoriasByEstudiante :many
+SELECT t.tutoria_id, t.estudiante_id, t.tutor_id, t.materia_id, t.fecha, t.hora_inicio, t.hora_fin, t.estado, t.fecha_solicitud, t.fecha_confirmacion, t.temas_tratados, t.asistencia_confirmada, t.lugar, tu.nombre as tutor_nombre, tu.apellido as tutor_apellido, m.nombre as materia_nombre
+FROM TUTORIAS t
+JOIN TUTORES tu ON t.tutor_id = tu.tutor_id
+JOIN MATERIAS m ON t.materia_id = m.materia_id
+WHERE t.estudiante_id = $1
+ORDER BY t.fecha DESC, t.hora_inicio DESC
 `
 
-func (q *Queries) ListMaterias(ctx context.Context) ([]Materia, error) {
-	rows, err := q.db.Query(ctx, listMaterias)
+type ListTutoriasByEstudianteRow struct {
+	TutoriaID            int32
+	EstudianteID         int32
+	TutorID              int32
+	MateriaID            int32
+	Fecha                pgtype.Date
+	HoraInicio           pgtype.Time
+	HoraFin              pgtype.Time
+	Estado               string
+	FechaSolicitud       pgtype.Timestamp
+	FechaConfirmacion    pgtype.Timestamp
+	TemasTratados        pgtype.Text
+	AsistenciaConfirmada pgtype.Bool
+	Lugar                string
+	TutorNombre          string
+	TutorApellido        string
+	MateriaNombre        string
+}
+
+func (q *Queries) ListTutoriasByEstudiante(ctx context.Context, estudianteID int32) ([]ListTutoriasByEstudianteRow, error) {
+	rows, err := q.db.Query(ctx, listTutoriasByEstudiante, estudianteID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Materia
+	var items []ListTutoriasByEstudianteRow
 	for rows.Next() {
-		var i Materia
+		var i ListTutoriasByEstudianteRow
 		if err := rows.Scan(
+			&i.TutoriaID,
+			&i.EstudianteID,
+			&i.TutorID,
 			&i.MateriaID,
-			&i.Nombre,
-			&i.Codigo,
-			&i.Facultad,
-			&i.Descripcion,
-			&i.Creditos,
+			&i.Fecha,
+			&i.HoraInicio,
+			&i.HoraFin,
+			&i.Estado,
+			&i.FechaSolicitud,
+			&i.FechaConfirmacion,
+			&i.TemasTratados,
+			&i.AsistenciaConfirmada,
+			&i.Lugar,
+			&i.TutorNombre,
+			&i.TutorApellido,
+			&i.MateriaNombre,
 		); err != nil {
 			return nil, err
 		}
@@ -889,26 +3141,60 @@ func (q *Queries) ListMaterias(ctx context.Context) ([]Materia, error) {
 	return items, nil
 }
 
-const listMateriasByFacultad = `-- name: ListMateriasByFacultad :many
-SELECT materia_id, nombre, codigo, facultad, descripcion, creditos FROM MATERIAS WHERE facultad = $1 ORDER BY codigo
+const listTutoriasByTutor = `-- name: ListTutoriasByTutor :many
+SELECT t.tutoria_id, t.estudiante_id, t.tutor_id, t.materia_id, t.fecha, t.hora_inicio, t.hora_fin, t.estado, t.fecha_solicitud, t.fecha_confirmacion, t.temas_tratados, t.asistencia_confirmada, t.lugar, e.nombre as estudiante_nombre, e.apellido as estudiante_apellido, m.nombre as materia_nombre
+FROM TUTORIAS t
+JOIN ESTUDIANTES e ON t.estudiante_id = e.estudiante_id
+JOIN MATERIAS m ON t.materia_id = m.materia_id
+WHERE t.tutor_id = $1
+ORDER BY t.fecha DESC, t.hora_inicio DESC
 `
 
-func (q *Queries) ListMateriasByFacultad(ctx context.Context, facultad string) ([]Materia, error) {
-	rows, err := q.db.Query(ctx, listMateriasByFacultad, facultad)
+type ListTutoriasByTutorRow struct {
+	TutoriaID            int32
+	EstudianteID         int32
+	TutorID              int32
+	MateriaID            int32
+	Fecha                pgtype.Date
+	HoraInicio           pgtype.Time
+	HoraFin              pgtype.Time
+	Estado               string
+	FechaSolicitud       pgtype.Timestamp
+	FechaConfirmacion    pgtype.Timestamp
+	TemasTratados        pgtype.Text
+	AsistenciaConfirmada pgtype.Bool
+	Lugar                string
+	EstudianteNombre     string
+	EstudianteApellido   string
+	MateriaNombre        string
+}
+
+func (q *Queries) ListTutoriasByTutor(ctx context.Context, tutorID int32) ([]ListTutoriasByTutorRow, error) {
+	rows, err := q.db.Query(ctx, listTutoriasByTutor, tutorID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Materia
+	var items []ListTutoriasByTutorRow
 	for rows.Next() {
-		var i Materia
+		var i ListTutoriasByTutorRow
 		if err := rows.Scan(
+			&i.TutoriaID,
+			&i.EstudianteID,
+			&i.TutorID,
 			&i.MateriaID,
-			&i.Nombre,
-			&i.Codigo,
-			&i.Facultad,
-			&i.Descripcion,
-			&i.Creditos,
+			&i.Fecha,
+			&i.HoraInicio,
+			&i.HoraFin,
+			&i.Estado,
+			&i.FechaSolicitud,
+			&i.FechaConfirmacion,
+			&i.TemasTratados,
+			&i.AsistenciaConfirmada,
+			&i.Lugar,
+			&i.EstudianteNombre,
+			&i.EstudianteApellido,
+			&i.MateriaNombre,
 		); err != nil {
 			return nil, err
 		}
@@ -920,41 +3206,65 @@ func (q *Queries) ListMateriasByFacultad(ctx context.Context, facultad string) (
 	return items, nil
 }
 
-const listMateriasByTutor = `-- name: ListMateriasByTutor :many
-SELECT tm.asignacion_id, tm.tutor_id, tm.materia_id, tm.fecha_asignacion, tm.activo, m.nombre as materia_nombre, m.codigo as materia_codigo
-FROM TUTOR_MATERIAS tm
-JOIN MATERIAS m ON tm.materia_id = m.materia_id
-WHERE tm.tutor_id = $1 AND tm.activo = true
-ORDER BY m.codigo
+const listTutoriasCertificables = `-- name: ListTutoriasCertificables :many
+
+SELECT
+    t.tutoria_id,
+    t.fecha,
+    t.hora_inicio,
+    t.hora_fin,
+    m.materia_id,
+    m.codigo AS materia_codigo,
+    m.nombre AS materia,
+    (EXTRACT(EPOCH FROM (t.hora_fin - t.hora_inicio)) / 60)::int AS minutos
+FROM TUTORIAS t
+JOIN MATERIAS m ON m.materia_id = t.materia_id
+WHERE t.tutor_id = $1
+  AND t.estado = 'completada'
+  AND t.asistencia_confirmada
+  AND t.fecha BETWEEN $2::date AND $3::date
+ORDER BY m.nombre, t.fecha, t.hora_inicio
 `
 
-type ListMateriasByTutorRow struct {
-	AsignacionID    int32
-	TutorID         int32
-	MateriaID       int32
-	FechaAsignacion pgtype.Date
-	Activo          bool
-	MateriaNombre   string
-	MateriaCodigo   string
+type ListTutoriasCertificablesParams struct {
+	TutorID int32
+	Desde   pgtype.Date
+	Hasta   pgtype.Date
 }
 
-func (q *Queries) ListMateriasByTutor(ctx context.Context, tutorID int32) ([]ListMateriasByTutorRow, error) {
-	rows, err := q.db.Query(ctx, listMateriasByTutor, tutorID)
+type ListTutoriasCertificablesRow struct {
+	TutoriaID     int32
+	Fecha         pgtype.Date
+	HoraInicio    pgtype.Time
+	HoraFin       pgtype.Time
+	MateriaID     int32
+	MateriaCodigo string
+	Materia       string
+	Minutos       int32
+}
+
+// ========================================
+// CERTIFICADOS QUERIES
+// ========================================
+// Completed tutorias of the tutor with confirmed attendance, dated within the period.
+func (q *Queries) ListTutoriasCertificables(ctx context.Context, arg ListTutoriasCertificablesParams) ([]ListTutoriasCertificablesRow, error) {
+	rows, err := q.db.Query(ctx, listTutoriasCertificables, arg.TutorID, arg.Desde, arg.Hasta)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListMateriasByTutorRow
+	var items []ListTutoriasCertificablesRow
 	for rows.Next() {
-		var i ListMateriasByTutorRow
+		var i ListTutoriasCertificablesRow
 		if err := rows.Scan(
-			&i.AsignacionID,
-			&i.TutorID,
+			&i.TutoriaID,
+			&i.Fecha,
+			&i.HoraInicio,
+			&i.HoraFin,
 			&i.MateriaID,
-			&i.FechaAsignacion,
-			&i.Activo,
-			&i.MateriaNombre,
 			&i.MateriaCodigo,
+			&i.Materia,
+			&i.Minutos,
 		); err != nil {
 			return nil, err
 		}
@@ -966,27 +3276,39 @@ func (q *Queries) ListMateriasByTutor(ctx context.Context, tutorID int32) ([]Lis
 	return items, nil
 }
 
-const listReportes = `-- name: ListReportes :many
-SELECT reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos FROM REPORTES ORDER BY fecha_generacion DESC
+const listWebhookEntregasByWebhook = `-- name: ListWebhookEntregasByWebhook :many
+SELECT entrega_id, webhook_id, evento, payload, estado, intentos, ultimo_codigo, ultimo_error, fecha_creacion, fecha_ultimo_intento, proximo_intento FROM WEBHOOK_ENTREGAS
+WHERE webhook_id = $1
+ORDER BY fecha_creacion DESC
+LIMIT $2
 `
 
-func (q *Queries) ListReportes(ctx context.Context) ([]Reporte, error) {
-	rows, err := q.db.Query(ctx, listReportes)
+type ListWebhookEntregasByWebhookParams struct {
+	WebhookID int32
+	Limit     int32
+}
+
+func (q *Queries) ListWebhookEntregasByWebhook(ctx context.Context, arg ListWebhookEntregasByWebhookParams) ([]WebhookEntrega, error) {
+	rows, err := q.db.Query(ctx, listWebhookEntregasByWebhook, arg.WebhookID, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Reporte
+	var items []WebhookEntrega
 	for rows.Next() {
-		var i Reporte
+		var i WebhookEntrega
 		if err := rows.Scan(
-			&i.ReporteID,
-			&i.TipoReporte,
-			&i.FechaGeneracion,
-			&i.PeriodoInicio,
-			&i.PeriodoFin,
-			&i.GeneradoPor,
-			&i.Datos,
+			&i.EntregaID,
+			&i.WebhookID,
+			&i.Evento,
+			&i.Payload,
+			&i.Estado,
+			&i.Intentos,
+			&i.UltimoCodigo,
+			&i.UltimoError,
+			&i.FechaCreacion,
+			&i.FechaUltimoIntento,
+			&i.ProximoIntento,
 		); err != nil {
 			return nil, err
 		}
@@ -998,34 +3320,54 @@ func (q *Queries) ListReportes(ctx context.Context) ([]Reporte, error) {
 	return items, nil
 }
 
-const listReportesByPeriodo = `-- name: ListReportesByPeriodo :many
-SELECT reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos FROM REPORTES 
-WHERE periodo_inicio >= $1 AND periodo_fin <= $2 
-ORDER BY fecha_generacion DESC
+const listWebhookEntregasPendientes = `-- name: ListWebhookEntregasPendientes :many
+SELECT e.entrega_id, e.webhook_id, e.evento, e.payload, e.estado, e.intentos, e.ultimo_codigo, e.ultimo_error, e.fecha_creacion, e.fecha_ultimo_intento, e.proximo_intento, w.url, w.secreto
+FROM WEBHOOK_ENTREGAS e
+JOIN WEBHOOKS w ON e.webhook_id = w.webhook_id
+WHERE e.estado = 'pendiente' AND e.proximo_intento <= CURRENT_TIMESTAMP AND w.activo = true
+ORDER BY e.proximo_intento
+LIMIT $1
 `
 
-type ListReportesByPeriodoParams struct {
-	PeriodoInicio pgtype.Date
-	PeriodoFin    pgtype.Date
+type ListWebhookEntregasPendientesRow struct {
+	EntregaID          int32
+	WebhookID          int32
+	Evento             string
+	Payload            []byte
+	Estado             string
+	Intentos           int32
+	UltimoCodigo       pgtype.Int4
+	UltimoError        pgtype.Text
+	FechaCreacion      pgtype.Timestamp
+	FechaUltimoIntento pgtype.Timestamp
+	ProximoIntento     pgtype.Timestamp
+	Url                string
+	Secreto            string
 }
 
-func (q *Queries) ListReportesByPeriodo(ctx context.Context, arg ListReportesByPeriodoParams) ([]Reporte, error) {
-	rows, err := q.db.Query(ctx, listReportesByPeriodo, arg.PeriodoInicio, arg.PeriodoFin)
+func (q *Queries) ListWebhookEntregasPendientes(ctx context.Context, limit int32) ([]ListWebhookEntregasPendientesRow, error) {
+	rows, err := q.db.Query(ctx, listWebhookEntregasPendientes, limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Reporte
+	var items []ListWebhookEntregasPendientesRow
 	for rows.Next() {
-		var i Reporte
+		var i ListWebhookEntregasPendientesRow
 		if err := rows.Scan(
-			&i.ReporteID,
-			&i.TipoReporte,
-			&i.FechaGeneracion,
-			&i.PeriodoInicio,
-			&i.PeriodoFin,
-			&i.GeneradoPor,
-			&i.Datos,
+			&i.EntregaID,
+			&i.WebhookID,
+			&i.Evento,
+			&i.Payload,
+			&i.Estado,
+			&i.Intentos,
+			&i.UltimoCodigo,
+			&i.UltimoError,
+			&i.FechaCreacion,
+			&i.FechaUltimoIntento,
+			&i.ProximoIntento,
+			&i.Url,
+			&i.Secreto,
 		); err != nil {
 			return nil, err
 		}
@@ -1037,27 +3379,27 @@ func (q *Queries) ListReportesByPeriodo(ctx context.Context, arg ListReportesByP
 	return items, nil
 }
 
-const listReportesByTipo = `-- name: ListReportesByTipo :many
-SELECT reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos FROM REPORTES WHERE tipo_reporte = $1 ORDER BY fecha_generacion DESC
+const listWebhooks = `-- name: ListWebhooks :many
+SELECT webhook_id, url, secreto, eventos, activo, creado_por, fecha_creacion FROM WEBHOOKS ORDER BY webhook_id
 `
 
-func (q *Queries) ListReportesByTipo(ctx context.Context, tipoReporte string) ([]Reporte, error) {
-	rows, err := q.db.Query(ctx, listReportesByTipo, tipoReporte)
+func (q *Queries) ListWebhooks(ctx context.Context) ([]Webhook, error) {
+	rows, err := q.db.Query(ctx, listWebhooks)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Reporte
+	var items []Webhook
 	for rows.Next() {
-		var i Reporte
+		var i Webhook
 		if err := rows.Scan(
-			&i.ReporteID,
-			&i.TipoReporte,
-			&i.FechaGeneracion,
-			&i.PeriodoInicio,
-			&i.PeriodoFin,
-			&i.GeneradoPor,
-			&i.Datos,
+			&i.WebhookID,
+			&i.Url,
+			&i.Secreto,
+			&i.Eventos,
+			&i.Activo,
+			&i.CreadoPor,
+			&i.FechaCreacion,
 		); err != nil {
 			return nil, err
 		}
@@ -1069,26 +3411,27 @@ func (q *Queries) ListReportesByTipo(ctx context.Context, tipoReporte string) ([
 	return items, nil
 }
 
-const listTutores = `-- name: ListTutores :many
-SELECT tutor_id, nombre, apellido, correo, programa_academico, fecha_registro FROM TUTORES ORDER BY apellido, nombre
+const listWebhooksActivos = `-- name: ListWebhooksActivos :many
+SELECT webhook_id, url, secreto, eventos, activo, creado_por, fecha_creacion FROM WEBHOOKS WHERE activo = true ORDER BY webhook_id
 `
 
-func (q *Queries) ListTutores(ctx context.Context) ([]Tutore, error) {
-	rows, err := q.db.Query(ctx, listTutores)
+func (q *Queries) ListWebhooksActivos(ctx context.Context) ([]Webhook, error) {
+	rows, err := q.db.Query(ctx, listWebhooksActivos)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Tutore
+	var items []Webhook
 	for rows.Next() {
-		var i Tutore
+		var i Webhook
 		if err := rows.Scan(
-			&i.TutorID,
-			&i.Nombre,
-			&i.Apellido,
-			&i.Correo,
-			&i.ProgramaAcademico,
-			&i.FechaRegistro,
+			&i.WebhookID,
+			&i.Url,
+			&i.Secreto,
+			&i.Eventos,
+			&i.Activo,
+			&i.CreadoPor,
+			&i.FechaCreacion,
 		); err != nil {
 			return nil, err
 		}
@@ -1100,45 +3443,28 @@ func (q *Queries) ListTutores(ctx context.Context) ([]Tutore, error) {
 	return items, nil
 }
 
-const listTutoresByMateria = `-- name: ListTutoresByMateria :many
-SELECT tm.asignacion_id, tm.tutor_id, tm.materia_id, tm.fecha_asignacion, tm.activo, t.nombre as tutor_nombre, t.apellido as tutor_apellido
-FROM TUTOR_MATERIAS tm
-JOIN TUTORES t ON tm.tutor_id = t.tutor_id
-WHERE tm.materia_id = $1 AND tm.activo = true
-ORDER BY t.apellido, t.nombre
+const lockSuperadminsActivos = `-- name: LockSuperadminsActivos :many
+SELECT admin_id FROM ADMINS
+WHERE rol = 'superadmin' AND activo IS NOT FALSE
+ORDER BY admin_id
+FOR UPDATE
 `
 
-type ListTutoresByMateriaRow struct {
-	AsignacionID    int32
-	TutorID         int32
-	MateriaID       int32
-	FechaAsignacion pgtype.Date
-	Activo          bool
-	TutorNombre     string
-	TutorApellido   string
-}
-
-func (q *Queries) ListTutoresByMateria(ctx context.Context, materiaID int32) ([]ListTutoresByMateriaRow, error) {
-	rows, err := q.db.Query(ctx, listTutoresByMateria, materiaID)
+// Locks the active superadmins until the end of the transaction, so that concurrent changes
+// cannot remove the last one.
+func (q *Queries) LockSuperadminsActivos(ctx context.Context) ([]int32, error) {
+	rows, err := q.db.Query(ctx, lockSuperadminsActivos)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListTutoresByMateriaRow
+	var items []int32
 	for rows.Next() {
-		var i ListTutoresByMateriaRow
-		if err := rows.Scan(
-			&i.AsignacionID,
-			&i.TutorID,
-			&i.MateriaID,
-			&i.FechaAsignacion,
-			&i.Activo,
-			&i.TutorNombre,
-			&i.TutorApellido,
-		); err != nil {
+		var admin_id int32
+		if err := rows.Scan(&admin_id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, admin_id)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -1146,51 +3472,212 @@ func (q *Queries) ListTutoresByMateria(ctx context.Context, materiaID int32) ([]
 	return items, nil
 }
 
-const listTutoresDisponiblesByMateriaAndDia = `-- name: ListTutoresDisponiblesByMateriaAndDia :many
-SELECT DISTINCT t.tutor_id, t.nombre, t.apellido, t.correo, t.programa_academico, t.fecha_registro, d.dia_semana, d.hora_inicio, d.hora_fin
-FROM TUTORES t
-JOIN TUTOR_MATERIAS tm ON t.tutor_id = tm.tutor_id
-JOIN DISPONIBILIDAD d ON t.tutor_id = d.tutor_id
-WHERE tm.materia_id = $1 AND tm.activo = true AND d.dia_semana = $2
-ORDER BY d.hora_inicio
+const lockTutor = `-- name: LockTutor :one
+SELECT tutor_id FROM TUTORES WHERE tutor_id = $1 FOR UPDATE
 `
 
-type ListTutoresDisponiblesByMateriaAndDiaParams struct {
-	MateriaID int32
-	DiaSemana int32
+// Locks the tutor until the end of the transaction, so that concurrent bookings are checked
+// against their hour limits one at a time.
+func (q *Queries) LockTutor(ctx context.Context, tutorID int32) (int32, error) {
+	row := q.db.QueryRow(ctx, lockTutor, tutorID)
+	var tutor_id int32
+	err := row.Scan(&tutor_id)
+	return tutor_id, err
 }
 
-type ListTutoresDisponiblesByMateriaAndDiaRow struct {
-	TutorID           int32
-	Nombre            string
-	Apellido          string
-	Correo            string
-	ProgramaAcademico pgtype.Text
-	FechaRegistro     pgtype.Timestamp
-	DiaSemana         int32
-	HoraInicio        pgtype.Time
-	HoraFin           pgtype.Time
+const loginAdmin = `-- name: LoginAdmin :one
+SELECT admin_id, nombre, apellido, correo, password_hash, rol, activo, fecha_registro FROM ADMINS
+WHERE correo = $1
+`
+
+func (q *Queries) LoginAdmin(ctx context.Context, correo string) (Admin, error) {
+	row := q.db.QueryRow(ctx, loginAdmin, correo)
+	var i Admin
+	err := row.Scan(
+		&i.AdminID,
+		&i.Nombre,
+		&i.Apellido,
+		&i.Correo,
+		&i.PasswordHash,
+		&i.Rol,
+		&i.Activo,
+		&i.FechaRegistro,
+	)
+	return i, err
 }
 
-func (q *Queries) ListTutoresDisponiblesByMateriaAndDia(ctx context.Context, arg ListTutoresDisponiblesByMateriaAndDiaParams) ([]ListTutoresDisponiblesByMateriaAndDiaRow, error) {
-	rows, err := q.db.Query(ctx, listTutoresDisponiblesByMateriaAndDia, arg.MateriaID, arg.DiaSemana)
+const loginEstudiante = `-- name: LoginEstudiante :one
+SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti, deleted_at FROM ESTUDIANTES WHERE correo = $1 AND ti = $2 AND deleted_at IS NULL
+`
+
+type LoginEstudianteParams struct {
+	Correo string
+	Ti     pgtype.Int4
+}
+
+func (q *Queries) LoginEstudiante(ctx context.Context, arg LoginEstudianteParams) (Estudiante, error) {
+	row := q.db.QueryRow(ctx, loginEstudiante, arg.Correo, arg.Ti)
+	var i Estudiante
+	err := row.Scan(
+		&i.EstudianteID,
+		&i.Nombre,
+		&i.Apellido,
+		&i.Correo,
+		&i.ProgramaAcademico,
+		&i.Semestre,
+		&i.FechaRegistro,
+		&i.Ti,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const loginTutor = `-- name: LoginTutor :one
+SELECT tutor_id, nombre, apellido, correo, programa_academico, fecha_registro, deleted_at FROM TUTORES WHERE correo = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) LoginTutor(ctx context.Context, correo string) (Tutore, error) {
+	row := q.db.QueryRow(ctx, loginTutor, correo)
+	var i Tutore
+	err := row.Scan(
+		&i.TutorID,
+		&i.Nombre,
+		&i.Apellido,
+		&i.Correo,
+		&i.ProgramaAcademico,
+		&i.FechaRegistro,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const purgeEstudiantes = `-- name: PurgeEstudiantes :execrows
+DELETE FROM ESTUDIANTES e
+WHERE e.deleted_at < $1::timestamp
+  AND NOT EXISTS (SELECT 1 FROM TUTORIAS t WHERE t.estudiante_id = e.estudiante_id)
+`
+
+// Permanently deletes the estudiantes moved to the papelera before the cutoff. Estudiantes with
+// tutorias stay in the papelera, so that the tutoria history is never lost.
+func (q *Queries) PurgeEstudiantes(ctx context.Context, antes pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeEstudiantes, antes)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const purgeMaterias = `-- name: PurgeMaterias :execrows
+DELETE FROM MATERIAS m
+WHERE m.deleted_at < $1::timestamp
+  AND NOT EXISTS (SELECT 1 FROM TUTORIAS t WHERE t.materia_id = m.materia_id)
+  AND NOT EXISTS (SELECT 1 FROM SOLICITUDES_FALLIDAS sf WHERE sf.materia_id = m.materia_id)
+`
+
+// Permanently deletes the materias moved to the papelera before the cutoff, along with their
+// tutor assignments. Materias with tutorias or failed requests stay in the papelera, so the
+// demanda insatisfecha history is not lost with them.
+func (q *Queries) PurgeMaterias(ctx context.Context, antes pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeMaterias, antes)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const purgeTutores = `-- name: PurgeTutores :execrows
+DELETE FROM TUTORES tu
+WHERE tu.deleted_at < $1::timestamp
+  AND NOT EXISTS (SELECT 1 FROM TUTORIAS t WHERE t.tutor_id = tu.tutor_id)
+`
+
+// Permanently deletes the tutores moved to the papelera before the cutoff, along with their
+// availability and materias. Tutores with tutorias stay in the papelera.
+func (q *Queries) PurgeTutores(ctx context.Context, antes pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeTutores, antes)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reenviarWebhookEntrega = `-- name: ReenviarWebhookEntrega :one
+UPDATE WEBHOOK_ENTREGAS
+SET estado = 'pendiente', intentos = 0, proximo_intento = CURRENT_TIMESTAMP
+WHERE entrega_id = $1
+RETURNING entrega_id, webhook_id, evento, payload, estado, intentos, ultimo_codigo, ultimo_error, fecha_creacion, fecha_ultimo_intento, proximo_intento
+`
+
+func (q *Queries) ReenviarWebhookEntrega(ctx context.Context, entregaID int32) (WebhookEntrega, error) {
+	row := q.db.QueryRow(ctx, reenviarWebhookEntrega, entregaID)
+	var i WebhookEntrega
+	err := row.Scan(
+		&i.EntregaID,
+		&i.WebhookID,
+		&i.Evento,
+		&i.Payload,
+		&i.Estado,
+		&i.Intentos,
+		&i.UltimoCodigo,
+		&i.UltimoError,
+		&i.FechaCreacion,
+		&i.FechaUltimoIntento,
+		&i.ProximoIntento,
+	)
+	return i, err
+}
+
+const reporteAsistenciaProgramas = `-- name: ReporteAsistenciaProgramas :many
+SELECT 
+    e.programa_academico,
+    COUNT(DISTINCT e.estudiante_id) AS estudiantes,
+    COUNT(t.tutoria_id) AS total_tutorias,
+    SUM(CASE WHEN t.asistencia_confirmada THEN 1 ELSE 0 END) AS asistencias,
+    SUM(CASE WHEN NOT t.asistencia_confirmada THEN 1 ELSE 0 END) AS inasistencias,
+    SUM(CASE WHEN t.asistencia_confirmada IS NULL THEN 1 ELSE 0 END) AS sin_registro,
+    COALESCE(ROUND(SUM(CASE WHEN t.asistencia_confirmada THEN 1 ELSE 0 END)::numeric /
+          NULLIF(COUNT(t.asistencia_confirmada), 0)::numeric * 100, 2), 0)::float8 AS porcentaje_asistencia
+FROM TUTORIAS t
+JOIN ESTUDIANTES e ON t.estudiante_id = e.estudiante_id
+WHERE t.fecha BETWEEN $1 AND $2
+  AND t.estado <> 'cancelada'
+GROUP BY e.programa_academico
+ORDER BY e.programa_academico
+`
+
+type ReporteAsistenciaProgramasParams struct {
+	PeriodoInicio pgtype.Date
+	PeriodoFin    pgtype.Date
+}
+
+type ReporteAsistenciaProgramasRow struct {
+	ProgramaAcademico    string
+	Estudiantes          int64
+	TotalTutorias        int64
+	Asistencias          int64
+	Inasistencias        int64
+	SinRegistro          int64
+	PorcentajeAsistencia float64
+}
+
+// Attendance of non-cancelled tutorias per academic program of the estudiante.
+func (q *Queries) ReporteAsistenciaProgramas(ctx context.Context, arg ReporteAsistenciaProgramasParams) ([]ReporteAsistenciaProgramasRow, error) {
+	rows, err := q.db.Query(ctx, reporteAsistenciaProgramas, arg.PeriodoInicio, arg.PeriodoFin)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListTutoresDisponiblesByMateriaAndDiaRow
+	var items []ReporteAsistenciaProgramasRow
 	for rows.Next() {
-		var i ListTutoresDisponiblesByMateriaAndDiaRow
+		var i ReporteAsistenciaProgramasRow
 		if err := rows.Scan(
-			&i.TutorID,
-			&i.Nombre,
-			&i.Apellido,
-			&i.Correo,
 			&i.ProgramaAcademico,
-			&i.FechaRegistro,
-			&i.DiaSemana,
-			&i.HoraInicio,
-			&i.HoraFin,
+			&i.Estudiantes,
+			&i.TotalTutorias,
+			&i.Asistencias,
+			&i.Inasistencias,
+			&i.SinRegistro,
+			&i.PorcentajeAsistencia,
 		); err != nil {
 			return nil, err
 		}
@@ -1202,54 +3689,66 @@ func (q *Queries) ListTutoresDisponiblesByMateriaAndDia(ctx context.Context, arg
 	return items, nil
 }
 
-const listTutoresWithMaterias = `-- name: ListTutoresWithMaterias :many
-
-
-SELECT t.tutor_id, t.nombre, t.apellido, t.correo, t.programa_academico, t.fecha_registro, 
-       STRING_AGG(m.nombre, ', ') as materias_asignadas,
-       COUNT(tm.materia_id) as total_materias
-FROM TUTORES t
-LEFT JOIN TUTOR_MATERIAS tm ON t.tutor_id = tm.tutor_id AND tm.activo = true
-LEFT JOIN MATERIAS m ON tm.materia_id = m.materia_id
-GROUP BY t.tutor_id, t.nombre, t.apellido, t.correo, t.programa_academico, t.fecha_registro
-ORDER BY t.apellido, t.nombre
+const reporteCancelaciones = `-- name: ReporteCancelaciones :many
+SELECT 
+    t.tutoria_id,
+    t.fecha,
+    t.hora_inicio,
+    t.fecha_solicitud,
+    t.lugar,
+    (e.nombre || ' ' || e.apellido)::text AS estudiante,
+    e.programa_academico,
+    (tu.nombre || ' ' || tu.apellido)::text AS tutor,
+    m.codigo AS materia_codigo,
+    m.nombre AS materia
+FROM TUTORIAS t
+JOIN ESTUDIANTES e ON t.estudiante_id = e.estudiante_id
+JOIN TUTORES tu ON t.tutor_id = tu.tutor_id
+JOIN MATERIAS m ON t.materia_id = m.materia_id
+WHERE t.fecha BETWEEN $1 AND $2
+  AND t.estado = 'cancelada'
+ORDER BY t.fecha DESC, t.hora_inicio DESC
 `
 
-type ListTutoresWithMateriasRow struct {
-	TutorID           int32
-	Nombre            string
-	Apellido          string
-	Correo            string
-	ProgramaAcademico pgtype.Text
-	FechaRegistro     pgtype.Timestamp
-	MateriasAsignadas []byte
-	TotalMaterias     int64
-}
-
-// ========================================
-// ADDITIONAL USEFUL QUERIES FOR URTUTORIAS
-// ========================================
-// ========================================
-// TUTORES - MISSING QUERIES
-// ========================================
-func (q *Queries) ListTutoresWithMaterias(ctx context.Context) ([]ListTutoresWithMateriasRow, error) {
-	rows, err := q.db.Query(ctx, listTutoresWithMaterias)
+type ReporteCancelacionesParams struct {
+	PeriodoInicio pgtype.Date
+	PeriodoFin    pgtype.Date
+}
+
+type ReporteCancelacionesRow struct {
+	TutoriaID         int32
+	Fecha             pgtype.Date
+	HoraInicio        pgtype.Time
+	FechaSolicitud    pgtype.Timestamp
+	Lugar             string
+	Estudiante        string
+	ProgramaAcademico string
+	Tutor             string
+	MateriaCodigo     string
+	Materia           string
+}
+
+// Cancelled tutorias of the period, most recent first.
+func (q *Queries) ReporteCancelaciones(ctx context.Context, arg ReporteCancelacionesParams) ([]ReporteCancelacionesRow, error) {
+	rows, err := q.db.Query(ctx, reporteCancelaciones, arg.PeriodoInicio, arg.PeriodoFin)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListTutoresWithMateriasRow
+	var items []ReporteCancelacionesRow
 	for rows.Next() {
-		var i ListTutoresWithMateriasRow
+		var i ReporteCancelacionesRow
 		if err := rows.Scan(
-			&i.TutorID,
-			&i.Nombre,
-			&i.Apellido,
-			&i.Correo,
+			&i.TutoriaID,
+			&i.Fecha,
+			&i.HoraInicio,
+			&i.FechaSolicitud,
+			&i.Lugar,
+			&i.Estudiante,
 			&i.ProgramaAcademico,
-			&i.FechaRegistro,
-			&i.MateriasAsignadas,
-			&i.TotalMaterias,
+			&i.Tutor,
+			&i.MateriaCodigo,
+			&i.Materia,
 		); err != nil {
 			return nil, err
 		}
@@ -1261,31 +3760,70 @@ func (q *Queries) ListTutoresWithMaterias(ctx context.Context) ([]ListTutoresWit
 	return items, nil
 }
 
-const listTutoriasActivas = `-- name: ListTutoriasActivas :many
-SELECT tutoria_id, nombre_estudiante, apellido_estudiante, nombre_tutor, apellido_tutor, materia, fecha, hora_inicio, hora_fin, lugar, estado FROM tutoriasActivas ORDER BY fecha, hora_inicio
+const reporteDemandaInsatisfecha = `-- name: ReporteDemandaInsatisfecha :many
+SELECT
+    m.materia_id,
+    m.codigo,
+    m.nombre,
+    m.facultad,
+    COUNT(*) AS intentos,
+    COUNT(DISTINCT s.estudiante_id) AS estudiantes,
+    COALESCE(SUM(EXTRACT(EPOCH FROM (s.hora_fin - s.hora_inicio)) / 3600), 0)::float8 AS horas_solicitadas,
+    COUNT(*) FILTER (WHERE s.motivo = 'sin_tutores') AS sin_tutores,
+    COUNT(*) FILTER (WHERE s.motivo = 'sin_horario') AS sin_horario,
+    COUNT(*) FILTER (WHERE s.motivo NOT IN ('sin_tutores', 'sin_horario')) AS otros_motivos,
+    (SELECT COUNT(*) FROM TUTOR_MATERIAS tm WHERE tm.materia_id = m.materia_id AND tm.activo = true) AS tutores_activos,
+    MAX(s.fecha_intento)::timestamp AS ultimo_intento
+FROM SOLICITUDES_FALLIDAS s
+JOIN MATERIAS m ON m.materia_id = s.materia_id
+WHERE s.fecha BETWEEN $1 AND $2
+GROUP BY m.materia_id, m.codigo, m.nombre, m.facultad
+ORDER BY intentos DESC, m.codigo
 `
 
-func (q *Queries) ListTutoriasActivas(ctx context.Context) ([]Tutoriasactiva, error) {
-	rows, err := q.db.Query(ctx, listTutoriasActivas)
+type ReporteDemandaInsatisfechaParams struct {
+	PeriodoInicio pgtype.Date
+	PeriodoFin    pgtype.Date
+}
+
+type ReporteDemandaInsatisfechaRow struct {
+	MateriaID        int32
+	Codigo           string
+	Nombre           string
+	Facultad         string
+	Intentos         int64
+	Estudiantes      int64
+	HorasSolicitadas float64
+	SinTutores       int64
+	SinHorario       int64
+	OtrosMotivos     int64
+	TutoresActivos   int64
+	UltimoIntento    pgtype.Timestamp
+}
+
+// Failed booking attempts per materia for the dates within the period, most unmet demand first.
+func (q *Queries) ReporteDemandaInsatisfecha(ctx context.Context, arg ReporteDemandaInsatisfechaParams) ([]ReporteDemandaInsatisfechaRow, error) {
+	rows, err := q.db.Query(ctx, reporteDemandaInsatisfecha, arg.PeriodoInicio, arg.PeriodoFin)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Tutoriasactiva
+	var items []ReporteDemandaInsatisfechaRow
 	for rows.Next() {
-		var i Tutoriasactiva
+		var i ReporteDemandaInsatisfechaRow
 		if err := rows.Scan(
-			&i.TutoriaID,
-			&i.NombreEstudiante,
-			&i.ApellidoEstudiante,
-			&i.NombreTutor,
-			&i.ApellidoTutor,
-			&i.Materia,
-			&i.Fecha,
-			&i.HoraInicio,
-			&i.HoraFin,
-			&i.Lugar,
-			&i.Estado,
+			&i.MateriaID,
+			&i.Codigo,
+			&i.Nombre,
+			&i.Facultad,
+			&i.Intentos,
+			&i.Estudiantes,
+			&i.HorasSolicitadas,
+			&i.SinTutores,
+			&i.SinHorario,
+			&i.OtrosMotivos,
+			&i.TutoresActivos,
+			&i.UltimoIntento,
 		); err != nil {
 			return nil, err
 		}
@@ -1297,66 +3835,57 @@ func (q *Queries) ListTutoriasActivas(ctx context.Context) ([]Tutoriasactiva, er
 	return items, nil
 }
 
-const listTutoriasByEstado = `-- name: ListTutoriasByEstado :many
-SELECT t.tutoria_id, t.estudiante_id, t.tutor_id, t.materia_id, t.fecha, t.hora_inicio, t.hora_fin, t.estado, t.fecha_solicitud, t.fecha_confirmacion, t.temas_tratados, t.asistencia_confirmada, t.lugar, e.nombre as estudiante_nombre, e.apellido as estudiante_apellido, 
-       tu.nombre as tutor_nombre, tu.apellido as tutor_apellido, m.nombre as materia_nombre
-FROM TUTORIAS t
-JOIN ESTUDIANTES e ON t.estudiante_id = e.estudiante_id
-JOIN TUTORES tu ON t.tutor_id = tu.tutor_id
+const reporteDesempenoTutores = `-- name: ReporteDesempenoTutores :many
+SELECT 
+    tu.tutor_id,
+    (tu.nombre || ' ' || tu.apellido)::text AS tutor,
+    m.nombre AS materia,
+    COUNT(t.tutoria_id) AS total_tutorias,
+    SUM(CASE WHEN t.asistencia_confirmada THEN 1 ELSE 0 END) AS tutorias_completadas,
+    SUM(CASE WHEN t.estado = 'cancelada' THEN 1 ELSE 0 END) AS tutorias_canceladas,
+    COALESCE(ROUND(SUM(CASE WHEN t.asistencia_confirmada THEN 1 ELSE 0 END)::numeric / 
+          NULLIF(COUNT(t.tutoria_id), 0)::numeric * 100, 2), 0)::float8 AS porcentaje_asistencia
+FROM TUTORES tu
+JOIN TUTORIAS t ON tu.tutor_id = t.tutor_id
 JOIN MATERIAS m ON t.materia_id = m.materia_id
-WHERE t.estado = $1
-ORDER BY t.fecha DESC, t.hora_inicio DESC
+WHERE t.fecha BETWEEN $1 AND $2
+GROUP BY tu.tutor_id, tu.nombre, tu.apellido, m.nombre
+ORDER BY tutor, materia
 `
 
-type ListTutoriasByEstadoRow struct {
-	TutoriaID            int32
-	EstudianteID         int32
+type ReporteDesempenoTutoresParams struct {
+	PeriodoInicio pgtype.Date
+	PeriodoFin    pgtype.Date
+}
+
+type ReporteDesempenoTutoresRow struct {
 	TutorID              int32
-	MateriaID            int32
-	Fecha                pgtype.Date
-	HoraInicio           pgtype.Time
-	HoraFin              pgtype.Time
-	Estado               string
-	FechaSolicitud       pgtype.Timestamp
-	FechaConfirmacion    pgtype.Timestamp
-	TemasTratados        pgtype.Text
-	AsistenciaConfirmada pgtype.Bool
-	Lugar                string
-	EstudianteNombre     string
-	EstudianteApellido   string
-	TutorNombre          string
-	TutorApellido        string
-	MateriaNombre        string
+	Tutor                string
+	Materia              string
+	TotalTutorias        int64
+	TutoriasCompletadas  int64
+	TutoriasCanceladas   int64
+	PorcentajeAsistencia float64
 }
 
-func (q *Queries) ListTutoriasByEstado(ctx context.Context, estado string) ([]ListTutoriasByEstadoRow, error) {
-	rows, err := q.db.Query(ctx, listTutoriasByEstado, estado)
+// Same figures as the desempenoTutores view, restricted to tutorias of the period.
+func (q *Queries) ReporteDesempenoTutores(ctx context.Context, arg ReporteDesempenoTutoresParams) ([]ReporteDesempenoTutoresRow, error) {
+	rows, err := q.db.Query(ctx, reporteDesempenoTutores, arg.PeriodoInicio, arg.PeriodoFin)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListTutoriasByEstadoRow
+	var items []ReporteDesempenoTutoresRow
 	for rows.Next() {
-		var i ListTutoriasByEstadoRow
+		var i ReporteDesempenoTutoresRow
 		if err := rows.Scan(
-			&i.TutoriaID,
-			&i.EstudianteID,
 			&i.TutorID,
-			&i.MateriaID,
-			&i.Fecha,
-			&i.HoraInicio,
-			&i.HoraFin,
-			&i.Estado,
-			&i.FechaSolicitud,
-			&i.FechaConfirmacion,
-			&i.TemasTratados,
-			&i.AsistenciaConfirmada,
-			&i.Lugar,
-			&i.EstudianteNombre,
-			&i.EstudianteApellido,
-			&i.TutorNombre,
-			&i.TutorApellido,
-			&i.MateriaNombre,
+			&i.Tutor,
+			&i.Materia,
+			&i.TotalTutorias,
+			&i.TutoriasCompletadas,
+			&i.TutoriasCanceladas,
+			&i.PorcentajeAsistencia,
 		); err != nil {
 			return nil, err
 		}
@@ -1368,60 +3897,66 @@ func (q *Queries) ListTutoriasByEstado(ctx context.Context, estado string) ([]Li
 	return items, nil
 }
 
-const listTutoriasByEstudiante = `-- name: ListTutoriasByEstudiante :many
-SELECT t.tutoria_id, t.estudiante_id, t.tutor_id, t.materia_id, t.fecha, t.hora_inicio, t.hora_fin, t.estado, t.fecha_solicitud, t.fecha_confirmacion, t.temas_tratados, t.asistencia_confirmada, t.lugar, tu.nombre as tutor_nombre, tu.apellido as tutor_apellido, m.nombre as materia_nombre
-FROM TUTORIAS t
-JOIN TUTORES tu ON t.tutor_id = tu.tutor_id
-JOIN MATERIAS m ON t.materia_id = m.materia_id
-WHERE t.estudiante_id = $1
-ORDER BY t.fecha DESC, t.hora_inicio DESC
+const reporteUsoMaterias = `-- name: ReporteUsoMaterias :many
+SELECT 
+    m.materia_id,
+    m.codigo,
+    m.nombre,
+    m.facultad,
+    COUNT(t.tutoria_id) AS total_tutorias,
+    COUNT(DISTINCT t.estudiante_id) AS estudiantes,
+    COUNT(DISTINCT t.tutor_id) AS tutores,
+    SUM(CASE WHEN t.estado = 'completada' THEN 1 ELSE 0 END) AS tutorias_completadas,
+    SUM(CASE WHEN t.estado = 'cancelada' THEN 1 ELSE 0 END) AS tutorias_canceladas,
+    COALESCE(SUM(EXTRACT(EPOCH FROM (t.hora_fin - t.hora_inicio)) / 3600)
+        FILTER (WHERE t.estado <> 'cancelada'), 0)::float8 AS horas
+FROM MATERIAS m
+LEFT JOIN TUTORIAS t ON t.materia_id = m.materia_id
+    AND t.fecha BETWEEN $1 AND $2
+WHERE m.activo = true
+GROUP BY m.materia_id, m.codigo, m.nombre, m.facultad
+ORDER BY total_tutorias DESC, m.codigo
 `
 
-type ListTutoriasByEstudianteRow struct {
-	TutoriaID            int32
-	EstudianteID         int32
-	TutorID              int32
-	MateriaID            int32
-	Fecha                pgtype.Date
-	HoraInicio           pgtype.Time
-	HoraFin              pgtype.Time
-	Estado               string
-	FechaSolicitud       pgtype.Timestamp
-	FechaConfirmacion    pgtype.Timestamp
-	TemasTratados        pgtype.Text
-	AsistenciaConfirmada pgtype.Bool
-	Lugar                string
-	TutorNombre          string
-	TutorApellido        string
-	MateriaNombre        string
+type ReporteUsoMateriasParams struct {
+	PeriodoInicio pgtype.Date
+	PeriodoFin    pgtype.Date
 }
 
-func (q *Queries) ListTutoriasByEstudiante(ctx context.Context, estudianteID int32) ([]ListTutoriasByEstudianteRow, error) {
-	rows, err := q.db.Query(ctx, listTutoriasByEstudiante, estudianteID)
+type ReporteUsoMateriasRow struct {
+	MateriaID           int32
+	Codigo              string
+	Nombre              string
+	Facultad            string
+	TotalTutorias       int64
+	Estudiantes         int64
+	Tutores             int64
+	TutoriasCompletadas int64
+	TutoriasCanceladas  int64
+	Horas               float64
+}
+
+// Demand and hours of tutoring per active materia, including materias without tutorias.
+func (q *Queries) ReporteUsoMaterias(ctx context.Context, arg ReporteUsoMateriasParams) ([]ReporteUsoMateriasRow, error) {
+	rows, err := q.db.Query(ctx, reporteUsoMaterias, arg.PeriodoInicio, arg.PeriodoFin)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListTutoriasByEstudianteRow
+	var items []ReporteUsoMateriasRow
 	for rows.Next() {
-		var i ListTutoriasByEstudianteRow
+		var i ReporteUsoMateriasRow
 		if err := rows.Scan(
-			&i.TutoriaID,
-			&i.EstudianteID,
-			&i.TutorID,
 			&i.MateriaID,
-			&i.Fecha,
-			&i.HoraInicio,
-			&i.HoraFin,
-			&i.Estado,
-			&i.FechaSolicitud,
-			&i.FechaConfirmacion,
-			&i.TemasTratados,
-			&i.AsistenciaConfirmada,
-			&i.Lugar,
-			&i.TutorNombre,
-			&i.TutorApellido,
-			&i.MateriaNombre,
+			&i.Codigo,
+			&i.Nombre,
+			&i.Facultad,
+			&i.TotalTutorias,
+			&i.Estudiantes,
+			&i.Tutores,
+			&i.TutoriasCompletadas,
+			&i.TutoriasCanceladas,
+			&i.Horas,
 		); err != nil {
 			return nil, err
 		}
@@ -1433,16 +3968,242 @@ func (q *Queries) ListTutoriasByEstudiante(ctx context.Context, estudianteID int
 	return items, nil
 }
 
-const listTutoriasByTutor = `-- name: ListTutoriasByTutor :many
-SELECT t.tutoria_id, t.estudiante_id, t.tutor_id, t.materia_id, t.fecha, t.hora_inicio, t.hora_fin, t.estado, t.fecha_solicitud, t.fecha_confirmacion, t.temas_tratados, t.asistencia_confirmada, t.lugar, e.nombre as estudiante_nombre, e.apellido as estudiante_apellido, m.nombre as materia_nombre
+const requeueReportesEstancados = `-- name: RequeueReportesEstancados :execrows
+UPDATE REPORTES
+SET estado = 'pendiente', proximo_intento = CURRENT_TIMESTAMP
+WHERE estado = 'en_progreso' AND fecha_inicio < CURRENT_TIMESTAMP - make_interval(secs => $1::float8)
+`
+
+// Jobs left en_progreso by a worker that stopped (e.g. a restart) go back to the queue.
+// Their age is measured on the database clock, which also set fecha_inicio.
+func (q *Queries) RequeueReportesEstancados(ctx context.Context, antiguedadSegundos float64) (int64, error) {
+	result, err := q.db.Exec(ctx, requeueReportesEstancados, antiguedadSegundos)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreEstudiante = `-- name: RestoreEstudiante :one
+UPDATE ESTUDIANTES SET deleted_at = NULL
+WHERE estudiante_id = $1 AND deleted_at IS NOT NULL
+RETURNING estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti, deleted_at
+`
+
+func (q *Queries) RestoreEstudiante(ctx context.Context, estudianteID int32) (Estudiante, error) {
+	row := q.db.QueryRow(ctx, restoreEstudiante, estudianteID)
+	var i Estudiante
+	err := row.Scan(
+		&i.EstudianteID,
+		&i.Nombre,
+		&i.Apellido,
+		&i.Correo,
+		&i.ProgramaAcademico,
+		&i.Semestre,
+		&i.FechaRegistro,
+		&i.Ti,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const restoreMateria = `-- name: RestoreMateria :one
+UPDATE MATERIAS SET deleted_at = NULL
+WHERE materia_id = $1 AND deleted_at IS NOT NULL
+RETURNING materia_id, nombre, codigo, facultad, descripcion, creditos, activo, deleted_at
+`
+
+func (q *Queries) RestoreMateria(ctx context.Context, materiaID int32) (Materia, error) {
+	row := q.db.QueryRow(ctx, restoreMateria, materiaID)
+	var i Materia
+	err := row.Scan(
+		&i.MateriaID,
+		&i.Nombre,
+		&i.Codigo,
+		&i.Facultad,
+		&i.Descripcion,
+		&i.Creditos,
+		&i.Activo,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const restoreTutor = `-- name: RestoreTutor :one
+UPDATE TUTORES SET deleted_at = NULL
+WHERE tutor_id = $1 AND deleted_at IS NOT NULL
+RETURNING tutor_id, nombre, apellido, correo, programa_academico, fecha_registro, deleted_at
+`
+
+func (q *Queries) RestoreTutor(ctx context.Context, tutorID int32) (Tutore, error) {
+	row := q.db.QueryRow(ctx, restoreTutor, tutorID)
+	var i Tutore
+	err := row.Scan(
+		&i.TutorID,
+		&i.Nombre,
+		&i.Apellido,
+		&i.Correo,
+		&i.ProgramaAcademico,
+		&i.FechaRegistro,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const retryReporte = `-- name: RetryReporte :one
+UPDATE REPORTES
+SET estado = 'pendiente', progreso = 0, intentos = 0, ultimo_error = NULL,
+    proximo_intento = CURRENT_TIMESTAMP, fecha_finalizacion = NULL
+WHERE reporte_id = $1 AND estado IN ('fallido', 'cancelado')
+RETURNING reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos, estado, progreso, intentos, ultimo_error, proximo_intento, fecha_inicio, fecha_finalizacion, programacion_id
+`
+
+func (q *Queries) RetryReporte(ctx context.Context, reporteID int32) (Reporte, error) {
+	row := q.db.QueryRow(ctx, retryReporte, reporteID)
+	var i Reporte
+	err := row.Scan(
+		&i.ReporteID,
+		&i.TipoReporte,
+		&i.FechaGeneracion,
+		&i.PeriodoInicio,
+		&i.PeriodoFin,
+		&i.GeneradoPor,
+		&i.Datos,
+		&i.Estado,
+		&i.Progreso,
+		&i.Intentos,
+		&i.UltimoError,
+		&i.ProximoIntento,
+		&i.FechaInicio,
+		&i.FechaFinalizacion,
+		&i.ProgramacionID,
+	)
+	return i, err
+}
+
+const reviewSolicitudTutor = `-- name: ReviewSolicitudTutor :one
+UPDATE SOLICITUDES_TUTOR
+SET estado = $1,
+    motivo_rechazo = $2,
+    revisado_por = $3,
+    tutor_id = $4,
+    fecha_revision = CURRENT_TIMESTAMP
+WHERE solicitud_id = $5 AND estado = 'pendiente'
+RETURNING solicitud_id, estudiante_id, motivacion, materias, estado, motivo_rechazo, revisado_por, tutor_id, fecha_solicitud, fecha_revision
+`
+
+type ReviewSolicitudTutorParams struct {
+	Estado        string
+	MotivoRechazo pgtype.Text
+	RevisadoPor   pgtype.Int4
+	TutorID       pgtype.Int4
+	SolicitudID   int32
+}
+
+// Returns no rows when the solicitud was already reviewed.
+func (q *Queries) ReviewSolicitudTutor(ctx context.Context, arg ReviewSolicitudTutorParams) (SolicitudesTutor, error) {
+	row := q.db.QueryRow(ctx, reviewSolicitudTutor, arg.Estado, arg.MotivoRechazo, arg.RevisadoPor, arg.TutorID, arg.SolicitudID)
+	var i SolicitudesTutor
+	err := row.Scan(
+		&i.SolicitudID,
+		&i.EstudianteID,
+		&i.Motivacion,
+		&i.Materias,
+		&i.Estado,
+		&i.MotivoRechazo,
+		&i.RevisadoPor,
+		&i.TutorID,
+		&i.FechaSolicitud,
+		&i.FechaRevision,
+	)
+	return i, err
+}
+
+const reviewTutorPostulacion = `-- name: ReviewTutorPostulacion :one
+UPDATE TUTOR_POSTULACIONES
+SET estado = $1,
+    motivo_rechazo = $2,
+    revisado_por = $3,
+    fecha_revision = CURRENT_TIMESTAMP
+WHERE postulacion_id = $4 AND estado = 'pendiente'
+RETURNING postulacion_id, tutor_id, materia_id, comentario, estado, motivo_rechazo, revisado_por, fecha_postulacion, fecha_revision
+`
+
+type ReviewTutorPostulacionParams struct {
+	Estado        string
+	MotivoRechazo pgtype.Text
+	RevisadoPor   pgtype.Int4
+	PostulacionID int32
+}
+
+// Returns no rows when the postulacion was already reviewed.
+func (q *Queries) ReviewTutorPostulacion(ctx context.Context, arg ReviewTutorPostulacionParams) (TutorPostulacione, error) {
+	row := q.db.QueryRow(ctx, reviewTutorPostulacion, arg.Estado, arg.MotivoRechazo, arg.RevisadoPor, arg.PostulacionID)
+	var i TutorPostulacione
+	err := row.Scan(
+		&i.PostulacionID,
+		&i.TutorID,
+		&i.MateriaID,
+		&i.Comentario,
+		&i.Estado,
+		&i.MotivoRechazo,
+		&i.RevisadoPor,
+		&i.FechaPostulacion,
+		&i.FechaRevision,
+	)
+	return i, err
+}
+
+const searchTutorias = `-- name: SearchTutorias :many
+SELECT t.tutoria_id, t.estudiante_id, t.tutor_id, t.materia_id, t.fecha, t.hora_inicio, t.hora_fin, t.estado, t.fecha_solicitud, t.fecha_confirmacion, t.temas_tratados, t.asistencia_confirmada, t.lugar, e.nombre as estudiante_nombre, e.apellido as estudiante_apellido, 
+       tu.nombre as tutor_nombre, tu.apellido as tutor_apellido, m.nombre as materia_nombre
 FROM TUTORIAS t
 JOIN ESTUDIANTES e ON t.estudiante_id = e.estudiante_id
+JOIN TUTORES tu ON t.tutor_id = tu.tutor_id
 JOIN MATERIAS m ON t.materia_id = m.materia_id
-WHERE t.tutor_id = $1
-ORDER BY t.fecha DESC, t.hora_inicio DESC
+CROSS JOIN LATERAL (
+    SELECT CASE $1::text
+        WHEN 'fecha_solicitud' THEN COALESCE(to_char(t.fecha_solicitud, 'YYYY-MM-DD HH24:MI:SS.US'), '')
+        WHEN 'id' THEN lpad(t.tutoria_id::text, 10, '0')
+        ELSE COALESCE(to_char(t.fecha + t.hora_inicio, 'YYYY-MM-DD HH24:MI:SS'), '')
+    END AS valor
+) k
+WHERE ($2::int IS NULL OR t.estudiante_id = $2::int)
+  AND ($3::int IS NULL OR t.tutor_id = $3::int)
+  AND ($4::int IS NULL OR t.materia_id = $4::int)
+  AND ($5::text[] IS NULL OR t.estado = ANY($5::text[]))
+  AND ($6::date IS NULL OR t.fecha >= $6::date)
+  AND ($7::date IS NULL OR t.fecha <= $7::date)
+  AND ($8::text IS NULL OR t.lugar ILIKE '%' || replace(replace(replace($8::text, '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND ($9::bool IS NULL OR t.asistencia_confirmada = $9::bool)
+  AND ($10::int IS NULL
+    OR (NOT $11::bool AND (k.valor, t.tutoria_id) > ($12::text, $10::int))
+    OR ($11::bool AND (k.valor, t.tutoria_id) < ($12::text, $10::int)))
+ORDER BY
+    CASE WHEN NOT $11::bool THEN k.valor END,
+    CASE WHEN $11::bool THEN k.valor END DESC,
+    CASE WHEN NOT $11::bool THEN t.tutoria_id END,
+    t.tutoria_id DESC
+LIMIT $13
 `
 
-type ListTutoriasByTutorRow struct {
+type SearchTutoriasParams struct {
+	Sort         string
+	EstudianteID pgtype.Int4
+	TutorID      pgtype.Int4
+	MateriaID    pgtype.Int4
+	Estados      []string
+	FechaDesde   pgtype.Date
+	FechaHasta   pgtype.Date
+	Lugar        pgtype.Text
+	Asistencia   pgtype.Bool
+	AfterID      pgtype.Int4
+	Descending   bool
+	AfterValor   pgtype.Text
+	RowLimit     int32
+}
+
+type SearchTutoriasRow struct {
 	TutoriaID            int32
 	EstudianteID         int32
 	TutorID              int32
@@ -1458,18 +4219,35 @@ type ListTutoriasByTutorRow struct {
 	Lugar                string
 	EstudianteNombre     string
 	EstudianteApellido   string
+	TutorNombre          string
+	TutorApellido        string
 	MateriaNombre        string
 }
 
-func (q *Queries) ListTutoriasByTutor(ctx context.Context, tutorID int32) ([]ListTutoriasByTutorRow, error) {
-	rows, err := q.db.Query(ctx, listTutoriasByTutor, tutorID)
+// Every filter is optional: a NULL argument matches all tutorias.
+func (q *Queries) SearchTutorias(ctx context.Context, arg SearchTutoriasParams) ([]SearchTutoriasRow, error) {
+	rows, err := q.db.Query(ctx, searchTutorias,
+		arg.Sort,
+		arg.EstudianteID,
+		arg.TutorID,
+		arg.MateriaID,
+		arg.Estados,
+		arg.FechaDesde,
+		arg.FechaHasta,
+		arg.Lugar,
+		arg.Asistencia,
+		arg.AfterID,
+		arg.Descending,
+		arg.AfterValor,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListTutoriasByTutorRow
+	var items []SearchTutoriasRow
 	for rows.Next() {
-		var i ListTutoriasByTutorRow
+		var i SearchTutoriasRow
 		if err := rows.Scan(
 			&i.TutoriaID,
 			&i.EstudianteID,
@@ -1486,6 +4264,8 @@ func (q *Queries) ListTutoriasByTutor(ctx context.Context, tutorID int32) ([]Lis
 			&i.Lugar,
 			&i.EstudianteNombre,
 			&i.EstudianteApellido,
+			&i.TutorNombre,
+			&i.TutorApellido,
 			&i.MateriaNombre,
 		); err != nil {
 			return nil, err
@@ -1498,13 +4278,12 @@ func (q *Queries) ListTutoriasByTutor(ctx context.Context, tutorID int32) ([]Lis
 	return items, nil
 }
 
-const loginAdmin = `-- name: LoginAdmin :one
-SELECT admin_id, nombre, apellido, correo, password_hash, rol, activo, fecha_registro FROM ADMINS
-WHERE correo = $1
+const selectAdminByCorreo = `-- name: SelectAdminByCorreo :one
+SELECT admin_id, nombre, apellido, correo, password_hash, rol, activo, fecha_registro FROM ADMINS WHERE correo = $1
 `
 
-func (q *Queries) LoginAdmin(ctx context.Context, correo string) (Admin, error) {
-	row := q.db.QueryRow(ctx, loginAdmin, correo)
+func (q *Queries) SelectAdminByCorreo(ctx context.Context, correo string) (Admin, error) {
+	row := q.db.QueryRow(ctx, selectAdminByCorreo, correo)
 	var i Admin
 	err := row.Scan(
 		&i.AdminID,
@@ -1519,85 +4298,70 @@ func (q *Queries) LoginAdmin(ctx context.Context, correo string) (Admin, error)
 	return i, err
 }
 
-const loginEstudiante = `-- name: LoginEstudiante :one
-SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti FROM ESTUDIANTES WHERE correo = $1 AND ti = $2
+const selectAdminById = `-- name: SelectAdminById :one
+SELECT admin_id, nombre, apellido, correo, password_hash, rol, activo, fecha_registro FROM ADMINS WHERE admin_id = $1
 `
 
-type LoginEstudianteParams struct {
-	Correo string
-	Ti     pgtype.Int4
-}
-
-func (q *Queries) LoginEstudiante(ctx context.Context, arg LoginEstudianteParams) (Estudiante, error) {
-	row := q.db.QueryRow(ctx, loginEstudiante, arg.Correo, arg.Ti)
-	var i Estudiante
+func (q *Queries) SelectAdminById(ctx context.Context, adminID int32) (Admin, error) {
+	row := q.db.QueryRow(ctx, selectAdminById, adminID)
+	var i Admin
 	err := row.Scan(
-		&i.EstudianteID,
+		&i.AdminID,
 		&i.Nombre,
 		&i.Apellido,
 		&i.Correo,
-		&i.ProgramaAcademico,
-		&i.Semestre,
+		&i.PasswordHash,
+		&i.Rol,
+		&i.Activo,
 		&i.FechaRegistro,
-		&i.Ti,
 	)
 	return i, err
 }
 
-const loginTutor = `-- name: LoginTutor :one
-SELECT tutor_id, nombre, apellido, correo, programa_academico, fecha_registro FROM TUTORES WHERE correo = $1
+const selectCalendarioToken = `-- name: SelectCalendarioToken :one
+
+SELECT tipo_usuario, usuario_id, token, fecha_creacion FROM CALENDARIO_TOKENS WHERE tipo_usuario = $1 AND usuario_id = $2
 `
 
-func (q *Queries) LoginTutor(ctx context.Context, correo string) (Tutore, error) {
-	row := q.db.QueryRow(ctx, loginTutor, correo)
-	var i Tutore
-	err := row.Scan(
-		&i.TutorID,
-		&i.Nombre,
-		&i.Apellido,
-		&i.Correo,
-		&i.ProgramaAcademico,
-		&i.FechaRegistro,
-	)
-	return i, err
+type SelectCalendarioTokenParams struct {
+	TipoUsuario string
+	UsuarioID   int32
 }
 
-const selectAdminByCorreo = `-- name: SelectAdminByCorreo :one
-SELECT admin_id, nombre, apellido, correo, password_hash, rol, activo, fecha_registro FROM ADMINS WHERE correo = $1
-`
-
-func (q *Queries) SelectAdminByCorreo(ctx context.Context, correo string) (Admin, error) {
-	row := q.db.QueryRow(ctx, selectAdminByCorreo, correo)
-	var i Admin
+// ========================================
+// CALENDARIO QUERIES
+// ========================================
+func (q *Queries) SelectCalendarioToken(ctx context.Context, arg SelectCalendarioTokenParams) (CalendarioToken, error) {
+	row := q.db.QueryRow(ctx, selectCalendarioToken, arg.TipoUsuario, arg.UsuarioID)
+	var i CalendarioToken
 	err := row.Scan(
-		&i.AdminID,
-		&i.Nombre,
-		&i.Apellido,
-		&i.Correo,
-		&i.PasswordHash,
-		&i.Rol,
-		&i.Activo,
-		&i.FechaRegistro,
+		&i.TipoUsuario,
+		&i.UsuarioID,
+		&i.Token,
+		&i.FechaCreacion,
 	)
 	return i, err
 }
 
-const selectAdminById = `-- name: SelectAdminById :one
-SELECT admin_id, nombre, apellido, correo, password_hash, rol, activo, fecha_registro FROM ADMINS WHERE admin_id = $1
+const selectCertificadoByCodigo = `-- name: SelectCertificadoByCodigo :one
+SELECT certificado_id, codigo, tutor_id, tutor_nombre, desde, hasta, total_tutorias, total_minutos, detalle, firma, fecha_emision FROM CERTIFICADOS WHERE codigo = $1
 `
 
-func (q *Queries) SelectAdminById(ctx context.Context, adminID int32) (Admin, error) {
-	row := q.db.QueryRow(ctx, selectAdminById, adminID)
-	var i Admin
+func (q *Queries) SelectCertificadoByCodigo(ctx context.Context, codigo string) (Certificado, error) {
+	row := q.db.QueryRow(ctx, selectCertificadoByCodigo, codigo)
+	var i Certificado
 	err := row.Scan(
-		&i.AdminID,
-		&i.Nombre,
-		&i.Apellido,
-		&i.Correo,
-		&i.PasswordHash,
-		&i.Rol,
-		&i.Activo,
-		&i.FechaRegistro,
+		&i.CertificadoID,
+		&i.Codigo,
+		&i.TutorID,
+		&i.TutorNombre,
+		&i.Desde,
+		&i.Hasta,
+		&i.TotalTutorias,
+		&i.TotalMinutos,
+		&i.Detalle,
+		&i.Firma,
+		&i.FechaEmision,
 	)
 	return i, err
 }
@@ -1620,7 +4384,7 @@ func (q *Queries) SelectDisponibilidadById(ctx context.Context, disponibilidadID
 }
 
 const selectEstudianteByCorreo = `-- name: SelectEstudianteByCorreo :one
-SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti FROM ESTUDIANTES WHERE correo = $1
+SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti, deleted_at FROM ESTUDIANTES WHERE correo = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) SelectEstudianteByCorreo(ctx context.Context, correo string) (Estudiante, error) {
@@ -1635,14 +4399,16 @@ func (q *Queries) SelectEstudianteByCorreo(ctx context.Context, correo string) (
 		&i.Semestre,
 		&i.FechaRegistro,
 		&i.Ti,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const selectEstudianteById = `-- name: SelectEstudianteById :one
-SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti FROM ESTUDIANTES WHERE estudiante_id = $1
+SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti, deleted_at FROM ESTUDIANTES WHERE estudiante_id = $1 AND deleted_at IS NULL
 `
 
+// Deleted estudiantes are only reachable through the papelera queries.
 func (q *Queries) SelectEstudianteById(ctx context.Context, estudianteID int32) (Estudiante, error) {
 	row := q.db.QueryRow(ctx, selectEstudianteById, estudianteID)
 	var i Estudiante
@@ -1655,12 +4421,35 @@ func (q *Queries) SelectEstudianteById(ctx context.Context, estudianteID int32)
 		&i.Semestre,
 		&i.FechaRegistro,
 		&i.Ti,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectEstudianteByIdConEliminados = `-- name: SelectEstudianteByIdConEliminados :one
+SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti, deleted_at FROM ESTUDIANTES WHERE estudiante_id = $1
+`
+
+// Includes estudiantes moved to the papelera, for records that must still name them.
+func (q *Queries) SelectEstudianteByIdConEliminados(ctx context.Context, estudianteID int32) (Estudiante, error) {
+	row := q.db.QueryRow(ctx, selectEstudianteByIdConEliminados, estudianteID)
+	var i Estudiante
+	err := row.Scan(
+		&i.EstudianteID,
+		&i.Nombre,
+		&i.Apellido,
+		&i.Correo,
+		&i.ProgramaAcademico,
+		&i.Semestre,
+		&i.FechaRegistro,
+		&i.Ti,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const selectEstudianteByTI = `-- name: SelectEstudianteByTI :one
-SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti FROM ESTUDIANTES WHERE ti = $1
+SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti, deleted_at FROM ESTUDIANTES WHERE ti = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) SelectEstudianteByTI(ctx context.Context, ti pgtype.Int4) (Estudiante, error) {
@@ -1675,12 +4464,51 @@ func (q *Queries) SelectEstudianteByTI(ctx context.Context, ti pgtype.Int4) (Est
 		&i.Semestre,
 		&i.FechaRegistro,
 		&i.Ti,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectEstudianteEliminado = `-- name: SelectEstudianteEliminado :one
+SELECT estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti, deleted_at FROM ESTUDIANTES WHERE estudiante_id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) SelectEstudianteEliminado(ctx context.Context, estudianteID int32) (Estudiante, error) {
+	row := q.db.QueryRow(ctx, selectEstudianteEliminado, estudianteID)
+	var i Estudiante
+	err := row.Scan(
+		&i.EstudianteID,
+		&i.Nombre,
+		&i.Apellido,
+		&i.Correo,
+		&i.ProgramaAcademico,
+		&i.Semestre,
+		&i.FechaRegistro,
+		&i.Ti,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectInscripcionById = `-- name: SelectInscripcionById :one
+SELECT inscripcion_id, estudiante_id, materia_id, periodo, fecha_inscripcion FROM INSCRIPCIONES WHERE inscripcion_id = $1
+`
+
+func (q *Queries) SelectInscripcionById(ctx context.Context, inscripcionID int32) (Inscripcione, error) {
+	row := q.db.QueryRow(ctx, selectInscripcionById, inscripcionID)
+	var i Inscripcione
+	err := row.Scan(
+		&i.InscripcionID,
+		&i.EstudianteID,
+		&i.MateriaID,
+		&i.Periodo,
+		&i.FechaInscripcion,
 	)
 	return i, err
 }
 
 const selectMateriaByCodigo = `-- name: SelectMateriaByCodigo :one
-SELECT materia_id, nombre, codigo, facultad, descripcion, creditos FROM MATERIAS WHERE codigo = $1
+SELECT materia_id, nombre, codigo, facultad, descripcion, creditos, activo, deleted_at FROM MATERIAS WHERE codigo = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) SelectMateriaByCodigo(ctx context.Context, codigo string) (Materia, error) {
@@ -1693,14 +4521,17 @@ func (q *Queries) SelectMateriaByCodigo(ctx context.Context, codigo string) (Mat
 		&i.Facultad,
 		&i.Descripcion,
 		&i.Creditos,
+		&i.Activo,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const selectMateriaById = `-- name: SelectMateriaById :one
-SELECT materia_id, nombre, codigo, facultad, descripcion, creditos FROM MATERIAS WHERE materia_id = $1
+SELECT materia_id, nombre, codigo, facultad, descripcion, creditos, activo, deleted_at FROM MATERIAS WHERE materia_id = $1 AND deleted_at IS NULL
 `
 
+// Deleted materias are only reachable through the papelera queries.
 func (q *Queries) SelectMateriaById(ctx context.Context, materiaID int32) (Materia, error) {
 	row := q.db.QueryRow(ctx, selectMateriaById, materiaID)
 	var i Materia
@@ -1711,26 +4542,90 @@ func (q *Queries) SelectMateriaById(ctx context.Context, materiaID int32) (Mater
 		&i.Facultad,
 		&i.Descripcion,
 		&i.Creditos,
+		&i.Activo,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectMateriaByIdConEliminadas = `-- name: SelectMateriaByIdConEliminadas :one
+SELECT materia_id, nombre, codigo, facultad, descripcion, creditos, activo, deleted_at FROM MATERIAS WHERE materia_id = $1
+`
+
+// Includes materias moved to the papelera, for records that must still name them.
+func (q *Queries) SelectMateriaByIdConEliminadas(ctx context.Context, materiaID int32) (Materia, error) {
+	row := q.db.QueryRow(ctx, selectMateriaByIdConEliminadas, materiaID)
+	var i Materia
+	err := row.Scan(
+		&i.MateriaID,
+		&i.Nombre,
+		&i.Codigo,
+		&i.Facultad,
+		&i.Descripcion,
+		&i.Creditos,
+		&i.Activo,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectMateriaEliminada = `-- name: SelectMateriaEliminada :one
+SELECT materia_id, nombre, codigo, facultad, descripcion, creditos, activo, deleted_at FROM MATERIAS WHERE materia_id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) SelectMateriaEliminada(ctx context.Context, materiaID int32) (Materia, error) {
+	row := q.db.QueryRow(ctx, selectMateriaEliminada, materiaID)
+	var i Materia
+	err := row.Scan(
+		&i.MateriaID,
+		&i.Nombre,
+		&i.Codigo,
+		&i.Facultad,
+		&i.Descripcion,
+		&i.Creditos,
+		&i.Activo,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectMateriaRequisitos = `-- name: SelectMateriaRequisitos :one
+
+SELECT materia_id, semestre_minimo, programas, requiere_aprobacion, fecha_actualizacion FROM MATERIA_REQUISITOS WHERE materia_id = $1
+`
+
+// ========================================
+// TUTOR POSTULACIONES QUERIES
+// ========================================
+func (q *Queries) SelectMateriaRequisitos(ctx context.Context, materiaID int32) (MateriaRequisito, error) {
+	row := q.db.QueryRow(ctx, selectMateriaRequisitos, materiaID)
+	var i MateriaRequisito
+	err := row.Scan(
+		&i.MateriaID,
+		&i.SemestreMinimo,
+		&i.Programas,
+		&i.RequiereAprobacion,
+		&i.FechaActualizacion,
 	)
 	return i, err
 }
 
 const selectMateriasByEstudiante = `-- name: SelectMateriasByEstudiante :many
-SELECT m.materia_id, m.nombre, m.codigo, m.facultad, m.descripcion, m.creditos
+SELECT m.materia_id, m.nombre, m.codigo, m.facultad, m.descripcion, m.creditos, m.activo, m.deleted_at
 FROM MATERIAS m
-JOIN ESTUDIANTES e ON e.programa_academico = m.facultad
-WHERE e.estudiante_id = $1 AND e.semestre = $2
+JOIN INSCRIPCIONES i ON i.materia_id = m.materia_id
+WHERE i.estudiante_id = $1 AND i.periodo = $2 AND m.activo = true AND m.deleted_at IS NULL
 ORDER BY m.codigo
 `
 
 type SelectMateriasByEstudianteParams struct {
 	EstudianteID int32
-	Semestre     pgtype.Int4
+	Periodo      string
 }
 
-// Get materias corresponding to a specific estudiante's semester
+// Active materias the estudiante is enrolled in for the academic period.
 func (q *Queries) SelectMateriasByEstudiante(ctx context.Context, arg SelectMateriasByEstudianteParams) ([]Materia, error) {
-	rows, err := q.db.Query(ctx, selectMateriasByEstudiante, arg.EstudianteID, arg.Semestre)
+	rows, err := q.db.Query(ctx, selectMateriasByEstudiante, arg.EstudianteID, arg.Periodo)
 	if err != nil {
 		return nil, err
 	}
@@ -1745,6 +4640,8 @@ func (q *Queries) SelectMateriasByEstudiante(ctx context.Context, arg SelectMate
 			&i.Facultad,
 			&i.Descripcion,
 			&i.Creditos,
+			&i.Activo,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1757,7 +4654,7 @@ func (q *Queries) SelectMateriasByEstudiante(ctx context.Context, arg SelectMate
 }
 
 const selectReporteById = `-- name: SelectReporteById :one
-SELECT reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos FROM REPORTES WHERE reporte_id = $1
+SELECT reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos, estado, progreso, intentos, ultimo_error, proximo_intento, fecha_inicio, fecha_finalizacion, programacion_id FROM REPORTES WHERE reporte_id = $1
 `
 
 func (q *Queries) SelectReporteById(ctx context.Context, reporteID int32) (Reporte, error) {
@@ -1771,12 +4668,77 @@ func (q *Queries) SelectReporteById(ctx context.Context, reporteID int32) (Repor
 		&i.PeriodoFin,
 		&i.GeneradoPor,
 		&i.Datos,
+		&i.Estado,
+		&i.Progreso,
+		&i.Intentos,
+		&i.UltimoError,
+		&i.ProximoIntento,
+		&i.FechaInicio,
+		&i.FechaFinalizacion,
+		&i.ProgramacionID,
+	)
+	return i, err
+}
+
+const selectReporteEstado = `-- name: SelectReporteEstado :one
+SELECT estado FROM REPORTES WHERE reporte_id = $1
+`
+
+func (q *Queries) SelectReporteEstado(ctx context.Context, reporteID int32) (string, error) {
+	row := q.db.QueryRow(ctx, selectReporteEstado, reporteID)
+	var estado string
+	err := row.Scan(&estado)
+	return estado, err
+}
+
+const selectReporteProgramacionById = `-- name: SelectReporteProgramacionById :one
+SELECT programacion_id, nombre, tipo_reporte, cron, periodo, destinatarios, adjuntar_pdf, activo, creado_por, proxima_ejecucion, ultima_ejecucion, fecha_creacion FROM REPORTE_PROGRAMACIONES WHERE programacion_id = $1
+`
+
+func (q *Queries) SelectReporteProgramacionById(ctx context.Context, programacionID int32) (ReporteProgramacione, error) {
+	row := q.db.QueryRow(ctx, selectReporteProgramacionById, programacionID)
+	var i ReporteProgramacione
+	err := row.Scan(
+		&i.ProgramacionID,
+		&i.Nombre,
+		&i.TipoReporte,
+		&i.Cron,
+		&i.Periodo,
+		&i.Destinatarios,
+		&i.AdjuntarPdf,
+		&i.Activo,
+		&i.CreadoPor,
+		&i.ProximaEjecucion,
+		&i.UltimaEjecucion,
+		&i.FechaCreacion,
+	)
+	return i, err
+}
+
+const selectSolicitudTutorById = `-- name: SelectSolicitudTutorById :one
+SELECT solicitud_id, estudiante_id, motivacion, materias, estado, motivo_rechazo, revisado_por, tutor_id, fecha_solicitud, fecha_revision FROM SOLICITUDES_TUTOR WHERE solicitud_id = $1
+`
+
+func (q *Queries) SelectSolicitudTutorById(ctx context.Context, solicitudID int32) (SolicitudesTutor, error) {
+	row := q.db.QueryRow(ctx, selectSolicitudTutorById, solicitudID)
+	var i SolicitudesTutor
+	err := row.Scan(
+		&i.SolicitudID,
+		&i.EstudianteID,
+		&i.Motivacion,
+		&i.Materias,
+		&i.Estado,
+		&i.MotivoRechazo,
+		&i.RevisadoPor,
+		&i.TutorID,
+		&i.FechaSolicitud,
+		&i.FechaRevision,
 	)
 	return i, err
 }
 
 const selectTutorByCorreo = `-- name: SelectTutorByCorreo :one
-SELECT tutor_id, nombre, apellido, correo, programa_academico, fecha_registro FROM TUTORES WHERE correo = $1
+SELECT tutor_id, nombre, apellido, correo, programa_academico, fecha_registro, deleted_at FROM TUTORES WHERE correo = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) SelectTutorByCorreo(ctx context.Context, correo string) (Tutore, error) {
@@ -1789,14 +4751,16 @@ func (q *Queries) SelectTutorByCorreo(ctx context.Context, correo string) (Tutor
 		&i.Correo,
 		&i.ProgramaAcademico,
 		&i.FechaRegistro,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const selectTutorById = `-- name: SelectTutorById :one
-SELECT tutor_id, nombre, apellido, correo, programa_academico, fecha_registro FROM TUTORES WHERE tutor_id = $1
+SELECT tutor_id, nombre, apellido, correo, programa_academico, fecha_registro, deleted_at FROM TUTORES WHERE tutor_id = $1 AND deleted_at IS NULL
 `
 
+// Deleted tutores are only reachable through the papelera queries.
 func (q *Queries) SelectTutorById(ctx context.Context, tutorID int32) (Tutore, error) {
 	row := q.db.QueryRow(ctx, selectTutorById, tutorID)
 	var i Tutore
@@ -1807,23 +4771,142 @@ func (q *Queries) SelectTutorById(ctx context.Context, tutorID int32) (Tutore, e
 		&i.Correo,
 		&i.ProgramaAcademico,
 		&i.FechaRegistro,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectTutorByIdConEliminados = `-- name: SelectTutorByIdConEliminados :one
+SELECT tutor_id, nombre, apellido, correo, programa_academico, fecha_registro, deleted_at FROM TUTORES WHERE tutor_id = $1
+`
+
+// Includes tutores moved to the papelera, for records that must still name them.
+func (q *Queries) SelectTutorByIdConEliminados(ctx context.Context, tutorID int32) (Tutore, error) {
+	row := q.db.QueryRow(ctx, selectTutorByIdConEliminados, tutorID)
+	var i Tutore
+	err := row.Scan(
+		&i.TutorID,
+		&i.Nombre,
+		&i.Apellido,
+		&i.Correo,
+		&i.ProgramaAcademico,
+		&i.FechaRegistro,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectTutorEliminado = `-- name: SelectTutorEliminado :one
+SELECT tutor_id, nombre, apellido, correo, programa_academico, fecha_registro, deleted_at FROM TUTORES WHERE tutor_id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) SelectTutorEliminado(ctx context.Context, tutorID int32) (Tutore, error) {
+	row := q.db.QueryRow(ctx, selectTutorEliminado, tutorID)
+	var i Tutore
+	err := row.Scan(
+		&i.TutorID,
+		&i.Nombre,
+		&i.Apellido,
+		&i.Correo,
+		&i.ProgramaAcademico,
+		&i.FechaRegistro,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const selectTutorHoras = `-- name: SelectTutorHoras :one
+SELECT
+    COALESCE(SUM(EXTRACT(EPOCH FROM (hora_fin - hora_inicio)) / 3600) FILTER (WHERE estado = 'completada'), 0)::float8 AS horas_completadas,
+    COALESCE(SUM(EXTRACT(EPOCH FROM (hora_fin - hora_inicio)) / 3600) FILTER (WHERE estado = 'confirmada'), 0)::float8 AS horas_confirmadas,
+    COALESCE(SUM(EXTRACT(EPOCH FROM (hora_fin - hora_inicio)) / 3600) FILTER (WHERE estado = 'solicitada'), 0)::float8 AS horas_pendientes,
+    COUNT(*) FILTER (WHERE estado = 'completada') AS tutorias_completadas,
+    COUNT(*) FILTER (WHERE estado = 'confirmada') AS tutorias_confirmadas,
+    COUNT(*) FILTER (WHERE estado = 'solicitada') AS tutorias_pendientes
+FROM TUTORIAS
+WHERE tutor_id = $1
+  AND fecha BETWEEN $2::date AND $3::date
+`
+
+type SelectTutorHorasParams struct {
+	TutorID int32
+	Desde   pgtype.Date
+	Hasta   pgtype.Date
+}
+
+type SelectTutorHorasRow struct {
+	HorasCompletadas    float64
+	HorasConfirmadas    float64
+	HorasPendientes     float64
+	TutoriasCompletadas int64
+	TutoriasConfirmadas int64
+	TutoriasPendientes  int64
+}
+
+// Hours of the tutor's tutorias dated within the period, by estado. Cancelled tutorias are not counted.
+func (q *Queries) SelectTutorHoras(ctx context.Context, arg SelectTutorHorasParams) (SelectTutorHorasRow, error) {
+	row := q.db.QueryRow(ctx, selectTutorHoras, arg.TutorID, arg.Desde, arg.Hasta)
+	var i SelectTutorHorasRow
+	err := row.Scan(
+		&i.HorasCompletadas,
+		&i.HorasConfirmadas,
+		&i.HorasPendientes,
+		&i.TutoriasCompletadas,
+		&i.TutoriasConfirmadas,
+		&i.TutoriasPendientes,
 	)
 	return i, err
 }
 
+const selectTutorLimites = `-- name: SelectTutorLimites :one
+
+SELECT tutor_id, horas_semana, horas_mes, fecha_actualizacion FROM TUTOR_LIMITES WHERE tutor_id = $1
+`
+
+// ========================================
+// TUTOR LIMITES QUERIES
+// ========================================
+func (q *Queries) SelectTutorLimites(ctx context.Context, tutorID int32) (TutorLimite, error) {
+	row := q.db.QueryRow(ctx, selectTutorLimites, tutorID)
+	var i TutorLimite
+	err := row.Scan(&i.TutorID, &i.HorasSemana, &i.HorasMes, &i.FechaActualizacion)
+	return i, err
+}
+
 const selectTutorMateriaById = `-- name: SelectTutorMateriaById :one
 SELECT asignacion_id, tutor_id, materia_id, fecha_asignacion, activo FROM TUTOR_MATERIAS WHERE asignacion_id = $1
 `
 
-func (q *Queries) SelectTutorMateriaById(ctx context.Context, asignacionID int32) (TutorMateria, error) {
-	row := q.db.QueryRow(ctx, selectTutorMateriaById, asignacionID)
-	var i TutorMateria
+func (q *Queries) SelectTutorMateriaById(ctx context.Context, asignacionID int32) (TutorMateria, error) {
+	row := q.db.QueryRow(ctx, selectTutorMateriaById, asignacionID)
+	var i TutorMateria
+	err := row.Scan(
+		&i.AsignacionID,
+		&i.TutorID,
+		&i.MateriaID,
+		&i.FechaAsignacion,
+		&i.Activo,
+	)
+	return i, err
+}
+
+const selectTutorPostulacionById = `-- name: SelectTutorPostulacionById :one
+SELECT postulacion_id, tutor_id, materia_id, comentario, estado, motivo_rechazo, revisado_por, fecha_postulacion, fecha_revision FROM TUTOR_POSTULACIONES WHERE postulacion_id = $1
+`
+
+func (q *Queries) SelectTutorPostulacionById(ctx context.Context, postulacionID int32) (TutorPostulacione, error) {
+	row := q.db.QueryRow(ctx, selectTutorPostulacionById, postulacionID)
+	var i TutorPostulacione
 	err := row.Scan(
-		&i.AsignacionID,
+		&i.PostulacionID,
 		&i.TutorID,
 		&i.MateriaID,
-		&i.FechaAsignacion,
-		&i.Activo,
+		&i.Comentario,
+		&i.Estado,
+		&i.MotivoRechazo,
+		&i.RevisadoPor,
+		&i.FechaPostulacion,
+		&i.FechaRevision,
 	)
 	return i, err
 }
@@ -1929,6 +5012,169 @@ func (q *Queries) SelectTutoriaByTutorId(ctx context.Context, tutorID int32) ([]
 	return items, nil
 }
 
+const selectTutoriaInvitacion = `-- name: SelectTutoriaInvitacion :one
+
+SELECT tutoria_id, secuencia, metodo, fecha_envio FROM TUTORIA_INVITACIONES WHERE tutoria_id = $1
+`
+
+// ========================================
+// TUTORIA INVITACIONES QUERIES
+// ========================================
+func (q *Queries) SelectTutoriaInvitacion(ctx context.Context, tutoriaID int32) (TutoriaInvitacione, error) {
+	row := q.db.QueryRow(ctx, selectTutoriaInvitacion, tutoriaID)
+	var i TutoriaInvitacione
+	err := row.Scan(
+		&i.TutoriaID,
+		&i.Secuencia,
+		&i.Metodo,
+		&i.FechaEnvio,
+	)
+	return i, err
+}
+
+const selectUltimoCertificado = `-- name: SelectUltimoCertificado :one
+SELECT certificado_id, codigo, tutor_id, tutor_nombre, desde, hasta, total_tutorias, total_minutos, detalle, firma, fecha_emision FROM CERTIFICADOS
+WHERE tutor_id = $1 AND desde = $2 AND hasta = $3
+ORDER BY fecha_emision DESC, certificado_id DESC
+LIMIT 1
+`
+
+type SelectUltimoCertificadoParams struct {
+	TutorID pgtype.Int4
+	Desde   pgtype.Date
+	Hasta   pgtype.Date
+}
+
+// Most recent certificate issued to the tutor for exactly this period.
+func (q *Queries) SelectUltimoCertificado(ctx context.Context, arg SelectUltimoCertificadoParams) (Certificado, error) {
+	row := q.db.QueryRow(ctx, selectUltimoCertificado, arg.TutorID, arg.Desde, arg.Hasta)
+	var i Certificado
+	err := row.Scan(
+		&i.CertificadoID,
+		&i.Codigo,
+		&i.TutorID,
+		&i.TutorNombre,
+		&i.Desde,
+		&i.Hasta,
+		&i.TotalTutorias,
+		&i.TotalMinutos,
+		&i.Detalle,
+		&i.Firma,
+		&i.FechaEmision,
+	)
+	return i, err
+}
+
+const selectWebhookById = `-- name: SelectWebhookById :one
+SELECT webhook_id, url, secreto, eventos, activo, creado_por, fecha_creacion FROM WEBHOOKS WHERE webhook_id = $1
+`
+
+func (q *Queries) SelectWebhookById(ctx context.Context, webhookID int32) (Webhook, error) {
+	row := q.db.QueryRow(ctx, selectWebhookById, webhookID)
+	var i Webhook
+	err := row.Scan(
+		&i.WebhookID,
+		&i.Url,
+		&i.Secreto,
+		&i.Eventos,
+		&i.Activo,
+		&i.CreadoPor,
+		&i.FechaCreacion,
+	)
+	return i, err
+}
+
+const selectWebhookEntregaById = `-- name: SelectWebhookEntregaById :one
+SELECT entrega_id, webhook_id, evento, payload, estado, intentos, ultimo_codigo, ultimo_error, fecha_creacion, fecha_ultimo_intento, proximo_intento FROM WEBHOOK_ENTREGAS WHERE entrega_id = $1
+`
+
+func (q *Queries) SelectWebhookEntregaById(ctx context.Context, entregaID int32) (WebhookEntrega, error) {
+	row := q.db.QueryRow(ctx, selectWebhookEntregaById, entregaID)
+	var i WebhookEntrega
+	err := row.Scan(
+		&i.EntregaID,
+		&i.WebhookID,
+		&i.Evento,
+		&i.Payload,
+		&i.Estado,
+		&i.Intentos,
+		&i.UltimoCodigo,
+		&i.UltimoError,
+		&i.FechaCreacion,
+		&i.FechaUltimoIntento,
+		&i.ProximoIntento,
+	)
+	return i, err
+}
+
+const setMateriaActivo = `-- name: SetMateriaActivo :one
+UPDATE MATERIAS SET activo = $2 WHERE materia_id = $1 AND deleted_at IS NULL
+RETURNING materia_id, nombre, codigo, facultad, descripcion, creditos, activo, deleted_at
+`
+
+type SetMateriaActivoParams struct {
+	MateriaID int32
+	Activo    bool
+}
+
+func (q *Queries) SetMateriaActivo(ctx context.Context, arg SetMateriaActivoParams) (Materia, error) {
+	row := q.db.QueryRow(ctx, setMateriaActivo, arg.MateriaID, arg.Activo)
+	var i Materia
+	err := row.Scan(
+		&i.MateriaID,
+		&i.Nombre,
+		&i.Codigo,
+		&i.Facultad,
+		&i.Descripcion,
+		&i.Creditos,
+		&i.Activo,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const softDeleteEstudiante = `-- name: SoftDeleteEstudiante :execrows
+UPDATE ESTUDIANTES SET deleted_at = CURRENT_TIMESTAMP
+WHERE estudiante_id = $1 AND deleted_at IS NULL
+`
+
+// Moves the estudiante to the papelera, keeping their tutorias and inscripciones.
+func (q *Queries) SoftDeleteEstudiante(ctx context.Context, estudianteID int32) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteEstudiante, estudianteID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const softDeleteMateria = `-- name: SoftDeleteMateria :execrows
+UPDATE MATERIAS SET deleted_at = CURRENT_TIMESTAMP
+WHERE materia_id = $1 AND deleted_at IS NULL
+`
+
+// Moves the materia to the papelera, keeping its tutorias and tutor assignments.
+func (q *Queries) SoftDeleteMateria(ctx context.Context, materiaID int32) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteMateria, materiaID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const softDeleteTutor = `-- name: SoftDeleteTutor :execrows
+UPDATE TUTORES SET deleted_at = CURRENT_TIMESTAMP
+WHERE tutor_id = $1 AND deleted_at IS NULL
+`
+
+// Moves the tutor to the papelera, keeping their tutorias, availability and materias.
+func (q *Queries) SoftDeleteTutor(ctx context.Context, tutorID int32) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteTutor, tutorID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateAdmin = `-- name: UpdateAdmin :one
 UPDATE ADMINS 
 SET nombre = $2, apellido = $3, correo = $4, password_hash = $5, rol = $6, activo = $7
@@ -2029,8 +5275,8 @@ func (q *Queries) UpdateDisponibilidad(ctx context.Context, arg UpdateDisponibil
 const updateEstudiante = `-- name: UpdateEstudiante :one
 UPDATE ESTUDIANTES 
 SET nombre = $2, apellido = $3, correo = $4, programa_academico = $5, semestre = $6, ti = $7
-WHERE estudiante_id = $1
-RETURNING estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti
+WHERE estudiante_id = $1 AND deleted_at IS NULL
+RETURNING estudiante_id, nombre, apellido, correo, programa_academico, semestre, fecha_registro, ti, deleted_at
 `
 
 type UpdateEstudianteParams struct {
@@ -2063,6 +5309,7 @@ func (q *Queries) UpdateEstudiante(ctx context.Context, arg UpdateEstudiantePara
 		&i.Semestre,
 		&i.FechaRegistro,
 		&i.Ti,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -2070,8 +5317,8 @@ func (q *Queries) UpdateEstudiante(ctx context.Context, arg UpdateEstudiantePara
 const updateMateria = `-- name: UpdateMateria :one
 UPDATE MATERIAS 
 SET nombre = $2, codigo = $3, facultad = $4, descripcion = $5, creditos = $6
-WHERE materia_id = $1
-RETURNING materia_id, nombre, codigo, facultad, descripcion, creditos
+WHERE materia_id = $1 AND deleted_at IS NULL
+RETURNING materia_id, nombre, codigo, facultad, descripcion, creditos, activo, deleted_at
 `
 
 type UpdateMateriaParams struct {
@@ -2100,42 +5347,119 @@ func (q *Queries) UpdateMateria(ctx context.Context, arg UpdateMateriaParams) (M
 		&i.Facultad,
 		&i.Descripcion,
 		&i.Creditos,
+		&i.Activo,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
-const updateReporte = `-- name: UpdateReporte :one
-UPDATE REPORTES 
-SET datos = $2
-WHERE reporte_id = $1
-RETURNING reporte_id, tipo_reporte, fecha_generacion, periodo_inicio, periodo_fin, generado_por, datos
+const updateMateriaByCodigo = `-- name: UpdateMateriaByCodigo :one
+UPDATE MATERIAS
+SET nombre = $2, facultad = $3, descripcion = $4, creditos = $5, activo = true
+WHERE codigo = $1 AND deleted_at IS NULL
+RETURNING materia_id, nombre, codigo, facultad, descripcion, creditos, activo, deleted_at
 `
 
-type UpdateReporteParams struct {
-	ReporteID int32
-	Datos     []byte
+type UpdateMateriaByCodigoParams struct {
+	Codigo      string
+	Nombre      string
+	Facultad    string
+	Descripcion pgtype.Text
+	Creditos    int32
 }
 
-func (q *Queries) UpdateReporte(ctx context.Context, arg UpdateReporteParams) (Reporte, error) {
-	row := q.db.QueryRow(ctx, updateReporte, arg.ReporteID, arg.Datos)
-	var i Reporte
+func (q *Queries) UpdateMateriaByCodigo(ctx context.Context, arg UpdateMateriaByCodigoParams) (Materia, error) {
+	row := q.db.QueryRow(ctx, updateMateriaByCodigo,
+		arg.Codigo,
+		arg.Nombre,
+		arg.Facultad,
+		arg.Descripcion,
+		arg.Creditos,
+	)
+	var i Materia
 	err := row.Scan(
-		&i.ReporteID,
+		&i.MateriaID,
+		&i.Nombre,
+		&i.Codigo,
+		&i.Facultad,
+		&i.Descripcion,
+		&i.Creditos,
+		&i.Activo,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const updateReporteProgramacion = `-- name: UpdateReporteProgramacion :one
+UPDATE REPORTE_PROGRAMACIONES
+SET nombre = $2, tipo_reporte = $3, cron = $4, periodo = $5, destinatarios = $6,
+    adjuntar_pdf = $7, activo = $8, proxima_ejecucion = $9
+WHERE programacion_id = $1
+RETURNING programacion_id, nombre, tipo_reporte, cron, periodo, destinatarios, adjuntar_pdf, activo, creado_por, proxima_ejecucion, ultima_ejecucion, fecha_creacion
+`
+
+type UpdateReporteProgramacionParams struct {
+	ProgramacionID   int32
+	Nombre           string
+	TipoReporte      string
+	Cron             string
+	Periodo          string
+	Destinatarios    []string
+	AdjuntarPdf      bool
+	Activo           bool
+	ProximaEjecucion pgtype.Timestamp
+}
+
+func (q *Queries) UpdateReporteProgramacion(ctx context.Context, arg UpdateReporteProgramacionParams) (ReporteProgramacione, error) {
+	row := q.db.QueryRow(ctx, updateReporteProgramacion,
+		arg.ProgramacionID,
+		arg.Nombre,
+		arg.TipoReporte,
+		arg.Cron,
+		arg.Periodo,
+		arg.Destinatarios,
+		arg.AdjuntarPdf,
+		arg.Activo,
+		arg.ProximaEjecucion,
+	)
+	var i ReporteProgramacione
+	err := row.Scan(
+		&i.ProgramacionID,
+		&i.Nombre,
 		&i.TipoReporte,
-		&i.FechaGeneracion,
-		&i.PeriodoInicio,
-		&i.PeriodoFin,
-		&i.GeneradoPor,
-		&i.Datos,
+		&i.Cron,
+		&i.Periodo,
+		&i.Destinatarios,
+		&i.AdjuntarPdf,
+		&i.Activo,
+		&i.CreadoPor,
+		&i.ProximaEjecucion,
+		&i.UltimaEjecucion,
+		&i.FechaCreacion,
 	)
 	return i, err
 }
 
+const updateReporteProgreso = `-- name: UpdateReporteProgreso :exec
+UPDATE REPORTES SET progreso = $2
+WHERE reporte_id = $1 AND estado = 'en_progreso'
+`
+
+type UpdateReporteProgresoParams struct {
+	ReporteID int32
+	Progreso  int32
+}
+
+func (q *Queries) UpdateReporteProgreso(ctx context.Context, arg UpdateReporteProgresoParams) error {
+	_, err := q.db.Exec(ctx, updateReporteProgreso, arg.ReporteID, arg.Progreso)
+	return err
+}
+
 const updateTutor = `-- name: UpdateTutor :one
 UPDATE TUTORES 
 SET nombre = $2, apellido = $3, correo = $4, programa_academico = $5
-WHERE tutor_id = $1
-RETURNING tutor_id, nombre, apellido, correo, programa_academico, fecha_registro
+WHERE tutor_id = $1 AND deleted_at IS NULL
+RETURNING tutor_id, nombre, apellido, correo, programa_academico, fecha_registro, deleted_at
 `
 
 type UpdateTutorParams struct {
@@ -2162,6 +5486,7 @@ func (q *Queries) UpdateTutor(ctx context.Context, arg UpdateTutorParams) (Tutor
 		&i.Correo,
 		&i.ProgramaAcademico,
 		&i.FechaRegistro,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -2239,3 +5564,293 @@ func (q *Queries) UpdateTutoria(ctx context.Context, arg UpdateTutoriaParams) (T
 	)
 	return i, err
 }
+
+const updateWebhook = `-- name: UpdateWebhook :one
+UPDATE WEBHOOKS
+SET url = $2, secreto = $3, eventos = $4, activo = $5
+WHERE webhook_id = $1
+RETURNING webhook_id, url, secreto, eventos, activo, creado_por, fecha_creacion
+`
+
+type UpdateWebhookParams struct {
+	WebhookID int32
+	Url       string
+	Secreto   string
+	Eventos   []string
+	Activo    bool
+}
+
+func (q *Queries) UpdateWebhook(ctx context.Context, arg UpdateWebhookParams) (Webhook, error) {
+	row := q.db.QueryRow(ctx, updateWebhook,
+		arg.WebhookID,
+		arg.Url,
+		arg.Secreto,
+		arg.Eventos,
+		arg.Activo,
+	)
+	var i Webhook
+	err := row.Scan(
+		&i.WebhookID,
+		&i.Url,
+		&i.Secreto,
+		&i.Eventos,
+		&i.Activo,
+		&i.CreadoPor,
+		&i.FechaCreacion,
+	)
+	return i, err
+}
+
+const updateWebhookEntregaIntento = `-- name: UpdateWebhookEntregaIntento :one
+UPDATE WEBHOOK_ENTREGAS
+SET estado = $1, intentos = intentos + 1, ultimo_codigo = $2, ultimo_error = $3,
+    fecha_ultimo_intento = CURRENT_TIMESTAMP,
+    proximo_intento = COALESCE(CURRENT_TIMESTAMP + make_interval(secs => $4::float8), proximo_intento)
+WHERE entrega_id = $5
+RETURNING entrega_id, webhook_id, evento, payload, estado, intentos, ultimo_codigo, ultimo_error, fecha_creacion, fecha_ultimo_intento, proximo_intento
+`
+
+type UpdateWebhookEntregaIntentoParams struct {
+	Estado         string
+	UltimoCodigo   pgtype.Int4
+	UltimoError    pgtype.Text
+	EsperaSegundos pgtype.Float8
+	EntregaID      int32
+}
+
+// The next attempt is scheduled on the database clock, the one ListWebhookEntregasPendientes
+// compares against. A NULL espera_segundos keeps the current proximo_intento.
+func (q *Queries) UpdateWebhookEntregaIntento(ctx context.Context, arg UpdateWebhookEntregaIntentoParams) (WebhookEntrega, error) {
+	row := q.db.QueryRow(ctx, updateWebhookEntregaIntento,
+		arg.Estado,
+		arg.UltimoCodigo,
+		arg.UltimoError,
+		arg.EsperaSegundos,
+		arg.EntregaID,
+	)
+	var i WebhookEntrega
+	err := row.Scan(
+		&i.EntregaID,
+		&i.WebhookID,
+		&i.Evento,
+		&i.Payload,
+		&i.Estado,
+		&i.Intentos,
+		&i.UltimoCodigo,
+		&i.UltimoError,
+		&i.FechaCreacion,
+		&i.FechaUltimoIntento,
+		&i.ProximoIntento,
+	)
+	return i, err
+}
+
+const upsertCalendarioToken = `-- name: UpsertCalendarioToken :one
+INSERT INTO CALENDARIO_TOKENS (tipo_usuario, usuario_id, token)
+VALUES ($1, $2, $3)
+ON CONFLICT (tipo_usuario, usuario_id) DO UPDATE SET token = EXCLUDED.token, fecha_creacion = CURRENT_TIMESTAMP
+RETURNING tipo_usuario, usuario_id, token, fecha_creacion
+`
+
+type UpsertCalendarioTokenParams struct {
+	TipoUsuario string
+	UsuarioID   int32
+	Token       string
+}
+
+func (q *Queries) UpsertCalendarioToken(ctx context.Context, arg UpsertCalendarioTokenParams) (CalendarioToken, error) {
+	row := q.db.QueryRow(ctx, upsertCalendarioToken, arg.TipoUsuario, arg.UsuarioID, arg.Token)
+	var i CalendarioToken
+	err := row.Scan(
+		&i.TipoUsuario,
+		&i.UsuarioID,
+		&i.Token,
+		&i.FechaCreacion,
+	)
+	return i, err
+}
+
+const upsertEstudianteByCorreo = `-- name: UpsertEstudianteByCorreo :one
+INSERT INTO ESTUDIANTES (nombre, apellido, correo, programa_academico, semestre, ti)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (correo) WHERE deleted_at IS NULL DO UPDATE SET
+    nombre = EXCLUDED.nombre,
+    apellido = EXCLUDED.apellido,
+    programa_academico = EXCLUDED.programa_academico,
+    semestre = EXCLUDED.semestre,
+    ti = EXCLUDED.ti
+RETURNING estudiante_id, (xmax = 0) AS creado
+`
+
+type UpsertEstudianteByCorreoParams struct {
+	Nombre            string
+	Apellido          string
+	Correo            string
+	ProgramaAcademico string
+	Semestre          pgtype.Int4
+	Ti                pgtype.Int4
+}
+
+type UpsertEstudianteByCorreoRow struct {
+	EstudianteID int32
+	Creado       bool
+}
+
+func (q *Queries) UpsertEstudianteByCorreo(ctx context.Context, arg UpsertEstudianteByCorreoParams) (UpsertEstudianteByCorreoRow, error) {
+	row := q.db.QueryRow(ctx, upsertEstudianteByCorreo,
+		arg.Nombre,
+		arg.Apellido,
+		arg.Correo,
+		arg.ProgramaAcademico,
+		arg.Semestre,
+		arg.Ti,
+	)
+	var i UpsertEstudianteByCorreoRow
+	err := row.Scan(&i.EstudianteID, &i.Creado)
+	return i, err
+}
+
+const upsertInscripcion = `-- name: UpsertInscripcion :one
+INSERT INTO INSCRIPCIONES (estudiante_id, materia_id, periodo)
+VALUES ($1, $2, $3)
+ON CONFLICT (estudiante_id, materia_id, periodo) DO UPDATE SET periodo = EXCLUDED.periodo
+RETURNING inscripcion_id, (xmax = 0) AS creado
+`
+
+type UpsertInscripcionParams struct {
+	EstudianteID int32
+	MateriaID    int32
+	Periodo      string
+}
+
+type UpsertInscripcionRow struct {
+	InscripcionID int32
+	Creado        bool
+}
+
+// Imports are idempotent: an existing inscripcion is returned unchanged.
+func (q *Queries) UpsertInscripcion(ctx context.Context, arg UpsertInscripcionParams) (UpsertInscripcionRow, error) {
+	row := q.db.QueryRow(ctx, upsertInscripcion, arg.EstudianteID, arg.MateriaID, arg.Periodo)
+	var i UpsertInscripcionRow
+	err := row.Scan(&i.InscripcionID, &i.Creado)
+	return i, err
+}
+
+const upsertMateriaRequisitos = `-- name: UpsertMateriaRequisitos :one
+INSERT INTO MATERIA_REQUISITOS (materia_id, semestre_minimo, programas, requiere_aprobacion)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (materia_id) DO UPDATE SET
+    semestre_minimo = EXCLUDED.semestre_minimo,
+    programas = EXCLUDED.programas,
+    requiere_aprobacion = EXCLUDED.requiere_aprobacion,
+    fecha_actualizacion = CURRENT_TIMESTAMP
+RETURNING materia_id, semestre_minimo, programas, requiere_aprobacion, fecha_actualizacion
+`
+
+type UpsertMateriaRequisitosParams struct {
+	MateriaID          int32
+	SemestreMinimo     pgtype.Int4
+	Programas          []string
+	RequiereAprobacion bool
+}
+
+func (q *Queries) UpsertMateriaRequisitos(ctx context.Context, arg UpsertMateriaRequisitosParams) (MateriaRequisito, error) {
+	row := q.db.QueryRow(ctx, upsertMateriaRequisitos, arg.MateriaID, arg.SemestreMinimo, arg.Programas, arg.RequiereAprobacion)
+	var i MateriaRequisito
+	err := row.Scan(
+		&i.MateriaID,
+		&i.SemestreMinimo,
+		&i.Programas,
+		&i.RequiereAprobacion,
+		&i.FechaActualizacion,
+	)
+	return i, err
+}
+
+const upsertTutorLimites = `-- name: UpsertTutorLimites :one
+INSERT INTO TUTOR_LIMITES (tutor_id, horas_semana, horas_mes)
+VALUES ($1, $2, $3)
+ON CONFLICT (tutor_id) DO UPDATE SET
+    horas_semana = EXCLUDED.horas_semana,
+    horas_mes = EXCLUDED.horas_mes,
+    fecha_actualizacion = CURRENT_TIMESTAMP
+RETURNING tutor_id, horas_semana, horas_mes, fecha_actualizacion
+`
+
+type UpsertTutorLimitesParams struct {
+	TutorID     int32
+	HorasSemana pgtype.Int4
+	HorasMes    pgtype.Int4
+}
+
+func (q *Queries) UpsertTutorLimites(ctx context.Context, arg UpsertTutorLimitesParams) (TutorLimite, error) {
+	row := q.db.QueryRow(ctx, upsertTutorLimites, arg.TutorID, arg.HorasSemana, arg.HorasMes)
+	var i TutorLimite
+	err := row.Scan(&i.TutorID, &i.HorasSemana, &i.HorasMes, &i.FechaActualizacion)
+	return i, err
+}
+
+const upsertTutorMateria = `-- name: UpsertTutorMateria :one
+INSERT INTO TUTOR_MATERIAS (tutor_id, materia_id, fecha_asignacion, activo)
+VALUES ($1, $2, CURRENT_DATE, true)
+ON CONFLICT (tutor_id, materia_id) DO UPDATE SET activo = true, fecha_asignacion = CURRENT_DATE
+WHERE TUTOR_MATERIAS.activo = false
+RETURNING asignacion_id, tutor_id, materia_id, fecha_asignacion, activo, (xmax = 0) AS creado
+`
+
+type UpsertTutorMateriaParams struct {
+	TutorID   int32
+	MateriaID int32
+}
+
+type UpsertTutorMateriaRow struct {
+	AsignacionID    int32
+	TutorID         int32
+	MateriaID       int32
+	FechaAsignacion pgtype.Date
+	Activo          bool
+	Creado          bool
+}
+
+// Assigns a materia to a tutor, reactivating an inactive assignment. Returns no rows when
+// the assignment is already active.
+func (q *Queries) UpsertTutorMateria(ctx context.Context, arg UpsertTutorMateriaParams) (UpsertTutorMateriaRow, error) {
+	row := q.db.QueryRow(ctx, upsertTutorMateria, arg.TutorID, arg.MateriaID)
+	var i UpsertTutorMateriaRow
+	err := row.Scan(
+		&i.AsignacionID,
+		&i.TutorID,
+		&i.MateriaID,
+		&i.FechaAsignacion,
+		&i.Activo,
+		&i.Creado,
+	)
+	return i, err
+}
+
+const upsertTutoriaInvitacion = `-- name: UpsertTutoriaInvitacion :one
+INSERT INTO TUTORIA_INVITACIONES (tutoria_id, metodo)
+VALUES ($1, $2)
+ON CONFLICT (tutoria_id) DO UPDATE SET
+    secuencia = TUTORIA_INVITACIONES.secuencia + 1,
+    metodo = EXCLUDED.metodo,
+    fecha_envio = CURRENT_TIMESTAMP
+RETURNING tutoria_id, secuencia, metodo, fecha_envio
+`
+
+type UpsertTutoriaInvitacionParams struct {
+	TutoriaID int32
+	Metodo    string
+}
+
+func (q *Queries) UpsertTutoriaInvitacion(ctx context.Context, arg UpsertTutoriaInvitacionParams) (TutoriaInvitacione, error) {
+	row := q.db.QueryRow(ctx, upsertTutoriaInvitacion, arg.TutoriaID, arg.Metodo)
+	var i TutoriaInvitacione
+	err := row.Scan(
+		&i.TutoriaID,
+		&i.Secuencia,
+		&i.Metodo,
+		&i.FechaEnvio,
+	)
+	return i, err
+}
@@ -16,87 +16,220 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/disponibilidad": {
+        "/v1/admin/auditoria": {
             "get": {
-                "description": "Retrieves disponibilidad slots for a specific day of the week.",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves the recorded POST, PUT, PATCH and DELETE calls, most recent first. Each entry has the admin\nthat made the call (or anonimo without an admin token), the affected record and, for successful calls,\nthe previous and new value of the fields that changed. The audit trail cannot be modified.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Disponibilidad"
+                    "Auditoria"
                 ],
-                "summary": "List Disponibilidad by Day",
+                "summary": "List Audit Trail",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only calls on this entity, e.g. estudiantes or materias",
+                        "name": "entidad",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Day of week (1=Monday, 7=Sunday)",
-                        "name": "dia_semana",
-                        "in": "query",
-                        "required": true
+                        "description": "Only calls on this record of the entity",
+                        "name": "entidad_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only calls made by this admin",
+                        "name": "admin_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "POST",
+                            "PUT",
+                            "PATCH",
+                            "DELETE"
+                        ],
+                        "type": "string",
+                        "description": "Only calls with this method",
+                        "name": "metodo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only calls made on or after this date (YYYY-MM-DD)",
+                        "name": "desde",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only calls made on or before this date (YYYY-MM-DD)",
+                        "name": "hasta",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of entries",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved disponibilidad",
+                        "description": "Audit trail entries",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/db.ListDisponibilidadByDiaRow"
+                                "$ref": "#/definitions/handler.AuditoriaEventoResponse"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid day",
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve disponibilidad",
+                        "description": "Failed to retrieve audit trail",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Creates a new tutor availability slot.",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/v1/admin/papelera": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
                 ],
+                "description": "Lists the deleted estudiantes, tutores and materias, most recently deleted first, with the time from\nwhich a purge may delete each one permanently. They can be restored until they are purged.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Disponibilidad"
+                    "Papelera"
                 ],
-                "summary": "Create Disponibilidad",
+                "summary": "List Papelera",
                 "parameters": [
                     {
-                        "description": "Disponibilidad Data",
-                        "name": "disponibilidad",
-                        "in": "body",
-                        "required": true,
+                        "enum": [
+                            "estudiante",
+                            "tutor",
+                            "materia"
+                        ],
+                        "type": "string",
+                        "description": "Only records of this type",
+                        "name": "tipo",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted records",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateDisponibilidadRequest"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.PapeleraItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tipo",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve papelera",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
+                }
+            }
+        },
+        "/v1/admin/papelera/purgar": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Permanently deletes the estudiantes, tutores and materias that have been in the papelera longer than\nthe retention period (PAPELERA_RETENCION_DIAS, 30 days by default). Records with tutorias are never\npurged, so the tutoria history is kept; they stay in the papelera. Neither are materias with failed\ntutoria requests, which the demanda insatisfecha reporte counts. Nothing is purged automatically.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Papelera"
                 ],
+                "summary": "Purge Papelera",
                 "responses": {
-                    "201": {
-                        "description": "Successfully created disponibilidad",
+                    "200": {
+                        "description": "Purged records",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateDisponibilidadResponse"
+                            "$ref": "#/definitions/handler.PurgePapeleraResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "401": {
+                        "description": "Admin authentication required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to create disponibilidad",
+                        "description": "Failed to purge papelera",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -104,136 +237,226 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/disponibilidad/{id}": {
+        "/v1/admin/solicitudes-tutor": {
             "get": {
-                "description": "Retrieves a specific disponibilidad by its ID.",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves the solicitudes of students to become tutors, most recent first, with the student's data.\nUse estado=pendiente for the review queue.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Disponibilidad"
+                    "Tutores"
                 ],
-                "summary": "Get Disponibilidad by ID",
+                "summary": "List Solicitudes to Become a Tutor",
                 "parameters": [
+                    {
+                        "enum": [
+                            "pendiente",
+                            "aprobada",
+                            "rechazada"
+                        ],
+                        "type": "string",
+                        "description": "Only solicitudes in this estado",
+                        "name": "estado",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Disponibilidad ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only solicitudes of this estudiante",
+                        "name": "estudiante_id",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of solicitudes",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved disponibilidad",
+                        "description": "Solicitudes",
                         "schema": {
-                            "$ref": "#/definitions/db.Disponibilidad"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.ListSolicitudesTutorRow"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid disponibilidad ID",
+                        "description": "Invalid filter",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Disponibilidad not found",
+                    "401": {
+                        "description": "Admin authentication required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve disponibilidad",
+                        "description": "Failed to retrieve solicitudes",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing disponibilidad slot.",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/v1/admin/solicitudes-tutor/{id}/aprobar": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
                 ],
+                "description": "Approves a pending solicitud: creates the tutor from the student's data, like POST /v1/tutores, and\nassigns the requested materias whose eligibility rules the student meets. The rest are reported in\nmaterias_omitidas. The student is notified by email.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Disponibilidad"
+                    "Tutores"
                 ],
-                "summary": "Update Disponibilidad",
+                "summary": "Approve Solicitud to Become a Tutor",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Disponibilidad ID",
+                        "description": "Solicitud ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated Disponibilidad Data",
-                        "name": "disponibilidad",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UpdateDisponibilidadRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated disponibilidad",
+                        "description": "Approved solicitud",
                         "schema": {
-                            "$ref": "#/definitions/db.Disponibilidad"
+                            "$ref": "#/definitions/handler.ApproveSolicitudTutorResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or disponibilidad ID",
+                        "description": "Invalid solicitud ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Disponibilidad not found",
+                        "description": "Solicitud not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Solicitud was already reviewed",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update disponibilidad",
+                        "description": "Failed to approve solicitud",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a disponibilidad slot by its ID.",
+            }
+        },
+        "/v1/admin/solicitudes-tutor/{id}/rechazar": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Rejects a pending solicitud with a reason. The student is notified by email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Disponibilidad"
+                    "Tutores"
                 ],
-                "summary": "Delete Disponibilidad",
+                "summary": "Reject Solicitud to Become a Tutor",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Disponibilidad ID",
+                        "description": "Solicitud ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Rejection reason",
+                        "name": "rechazo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RejectSolicitudTutorRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Successfully deleted disponibilidad"
+                    "200": {
+                        "description": "Rejected solicitud",
+                        "schema": {
+                            "$ref": "#/definitions/db.SolicitudesTutor"
+                        }
                     },
                     "400": {
-                        "description": "Invalid disponibilidad ID",
+                        "description": "Invalid solicitud ID or missing motivo",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Solicitud not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Solicitud was already reviewed",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete disponibilidad",
+                        "description": "Failed to reject solicitud",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -241,45 +464,73 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/estudiantes": {
+        "/v1/admins": {
             "get": {
-                "description": "Retrieves students filtered by academic program.",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves every admin account, ordered by apellido and nombre. Only superadmins can manage admins.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Estudiantes"
+                    "Admins"
                 ],
-                "summary": "List Estudiantes by Program",
+                "summary": "List Admins",
                 "parameters": [
                     {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
                         "type": "string",
-                        "description": "Academic program",
-                        "name": "programa",
-                        "in": "query",
-                        "required": true
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved estudiantes",
+                        "description": "Admins",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/db.Estudiante"
+                                "$ref": "#/definitions/handler.AdminResponse"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Failed to retrieve estudiantes",
+                    "401": {
+                        "description": "Admin authentication required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            },
-            "post": {
-                "description": "Creates a new student record.",
+                    },
+                    "403": {
+                        "description": "Superadmin rol required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve admins",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Creates an active admin account. The password is hashed with bcrypt and never returned.",
                 "consumes": [
                     "application/json"
                 ],
@@ -287,25 +538,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Estudiantes"
+                    "Admins"
                 ],
-                "summary": "Create Estudiante",
+                "summary": "Create Admin",
                 "parameters": [
                     {
-                        "description": "Estudiante Data",
-                        "name": "estudiante",
+                        "description": "Admin data",
+                        "name": "admin",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateEstudianteRequest"
+                            "$ref": "#/definitions/handler.CreateAdminRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Successfully created student",
+                        "description": "Created admin",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateEstudianteResponse"
+                            "$ref": "#/definitions/handler.AdminResponse"
                         }
                     },
                     "400": {
@@ -314,8 +565,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Superadmin rol required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another admin already uses the correo",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to create student",
+                        "description": "Failed to create admin",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -323,20 +592,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/estudiantes/{id}": {
+        "/v1/admins/{id}": {
             "get": {
-                "description": "Retrieves a specific student by their ID.",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves an admin account.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Estudiantes"
+                    "Admins"
                 ],
-                "summary": "Get Estudiante by ID",
+                "summary": "Get Admin",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Estudiante ID",
+                        "description": "Admin ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -344,25 +618,37 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved estudiante",
+                        "description": "Admin",
                         "schema": {
-                            "$ref": "#/definitions/db.Estudiante"
+                            "$ref": "#/definitions/handler.AdminResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid estudiante ID",
+                        "description": "Invalid admin ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Superadmin rol required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Estudiante not found",
+                        "description": "Admin not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve estudiante",
+                        "description": "Failed to get admin",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -370,7 +656,12 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates an existing student.",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Updates an admin account. Setting activo to false deactivates it: its tokens stop working and it can no\nlonger log in. A password resets the current one. The last active superadmin cannot be demoted or\ndeactivated.",
                 "consumes": [
                     "application/json"
                 ],
@@ -378,48 +669,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Estudiantes"
+                    "Admins"
                 ],
-                "summary": "Update Estudiante",
+                "summary": "Update Admin",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Estudiante ID",
+                        "description": "Admin ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated Estudiante Data",
-                        "name": "estudiante",
+                        "description": "Admin data",
+                        "name": "admin",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateEstudianteRequest"
+                            "$ref": "#/definitions/handler.UpdateAdminRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated estudiante",
+                        "description": "Updated admin",
                         "schema": {
-                            "$ref": "#/definitions/db.Estudiante"
+                            "$ref": "#/definitions/handler.AdminResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or estudiante ID",
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Superadmin rol required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Estudiante not found",
+                        "description": "Admin not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Correo in use, or the admin is the last active superadmin",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update estudiante",
+                        "description": "Failed to update admin",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -427,15 +736,20 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a student by their ID.",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Deletes an admin account permanently. Reports and webhooks it created are kept. The last active\nsuperadmin and admins that still own reporte programaciones cannot be deleted; deactivate them instead.",
                 "tags": [
-                    "Estudiantes"
+                    "Admins"
                 ],
-                "summary": "Delete Estudiante",
+                "summary": "Delete Admin",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Estudiante ID",
+                        "description": "Admin ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -443,16 +757,40 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "Successfully deleted estudiante"
+                        "description": "Admin deleted"
                     },
                     "400": {
-                        "description": "Invalid estudiante ID",
+                        "description": "Invalid admin ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Superadmin rol required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Admin not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Admin is the last active superadmin or owns reporte programaciones",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete estudiante",
+                        "description": "Failed to delete admin",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -460,57 +798,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/login": {
-            "post": {
-                "description": "Authenticates a student using their email and TI (Tarjeta de Identidad).",
-                "consumes": [
-                    "application/json"
+        "/v1/admins/{id}/password": {
+            "put": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
                 ],
-                "produces": [
+                "description": "Changes the password of the authenticated admin, who must provide the current one. Superadmins reset\nthe password of other admins through PUT /v1/admins/{id}.",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admins"
                 ],
-                "summary": "Student Login (Legacy)",
+                "summary": "Change Admin Password",
                 "parameters": [
                     {
-                        "description": "Login Credentials",
-                        "name": "login",
+                        "type": "integer",
+                        "description": "Admin ID, which must be the authenticated admin",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Current and new password",
+                        "name": "password",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.StudentLoginRequest"
+                            "$ref": "#/definitions/handler.UpdateAdminPasswordRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully authenticated. Returns student details.",
-                        "schema": {
-                            "$ref": "#/definitions/db.Estudiante"
-                        }
+                    "204": {
+                        "description": "Password changed"
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body or wrong current password",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials (user not found or TI mismatch)",
+                        "description": "Admin authentication required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "405": {
-                        "description": "Method not allowed",
+                    "403": {
+                        "description": "Admins can only change their own password",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error during login",
+                        "description": "Failed to change password",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -518,69 +862,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/login/{mode}": {
-            "post": {
-                "description": "Authenticates users (estudiantes, tutores, or admins) based on the mode parameter.",
-                "consumes": [
-                    "application/json"
+        "/v1/analytics/anticipacion": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
                 ],
+                "description": "Median, quartiles and average of the hours between the request of a tutoria (fecha_solicitud) and its start,\nfor the tutorias dated within the period.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Analytics"
                 ],
-                "summary": "Unified Login",
+                "summary": "Booking Lead Time",
                 "parameters": [
                     {
-                        "enum": [
-                            "estudiante",
-                            "tutor",
-                            "admin"
-                        ],
                         "type": "string",
-                        "description": "Login mode",
-                        "name": "mode",
-                        "in": "path",
-                        "required": true
+                        "description": "Start of the period (YYYY-MM-DD); defaults to 29 days before hasta",
+                        "name": "desde",
+                        "in": "query"
                     },
                     {
-                        "description": "Login Credentials",
-                        "name": "login",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UnifiedLoginRequest"
-                        }
+                        "type": "string",
+                        "description": "End of the period (YYYY-MM-DD); defaults to today",
+                        "name": "hasta",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only tutorias of this materia",
+                        "name": "materia_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully authenticated",
+                        "description": "Lead time statistics, in hours",
                         "schema": {
-                            "$ref": "#/definitions/handler.LoginResponse"
+                            "$ref": "#/definitions/db.AnalyticsAnticipacionRow"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or mode",
+                        "description": "Invalid period or materia ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
-                    "405": {
-                        "description": "Method not allowed",
+                        "description": "Admin authentication required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to compute anticipacion",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -588,72 +925,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/materias": {
+        "/v1/analytics/demanda-oferta": {
             "get": {
-                "description": "Retrieves a list of all materia names.",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Compares, for every active materia, the tutorias requested within the period and their hours with the\nnumber of active tutors qualified to teach it and the hours they are available on the days of the period,\naccording to DISPONIBILIDAD. A tutor's availability counts for every materia they teach.\nMaterias whose demand most exceeds their supply come first.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Materias"
+                    "Analytics"
+                ],
+                "summary": "Demand versus Supply per Materia",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the period (YYYY-MM-DD); defaults to 29 days before hasta",
+                        "name": "desde",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period (YYYY-MM-DD); defaults to today",
+                        "name": "hasta",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List Materia Names",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved materia names",
+                        "description": "Demand and supply per materia",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "string"
+                                "$ref": "#/definitions/db.AnalyticsDemandaOfertaRow"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Failed to retrieve materia names",
+                    "400": {
+                        "description": "Invalid period",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            },
-            "post": {
-                "description": "Creates a new materia (subject).",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Materias"
-                ],
-                "summary": "Create Materia",
-                "parameters": [
-                    {
-                        "description": "Materia Data",
-                        "name": "materia",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateMateriaRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Successfully created materia",
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateMateriaResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "401": {
+                        "description": "Admin authentication required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to create materia",
+                        "description": "Failed to compute demanda-oferta",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -661,40 +998,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/materias/codigo/{codigo}": {
+        "/v1/analytics/horas-pico": {
             "get": {
-                "description": "Retrieves a specific materia by its code.",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Counts the non-cancelled tutorias dated within the period per day of the week (1 = Monday, 7 = Sunday)\nand starting hour (0-23). Every one of the 7 x 24 cells is returned, in day then hour order.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Materias"
+                    "Analytics"
                 ],
-                "summary": "Get Materia by Code",
+                "summary": "Peak Hours Heatmap",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Materia Code",
-                        "name": "codigo",
-                        "in": "path",
-                        "required": true
+                        "description": "Start of the period (YYYY-MM-DD); defaults to 29 days before hasta",
+                        "name": "desde",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period (YYYY-MM-DD); defaults to today",
+                        "name": "hasta",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved materia",
+                        "description": "Tutorias per day of the week and hour",
                         "schema": {
-                            "$ref": "#/definitions/db.Materia"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.AnalyticsHorasPicoRow"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Materia not found",
+                    "400": {
+                        "description": "Invalid period",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve materia",
+                        "description": "Failed to compute horas-pico",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -702,54 +1071,217 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/materias/{id}": {
+        "/v1/analytics/latencia-confirmacion": {
             "get": {
-                "description": "Retrieves a specific materia by its ID.",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Median, quartiles and average of the hours between the request of a tutoria and its confirmation by the tutor,\nfor the confirmed tutorias dated within the period.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Materias"
+                    "Analytics"
                 ],
-                "summary": "Get Materia by ID",
+                "summary": "Confirmation Latency",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the period (YYYY-MM-DD); defaults to 29 days before hasta",
+                        "name": "desde",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period (YYYY-MM-DD); defaults to today",
+                        "name": "hasta",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Materia ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only tutorias of this materia",
+                        "name": "materia_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved materia",
+                        "description": "Confirmation latency statistics, in hours",
                         "schema": {
-                            "$ref": "#/definitions/db.Materia"
+                            "$ref": "#/definitions/db.AnalyticsLatenciaConfirmacionRow"
                         }
                     },
                     "400": {
-                        "description": "Invalid materia ID",
+                        "description": "Invalid period or materia ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Materia not found",
+                    "401": {
+                        "description": "Admin authentication required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve materia",
+                        "description": "Failed to compute latencia-confirmacion",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing materia.",
+            }
+        },
+        "/v1/analytics/sesiones": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Counts the tutorias dated within the period per day or per week (starting on Monday), by estado.\nPeriods without tutorias are included with zero counts.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "Tutorias per Day or Week",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the period (YYYY-MM-DD); defaults to 29 days before hasta",
+                        "name": "desde",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period (YYYY-MM-DD); defaults to today",
+                        "name": "hasta",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "dia",
+                            "semana"
+                        ],
+                        "type": "string",
+                        "default": "dia",
+                        "description": "Length of each period",
+                        "name": "agrupacion",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tutorias per period",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.AnalyticsSesionesRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period or agrupacion",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to compute sesiones",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/buscar": {
+            "get": {
+                "description": "Searches active materias (nombre, codigo, descripcion, facultad) and tutors (nombre, apellido, materias taught).\nMatching ignores case and accents and tolerates typos; results are ranked by similarity.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Busqueda"
+                ],
+                "summary": "Search Materias and Tutores",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "calculo",
+                        "description": "Search text, at least 2 characters",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "materias",
+                            "tutores"
+                        ],
+                        "type": "string",
+                        "description": "Only search one kind of result",
+                        "name": "tipo",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 50,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of results of each kind",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ranked results",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BuscarResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid search parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to search",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/calendario/token/{mode}": {
+            "post": {
+                "description": "Returns the secret calendar feed token of an estudiante or tutor, creating it on first use. Requires the same correo and TI as the login; set rotar to invalidate the previous feed URL.",
                 "consumes": [
                     "application/json"
                 ],
@@ -757,81 +1289,99 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Materias"
+                    "Calendario"
                 ],
-                "summary": "Update Materia",
+                "summary": "Get Calendar Feed Token",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Materia ID",
-                        "name": "id",
+                        "enum": [
+                            "estudiante",
+                            "tutor"
+                        ],
+                        "type": "string",
+                        "description": "User type",
+                        "name": "mode",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated Materia Data",
-                        "name": "materia",
+                        "description": "Login credentials",
+                        "name": "credenciales",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateMateriaRequest"
+                            "$ref": "#/definitions/handler.CalendarioTokenRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated materia",
+                        "description": "Feed token and subscription URL",
                         "schema": {
-                            "$ref": "#/definitions/db.Materia"
+                            "$ref": "#/definitions/handler.CalendarioTokenResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or materia ID",
+                        "description": "Invalid request body or mode",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Materia not found",
+                    "401": {
+                        "description": "Invalid credentials",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update materia",
+                        "description": "Failed to issue feed token",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a materia by its ID.",
+            }
+        },
+        "/v1/certificados/{codigo}": {
+            "get": {
+                "description": "Public verification of an hours certificate by the code printed on it. valido is false when the stored\ncontent no longer matches its signature.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Materias"
+                    "Tutores"
                 ],
-                "summary": "Delete Materia",
+                "summary": "Verify Hours Certificate",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Materia ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Verification code",
+                        "name": "codigo",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Successfully deleted materia"
+                    "200": {
+                        "description": "Certificate details",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CertificadoVerificacionResponse"
+                        }
                     },
-                    "400": {
-                        "description": "Invalid materia ID",
+                    "404": {
+                        "description": "Certificate not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete materia",
+                        "description": "Failed to verify certificate",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Certificate signing is not configured",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -839,50 +1389,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/reportes": {
+        "/v1/disponibilidad": {
             "get": {
-                "description": "Retrieves reports filtered by date period.",
+                "description": "Retrieves disponibilidad slots for a specific day of the week.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reportes"
+                    "Disponibilidad"
                 ],
-                "summary": "List Reportes by Period",
+                "summary": "List Disponibilidad by Day",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "periodo_inicio",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "periodo_fin",
+                        "type": "integer",
+                        "description": "Day of week (1=Monday, 7=Sunday)",
+                        "name": "dia_semana",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved reportes",
+                        "description": "Successfully retrieved disponibilidad",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/db.Reporte"
+                                "$ref": "#/definitions/db.ListDisponibilidadByDiaRow"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid date format",
+                        "description": "Invalid day",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve reportes",
+                        "description": "Failed to retrieve disponibilidad",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -890,7 +1433,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new report.",
+                "description": "Creates a new tutor availability slot.",
                 "consumes": [
                     "application/json"
                 ],
@@ -898,25 +1441,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reportes"
+                    "Disponibilidad"
                 ],
-                "summary": "Create Reporte",
+                "summary": "Create Disponibilidad",
                 "parameters": [
                     {
-                        "description": "Reporte Data",
-                        "name": "reporte",
+                        "description": "Disponibilidad Data",
+                        "name": "disponibilidad",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateReporteRequest"
+                            "$ref": "#/definitions/handler.CreateDisponibilidadRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Successfully created reporte",
+                        "description": "Successfully created disponibilidad",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateReporteResponse"
+                            "$ref": "#/definitions/handler.CreateDisponibilidadResponse"
                         }
                     },
                     "400": {
@@ -926,7 +1469,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to create reporte",
+                        "description": "Failed to create disponibilidad",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -934,20 +1477,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/reportes/{id}": {
+        "/v1/disponibilidad/{id}": {
             "get": {
-                "description": "Retrieves a specific report by its ID.",
+                "description": "Retrieves a specific disponibilidad by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reportes"
+                    "Disponibilidad"
                 ],
-                "summary": "Get Reporte by ID",
+                "summary": "Get Disponibilidad by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Reporte ID",
+                        "description": "Disponibilidad ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -955,25 +1498,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved reporte",
+                        "description": "Successfully retrieved disponibilidad",
                         "schema": {
-                            "$ref": "#/definitions/db.Reporte"
+                            "$ref": "#/definitions/db.Disponibilidad"
                         }
                     },
                     "400": {
-                        "description": "Invalid reporte ID",
+                        "description": "Invalid disponibilidad ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Reporte not found",
+                        "description": "Disponibilidad not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve reporte",
+                        "description": "Failed to retrieve disponibilidad",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -981,7 +1524,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates the data of an existing report.",
+                "description": "Updates an existing disponibilidad slot.",
                 "consumes": [
                     "application/json"
                 ],
@@ -989,48 +1532,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reportes"
+                    "Disponibilidad"
                 ],
-                "summary": "Update Reporte",
+                "summary": "Update Disponibilidad",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Reporte ID",
+                        "description": "Disponibilidad ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated Reporte Data",
-                        "name": "reporte",
+                        "description": "Updated Disponibilidad Data",
+                        "name": "disponibilidad",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateReporteRequest"
+                            "$ref": "#/definitions/handler.UpdateDisponibilidadRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated reporte",
+                        "description": "Successfully updated disponibilidad",
                         "schema": {
-                            "$ref": "#/definitions/db.Reporte"
+                            "$ref": "#/definitions/db.Disponibilidad"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or reporte ID",
+                        "description": "Invalid request body or disponibilidad ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Reporte not found",
+                        "description": "Disponibilidad not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update reporte",
+                        "description": "Failed to update disponibilidad",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1038,15 +1581,15 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a report by its ID.",
+                "description": "Deletes a disponibilidad slot by its ID.",
                 "tags": [
-                    "Reportes"
+                    "Disponibilidad"
                 ],
-                "summary": "Delete Reporte",
+                "summary": "Delete Disponibilidad",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Reporte ID",
+                        "description": "Disponibilidad ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1054,16 +1597,16 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "Successfully deleted reporte"
+                        "description": "Successfully deleted disponibilidad"
                     },
                     "400": {
-                        "description": "Invalid reporte ID",
+                        "description": "Invalid disponibilidad ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete reporte",
+                        "description": "Failed to delete disponibilidad",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1071,43 +1614,107 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/tutor-materias": {
+        "/v1/estudiantes": {
             "get": {
-                "description": "Retrieves tutores assigned to a specific materia.",
+                "description": "Retrieves a list of all students.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "TutorMaterias"
+                    "Estudiantes"
                 ],
-                "summary": "List Tutores by Materia",
+                "summary": "List All Estudiantes",
                 "parameters": [
                     {
+                        "type": "string",
+                        "description": "Only estudiantes of this academic program; the result is not paginated",
+                        "name": "programa",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
                         "type": "integer",
-                        "description": "Materia ID",
-                        "name": "materia_id",
-                        "in": "query",
-                        "required": true
+                        "default": 50,
+                        "description": "Maximum number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, as returned in X-Next-Cursor",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "apellido",
+                            "nombre",
+                            "correo",
+                            "programa_academico",
+                            "semestre",
+                            "fecha_registro",
+                            "id"
+                        ],
+                        "type": "string",
+                        "default": "apellido",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved tutores",
+                        "description": "Successfully retrieved estudiantes",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/db.ListTutoresByMateriaRow"
+                                "$ref": "#/definitions/db.Estudiante"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page; absent on the last page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total number of estudiantes"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid materia ID",
+                        "description": "Invalid pagination parameters",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve tutores",
+                        "description": "Failed to retrieve estudiantes",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1115,7 +1722,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new tutor-materia assignment.",
+                "description": "Creates a new student record.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1123,25 +1730,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TutorMaterias"
+                    "Estudiantes"
                 ],
-                "summary": "Create TutorMateria Assignment",
+                "summary": "Create Estudiante",
                 "parameters": [
                     {
-                        "description": "TutorMateria Assignment Data",
-                        "name": "assignment",
+                        "description": "Estudiante Data",
+                        "name": "estudiante",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateTutorMateriaRequest"
+                            "$ref": "#/definitions/handler.CreateEstudianteRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Successfully created assignment",
+                        "description": "Successfully created student",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateTutorMateriaResponse"
+                            "$ref": "#/definitions/handler.CreateEstudianteResponse"
                         }
                     },
                     "400": {
@@ -1151,7 +1758,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to create assignment",
+                        "description": "Failed to create student",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1159,20 +1766,81 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/tutor-materias/{id}": {
+        "/v1/estudiantes/import": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Bulk-loads students from a registrar CSV (multipart field \"archivo\" or raw text/csv body) with the columns nombre, apellido, correo, programa_academico, semestre and ti. Every row is validated; valid rows are upserted by correo in a single transaction, and invalid rows are reported with their reasons. With dry_run=true nothing is saved.",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Estudiantes"
+                ],
+                "summary": "Import Estudiantes from CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "archivo",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate and report, without saving",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-row import report",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ImportEstudiantesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid CSV file",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to import estudiantes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/estudiantes/{id}": {
             "get": {
-                "description": "Retrieves a specific tutor-materia assignment by its ID.",
+                "description": "Retrieves a specific student by their ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "TutorMaterias"
+                    "Estudiantes"
                 ],
-                "summary": "Get TutorMateria by ID",
+                "summary": "Get Estudiante by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Assignment ID",
+                        "description": "Estudiante ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1180,25 +1848,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved assignment",
+                        "description": "Successfully retrieved estudiante",
                         "schema": {
-                            "$ref": "#/definitions/db.TutorMateria"
+                            "$ref": "#/definitions/db.Estudiante"
                         }
                     },
                     "400": {
-                        "description": "Invalid assignment ID",
+                        "description": "Invalid estudiante ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Assignment not found",
+                        "description": "Estudiante not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve assignment",
+                        "description": "Failed to retrieve estudiante",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1206,7 +1874,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates an existing tutor-materia assignment.",
+                "description": "Updates an existing student.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1214,48 +1882,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "TutorMaterias"
+                    "Estudiantes"
                 ],
-                "summary": "Update TutorMateria Assignment",
+                "summary": "Update Estudiante",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Assignment ID",
+                        "description": "Estudiante ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated Assignment Data",
-                        "name": "assignment",
+                        "description": "Updated Estudiante Data",
+                        "name": "estudiante",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateTutorMateriaRequest"
+                            "$ref": "#/definitions/handler.UpdateEstudianteRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated assignment",
+                        "description": "Successfully updated estudiante",
                         "schema": {
-                            "$ref": "#/definitions/db.TutorMateria"
+                            "$ref": "#/definitions/db.Estudiante"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or assignment ID",
+                        "description": "Invalid request body or estudiante ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Assignment not found",
+                        "description": "Estudiante not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update assignment",
+                        "description": "Failed to update estudiante",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1263,15 +1931,15 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a tutor-materia assignment by its ID.",
+                "description": "Moves a student to the papelera, keeping their tutorias and inscripciones. The student is no longer\nlisted and can be restored by an admin until the retention period ends, when they are deleted\npermanently.",
                 "tags": [
-                    "TutorMaterias"
+                    "Estudiantes"
                 ],
-                "summary": "Delete TutorMateria Assignment",
+                "summary": "Delete Estudiante",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Assignment ID",
+                        "description": "Estudiante ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1279,95 +1947,76 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "Successfully deleted assignment"
+                        "description": "Successfully deleted estudiante"
                     },
                     "400": {
-                        "description": "Invalid assignment ID",
+                        "description": "Invalid estudiante ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to delete assignment",
+                    "404": {
+                        "description": "Estudiante not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/v1/tutores": {
-            "get": {
-                "description": "Retrieves a list of all tutors.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Tutores"
-                ],
-                "summary": "List All Tutores",
-                "responses": {
-                    "200": {
-                        "description": "Successfully retrieved tutores",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.Tutore"
-                            }
-                        }
                     },
                     "500": {
-                        "description": "Failed to retrieve tutores",
+                        "description": "Failed to delete estudiante",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Creates a new tutor record using data from an existing student.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/v1/estudiantes/{id}/calendario.ics": {
+            "get": {
+                "description": "iCalendar feed with every tutoria of a student. Cancelled sessions are included with STATUS:CANCELLED.",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "Tutores"
+                    "Calendario"
                 ],
-                "summary": "Create Tutor from Estudiante",
+                "summary": "Estudiante Calendar Feed",
                 "parameters": [
                     {
-                        "description": "Estudiante ID to become Tutor",
-                        "name": "tutor",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateTutorRequest"
-                        }
+                        "type": "integer",
+                        "description": "Estudiante ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feed token from /v1/calendario/token/estudiante",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully created tutor",
+                    "200": {
+                        "description": "iCalendar document",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateTutorResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or Estudiante ID",
+                        "description": "Invalid estudiante ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Estudiante not found",
+                    "403": {
+                        "description": "Invalid feed token",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to create tutor",
+                        "description": "Failed to build calendar",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1375,25 +2024,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/tutores/count-with-materias": {
+        "/v1/estudiantes/{id}/materias": {
             "get": {
-                "description": "Returns the count of tutors that have at least one materia assigned.",
+                "description": "Retrieves the active materias a student is enrolled in for an academic period, which are the ones\nthey can book tutorias for.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tutores"
+                    "Inscripciones"
                 ],
-                "summary": "Count Tutors with Materias",
-                "responses": {
-                    "200": {
-                        "description": "Successfully retrieved count",
-                        "schema": {
-                            "$ref": "#/definitions/handler.CountTutorsWithMateriasResponse"
-                        }
-                    },
+                "summary": "List Materias of Estudiante",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Estudiante ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Academic period (YYYY-1 or YYYY-2); defaults to the current one",
+                        "name": "periodo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Enrolled materias",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Materia"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid estudiante ID or periodo",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to count tutors",
+                        "description": "Failed to retrieve materias",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1401,58 +2074,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/tutores/login": {
+        "/v1/estudiantes/{id}/restaurar": {
             "post": {
-                "description": "Authenticates a tutor using their email and TI (Tarjeta de Identidad), then fetches tutor-specific data.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
                 ],
+                "description": "Takes a deleted student out of the papelera.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Papelera"
                 ],
-                "summary": "Tutor Login (Legacy)",
+                "summary": "Restore Estudiante",
                 "parameters": [
                     {
-                        "description": "Login Credentials for Tutor (as Student)",
-                        "name": "login",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.StudentLoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "Estudiante ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully authenticated. Returns combined student and tutor details.",
+                        "description": "Restored estudiante",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/db.Estudiante"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid estudiante ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials or tutor record not found",
+                        "description": "Admin authentication required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "405": {
-                        "description": "Method not allowed",
+                    "404": {
+                        "description": "Estudiante not found in papelera",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another estudiante now uses the same correo",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to restore estudiante",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1460,54 +2138,100 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/tutores/{id}": {
+        "/v1/inscripciones": {
             "get": {
-                "description": "Retrieves a specific tutor by their ID.",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves enrollments, most recent period first, optionally filtered by estudiante, materia and period.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Tutores"
+                    "Inscripciones"
                 ],
-                "summary": "Get Tutor by ID",
+                "summary": "List Inscripciones",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tutor ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only inscripciones of this estudiante",
+                        "name": "estudiante_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only inscripciones in this materia",
+                        "name": "materia_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only inscripciones in this academic period (YYYY-1 or YYYY-2)",
+                        "name": "periodo",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of inscripciones",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved tutor",
+                        "description": "Inscripciones",
                         "schema": {
-                            "$ref": "#/definitions/db.Tutore"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.ListInscripcionesRow"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid tutor ID",
+                        "description": "Invalid filter",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Tutor not found",
+                    "401": {
+                        "description": "Admin authentication required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve tutor",
+                        "description": "Failed to retrieve inscripciones",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Updates an existing tutor.",
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Enrolls a student in a materia for an academic period (YYYY-1 or YYYY-2, the current one by default).\nStudents can only book tutorias of the materias they are enrolled in.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1515,81 +2239,108 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tutores"
+                    "Inscripciones"
                 ],
-                "summary": "Update Tutor",
+                "summary": "Enroll Estudiante in Materia",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Tutor ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated Tutor Data",
-                        "name": "tutor",
+                        "description": "Inscripcion Data",
+                        "name": "inscripcion",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateTutorRequest"
+                            "$ref": "#/definitions/handler.CreateInscripcionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully updated tutor",
+                    "201": {
+                        "description": "Successfully created inscripcion",
                         "schema": {
-                            "$ref": "#/definitions/db.Tutore"
+                            "$ref": "#/definitions/db.Inscripcione"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or tutor ID",
+                        "description": "Invalid request body, estudiante, materia or periodo",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Tutor not found",
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The student is already enrolled",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update tutor",
+                        "description": "Failed to create inscripcion",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a tutor by their ID.",
+            }
+        },
+        "/v1/inscripciones/import": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Bulk-loads enrollments from a registrar CSV (multipart field \"archivo\" or raw text/csv body) with the columns correo (of the estudiante), codigo (of the materia) and periodo (YYYY-1 or YYYY-2). Every row is validated; valid rows are saved in a single transaction, enrollments that already exist are left unchanged, and invalid rows are reported with their reasons. With dry_run=true nothing is saved.",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Tutores"
+                    "Inscripciones"
                 ],
-                "summary": "Delete Tutor",
+                "summary": "Import Inscripciones from CSV",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Tutor ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "archivo",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate and report, without saving",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Successfully deleted tutor"
+                    "200": {
+                        "description": "Per-row import report",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ImportInscripcionesResponse"
+                        }
                     },
                     "400": {
-                        "description": "Invalid tutor ID",
+                        "description": "Invalid CSV file",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete tutor",
+                        "description": "Failed to import inscripciones",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1597,20 +2348,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/tutores/{id}/nombre": {
+        "/v1/inscripciones/{id}": {
             "get": {
-                "description": "Retrieves just the name of a specific tutor by their ID.",
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves an enrollment by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tutores"
+                    "Inscripciones"
                 ],
-                "summary": "Get Tutor Name by ID",
+                "summary": "Get Inscripcion",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tutor ID",
+                        "description": "Inscripcion ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1618,77 +2374,91 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved tutor name",
+                        "description": "Inscripcion",
                         "schema": {
-                            "$ref": "#/definitions/handler.GetTutorNameResponse"
+                            "$ref": "#/definitions/db.Inscripcione"
                         }
                     },
                     "400": {
-                        "description": "Invalid tutor ID",
+                        "description": "Invalid inscripcion ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Tutor not found",
+                        "description": "Inscripcion not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve tutor name",
+                        "description": "Failed to get inscripcion",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/tutorias": {
-            "get": {
-                "description": "Retrieves upcoming tutorias for a specific student that have not started yet.",
-                "produces": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
                 ],
+                "description": "Removes an enrollment. Tutorias already booked for the materia are kept.",
                 "tags": [
-                    "Tutorias"
+                    "Inscripciones"
                 ],
-                "summary": "Get Upcoming Tutorias for Student",
+                "summary": "Delete Inscripcion",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Student ID",
-                        "name": "proximas_estudiante_id",
-                        "in": "query",
+                        "description": "Inscripcion ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully retrieved upcoming tutorias",
+                    "204": {
+                        "description": "Successfully deleted inscripcion"
+                    },
+                    "400": {
+                        "description": "Invalid inscripcion ID",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.Tutoria"
-                            }
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid student ID",
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Inscripcion not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve upcoming tutorias",
+                        "description": "Failed to delete inscripcion",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/login": {
             "post": {
-                "description": "Creates a new tutoring session with intelligent tutor assignment and validation.",
+                "description": "Authenticates a student using their email and TI (Tarjeta de Identidad).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1696,35 +2466,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tutorias"
+                    "Authentication"
                 ],
-                "summary": "Create Tutoria",
+                "summary": "Student Login (Legacy)",
                 "parameters": [
                     {
-                        "description": "Tutoria Data",
-                        "name": "tutoria",
+                        "description": "Login Credentials",
+                        "name": "login",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateTutoriaRequest"
+                            "$ref": "#/definitions/handler.StudentLoginRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully created tutoria",
+                    "200": {
+                        "description": "Successfully authenticated. Returns student details.",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateTutoriaResponse"
+                            "$ref": "#/definitions/db.Estudiante"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation failed",
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials (user not found or TI mismatch)",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to create tutoria",
+                        "description": "Internal server error during login",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1732,54 +2514,189 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/tutorias/{id}": {
-            "get": {
-                "description": "Retrieves a specific tutoria by its ID.",
-                "produces": [
-                    "application/json"
-                ],
+        "/v1/login/{mode}": {
+            "post": {
+                "description": "Authenticates users (estudiantes, tutores, or admins) based on the mode parameter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Tutorias"
+                    "Authentication"
                 ],
-                "summary": "Get Tutoria by ID",
+                "summary": "Unified Login",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Tutoria ID",
-                        "name": "id",
+                        "enum": [
+                            "estudiante",
+                            "tutor",
+                            "admin"
+                        ],
+                        "type": "string",
+                        "description": "Login mode",
+                        "name": "mode",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Login Credentials",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UnifiedLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved tutoria",
+                        "description": "Successfully authenticated",
                         "schema": {
-                            "$ref": "#/definitions/db.Tutoria"
+                            "$ref": "#/definitions/handler.LoginResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid tutoria ID",
+                        "description": "Invalid request body or mode",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Tutoria not found",
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin account is inactive",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve tutoria",
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/materias": {
+            "get": {
+                "description": "Retrieves a list of all materias.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Materias"
+                ],
+                "summary": "List All Materias",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only return the names of active materias, as a list of strings",
+                        "name": "nombres",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, as returned in X-Next-Cursor",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "codigo",
+                            "nombre",
+                            "facultad",
+                            "creditos",
+                            "id"
+                        ],
+                        "type": "string",
+                        "default": "codigo",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved materias",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Materia"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page; absent on the last page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total number of materias"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve materias",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Updates a specific tutoria with new details.",
+            "post": {
+                "description": "Creates a new materia (subject).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1787,90 +2704,76 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tutorias"
+                    "Materias"
                 ],
-                "summary": "Update Tutoria",
+                "summary": "Create Materia",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Tutoria ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Tutoria Update Data",
-                        "name": "tutoria",
+                        "description": "Materia Data",
+                        "name": "materia",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateTutoriaRequest"
+                            "$ref": "#/definitions/handler.CreateMateriaRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully updated tutoria",
+                    "201": {
+                        "description": "Successfully created materia",
                         "schema": {
-                            "$ref": "#/definitions/db.Tutoria"
+                            "$ref": "#/definitions/handler.CreateMateriaResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or tutoria ID",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Tutoria not found",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update tutoria",
+                        "description": "Failed to create materia",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes a specific tutoria.",
+            }
+        },
+        "/v1/materias/codigo/{codigo}": {
+            "get": {
+                "description": "Retrieves a specific materia by its code.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tutorias"
+                    "Materias"
                 ],
-                "summary": "Delete Tutoria",
+                "summary": "Get Materia by Code",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Tutoria ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Materia Code",
+                        "name": "codigo",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Successfully deleted tutoria"
-                    },
-                    "400": {
-                        "description": "Invalid tutoria ID",
+                    "200": {
+                        "description": "Successfully retrieved materia",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/db.Materia"
                         }
                     },
                     "404": {
-                        "description": "Tutoria not found",
+                        "description": "Materia not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete tutoria",
+                        "description": "Failed to retrieve materia",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1878,58 +2781,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/tutorias/{id}/asistencia": {
-            "patch": {
-                "description": "Updates only the asistencia_confirmada field of a specific tutoria using path parameter.",
+        "/v1/materias/import": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Syncs MATERIAS with a catalog keyed by codigo, given as CSV (codigo, nombre, facultad, creditos and optional descripcion) or as a JSON array, in the multipart field \"archivo\" or the raw body. New codigos are created; existing ones get their nombre, facultad, creditos and descripcion updated and are reactivated. With desactivar_faltantes=true, active materias missing from the catalog are deactivated (never deleted, so their tutorias are kept). Everything runs in one transaction; with dry_run=true only the diff is returned.",
                 "consumes": [
+                    "multipart/form-data",
+                    "text/csv",
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tutorias"
+                    "Materias"
                 ],
-                "summary": "Update Tutoria Asistencia",
+                "summary": "Import Materia Catalog",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Tutoria ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "file",
+                        "description": "Catalog file (CSV or JSON)",
+                        "name": "archivo",
+                        "in": "formData"
                     },
                     {
-                        "description": "Asistencia Update Data",
-                        "name": "asistencia",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UpdateTutoriaAsistenciaRequest"
-                        }
+                        "type": "boolean",
+                        "description": "Only compute the diff, without saving",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Deactivate materias missing from the catalog",
+                        "name": "desactivar_faltantes",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated tutoria asistencia",
+                        "description": "Catalog diff",
                         "schema": {
-                            "$ref": "#/definitions/db.Tutoria"
+                            "$ref": "#/definitions/handler.ImportMateriasResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or tutoria ID",
+                        "description": "Invalid catalog file",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Tutoria not found",
+                    "401": {
+                        "description": "Missing or invalid admin token",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update tutoria asistencia",
+                        "description": "Failed to import materias",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1937,66 +2849,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/tutorias/{id}/estado": {
-            "put": {
-                "description": "Updates the status of a specific tutoria.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/v1/materias/{id}": {
+            "get": {
+                "description": "Retrieves a specific materia by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tutorias"
+                    "Materias"
                 ],
-                "summary": "Update Tutoria Status",
+                "summary": "Get Materia by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tutoria ID",
+                        "description": "Materia ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Status Update Data",
-                        "name": "estado",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UpdateTutoriaEstadoRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated tutoria status",
+                        "description": "Successfully retrieved materia",
                         "schema": {
-                            "$ref": "#/definitions/db.Tutoria"
+                            "$ref": "#/definitions/db.Materia"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or tutoria ID",
+                        "description": "Invalid materia ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Tutoria not found",
+                        "description": "Materia not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update tutoria status",
+                        "description": "Failed to retrieve materia",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
             },
-            "patch": {
-                "description": "Updates only the estado (status) of a specific tutoria using path parameter.",
+            "put": {
+                "description": "Updates an existing materia.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2004,746 +2904,5975 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tutorias"
+                    "Materias"
                 ],
-                "summary": "Update Tutoria Estado",
+                "summary": "Update Materia",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Tutoria ID",
+                        "description": "Materia ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Estado Update Data",
-                        "name": "estado",
+                        "description": "Updated Materia Data",
+                        "name": "materia",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateTutoriaEstadoRequest"
+                            "$ref": "#/definitions/handler.UpdateMateriaRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated tutoria estado",
+                        "description": "Successfully updated materia",
                         "schema": {
-                            "$ref": "#/definitions/db.Tutoria"
+                            "$ref": "#/definitions/db.Materia"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or tutoria ID",
+                        "description": "Invalid request body or materia ID",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Tutoria not found",
+                        "description": "Materia not found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update tutoria estado",
+                        "description": "Failed to update materia",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Moves a materia to the papelera, keeping its tutorias and tutor assignments. It is no longer listed\nand can be restored by an admin until the retention period ends, when it is deleted permanently.",
+                "tags": [
+                    "Materias"
+                ],
+                "summary": "Delete Materia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Materia ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully deleted materia"
+                    },
+                    "400": {
+                        "description": "Invalid materia ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Materia not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete materia",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/materias/{id}/requisitos": {
+            "get": {
+                "description": "Retrieves the rules a tutor must meet to teach the materia. Materias without rules accept tutors of any\nsemestre and programa_academico, with admin approval.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Materias"
+                ],
+                "summary": "Get Materia Eligibility Rules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Materia ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Eligibility rules",
+                        "schema": {
+                            "$ref": "#/definitions/db.MateriaRequisito"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid materia ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Materia not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get eligibility rules",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Replaces the rules a tutor must meet to teach the materia: a minimum semestre and the accepted\nprogramas académicos of the tutor's estudiante record, and whether postulaciones need admin approval.\nExisting assignments are not affected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Materias"
+                ],
+                "summary": "Set Materia Eligibility Rules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Materia ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Eligibility rules",
+                        "name": "requisitos",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateMateriaRequisitosRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated eligibility rules",
+                        "schema": {
+                            "$ref": "#/definitions/db.MateriaRequisito"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or materia ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Materia not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update eligibility rules",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/materias/{id}/restaurar": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Takes a deleted materia out of the papelera, along with its tutor assignments.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Papelera"
+                ],
+                "summary": "Restore Materia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Materia ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored materia",
+                        "schema": {
+                            "$ref": "#/definitions/db.Materia"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid materia ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Materia not found in papelera",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another materia now uses the same codigo",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to restore materia",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reporte-programaciones": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves every report schedule with its next and last run.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reportes"
+                ],
+                "summary": "List All Report Schedules",
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved schedules",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.ReporteProgramacione"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve schedules",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     }
                 }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Schedules a report to be generated on every match of a cron expression, covering the period before the run.\nFor example cron \"0 7 * * 1\" with periodo semana_anterior generates every Monday at 7:00 the report of the previous week.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reportes"
+                ],
+                "summary": "Create Report Schedule",
+                "parameters": [
+                    {
+                        "description": "Schedule Data",
+                        "name": "programacion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateReporteProgramacionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created schedule",
+                        "schema": {
+                            "$ref": "#/definitions/db.ReporteProgramacione"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create schedule",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reporte-programaciones/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves a specific report schedule by its ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reportes"
+                ],
+                "summary": "Get Report Schedule by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved schedule",
+                        "schema": {
+                            "$ref": "#/definitions/db.ReporteProgramacione"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid schedule ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve schedule",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Replaces a report schedule. Its next run is recomputed from the cron expression.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reportes"
+                ],
+                "summary": "Update Report Schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Schedule Data",
+                        "name": "programacion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateReporteProgramacionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated schedule",
+                        "schema": {
+                            "$ref": "#/definitions/db.ReporteProgramacione"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or schedule ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update schedule",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Deletes a report schedule. Reportes it already generated are kept.",
+                "tags": [
+                    "Reportes"
+                ],
+                "summary": "Delete Report Schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully deleted schedule"
+                    },
+                    "400": {
+                        "description": "Invalid schedule ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete schedule",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reportes": {
+            "get": {
+                "description": "Retrieves a list of all reports.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Reportes"
+                ],
+                "summary": "List All Reportes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only reportes of this type; the result is not paginated",
+                        "name": "tipo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "With periodo_fin, only reportes within the period (YYYY-MM-DD); the result is not paginated",
+                        "name": "periodo_inicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period (YYYY-MM-DD)",
+                        "name": "periodo_fin",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, as returned in X-Next-Cursor",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "fecha_generacion",
+                            "tipo_reporte",
+                            "periodo_inicio",
+                            "id"
+                        ],
+                        "type": "string",
+                        "default": "fecha_generacion",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction; defaults to desc for the default sort and to asc otherwise",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved reportes",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Reporte"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page; absent on the last page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total number of reportes"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve reportes",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Queues the generation of a report of the given type from the tutorias dated within the period.\nThe report is generated in the background; poll GET /v1/reportes/{id} (also sent in the Location header)\nuntil estado is listo, fallido or cancelado.\ndesempeno_tutores: attendance and cancellations per tutor and materia; uso_materias: demand and hours per materia;\nasistencia_programas: attendance per academic program; cancelaciones: the cancelled tutorias;\ndemanda_insatisfecha: failed booking attempts per materia.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reportes"
+                ],
+                "summary": "Generate Reporte",
+                "parameters": [
+                    {
+                        "description": "Report type and period",
+                        "name": "reporte",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateReporteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Reporte queued for generation",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateReporteResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the queued reporte"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, period or report type",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to queue reporte",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reportes/{id}": {
+            "get": {
+                "description": "Retrieves a specific report by its ID. Estado and progreso (0-100) track the generation of a queued\nreport; datos is filled in once estado is listo, and ultimo_error describes the last failed attempt.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reportes"
+                ],
+                "summary": "Get Reporte by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reporte ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved reporte",
+                        "schema": {
+                            "$ref": "#/definitions/db.Reporte"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reporte ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reporte not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve reporte",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Deletes a report by its ID.",
+                "tags": [
+                    "Reportes"
+                ],
+                "summary": "Delete Reporte",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reporte ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully deleted reporte"
+                    },
+                    "400": {
+                        "description": "Invalid reporte ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete reporte",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reportes/{id}.pdf": {
+            "get": {
+                "description": "Renders a generated report as a printable PDF: a header with the period and the admin who generated it,\nfollowed by a bar chart and the detail table of its tipo_reporte.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Reportes"
+                ],
+                "summary": "Get Reporte as PDF",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reporte ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF document",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reporte ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reporte not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Reporte is not listo yet",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to render reporte",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reportes/{id}/cancelar": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Cancels a queued reporte. A reporte being generated stops within a few seconds.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reportes"
+                ],
+                "summary": "Cancel Reporte",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reporte ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reporte cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/db.Reporte"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reporte ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reporte not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Reporte is not pendiente or en_progreso",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to cancel reporte",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reportes/{id}/reintentar": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Queues a failed or cancelled reporte again, resetting its attempt count.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reportes"
+                ],
+                "summary": "Retry Reporte",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reporte ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Reporte queued for generation",
+                        "schema": {
+                            "$ref": "#/definitions/db.Reporte"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reporte ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Reporte not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Reporte is not fallido or cancelado",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to queue reporte",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/solicitudes-fallidas": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves the booking attempts that could not be served, most recent first. The demanda_insatisfecha\nreporte ranks the materias with the most unmet demand.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Tutorias"
+                ],
+                "summary": "List Failed Booking Attempts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only attempts for this materia",
+                        "name": "materia_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "materia_inactiva",
+                            "sin_tutores",
+                            "sin_horario",
+                            "tutor_no_calificado",
+                            "tutor_ocupado",
+                            "tutor_no_disponible",
+                            "tutor_sin_cupo"
+                        ],
+                        "type": "string",
+                        "description": "Only attempts that failed for this reason",
+                        "name": "motivo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only attempts made on or after this date (YYYY-MM-DD)",
+                        "name": "desde",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only attempts made on or before this date (YYYY-MM-DD)",
+                        "name": "hasta",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of attempts",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Failed booking attempts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.ListSolicitudesFallidasRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve failed booking attempts",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/solicitudes-tutor": {
+            "post": {
+                "description": "A student applies to become a tutor, with a motivation and the materias they want to teach. Admins\nreview the solicitud through /v1/admin/solicitudes-tutor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "Apply to Become a Tutor",
+                "parameters": [
+                    {
+                        "description": "Solicitud Data",
+                        "name": "solicitud",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateSolicitudTutorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created solicitud",
+                        "schema": {
+                            "$ref": "#/definitions/db.SolicitudesTutor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, estudiante or materias",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The student is already a tutor or has a pending solicitud",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create solicitud",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/solicitudes-tutor/{id}": {
+            "get": {
+                "description": "Retrieves a solicitud, so that the student can follow its review.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "Get Solicitud to Become a Tutor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Solicitud ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Solicitud",
+                        "schema": {
+                            "$ref": "#/definitions/db.SolicitudesTutor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid solicitud ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Solicitud not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get solicitud",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutor-materias": {
+            "get": {
+                "description": "Retrieves tutores assigned to a specific materia.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TutorMaterias"
+                ],
+                "summary": "List Tutores by Materia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Materia ID",
+                        "name": "materia_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved tutores",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.ListTutoresByMateriaRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid materia ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve tutores",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Creates a new tutor-materia assignment. Requires an admin bearer token; tutors apply through POST\n/v1/tutor-postulaciones instead. The tutor must meet the materia's eligibility rules.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TutorMaterias"
+                ],
+                "summary": "Create TutorMateria Assignment",
+                "parameters": [
+                    {
+                        "description": "TutorMateria Assignment Data",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTutorMateriaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created assignment",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTutorMateriaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or tutor not eligible",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create assignment",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutor-materias/{id}": {
+            "get": {
+                "description": "Retrieves a specific tutor-materia assignment by its ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TutorMaterias"
+                ],
+                "summary": "Get TutorMateria by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Assignment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved assignment",
+                        "schema": {
+                            "$ref": "#/definitions/db.TutorMateria"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid assignment ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Assignment not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve assignment",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Updates an existing tutor-materia assignment. Requires an admin bearer token. Reactivating an\nassignment checks the materia's eligibility rules again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TutorMaterias"
+                ],
+                "summary": "Update TutorMateria Assignment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Assignment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Assignment Data",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateTutorMateriaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated assignment",
+                        "schema": {
+                            "$ref": "#/definitions/db.TutorMateria"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or assignment ID, or tutor not eligible",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Assignment not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update assignment",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Deletes a tutor-materia assignment by its ID. Requires an admin bearer token.",
+                "tags": [
+                    "TutorMaterias"
+                ],
+                "summary": "Delete TutorMateria Assignment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Assignment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully deleted assignment"
+                    },
+                    "400": {
+                        "description": "Invalid assignment ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Assignment not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete assignment",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutor-postulaciones": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves the postulaciones of tutors to teach materias, most recent first. Use estado=pendiente for\nthe review queue.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "TutorMaterias"
+                ],
+                "summary": "List Postulaciones",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pendiente",
+                            "aprobada",
+                            "rechazada"
+                        ],
+                        "type": "string",
+                        "description": "Only postulaciones in this estado",
+                        "name": "estado",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only postulaciones of this tutor",
+                        "name": "tutor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only postulaciones for this materia",
+                        "name": "materia_id",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of postulaciones",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Postulaciones",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.ListTutorPostulacionesRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve postulaciones",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "A tutor applies to teach a materia. The tutor must meet the materia's eligibility rules. The\npostulacion waits for admin review, unless the materia does not require approval, in which case it is\napproved at once and the materia is assigned to the tutor. Requires the tutor's calendar feed token\nor an admin bearer token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TutorMaterias"
+                ],
+                "summary": "Apply to Teach a Materia",
+                "parameters": [
+                    {
+                        "description": "Postulacion Data",
+                        "name": "postulacion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTutorPostulacionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feed token of the tutor, from /v1/calendario/token/{mode}",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created postulacion",
+                        "schema": {
+                            "$ref": "#/definitions/db.TutorPostulacione"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, unknown tutor or materia, or tutor not eligible",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid feed token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The tutor already teaches the materia or has a pending postulacion for it",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create postulacion",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutor-postulaciones/{id}": {
+            "get": {
+                "description": "Retrieves a postulacion, so that the tutor can follow its review.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TutorMaterias"
+                ],
+                "summary": "Get Postulacion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Postulacion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Postulacion",
+                        "schema": {
+                            "$ref": "#/definitions/db.TutorPostulacione"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid postulacion ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Postulacion not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get postulacion",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutor-postulaciones/{id}/aprobar": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Approves a pending postulacion and assigns the materia to the tutor. The eligibility rules are checked\nagain, in case they changed since the tutor applied. The tutor is notified by email.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TutorMaterias"
+                ],
+                "summary": "Approve Postulacion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Postulacion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Approved postulacion",
+                        "schema": {
+                            "$ref": "#/definitions/db.TutorPostulacione"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid postulacion ID or tutor no longer eligible",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Postulacion not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Postulacion was already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to approve postulacion",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutor-postulaciones/{id}/rechazar": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Rejects a pending postulacion with a reason. The tutor is notified by email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "TutorMaterias"
+                ],
+                "summary": "Reject Postulacion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Postulacion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection reason",
+                        "name": "rechazo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RejectTutorPostulacionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rejected postulacion",
+                        "schema": {
+                            "$ref": "#/definitions/db.TutorPostulacione"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid postulacion ID or missing motivo",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Postulacion not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Postulacion was already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reject postulacion",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutores": {
+            "get": {
+                "description": "Retrieves a list of all tutors.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "List All Tutores",
+                "parameters": [
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, as returned in X-Next-Cursor",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "apellido",
+                            "nombre",
+                            "correo",
+                            "programa_academico",
+                            "fecha_registro",
+                            "id"
+                        ],
+                        "type": "string",
+                        "default": "apellido",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved tutores",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Tutore"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page; absent on the last page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total number of tutores"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve tutores",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Creates a new tutor record using data from an existing student. Requires an admin bearer token;\nstudents apply through POST /v1/solicitudes-tutor instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "Create Tutor from Estudiante",
+                "parameters": [
+                    {
+                        "description": "Estudiante ID to become Tutor",
+                        "name": "tutor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTutorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created tutor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTutorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or Estudiante ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Estudiante not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create tutor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutores/count-with-materias": {
+            "get": {
+                "description": "Returns the count of tutors that have at least one materia assigned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "Count Tutors with Materias",
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved count",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CountTutorsWithMateriasResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to count tutors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutores/login": {
+            "post": {
+                "description": "Authenticates a tutor using their email and TI (Tarjeta de Identidad), then fetches tutor-specific data.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Tutor Login (Legacy)",
+                "parameters": [
+                    {
+                        "description": "Login Credentials for Tutor (as Student)",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.StudentLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully authenticated. Returns combined student and tutor details.",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials or tutor record not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutores/onboard": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Turns an estudiante into a tutor, assigns the selected materias (by ID, codigo or facultad, \"*\" meaning every active materia) and creates the weekly availability template, all in one transaction. Materias whose eligibility rules the student does not meet are skipped and listed in materias_omitidas. It is idempotent: an existing tutor with the student's correo is reused, active assignments and identical availability slots are left alone, and inactive assignments are reactivated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "Onboard Tutor",
+                "parameters": [
+                    {
+                        "description": "Onboarding data",
+                        "name": "onboarding",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.OnboardTutorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Onboarding summary",
+                        "schema": {
+                            "$ref": "#/definitions/handler.OnboardTutorResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, materias or availability",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Estudiante not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to onboard tutor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutores/{id}": {
+            "get": {
+                "description": "Retrieves a specific tutor by their ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "Get Tutor by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved tutor",
+                        "schema": {
+                            "$ref": "#/definitions/db.Tutore"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tutor ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutor not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve tutor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing tutor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "Update Tutor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Tutor Data",
+                        "name": "tutor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateTutorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated tutor",
+                        "schema": {
+                            "$ref": "#/definitions/db.Tutore"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or tutor ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutor not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update tutor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Moves a tutor to the papelera, keeping their tutorias, availability and materias. It is no longer\nlisted and can be restored by an admin until the retention period ends, when it is deleted\npermanently.",
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "Delete Tutor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully deleted tutor"
+                    },
+                    "400": {
+                        "description": "Invalid tutor ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutor not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete tutor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutores/{id}/calendario.ics": {
+            "get": {
+                "description": "iCalendar feed with every tutoria given by a tutor. Cancelled sessions are included with STATUS:CANCELLED.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Calendario"
+                ],
+                "summary": "Tutor Calendar Feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feed token from /v1/calendario/token/tutor",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tutor ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid feed token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to build calendar",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutores/{id}/certificado": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Issues a signed PDF certificate of the tutor's completed tutorias with confirmed attendance within the\nperiod, with the hours per materia. Requesting the same period again returns the same verification\ncode unless the certified sessions changed. The code can be checked at GET /v1/certificados/{codigo}.\nRequires the tutor's calendar feed token or an admin bearer token. Certificates are only issued when\nCERTIFICADO_SECRET or AUTH_SECRET is configured, so that their signatures survive a restart.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "Get Tutor Hours Certificate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the period (YYYY-MM-DD)",
+                        "name": "desde",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period (YYYY-MM-DD)",
+                        "name": "hasta",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feed token of the tutor, from /v1/calendario/token/{mode}",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF certificate",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tutor ID or period",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid feed token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutor not found or no certifiable tutorias in the period",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to issue certificate",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Certificate signing is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutores/{id}/disponibilidad/import": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Computes a tutor's weekly availability from the busy blocks of an uploaded .ics file (multipart field \"archivo\" or raw text/calendar body). Free windows are the gaps between busy blocks inside the working-hours frame, over every occurrence within [desde, hasta). The tutor's DISPONIBILIDAD rows are replaced in one transaction; unchanged windows keep their IDs. With dry_run=true only the diff is returned. Recurring events may use DAILY and WEEKLY rules with INTERVAL, COUNT, UNTIL and BYDAY; files with other rules, RDATE or an unknown TZID are rejected.",
+                "consumes": [
+                    "multipart/form-data",
+                    "text/calendar"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Disponibilidad"
+                ],
+                "summary": "Import Disponibilidad from iCalendar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "iCalendar file with the tutor's schedule",
+                        "name": "archivo",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only compute the diff, without saving",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day of the reference period (YYYY-MM-DD), defaults to today",
+                        "name": "desde",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Day after the reference period (YYYY-MM-DD), defaults to desde + 7 days",
+                        "name": "hasta",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "07:00",
+                        "description": "Start of the working-hours frame (HH:MM)",
+                        "name": "hora_inicio",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "19:00",
+                        "description": "End of the working-hours frame (HH:MM)",
+                        "name": "hora_fin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "1,2,3,4,5",
+                        "description": "Comma-separated working days (1=Monday, 7=Sunday)",
+                        "name": "dias",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Shortest free window kept, in minutes",
+                        "name": "duracion_minima",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Availability diff",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ImportDisponibilidadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file or parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutor not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to import disponibilidad",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutores/{id}/horas": {
+            "get": {
+                "description": "Sums the hours of the tutor's completed, confirmed and pending tutorias dated within a month or an ISO\nweek, for payroll and credit certification. Cancelled tutorias are not counted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "Get Tutor Hours",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Month (YYYY-MM) or ISO week (YYYY-Www); defaults to the current month",
+                        "name": "periodo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tutor hours",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TutorHorasResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tutor ID or periodo",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutor not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to compute tutor hours",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutores/{id}/limites": {
+            "put": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Sets the maximum hours a tutor can be booked per week (Monday to Sunday) and per calendar month.\nNew tutorias that would exceed a limit are rejected, and auto-assignment skips the tutor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "Set Tutor Workload Limits",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Workload Limits",
+                        "name": "limites",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateTutorLimitesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated limits",
+                        "schema": {
+                            "$ref": "#/definitions/db.TutorLimite"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or tutor ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutor not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update tutor limits",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutores/{id}/materias": {
+            "get": {
+                "description": "Retrieves all materias taught by a specific tutor.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "Get Tutor Materias",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved materias",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Materia"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tutor ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutor not found or no materias assigned",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve materias",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutores/{id}/nombre": {
+            "get": {
+                "description": "Retrieves just the name of a specific tutor by their ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutores"
+                ],
+                "summary": "Get Tutor Name by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved tutor name",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GetTutorNameResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tutor ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutor not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve tutor name",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutores/{id}/restaurar": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Takes a deleted tutor out of the papelera, along with their availability and materias.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Papelera"
+                ],
+                "summary": "Restore Tutor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored tutor",
+                        "schema": {
+                            "$ref": "#/definitions/db.Tutore"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tutor ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutor not found in papelera",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another tutor now uses the same correo",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to restore tutor",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutorias": {
+            "get": {
+                "description": "Lists tutorias matching every given filter, with the names of their estudiante, tutor and materia.\nactivas=true and proximas_estudiante_id take precedence over the filters and return unpaginated lists.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Tutorias"
+                ],
+                "summary": "Search Tutorias",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "estudiante_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tutor ID",
+                        "name": "tutor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Materia ID",
+                        "name": "materia_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "solicitada",
+                                "confirmada",
+                                "cancelada",
+                                "completada"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Tutoria status; repeat or comma-separate for several",
+                        "name": "estado",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest date (YYYY-MM-DD)",
+                        "name": "fecha_desde",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest date (YYYY-MM-DD)",
+                        "name": "fecha_hasta",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive substring of the place; % and _ match literally",
+                        "name": "lugar",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether attendance was confirmed",
+                        "name": "asistencia",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only active (solicitada or confirmada) tutorias",
+                        "name": "activas",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only the upcoming tutorias of this student",
+                        "name": "proximas_estudiante_id",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page, as returned in X-Next-Cursor",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "fecha",
+                            "fecha_solicitud",
+                            "id"
+                        ],
+                        "type": "string",
+                        "default": "fecha",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort direction; defaults to desc for the default sort and to asc otherwise",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved tutorias",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.SearchTutoriasRow"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page; absent on the last page"
+                            },
+                            "X-Total-Count": {
+                                "type": "integer",
+                                "description": "Total number of matching tutorias"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve tutorias",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new tutoring session with intelligent tutor assignment and validation. The estudiante must be\nenrolled in the materia for the academic period of the fecha.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutorias"
+                ],
+                "summary": "Create Tutoria",
+                "parameters": [
+                    {
+                        "description": "Tutoria Data",
+                        "name": "tutoria",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTutoriaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created tutoria",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTutoriaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create tutoria",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutorias/estudiante/{estudiante_id}": {
+            "get": {
+                "description": "Retrieves all tutorias for a specific student.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Tutorias"
+                ],
+                "summary": "List Tutorias by Estudiante ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Estudiante ID",
+                        "name": "estudiante_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved tutorias",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Tutoria"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid estudiante ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve tutorias",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutorias/tutor/{tutor_id}": {
+            "get": {
+                "description": "Retrieves all tutorias for a specific tutor.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Tutorias"
+                ],
+                "summary": "List Tutorias by Tutor ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutor ID",
+                        "name": "tutor_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "Response format; Accept: text/csv is also honored",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved tutorias",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Tutoria"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tutor ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve tutorias",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutorias/{id}": {
+            "get": {
+                "description": "Retrieves a specific tutoria by its ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutorias"
+                ],
+                "summary": "Get Tutoria by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutoria ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved tutoria",
+                        "schema": {
+                            "$ref": "#/definitions/db.Tutoria"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tutoria ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutoria not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve tutoria",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a specific tutoria with new details.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutorias"
+                ],
+                "summary": "Update Tutoria",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutoria ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tutoria Update Data",
+                        "name": "tutoria",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateTutoriaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated tutoria",
+                        "schema": {
+                            "$ref": "#/definitions/db.Tutoria"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or tutoria ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutoria not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Tutor has a scheduling conflict or has reached their hour limit",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update tutoria",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a specific tutoria. If a calendar invitation was sent for it, a METHOD:CANCEL invitation is emailed to the estudiante and tutor first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutorias"
+                ],
+                "summary": "Delete Tutoria",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutoria ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully deleted tutoria"
+                    },
+                    "400": {
+                        "description": "Invalid tutoria ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutoria not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete tutoria",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutorias/{id}/asistencia": {
+            "patch": {
+                "description": "Updates only the asistencia_confirmada field of a specific tutoria using path parameter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutorias"
+                ],
+                "summary": "Update Tutoria Asistencia",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutoria ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Asistencia Update Data",
+                        "name": "asistencia",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateTutoriaAsistenciaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated tutoria asistencia",
+                        "schema": {
+                            "$ref": "#/definitions/db.Tutoria"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or tutoria ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutoria not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update tutoria asistencia",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutorias/{id}/estado": {
+            "put": {
+                "description": "Updates the status of a specific tutoria.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutorias"
+                ],
+                "summary": "Update Tutoria Status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutoria ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status Update Data",
+                        "name": "estado",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateTutoriaEstadoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated tutoria status",
+                        "schema": {
+                            "$ref": "#/definitions/db.Tutoria"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or tutoria ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutoria not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Tutor has a scheduling conflict or has reached their hour limit",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update tutoria status",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates only the estado (status) of a specific tutoria using path parameter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tutorias"
+                ],
+                "summary": "Update Tutoria Estado",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutoria ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Estado Update Data",
+                        "name": "estado",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateTutoriaEstadoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated tutoria estado",
+                        "schema": {
+                            "$ref": "#/definitions/db.Tutoria"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or tutoria ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutoria not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Tutor has a scheduling conflict or has reached their hour limit",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update tutoria estado",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tutorias/{id}/invitacion.ics": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Returns the last calendar invitation issued for a tutoria: METHOD:REQUEST once it is confirmed, METHOD:CANCEL once it is cancelled. The UID is stable and SEQUENCE grows with every invitation, so importing it updates the existing event. Requires the calendar feed token of the estudiante or tutor of the tutoria, or an admin bearer token.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Calendario"
+                ],
+                "summary": "Tutoria Calendar Invitation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tutoria ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feed token of the estudiante or tutor, from /v1/calendario/token/{mode}",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar invitation",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tutoria ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid feed token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tutoria not found or no invitation issued",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to build invitation",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves every webhook subscription. Requires an admin bearer token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List All Webhooks",
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved webhooks",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.WebhookResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve webhooks",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Creates a webhook subscription. The response is the only one that includes the secreto, so\nreceivers must store it now. Requires an admin bearer token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create Webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook Data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created webhook",
+                        "schema": {
+                            "$ref": "#/definitions/handler.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create webhook",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/entregas/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves a single webhook delivery with its payload and last attempt. Requires an admin bearer token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Webhook Delivery by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved delivery",
+                        "schema": {
+                            "$ref": "#/definitions/db.WebhookEntrega"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid delivery ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve delivery",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/entregas/{id}/reenviar": {
+            "post": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Queues a delivery to be sent again on the next dispatcher run, resetting its attempt count. Requires an admin bearer token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Redeliver Webhook Delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Delivery queued for redelivery",
+                        "schema": {
+                            "$ref": "#/definitions/db.WebhookEntrega"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid delivery ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to queue redelivery",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves a specific webhook subscription. Requires an admin bearer token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get Webhook by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved webhook",
+                        "schema": {
+                            "$ref": "#/definitions/handler.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve webhook",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Updates a webhook subscription. An empty secreto keeps the current one. Requires an admin bearer token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Webhook Data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated webhook",
+                        "schema": {
+                            "$ref": "#/definitions/handler.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update webhook",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Deletes a webhook subscription and its delivery log. Requires an admin bearer token.",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully deleted webhook"
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete webhook",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{id}/entregas": {
+            "get": {
+                "security": [
+                    {
+                        "AdminBearer": []
+                    }
+                ],
+                "description": "Retrieves the delivery log of a webhook, newest first. Requires an admin bearer token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List Webhook Deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of deliveries (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved deliveries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.WebhookEntrega"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook ID or limit",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Admin authentication required",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve deliveries",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "db.AnalyticsAnticipacionRow": {
+            "type": "object",
+            "properties": {
+                "medianaHoras": {
+                    "type": "number"
+                },
+                "p25Horas": {
+                    "type": "number"
+                },
+                "p75Horas": {
+                    "type": "number"
+                },
+                "promedioHoras": {
+                    "type": "number"
+                },
+                "tutorias": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.AnalyticsDemandaOfertaRow": {
+            "type": "object",
+            "properties": {
+                "codigo": {
+                    "type": "string"
+                },
+                "facultad": {
+                    "type": "string"
+                },
+                "horasDisponibles": {
+                    "type": "number"
+                },
+                "horasSolicitadas": {
+                    "type": "number"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "solicitudes": {
+                    "type": "integer"
+                },
+                "tutores": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.AnalyticsHorasPicoRow": {
+            "type": "object",
+            "properties": {
+                "diaSemana": {
+                    "type": "integer"
+                },
+                "hora": {
+                    "type": "integer"
+                },
+                "tutorias": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.AnalyticsLatenciaConfirmacionRow": {
+            "type": "object",
+            "properties": {
+                "medianaHoras": {
+                    "type": "number"
+                },
+                "p25Horas": {
+                    "type": "number"
+                },
+                "p75Horas": {
+                    "type": "number"
+                },
+                "promedioHoras": {
+                    "type": "number"
+                },
+                "tutorias": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.AnalyticsSesionesRow": {
+            "type": "object",
+            "properties": {
+                "canceladas": {
+                    "type": "integer"
+                },
+                "completadas": {
+                    "type": "integer"
+                },
+                "confirmadas": {
+                    "type": "integer"
+                },
+                "periodo": {
+                    "$ref": "#/definitions/pgtype.Date"
+                },
+                "solicitadas": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.BuscarMateriasRow": {
+            "type": "object",
+            "properties": {
+                "codigo": {
+                    "type": "string"
+                },
+                "creditos": {
+                    "type": "integer"
+                },
+                "descripcion": {
+                    "$ref": "#/definitions/pgtype.Text"
+                },
+                "facultad": {
+                    "type": "string"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "number"
+                }
+            }
+        },
+        "db.BuscarTutoresRow": {
+            "type": "object",
+            "properties": {
+                "apellido": {
+                    "type": "string"
+                },
+                "correo": {
+                    "type": "string"
+                },
+                "materias": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "programaAcademico": {
+                    "$ref": "#/definitions/pgtype.Text"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "tutorID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.Disponibilidad": {
+            "type": "object",
+            "properties": {
+                "diaSemana": {
+                    "type": "integer"
+                },
+                "disponibilidadID": {
+                    "type": "integer"
+                },
+                "horaFin": {
+                    "type": "string"
+                },
+                "horaInicio": {
+                    "type": "string"
+                },
+                "tutorID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.Estudiante": {
+            "type": "object",
+            "properties": {
+                "apellido": {
+                    "type": "string"
+                },
+                "correo": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "estudianteID": {
+                    "type": "integer"
+                },
+                "fechaRegistro": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "programaAcademico": {
+                    "type": "string"
+                },
+                "semestre": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                },
+                "ti": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                }
+            }
+        },
+        "db.Inscripcione": {
+            "type": "object",
+            "properties": {
+                "estudianteID": {
+                    "type": "integer"
+                },
+                "fechaInscripcion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "inscripcionID": {
+                    "type": "integer"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "periodo": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.ListDisponibilidadByDiaRow": {
+            "type": "object",
+            "properties": {
+                "diaSemana": {
+                    "type": "integer"
+                },
+                "disponibilidadID": {
+                    "type": "integer"
+                },
+                "horaFin": {
+                    "type": "string"
+                },
+                "horaInicio": {
+                    "type": "string"
+                },
+                "tutorApellido": {
+                    "type": "string"
+                },
+                "tutorID": {
+                    "type": "integer"
+                },
+                "tutorNombre": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.ListInscripcionesRow": {
+            "type": "object",
+            "properties": {
+                "correo": {
+                    "type": "string"
+                },
+                "estudiante": {
+                    "type": "string"
+                },
+                "estudianteID": {
+                    "type": "integer"
+                },
+                "fechaInscripcion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "inscripcionID": {
+                    "type": "integer"
+                },
+                "materia": {
+                    "type": "string"
+                },
+                "materiaCodigo": {
+                    "type": "string"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "periodo": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.ListMateriasByTutorRow": {
+            "type": "object",
+            "properties": {
+                "activo": {
+                    "type": "boolean"
+                },
+                "asignacionID": {
+                    "type": "integer"
+                },
+                "fechaAsignacion": {
+                    "$ref": "#/definitions/pgtype.Date"
+                },
+                "materiaCodigo": {
+                    "type": "string"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "materiaNombre": {
+                    "type": "string"
+                },
+                "tutorID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.ListSolicitudesFallidasRow": {
+            "type": "object",
+            "properties": {
+                "estudianteID": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                },
+                "fecha": {
+                    "$ref": "#/definitions/pgtype.Date"
+                },
+                "fechaIntento": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "horaFin": {
+                    "type": "string"
+                },
+                "horaInicio": {
+                    "type": "string"
+                },
+                "materia": {
+                    "type": "string"
+                },
+                "materiaCodigo": {
+                    "type": "string"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "motivo": {
+                    "type": "string"
+                },
+                "solicitudID": {
+                    "type": "integer"
+                },
+                "tutorID": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                }
+            }
+        },
+        "db.ListSolicitudesTutorRow": {
+            "type": "object",
+            "properties": {
+                "correo": {
+                    "type": "string"
+                },
+                "estado": {
+                    "type": "string"
+                },
+                "estudiante": {
+                    "type": "string"
+                },
+                "estudianteID": {
+                    "type": "integer"
+                },
+                "fechaRevision": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "fechaSolicitud": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "materias": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "motivacion": {
+                    "type": "string"
+                },
+                "motivoRechazo": {
+                    "$ref": "#/definitions/pgtype.Text"
+                },
+                "programaAcademico": {
+                    "type": "string"
+                },
+                "revisadoPor": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                },
+                "semestre": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                },
+                "solicitudID": {
+                    "type": "integer"
+                },
+                "tutorID": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                }
+            }
+        },
+        "db.ListTutorPostulacionesRow": {
+            "type": "object",
+            "properties": {
+                "comentario": {
+                    "$ref": "#/definitions/pgtype.Text"
+                },
+                "estado": {
+                    "type": "string"
+                },
+                "fechaPostulacion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "fechaRevision": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "materia": {
+                    "type": "string"
+                },
+                "materiaCodigo": {
+                    "type": "string"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "motivoRechazo": {
+                    "$ref": "#/definitions/pgtype.Text"
+                },
+                "postulacionID": {
+                    "type": "integer"
+                },
+                "revisadoPor": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                },
+                "tutor": {
+                    "type": "string"
+                },
+                "tutorID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.ListTutoresByMateriaRow": {
+            "type": "object",
+            "properties": {
+                "activo": {
+                    "type": "boolean"
+                },
+                "asignacionID": {
+                    "type": "integer"
+                },
+                "fechaAsignacion": {
+                    "$ref": "#/definitions/pgtype.Date"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "tutorApellido": {
+                    "type": "string"
+                },
+                "tutorID": {
+                    "type": "integer"
+                },
+                "tutorNombre": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.Materia": {
+            "type": "object",
+            "properties": {
+                "activo": {
+                    "type": "boolean"
+                },
+                "codigo": {
+                    "type": "string"
+                },
+                "creditos": {
+                    "type": "integer"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "descripcion": {
+                    "$ref": "#/definitions/pgtype.Text"
+                },
+                "facultad": {
+                    "type": "string"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "nombre": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.MateriaRequisito": {
+            "type": "object",
+            "properties": {
+                "fechaActualizacion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "programas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "requiereAprobacion": {
+                    "type": "boolean"
+                },
+                "semestreMinimo": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                }
+            }
+        },
+        "db.Reporte": {
+            "type": "object",
+            "properties": {
+                "datos": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "estado": {
+                    "type": "string"
+                },
+                "fechaFinalizacion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "fechaGeneracion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "fechaInicio": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "generadoPor": {
+                    "type": "integer"
+                },
+                "intentos": {
+                    "type": "integer"
+                },
+                "periodoFin": {
+                    "$ref": "#/definitions/pgtype.Date"
+                },
+                "periodoInicio": {
+                    "$ref": "#/definitions/pgtype.Date"
+                },
+                "programacionID": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                },
+                "progreso": {
+                    "type": "integer"
+                },
+                "proximoIntento": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "reporteID": {
+                    "type": "integer"
+                },
+                "tipoReporte": {
+                    "type": "string"
+                },
+                "ultimoError": {
+                    "$ref": "#/definitions/pgtype.Text"
+                }
+            }
+        },
+        "db.ReporteProgramacione": {
+            "type": "object",
+            "properties": {
+                "activo": {
+                    "type": "boolean"
+                },
+                "adjuntarPdf": {
+                    "type": "boolean"
+                },
+                "creadoPor": {
+                    "type": "integer"
+                },
+                "cron": {
+                    "type": "string"
+                },
+                "destinatarios": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "fechaCreacion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "periodo": {
+                    "type": "string"
+                },
+                "programacionID": {
+                    "type": "integer"
+                },
+                "proximaEjecucion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "tipoReporte": {
+                    "type": "string"
+                },
+                "ultimaEjecucion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                }
+            }
+        },
+        "db.SearchTutoriasRow": {
+            "type": "object",
+            "properties": {
+                "asistenciaConfirmada": {
+                    "$ref": "#/definitions/pgtype.Bool"
+                },
+                "estado": {
+                    "type": "string"
+                },
+                "estudianteApellido": {
+                    "type": "string"
+                },
+                "estudianteID": {
+                    "type": "integer"
+                },
+                "estudianteNombre": {
+                    "type": "string"
+                },
+                "fecha": {
+                    "$ref": "#/definitions/pgtype.Date"
+                },
+                "fechaConfirmacion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "fechaSolicitud": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "horaFin": {
+                    "type": "string"
+                },
+                "horaInicio": {
+                    "type": "string"
+                },
+                "lugar": {
+                    "type": "string"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "materiaNombre": {
+                    "type": "string"
+                },
+                "temasTratados": {
+                    "$ref": "#/definitions/pgtype.Text"
+                },
+                "tutorApellido": {
+                    "type": "string"
+                },
+                "tutorID": {
+                    "type": "integer"
+                },
+                "tutorNombre": {
+                    "type": "string"
+                },
+                "tutoriaID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.SolicitudesTutor": {
+            "type": "object",
+            "properties": {
+                "estado": {
+                    "type": "string"
+                },
+                "estudianteID": {
+                    "type": "integer"
+                },
+                "fechaRevision": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "fechaSolicitud": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "materias": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "motivacion": {
+                    "type": "string"
+                },
+                "motivoRechazo": {
+                    "$ref": "#/definitions/pgtype.Text"
+                },
+                "revisadoPor": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                },
+                "solicitudID": {
+                    "type": "integer"
+                },
+                "tutorID": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                }
+            }
+        },
+        "db.TutorLimite": {
+            "type": "object",
+            "properties": {
+                "fechaActualizacion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "horasMes": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                },
+                "horasSemana": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                },
+                "tutorID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.TutorMateria": {
+            "type": "object",
+            "properties": {
+                "activo": {
+                    "type": "boolean"
+                },
+                "asignacionID": {
+                    "type": "integer"
+                },
+                "fechaAsignacion": {
+                    "$ref": "#/definitions/pgtype.Date"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "tutorID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.TutorPostulacione": {
+            "type": "object",
+            "properties": {
+                "comentario": {
+                    "$ref": "#/definitions/pgtype.Text"
+                },
+                "estado": {
+                    "type": "string"
+                },
+                "fechaPostulacion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "fechaRevision": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "motivoRechazo": {
+                    "$ref": "#/definitions/pgtype.Text"
+                },
+                "postulacionID": {
+                    "type": "integer"
+                },
+                "revisadoPor": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                },
+                "tutorID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.Tutore": {
+            "type": "object",
+            "properties": {
+                "apellido": {
+                    "type": "string"
+                },
+                "correo": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "fechaRegistro": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "nombre": {
+                    "type": "string"
+                },
+                "programaAcademico": {
+                    "$ref": "#/definitions/pgtype.Text"
+                },
+                "tutorID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.Tutoria": {
+            "type": "object",
+            "properties": {
+                "asistenciaConfirmada": {
+                    "$ref": "#/definitions/pgtype.Bool"
+                },
+                "estado": {
+                    "type": "string"
+                },
+                "estudianteID": {
+                    "type": "integer"
+                },
+                "fecha": {
+                    "$ref": "#/definitions/pgtype.Date"
+                },
+                "fechaConfirmacion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "fechaSolicitud": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "horaFin": {
+                    "type": "string"
+                },
+                "horaInicio": {
+                    "type": "string"
+                },
+                "lugar": {
+                    "type": "string"
+                },
+                "materiaID": {
+                    "type": "integer"
+                },
+                "temasTratados": {
+                    "$ref": "#/definitions/pgtype.Text"
+                },
+                "tutorID": {
+                    "type": "integer"
+                },
+                "tutoriaID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.WebhookEntrega": {
+            "type": "object",
+            "properties": {
+                "entregaID": {
+                    "type": "integer"
+                },
+                "estado": {
+                    "type": "string"
+                },
+                "evento": {
+                    "type": "string"
+                },
+                "fechaCreacion": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "fechaUltimoIntento": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "intentos": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "proximoIntento": {
+                    "$ref": "#/definitions/pgtype.Timestamp"
+                },
+                "ultimoCodigo": {
+                    "$ref": "#/definitions/pgtype.Int4"
+                },
+                "ultimoError": {
+                    "$ref": "#/definitions/pgtype.Text"
+                },
+                "webhookID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.AdminResponse": {
+            "type": "object",
+            "properties": {
+                "activo": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "admin_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "apellido": {
+                    "type": "string",
+                    "example": "Gómez"
+                },
+                "correo": {
+                    "type": "string",
+                    "example": "ana.gomez@urosario.edu.co"
+                },
+                "fecha_registro": {
+                    "type": "string",
+                    "example": "2025-03-01T10:00:00Z"
+                },
+                "nombre": {
+                    "type": "string",
+                    "example": "Ana"
+                },
+                "rol": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "handler.ApproveSolicitudTutorResponse": {
+            "type": "object",
+            "properties": {
+                "materias_asignadas": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3
+                    ]
+                },
+                "materias_omitidas": {
+                    "description": "Requested materias the student is not eligible for",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "materia 7: semestre 2 is below the minimum of 4"
+                    ]
+                },
+                "solicitud": {
+                    "$ref": "#/definitions/db.SolicitudesTutor"
+                },
+                "tutor_id": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "handler.AuditoriaEventoResponse": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "description": "Correo of the admin, or anonimo",
+                    "type": "string",
+                    "example": "admin@urosario.edu.co"
+                },
+                "admin_id": {
+                    "description": "Null for calls made without an admin token",
+                    "type": "integer",
+                    "example": 1
+                },
+                "antes": {
+                    "description": "Previous value of the fields that changed",
+                    "type": "object"
+                },
+                "despues": {
+                    "description": "New value of the fields that changed",
+                    "type": "object"
+                },
+                "entidad": {
+                    "type": "string",
+                    "example": "estudiantes"
+                },
+                "entidad_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "estado_http": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "evento_id": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "fecha": {
+                    "type": "string",
+                    "example": "2025-03-01T10:00:00"
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "10.0.0.8"
+                },
+                "metodo": {
+                    "type": "string",
+                    "example": "PUT"
+                },
+                "ruta": {
+                    "type": "string",
+                    "example": "/v1/estudiantes/12"
+                }
             }
-        }
-    },
-    "definitions": {
-        "db.Disponibilidad": {
+        },
+        "handler.BuscarResponse": {
             "type": "object",
             "properties": {
-                "diaSemana": {
-                    "type": "integer"
-                },
-                "disponibilidadID": {
-                    "type": "integer"
-                },
-                "horaFin": {
-                    "type": "string"
+                "materias": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.BuscarMateriasRow"
+                    }
                 },
-                "horaInicio": {
-                    "type": "string"
+                "q": {
+                    "type": "string",
+                    "example": "calculo"
                 },
-                "tutorID": {
-                    "type": "integer"
+                "tutores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.BuscarTutoresRow"
+                    }
                 }
             }
         },
-        "db.Estudiante": {
+        "handler.CalendarioTokenRequest": {
             "type": "object",
             "properties": {
-                "apellido": {
-                    "type": "string"
-                },
                 "correo": {
-                    "type": "string"
-                },
-                "estudianteID": {
-                    "type": "integer"
-                },
-                "fechaRegistro": {
-                    "$ref": "#/definitions/pgtype.Timestamp"
-                },
-                "nombre": {
-                    "type": "string"
-                },
-                "programaAcademico": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "juan.perez@urosario.edu.co"
                 },
-                "semestre": {
-                    "$ref": "#/definitions/pgtype.Int4"
+                "rotar": {
+                    "description": "Issue a new token, invalidating the previous feed URL",
+                    "type": "boolean",
+                    "example": false
                 },
                 "ti": {
-                    "$ref": "#/definitions/pgtype.Int4"
+                    "type": "integer",
+                    "example": 1000123456
                 }
             }
         },
-        "db.ListDisponibilidadByDiaRow": {
+        "handler.CalendarioTokenResponse": {
             "type": "object",
             "properties": {
-                "diaSemana": {
-                    "type": "integer"
-                },
-                "disponibilidadID": {
-                    "type": "integer"
+                "token": {
+                    "type": "string",
+                    "example": "3f9a..."
                 },
-                "horaFin": {
-                    "type": "string"
+                "url": {
+                    "type": "string",
+                    "example": "/v1/estudiantes/1/calendario.ics?token=3f9a..."
+                }
+            }
+        },
+        "handler.CertificadoMateria": {
+            "type": "object",
+            "properties": {
+                "codigo": {
+                    "type": "string",
+                    "example": "MAT101"
                 },
-                "horaInicio": {
-                    "type": "string"
+                "materia_id": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "tutorApellido": {
-                    "type": "string"
+                "minutos": {
+                    "type": "integer",
+                    "example": 720
                 },
-                "tutorID": {
-                    "type": "integer"
+                "nombre": {
+                    "type": "string",
+                    "example": "Cálculo I"
                 },
-                "tutorNombre": {
-                    "type": "string"
+                "tutorias": {
+                    "type": "integer",
+                    "example": 8
                 }
             }
         },
-        "db.ListMateriasByTutorRow": {
+        "handler.CertificadoVerificacionResponse": {
             "type": "object",
             "properties": {
-                "activo": {
-                    "type": "boolean"
+                "codigo": {
+                    "type": "string",
+                    "example": "K7QF-M2XA-9BTR-C4WE"
                 },
-                "asignacionID": {
-                    "type": "integer"
+                "desde": {
+                    "type": "string",
+                    "example": "2025-01-01"
                 },
-                "fechaAsignacion": {
-                    "$ref": "#/definitions/pgtype.Date"
+                "fecha_emision": {
+                    "type": "string",
+                    "example": "2025-07-01 10:30:00"
                 },
-                "materiaCodigo": {
+                "firma": {
                     "type": "string"
                 },
-                "materiaID": {
-                    "type": "integer"
+                "hasta": {
+                    "type": "string",
+                    "example": "2025-06-30"
                 },
-                "materiaNombre": {
-                    "type": "string"
+                "materias": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.CertificadoMateria"
+                    }
                 },
-                "tutorID": {
-                    "type": "integer"
+                "total_horas": {
+                    "type": "number",
+                    "example": 31.5
+                },
+                "total_tutorias": {
+                    "type": "integer",
+                    "example": 24
+                },
+                "tutor_nombre": {
+                    "type": "string",
+                    "example": "Juan Perez"
+                },
+                "valido": {
+                    "description": "The stored content matches its signature",
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "db.ListTutoresByMateriaRow": {
+        "handler.CountTutorsWithMateriasResponse": {
             "type": "object",
             "properties": {
-                "activo": {
-                    "type": "boolean"
-                },
-                "asignacionID": {
+                "count": {
                     "type": "integer"
+                }
+            }
+        },
+        "handler.CreateAdminRequest": {
+            "type": "object",
+            "properties": {
+                "apellido": {
+                    "type": "string",
+                    "example": "Gómez"
                 },
-                "fechaAsignacion": {
-                    "$ref": "#/definitions/pgtype.Date"
-                },
-                "materiaID": {
-                    "type": "integer"
+                "correo": {
+                    "type": "string",
+                    "example": "ana.gomez@urosario.edu.co"
                 },
-                "tutorApellido": {
-                    "type": "string"
+                "nombre": {
+                    "type": "string",
+                    "example": "Ana"
                 },
-                "tutorID": {
-                    "type": "integer"
+                "password": {
+                    "type": "string",
+                    "example": "cambiar-en-el-primer-ingreso"
                 },
-                "tutorNombre": {
-                    "type": "string"
+                "rol": {
+                    "description": "admin (default) or superadmin",
+                    "type": "string",
+                    "example": "admin"
                 }
             }
         },
-        "db.ListTutoriasByEstadoRow": {
+        "handler.CreateDisponibilidadRequest": {
             "type": "object",
             "properties": {
-                "asistenciaConfirmada": {
-                    "$ref": "#/definitions/pgtype.Bool"
+                "dia_semana": {
+                    "description": "1 = Monday, 7 = Sunday",
+                    "type": "integer",
+                    "example": 1
                 },
-                "estado": {
-                    "type": "string"
+                "hora_fin": {
+                    "type": "string",
+                    "example": "11:00"
                 },
-                "estudianteApellido": {
-                    "type": "string"
+                "hora_inicio": {
+                    "type": "string",
+                    "example": "09:00"
                 },
-                "estudianteID": {
+                "tutor_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handler.CreateDisponibilidadResponse": {
+            "type": "object",
+            "properties": {
+                "disponibilidad_id": {
                     "type": "integer"
+                }
+            }
+        },
+        "handler.CreateEstudianteRequest": {
+            "type": "object",
+            "properties": {
+                "apellido": {
+                    "type": "string",
+                    "example": "Perez"
                 },
-                "estudianteNombre": {
-                    "type": "string"
-                },
-                "fecha": {
-                    "$ref": "#/definitions/pgtype.Date"
-                },
-                "fechaConfirmacion": {
-                    "$ref": "#/definitions/pgtype.Timestamp"
-                },
-                "fechaSolicitud": {
-                    "$ref": "#/definitions/pgtype.Timestamp"
-                },
-                "horaFin": {
-                    "type": "string"
-                },
-                "horaInicio": {
-                    "type": "string"
-                },
-                "lugar": {
-                    "type": "string"
-                },
-                "materiaID": {
-                    "type": "integer"
+                "correo": {
+                    "type": "string",
+                    "example": "juan.perez@urosario.edu.co"
                 },
-                "materiaNombre": {
-                    "type": "string"
+                "nombre": {
+                    "type": "string",
+                    "example": "Juan"
                 },
-                "temasTratados": {
-                    "$ref": "#/definitions/pgtype.Text"
+                "programa_academico": {
+                    "type": "string",
+                    "example": "Ingeniería de Sistemas"
                 },
-                "tutorApellido": {
-                    "type": "string"
+                "semestre": {
+                    "type": "integer",
+                    "example": 5
                 },
-                "tutorID": {
+                "ti": {
+                    "type": "integer",
+                    "example": 1000123456
+                }
+            }
+        },
+        "handler.CreateEstudianteResponse": {
+            "type": "object",
+            "properties": {
+                "estudiante_id": {
                     "type": "integer"
+                }
+            }
+        },
+        "handler.CreateInscripcionRequest": {
+            "type": "object",
+            "properties": {
+                "estudiante_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "tutorNombre": {
-                    "type": "string"
+                "materia_id": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "tutoriaID": {
+                "periodo": {
+                    "description": "Defaults to the current academic period",
+                    "type": "string",
+                    "example": "2025-1"
+                }
+            }
+        },
+        "handler.CreateMateriaRequest": {
+            "type": "object"
+        },
+        "handler.CreateMateriaResponse": {
+            "type": "object",
+            "properties": {
+                "materia_id": {
                     "type": "integer"
                 }
             }
         },
-        "db.ListTutoriasByEstudianteRow": {
+        "handler.CreateReporteProgramacionRequest": {
             "type": "object",
             "properties": {
-                "asistenciaConfirmada": {
-                    "$ref": "#/definitions/pgtype.Bool"
-                },
-                "estado": {
-                    "type": "string"
+                "activo": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "estudianteID": {
-                    "type": "integer"
+                "adjuntar_pdf": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "fecha": {
-                    "$ref": "#/definitions/pgtype.Date"
+                "cron": {
+                    "type": "string",
+                    "example": "0 7 * * 1"
                 },
-                "fechaConfirmacion": {
-                    "$ref": "#/definitions/pgtype.Timestamp"
+                "destinatarios": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "decanatura@urosario.edu.co"
+                    ]
                 },
-                "fechaSolicitud": {
-                    "$ref": "#/definitions/pgtype.Timestamp"
+                "nombre": {
+                    "type": "string",
+                    "example": "Desempeño semanal"
                 },
-                "horaFin": {
-                    "type": "string"
+                "periodo": {
+                    "type": "string",
+                    "enum": [
+                        "dia_anterior",
+                        "semana_anterior",
+                        "mes_anterior"
+                    ],
+                    "example": "semana_anterior"
+                },
+                "tipo_reporte": {
+                    "type": "string",
+                    "enum": [
+                        "desempeno_tutores",
+                        "uso_materias",
+                        "asistencia_programas",
+                        "cancelaciones",
+                        "demanda_insatisfecha"
+                    ],
+                    "example": "desempeno_tutores"
+                }
+            }
+        },
+        "handler.CreateReporteRequest": {
+            "type": "object",
+            "properties": {
+                "periodo_fin": {
+                    "type": "string",
+                    "example": "2024-12-31"
                 },
-                "horaInicio": {
-                    "type": "string"
+                "periodo_inicio": {
+                    "type": "string",
+                    "example": "2024-01-01"
                 },
-                "lugar": {
-                    "type": "string"
+                "tipo_reporte": {
+                    "type": "string",
+                    "enum": [
+                        "desempeno_tutores",
+                        "uso_materias",
+                        "asistencia_programas",
+                        "cancelaciones",
+                        "demanda_insatisfecha"
+                    ],
+                    "example": "desempeno_tutores"
+                }
+            }
+        },
+        "handler.CreateReporteResponse": {
+            "type": "object",
+            "properties": {
+                "estado": {
+                    "type": "string",
+                    "example": "pendiente"
                 },
-                "materiaID": {
+                "reporte_id": {
                     "type": "integer"
+                }
+            }
+        },
+        "handler.CreateSolicitudTutorRequest": {
+            "type": "object",
+            "properties": {
+                "estudiante_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "materiaNombre": {
-                    "type": "string"
-                },
-                "temasTratados": {
-                    "$ref": "#/definitions/pgtype.Text"
+                "materias": {
+                    "description": "IDs of the materias the student wants to teach",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        7
+                    ]
                 },
-                "tutorApellido": {
-                    "type": "string"
+                "motivacion": {
+                    "type": "string",
+                    "example": "Me gustaría apoyar a mis compañeros en las materias de primer semestre"
+                }
+            }
+        },
+        "handler.CreateTutorMateriaRequest": {
+            "type": "object",
+            "properties": {
+                "activo": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "tutorID": {
-                    "type": "integer"
+                "fecha_asignacion": {
+                    "type": "string",
+                    "example": "2024-12-15"
                 },
-                "tutorNombre": {
-                    "type": "string"
+                "materia_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "tutoriaID": {
+                "tutor_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handler.CreateTutorMateriaResponse": {
+            "type": "object",
+            "properties": {
+                "asignacion_id": {
                     "type": "integer"
                 }
             }
         },
-        "db.ListTutoriasByTutorRow": {
+        "handler.CreateTutorPostulacionRequest": {
             "type": "object",
             "properties": {
-                "asistenciaConfirmada": {
-                    "$ref": "#/definitions/pgtype.Bool"
-                },
-                "estado": {
-                    "type": "string"
+                "comentario": {
+                    "type": "string",
+                    "example": "Fui monitor de esta materia el semestre pasado"
                 },
-                "estudianteApellido": {
-                    "type": "string"
+                "materia_id": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "estudianteID": {
+                "tutor_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handler.CreateTutorRequest": {
+            "type": "object",
+            "properties": {
+                "estudiante_id": {
+                    "description": "Changed to int32 to match SelectEstudianteById param type",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handler.CreateTutorResponse": {
+            "type": "object",
+            "properties": {
+                "tutor_id": {
+                    "description": "Changed to int32 to match sqlc generated type",
                     "type": "integer"
+                }
+            }
+        },
+        "handler.CreateTutoriaRequest": {
+            "type": "object",
+            "properties": {
+                "estado": {
+                    "type": "string",
+                    "example": "solicitada"
                 },
-                "estudianteNombre": {
-                    "type": "string"
+                "estudiante_id": {
+                    "type": "integer",
+                    "example": 1
                 },
                 "fecha": {
-                    "$ref": "#/definitions/pgtype.Date"
-                },
-                "fechaConfirmacion": {
-                    "$ref": "#/definitions/pgtype.Timestamp"
-                },
-                "fechaSolicitud": {
-                    "$ref": "#/definitions/pgtype.Timestamp"
+                    "type": "string",
+                    "example": "2024-12-15"
                 },
-                "horaFin": {
-                    "type": "string"
+                "hora_fin": {
+                    "type": "string",
+                    "example": "11:00"
                 },
-                "horaInicio": {
-                    "type": "string"
+                "hora_inicio": {
+                    "type": "string",
+                    "example": "10:00"
                 },
                 "lugar": {
-                    "type": "string"
-                },
-                "materiaID": {
-                    "type": "integer"
-                },
-                "materiaNombre": {
-                    "type": "string"
-                },
-                "temasTratados": {
-                    "$ref": "#/definitions/pgtype.Text"
+                    "type": "string",
+                    "example": "Biblioteca Central"
                 },
-                "tutorID": {
-                    "type": "integer"
+                "materia_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "tutoriaID": {
-                    "type": "integer"
+                "tutor_id": {
+                    "description": "Optional: if 0 or not provided, auto-assign qualified tutor",
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "db.Materia": {
+        "handler.CreateTutoriaResponse": {
             "type": "object",
             "properties": {
-                "codigo": {
-                    "type": "string"
-                },
-                "creditos": {
-                    "type": "integer"
-                },
-                "descripcion": {
-                    "$ref": "#/definitions/pgtype.Text"
-                },
-                "facultad": {
-                    "type": "string"
-                },
-                "materiaID": {
+                "tutoria_id": {
                     "type": "integer"
-                },
-                "nombre": {
-                    "type": "string"
                 }
             }
         },
-        "db.Reporte": {
+        "handler.CreateWebhookRequest": {
             "type": "object",
             "properties": {
-                "datos": {
+                "activo": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "eventos": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
-                    }
-                },
-                "fechaGeneracion": {
-                    "$ref": "#/definitions/pgtype.Timestamp"
+                        "type": "string"
+                    },
+                    "example": [
+                        "tutoria.confirmada",
+                        "tutoria.completada"
+                    ]
                 },
-                "generadoPor": {
-                    "type": "integer"
+                "secreto": {
+                    "type": "string",
+                    "example": "s3cr3t"
                 },
-                "periodoFin": {
-                    "$ref": "#/definitions/pgtype.Date"
+                "url": {
+                    "type": "string",
+                    "example": "https://sistemas.urosario.edu.co/hooks/tutorias"
+                }
+            }
+        },
+        "handler.DisponibilidadVentana": {
+            "type": "object",
+            "properties": {
+                "dia_semana": {
+                    "description": "1 = Monday, 7 = Sunday",
+                    "type": "integer",
+                    "example": 1
                 },
-                "periodoInicio": {
-                    "$ref": "#/definitions/pgtype.Date"
+                "disponibilidad_id": {
+                    "description": "Omitted for windows not stored yet",
+                    "type": "integer",
+                    "example": 12
                 },
-                "reporteID": {
-                    "type": "integer"
+                "hora_fin": {
+                    "type": "string",
+                    "example": "11:00"
                 },
-                "tipoReporte": {
-                    "type": "string"
+                "hora_inicio": {
+                    "type": "string",
+                    "example": "09:00"
                 }
             }
         },
-        "db.TutorMateria": {
+        "handler.ErrorResponse": {
             "type": "object",
             "properties": {
-                "activo": {
-                    "type": "boolean"
-                },
-                "asignacionID": {
-                    "type": "integer"
-                },
-                "fechaAsignacion": {
-                    "$ref": "#/definitions/pgtype.Date"
-                },
-                "materiaID": {
-                    "type": "integer"
-                },
-                "tutorID": {
-                    "type": "integer"
+                "error": {
+                    "type": "string",
+                    "example": "Descriptive error message"
                 }
             }
         },
-        "db.Tutore": {
+        "handler.GetTutorNameResponse": {
             "type": "object",
             "properties": {
                 "apellido": {
                     "type": "string"
                 },
-                "correo": {
-                    "type": "string"
-                },
-                "fechaRegistro": {
-                    "$ref": "#/definitions/pgtype.Timestamp"
-                },
                 "nombre": {
                     "type": "string"
-                },
-                "programaAcademico": {
-                    "$ref": "#/definitions/pgtype.Text"
-                },
-                "tutorID": {
-                    "type": "integer"
                 }
             }
         },
-        "db.Tutoria": {
+        "handler.ImportDisponibilidadResponse": {
             "type": "object",
             "properties": {
-                "asistenciaConfirmada": {
-                    "$ref": "#/definitions/pgtype.Bool"
-                },
-                "estado": {
-                    "type": "string"
-                },
-                "estudianteID": {
-                    "type": "integer"
-                },
-                "fecha": {
-                    "$ref": "#/definitions/pgtype.Date"
-                },
-                "fechaConfirmacion": {
-                    "$ref": "#/definitions/pgtype.Timestamp"
-                },
-                "fechaSolicitud": {
-                    "$ref": "#/definitions/pgtype.Timestamp"
-                },
-                "horaFin": {
-                    "type": "string"
-                },
-                "horaInicio": {
-                    "type": "string"
-                },
-                "lugar": {
-                    "type": "string"
+                "agregadas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.DisponibilidadVentana"
+                    }
                 },
-                "materiaID": {
-                    "type": "integer"
+                "bloques_ocupados": {
+                    "description": "Busy blocks found in the reference period",
+                    "type": "integer",
+                    "example": 14
                 },
-                "temasTratados": {
-                    "$ref": "#/definitions/pgtype.Text"
+                "conservadas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.DisponibilidadVentana"
+                    }
                 },
-                "tutorID": {
-                    "type": "integer"
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "tutoriaID": {
-                    "type": "integer"
+                "eliminadas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.DisponibilidadVentana"
+                    }
                 }
             }
         },
-        "db.Tutoriasactiva": {
+        "handler.ImportEstudianteFila": {
             "type": "object",
             "properties": {
-                "apellidoEstudiante": {
-                    "type": "string"
+                "correo": {
+                    "type": "string",
+                    "example": "juan.perez@urosario.edu.co"
                 },
-                "apellidoTutor": {
-                    "type": "string"
+                "errores": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "estado": {
-                    "type": "string"
-                },
-                "fecha": {
-                    "$ref": "#/definitions/pgtype.Date"
-                },
-                "horaFin": {
-                    "type": "string"
-                },
-                "horaInicio": {
-                    "type": "string"
-                },
-                "lugar": {
-                    "type": "string"
-                },
-                "materia": {
-                    "type": "string"
-                },
-                "nombreEstudiante": {
-                    "type": "string"
+                    "description": "creado, actualizado or rechazado",
+                    "type": "string",
+                    "example": "creado"
                 },
-                "nombreTutor": {
-                    "type": "string"
+                "estudiante_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "tutoriaID": {
-                    "type": "integer"
+                "fila": {
+                    "description": "Line number in the CSV file",
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "handler.CountTutorsWithMateriasResponse": {
+        "handler.ImportEstudiantesResponse": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
+                "actualizados": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "creados": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "filas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ImportEstudianteFila"
+                    }
+                },
+                "rechazados": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "handler.CreateDisponibilidadRequest": {
+        "handler.ImportInscripcionFila": {
             "type": "object",
             "properties": {
-                "dia_semana": {
-                    "description": "1 = Monday, 7 = Sunday",
-                    "type": "integer",
-                    "example": 1
+                "codigo": {
+                    "type": "string",
+                    "example": "MATH101"
                 },
-                "hora_fin": {
+                "correo": {
                     "type": "string",
-                    "example": "11:00"
+                    "example": "juan.perez@urosario.edu.co"
                 },
-                "hora_inicio": {
+                "errores": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "estado": {
+                    "description": "creada, existente or rechazada",
                     "type": "string",
-                    "example": "09:00"
+                    "example": "creada"
                 },
-                "tutor_id": {
+                "fila": {
+                    "description": "Line number in the CSV file",
+                    "type": "integer",
+                    "example": 2
+                },
+                "inscripcion_id": {
                     "type": "integer",
                     "example": 1
+                },
+                "periodo": {
+                    "type": "string",
+                    "example": "2025-1"
                 }
             }
         },
-        "handler.CreateDisponibilidadResponse": {
+        "handler.ImportInscripcionesResponse": {
             "type": "object",
             "properties": {
-                "disponibilidad_id": {
-                    "type": "integer"
+                "creadas": {
+                    "type": "integer",
+                    "example": 240
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "existentes": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "filas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ImportInscripcionFila"
+                    }
+                },
+                "rechazadas": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "handler.CreateEstudianteRequest": {
+        "handler.ImportMateriaFila": {
             "type": "object",
             "properties": {
-                "apellido": {
-                    "type": "string",
-                    "example": "Perez"
+                "cambios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.MateriaCambio"
+                    }
                 },
-                "correo": {
+                "codigo": {
                     "type": "string",
-                    "example": "juan.perez@urosario.edu.co"
+                    "example": "MATH101"
                 },
-                "nombre": {
-                    "type": "string",
-                    "example": "Juan"
+                "errores": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "programa_academico": {
+                "estado": {
+                    "description": "creada, actualizada, sin_cambios, desactivada or rechazada",
                     "type": "string",
-                    "example": "Ingeniería de Sistemas"
+                    "example": "actualizada"
                 },
-                "semestre": {
+                "fila": {
+                    "description": "CSV line or 1-based JSON index; omitted for desactivada",
                     "type": "integer",
-                    "example": 5
+                    "example": 2
                 },
-                "ti": {
+                "materia_id": {
                     "type": "integer",
-                    "example": 1000123456
+                    "example": 1
                 }
             }
         },
-        "handler.CreateEstudianteResponse": {
+        "handler.ImportMateriasResponse": {
             "type": "object",
             "properties": {
-                "estudiante_id": {
-                    "type": "integer"
+                "actualizadas": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "creadas": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "desactivadas": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "materias": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ImportMateriaFila"
+                    }
+                },
+                "rechazadas": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "sin_cambios": {
+                    "type": "integer",
+                    "example": 80
                 }
             }
         },
-        "handler.CreateMateriaRequest": {
-            "type": "object"
-        },
-        "handler.CreateMateriaResponse": {
+        "handler.LoginResponse": {
             "type": "object",
             "properties": {
-                "materia_id": {
-                    "type": "integer"
+                "data": {
+                    "description": "The actual user data"
+                },
+                "token": {
+                    "description": "Bearer token, only issued for admins",
+                    "type": "string"
+                },
+                "user_type": {
+                    "description": "\"estudiante\", \"tutor\", or \"admin\"",
+                    "type": "string"
                 }
             }
         },
-        "handler.CreateReporteRequest": {
-            "type": "object"
-        },
-        "handler.CreateReporteResponse": {
+        "handler.MateriaCambio": {
             "type": "object",
             "properties": {
-                "reporte_id": {
-                    "type": "integer"
+                "anterior": {
+                    "type": "string",
+                    "example": "3"
+                },
+                "campo": {
+                    "type": "string",
+                    "example": "creditos"
+                },
+                "nuevo": {
+                    "type": "string",
+                    "example": "4"
                 }
             }
         },
-        "handler.CreateTutorMateriaRequest": {
+        "handler.OnboardTutorBloque": {
             "type": "object",
             "properties": {
-                "activo": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "fecha_asignacion": {
-                    "type": "string",
-                    "example": "2024-12-15"
+                "dias": {
+                    "description": "1 = Monday, 7 = Sunday",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3,
+                        4,
+                        5
+                    ]
                 },
-                "materia_id": {
-                    "type": "integer",
-                    "example": 1
+                "hora_fin": {
+                    "type": "string",
+                    "example": "10:00"
                 },
-                "tutor_id": {
-                    "type": "integer",
-                    "example": 1
+                "hora_inicio": {
+                    "type": "string",
+                    "example": "08:00"
                 }
             }
         },
-        "handler.CreateTutorMateriaResponse": {
+        "handler.OnboardTutorMaterias": {
             "type": "object",
             "properties": {
-                "asignacion_id": {
-                    "type": "integer"
+                "codigos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "MATH101"
+                    ]
+                },
+                "facultad": {
+                    "description": "Every materia of a facultad, or \"*\" for the whole catalog",
+                    "type": "string",
+                    "example": "Ingeniería"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        18,
+                        19
+                    ]
                 }
             }
         },
-        "handler.CreateTutorRequest": {
+        "handler.OnboardTutorRequest": {
             "type": "object",
             "properties": {
+                "disponibilidad": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.OnboardTutorBloque"
+                    }
+                },
                 "estudiante_id": {
-                    "description": "Changed to int32 to match SelectEstudianteById param type",
                     "type": "integer",
-                    "example": 1
+                    "example": 6
+                },
+                "materias": {
+                    "$ref": "#/definitions/handler.OnboardTutorMaterias"
                 }
             }
         },
-        "handler.CreateTutorResponse": {
+        "handler.OnboardTutorResponse": {
             "type": "object",
             "properties": {
+                "disponibilidad_creada": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "disponibilidad_existente": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "materias_asignadas": {
+                    "description": "New or reactivated assignments",
+                    "type": "integer",
+                    "example": 75
+                },
+                "materias_existentes": {
+                    "description": "Assignments that were already active",
+                    "type": "integer",
+                    "example": 0
+                },
+                "materias_omitidas": {
+                    "description": "Materias whose eligibility rules the student does not meet",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "materia 12: semestre 3 is below the minimum of 5"
+                    ]
+                },
+                "tutor_creado": {
+                    "type": "boolean",
+                    "example": true
+                },
                 "tutor_id": {
-                    "description": "Changed to int32 to match sqlc generated type",
-                    "type": "integer"
+                    "type": "integer",
+                    "example": 6
                 }
             }
         },
-        "handler.CreateTutoriaRequest": {
+        "handler.PapeleraItem": {
             "type": "object",
             "properties": {
-                "estado": {
+                "detalle": {
+                    "description": "Correo of estudiantes and tutores, codigo of materias",
                     "type": "string",
-                    "example": "solicitada"
+                    "example": "juan.perez@urosario.edu.co"
                 },
-                "estudiante_id": {
+                "fecha_eliminacion": {
+                    "type": "string",
+                    "example": "2025-03-01T10:00:00"
+                },
+                "id": {
                     "type": "integer",
-                    "example": 1
+                    "example": 12
                 },
-                "fecha": {
+                "nombre": {
                     "type": "string",
-                    "example": "2024-12-15"
+                    "example": "Juan Pérez"
                 },
-                "hora_fin": {
+                "purgable_desde": {
+                    "description": "From when a purge deletes it permanently, unless it has tutorias",
                     "type": "string",
-                    "example": "11:00"
+                    "example": "2025-03-31T10:00:00"
                 },
-                "hora_inicio": {
+                "tipo": {
+                    "description": "estudiante, tutor or materia",
                     "type": "string",
-                    "example": "10:00"
-                },
-                "lugar": {
+                    "example": "estudiante"
+                }
+            }
+        },
+        "handler.PurgePapeleraResponse": {
+            "type": "object",
+            "properties": {
+                "antes": {
+                    "description": "Records deleted before this time were purged, unless they have tutorias or failed requests",
                     "type": "string",
-                    "example": "Biblioteca Central"
+                    "example": "2025-03-01T10:00:00Z"
                 },
-                "materia_id": {
+                "estudiantes": {
                     "type": "integer",
-                    "example": 1
+                    "example": 3
                 },
-                "tutor_id": {
-                    "description": "Optional: if 0 or not provided, auto-assign qualified tutor",
+                "materias": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "tutores": {
                     "type": "integer",
                     "example": 1
                 }
             }
         },
-        "handler.CreateTutoriaResponse": {
-            "type": "object",
-            "properties": {
-                "tutoria_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "handler.ErrorResponse": {
+        "handler.RejectSolicitudTutorRequest": {
             "type": "object",
             "properties": {
-                "error": {
+                "motivo": {
                     "type": "string",
-                    "example": "Descriptive error message"
-                }
-            }
-        },
-        "handler.GetTutorNameResponse": {
-            "type": "object",
-            "properties": {
-                "apellido": {
-                    "type": "string"
-                },
-                "nombre": {
-                    "type": "string"
+                    "example": "Por ahora no hay cupos para nuevos tutores"
                 }
             }
         },
-        "handler.LoginResponse": {
+        "handler.RejectTutorPostulacionRequest": {
             "type": "object",
             "properties": {
-                "data": {
-                    "description": "The actual user data"
-                },
-                "user_type": {
-                    "description": "\"estudiante\", \"tutor\", or \"admin\"",
-                    "type": "string"
+                "motivo": {
+                    "type": "string",
+                    "example": "Se requiere haber aprobado la materia con nota superior a 4.0"
                 }
             }
         },
@@ -2762,6 +8891,57 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.TutorHorasResponse": {
+            "type": "object",
+            "properties": {
+                "desde": {
+                    "type": "string",
+                    "example": "2025-03-01"
+                },
+                "hasta": {
+                    "type": "string",
+                    "example": "2025-03-31"
+                },
+                "horas_completadas": {
+                    "type": "number",
+                    "example": 12.5
+                },
+                "horas_confirmadas": {
+                    "type": "number",
+                    "example": 4
+                },
+                "horas_pendientes": {
+                    "description": "Requested but not yet confirmed",
+                    "type": "number",
+                    "example": 2
+                },
+                "limite_horas": {
+                    "description": "Weekly or monthly limit, matching the periodo",
+                    "type": "integer",
+                    "example": 32
+                },
+                "periodo": {
+                    "type": "string",
+                    "example": "2025-03"
+                },
+                "tutor_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "tutorias_completadas": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "tutorias_confirmadas": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "tutorias_pendientes": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
         "handler.UnifiedLoginRequest": {
             "type": "object",
             "properties": {
@@ -2781,6 +8961,50 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.UpdateAdminPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "password_actual": {
+                    "type": "string",
+                    "example": "actual"
+                },
+                "password_nueva": {
+                    "type": "string",
+                    "example": "nueva-contraseña"
+                }
+            }
+        },
+        "handler.UpdateAdminRequest": {
+            "type": "object",
+            "properties": {
+                "activo": {
+                    "description": "Inactive admins cannot log in; unchanged if omitted",
+                    "type": "boolean",
+                    "example": false
+                },
+                "apellido": {
+                    "type": "string",
+                    "example": "Gómez"
+                },
+                "correo": {
+                    "type": "string",
+                    "example": "ana.gomez@urosario.edu.co"
+                },
+                "nombre": {
+                    "type": "string",
+                    "example": "Ana"
+                },
+                "password": {
+                    "description": "Resets the password; unchanged if omitted",
+                    "type": "string",
+                    "example": "nueva-contraseña"
+                },
+                "rol": {
+                    "type": "string",
+                    "example": "superadmin"
+                }
+            }
+        },
         "handler.UpdateDisponibilidadRequest": {
             "type": "object",
             "properties": {
@@ -2830,8 +9054,96 @@ const docTemplate = `{
         "handler.UpdateMateriaRequest": {
             "type": "object"
         },
-        "handler.UpdateReporteRequest": {
-            "type": "object"
+        "handler.UpdateMateriaRequisitosRequest": {
+            "type": "object",
+            "properties": {
+                "programas": {
+                    "description": "Empty: any programa_academico",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Matemáticas",
+                        "Ingeniería"
+                    ]
+                },
+                "requiere_aprobacion": {
+                    "description": "Defaults to true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "semestre_minimo": {
+                    "description": "Absent: any semestre",
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "handler.UpdateReporteProgramacionRequest": {
+            "type": "object",
+            "properties": {
+                "activo": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "adjuntar_pdf": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "cron": {
+                    "type": "string",
+                    "example": "0 7 * * 1"
+                },
+                "destinatarios": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "decanatura@urosario.edu.co"
+                    ]
+                },
+                "nombre": {
+                    "type": "string",
+                    "example": "Desempeño semanal"
+                },
+                "periodo": {
+                    "type": "string",
+                    "enum": [
+                        "dia_anterior",
+                        "semana_anterior",
+                        "mes_anterior"
+                    ],
+                    "example": "semana_anterior"
+                },
+                "tipo_reporte": {
+                    "type": "string",
+                    "enum": [
+                        "desempeno_tutores",
+                        "uso_materias",
+                        "asistencia_programas",
+                        "cancelaciones",
+                        "demanda_insatisfecha"
+                    ],
+                    "example": "desempeno_tutores"
+                }
+            }
+        },
+        "handler.UpdateTutorLimitesRequest": {
+            "type": "object",
+            "properties": {
+                "horas_mes": {
+                    "description": "null or absent removes the monthly limit",
+                    "type": "integer",
+                    "example": 32
+                },
+                "horas_semana": {
+                    "description": "null or absent removes the weekly limit",
+                    "type": "integer",
+                    "example": 10
+                }
+            }
         },
         "handler.UpdateTutorMateriaRequest": {
             "type": "object",
@@ -2870,6 +9182,10 @@ const docTemplate = `{
                     "type": "boolean",
                     "example": true
                 },
+                "estado": {
+                    "type": "string",
+                    "example": "confirmada"
+                },
                 "fecha": {
                     "type": "string",
                     "example": "2024-12-15"
@@ -2892,6 +9208,71 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.UpdateWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "activo": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "eventos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "tutoria.*"
+                    ]
+                },
+                "secreto": {
+                    "type": "string",
+                    "example": "n3w-s3cr3t"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://sistemas.urosario.edu.co/hooks/tutorias"
+                }
+            }
+        },
+        "handler.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "activo": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "creado_por": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "eventos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "tutoria.confirmada",
+                        "tutoria.completada"
+                    ]
+                },
+                "fecha_creacion": {
+                    "type": "string",
+                    "example": "2025-03-01T10:00:00Z"
+                },
+                "secreto": {
+                    "type": "string",
+                    "example": "s3cr3t"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://sistemas.urosario.edu.co/hooks/tutorias"
+                },
+                "webhook_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
         "pgtype.Bool": {
             "type": "object",
             "properties": {
@@ -2967,6 +9348,14 @@ const docTemplate = `{
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "AdminBearer": {
+            "description": "Admin token from POST /v1/login/admin, sent as \"Bearer \u003ctoken\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -3,8 +3,10 @@ module github.com/matwate/proyecto-datos
 go 1.24.3
 
 require (
+	github.com/go-pdf/fpdf v0.9.0
 	github.com/jackc/pgx/v5 v5.7.4
 	github.com/joho/godotenv v1.5.1
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/swaggo/swag v1.16.4
 	golang.org/x/crypto v0.38.0
 )
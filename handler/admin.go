@@ -0,0 +1,487 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/matwate/proyecto-datos/db"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Roles of an admin, as stored in ADMINS.rol. Only superadmins can manage admin accounts.
+const (
+	RolAdmin      = "admin"
+	RolSuperadmin = "superadmin"
+)
+
+// adminRoles lists every rol an admin can have.
+var adminRoles = []string{RolAdmin, RolSuperadmin}
+
+// Admin passwords must be long enough to resist guessing; bcrypt ignores anything past 72 bytes.
+const (
+	adminPasswordMinLength = 8
+	adminPasswordMaxLength = 72
+)
+
+// errUltimoSuperadmin is returned when a change would leave no active superadmin.
+var errUltimoSuperadmin = errors.New("cannot remove the last active superadmin")
+
+// AdminResponse is an admin account, without its password hash.
+type AdminResponse struct {
+	AdminID       int32              `json:"admin_id" example:"1"`
+	Nombre        string             `json:"nombre" example:"Ana"`
+	Apellido      string             `json:"apellido" example:"Gómez"`
+	Correo        string             `json:"correo" example:"ana.gomez@urosario.edu.co"`
+	Rol           string             `json:"rol" example:"admin"`
+	Activo        pgtype.Bool        `json:"activo" swaggertype:"boolean" example:"true"`
+	FechaRegistro pgtype.Timestamptz `json:"fecha_registro" swaggertype:"string" example:"2025-03-01T10:00:00Z"`
+}
+
+// CreateAdminRequest is the body of POST /v1/admins.
+type CreateAdminRequest struct {
+	Nombre   string `json:"nombre" example:"Ana"`
+	Apellido string `json:"apellido" example:"Gómez"`
+	Correo   string `json:"correo" example:"ana.gomez@urosario.edu.co"`
+	Password string `json:"password" example:"cambiar-en-el-primer-ingreso"`
+	Rol      string `json:"rol" example:"admin"` // admin (default) or superadmin
+}
+
+// UpdateAdminRequest is the body of PUT /v1/admins/{id}.
+type UpdateAdminRequest struct {
+	Nombre   string `json:"nombre" example:"Ana"`
+	Apellido string `json:"apellido" example:"Gómez"`
+	Correo   string `json:"correo" example:"ana.gomez@urosario.edu.co"`
+	Rol      string `json:"rol" example:"superadmin"`
+	Activo   *bool  `json:"activo,omitempty" example:"false"`              // Inactive admins cannot log in; unchanged if omitted
+	Password string `json:"password,omitempty" example:"nueva-contraseña"` // Resets the password; unchanged if omitted
+}
+
+// UpdateAdminPasswordRequest is the body of PUT /v1/admins/{id}/password.
+type UpdateAdminPasswordRequest struct {
+	PasswordActual string `json:"password_actual" example:"actual"`
+	PasswordNueva  string `json:"password_nueva" example:"nueva-contraseña"`
+}
+
+// newAdminResponse removes the password hash from an admin.
+func newAdminResponse(admin db.Admin) AdminResponse {
+	return AdminResponse{
+		AdminID:       admin.AdminID,
+		Nombre:        admin.Nombre,
+		Apellido:      admin.Apellido,
+		Correo:        admin.Correo,
+		Rol:           admin.Rol,
+		Activo:        admin.Activo,
+		FechaRegistro: admin.FechaRegistro,
+	}
+}
+
+// esSuperadminActivo reports whether an admin counts towards the superadmins that must remain.
+func esSuperadminActivo(rol string, activo pgtype.Bool) bool {
+	return rol == RolSuperadmin && (!activo.Valid || activo.Bool)
+}
+
+// hashAdminPassword validates a new admin password and returns its bcrypt hash.
+func hashAdminPassword(password string) (string, error) {
+	if len(password) < adminPasswordMinLength || len(password) > adminPasswordMaxLength {
+		return "", errors.New("password must be between " + strconv.Itoa(adminPasswordMinLength) +
+			" and " + strconv.Itoa(adminPasswordMaxLength) + " bytes")
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// adminCorreoEnUso reports whether another admin than adminID already uses correo.
+func adminCorreoEnUso(r *http.Request, queries *db.Queries, correo string, adminID int32) (bool, error) {
+	existente, err := queries.SelectAdminByCorreo(r.Context(), correo)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return false, nil
+		}
+		return false, err
+	}
+	return existente.AdminID != adminID, nil
+}
+
+// ListAdminsEndpoint handles GET /v1/admins using Go 1.22 routing
+// @Summary      List Admins
+// @Description  Retrieves every admin account, ordered by apellido and nombre. Only superadmins can manage admins.
+// @Tags         Admins
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     AdminBearer
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
+// @Success      200 {array} AdminResponse "Admins"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      403 {object} ErrorResponse "Superadmin rol required"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve admins"
+// @Router       /v1/admins [get]
+func ListAdminsEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		admins, err := queries.ListAdmins(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve admins: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		response := make([]AdminResponse, len(admins))
+		for i, admin := range admins {
+			response[i] = AdminResponse(admin)
+		}
+
+		writeList(w, r, "admins", response)
+	}
+}
+
+// CreateAdminEndpoint handles POST /v1/admins using Go 1.22 routing
+// @Summary      Create Admin
+// @Description  Creates an active admin account. The password is hashed with bcrypt and never returned.
+// @Tags         Admins
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        admin body CreateAdminRequest true "Admin data"
+// @Success      201 {object} AdminResponse "Created admin"
+// @Failure      400 {object} ErrorResponse "Invalid request body"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      403 {object} ErrorResponse "Superadmin rol required"
+// @Failure      409 {object} ErrorResponse "Another admin already uses the correo"
+// @Failure      500 {object} ErrorResponse "Failed to create admin"
+// @Router       /v1/admins [post]
+func CreateAdminEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CreateAdminRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		req.Correo = strings.TrimSpace(req.Correo)
+		if req.Nombre == "" || req.Apellido == "" || req.Correo == "" {
+			http.Error(w, "Nombre, apellido and correo are required", http.StatusBadRequest)
+			return
+		}
+		if req.Rol == "" {
+			req.Rol = RolAdmin
+		}
+		if !slices.Contains(adminRoles, req.Rol) {
+			http.Error(w, "Invalid rol (use admin or superadmin)", http.StatusBadRequest)
+			return
+		}
+
+		passwordHash, err := hashAdminPassword(req.Password)
+		if err != nil {
+			http.Error(w, "Invalid password: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		enUso, err := adminCorreoEnUso(r, queries, req.Correo, 0)
+		if err != nil {
+			http.Error(w, "Failed to check correo: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if enUso {
+			http.Error(w, "Another admin already uses correo "+req.Correo, http.StatusConflict)
+			return
+		}
+
+		admin, err := queries.CreateAdmin(r.Context(), db.CreateAdminParams{
+			Nombre:       req.Nombre,
+			Apellido:     req.Apellido,
+			Correo:       req.Correo,
+			PasswordHash: passwordHash,
+			Rol:          req.Rol,
+			Activo:       pgtype.Bool{Bool: true, Valid: true},
+		})
+		if err != nil {
+			http.Error(w, "Failed to create admin: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(AdminResponse(admin))
+	}
+}
+
+// GetAdminEndpoint handles GET /v1/admins/{id} using Go 1.22 routing
+// @Summary      Get Admin
+// @Description  Retrieves an admin account.
+// @Tags         Admins
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Admin ID"
+// @Success      200 {object} AdminResponse "Admin"
+// @Failure      400 {object} ErrorResponse "Invalid admin ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      403 {object} ErrorResponse "Superadmin rol required"
+// @Failure      404 {object} ErrorResponse "Admin not found"
+// @Failure      500 {object} ErrorResponse "Failed to get admin"
+// @Router       /v1/admins/{id} [get]
+func GetAdminEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid admin ID", http.StatusBadRequest)
+			return
+		}
+
+		admin, err := queries.SelectAdminById(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Admin not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get admin: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(newAdminResponse(admin))
+	}
+}
+
+// UpdateAdminEndpoint handles PUT /v1/admins/{id} using Go 1.22 routing
+// @Summary      Update Admin
+// @Description  Updates an admin account. Setting activo to false deactivates it: its tokens stop working and it can no
+// @Description  longer log in. A password resets the current one. The last active superadmin cannot be demoted or
+// @Description  deactivated.
+// @Tags         Admins
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Admin ID"
+// @Param        admin body UpdateAdminRequest true "Admin data"
+// @Success      200 {object} AdminResponse "Updated admin"
+// @Failure      400 {object} ErrorResponse "Invalid request body"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      403 {object} ErrorResponse "Superadmin rol required"
+// @Failure      404 {object} ErrorResponse "Admin not found"
+// @Failure      409 {object} ErrorResponse "Correo in use, or the admin is the last active superadmin"
+// @Failure      500 {object} ErrorResponse "Failed to update admin"
+// @Router       /v1/admins/{id} [put]
+func UpdateAdminEndpoint(pool *pgxpool.Pool, queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid admin ID", http.StatusBadRequest)
+			return
+		}
+
+		var req UpdateAdminRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		req.Correo = strings.TrimSpace(req.Correo)
+		if req.Nombre == "" || req.Apellido == "" || req.Correo == "" {
+			http.Error(w, "Nombre, apellido and correo are required", http.StatusBadRequest)
+			return
+		}
+		if !slices.Contains(adminRoles, req.Rol) {
+			http.Error(w, "Invalid rol (use admin or superadmin)", http.StatusBadRequest)
+			return
+		}
+
+		var passwordHash string
+		if req.Password != "" {
+			if passwordHash, err = hashAdminPassword(req.Password); err != nil {
+				http.Error(w, "Invalid password: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		enUso, err := adminCorreoEnUso(r, queries, req.Correo, int32(id))
+		if err != nil {
+			http.Error(w, "Failed to check correo: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if enUso {
+			http.Error(w, "Another admin already uses correo "+req.Correo, http.StatusConflict)
+			return
+		}
+
+		var admin db.Admin
+		err = withTx(r.Context(), pool, queries, func(q *db.Queries) error {
+			superadmins, err := q.LockSuperadminsActivos(r.Context())
+			if err != nil {
+				return err
+			}
+
+			actual, err := q.SelectAdminById(r.Context(), int32(id))
+			if err != nil {
+				return err
+			}
+
+			params := db.UpdateAdminParams{
+				AdminID:      actual.AdminID,
+				Nombre:       req.Nombre,
+				Apellido:     req.Apellido,
+				Correo:       req.Correo,
+				PasswordHash: actual.PasswordHash,
+				Rol:          req.Rol,
+				Activo:       actual.Activo,
+			}
+			if passwordHash != "" {
+				params.PasswordHash = passwordHash
+			}
+			if req.Activo != nil {
+				params.Activo = pgtype.Bool{Bool: *req.Activo, Valid: true}
+			}
+
+			if esSuperadminActivo(actual.Rol, actual.Activo) && !esSuperadminActivo(params.Rol, params.Activo) &&
+				len(superadmins) <= 1 {
+				return errUltimoSuperadmin
+			}
+
+			admin, err = q.UpdateAdmin(r.Context(), params)
+			return err
+		})
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Admin not found", http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, errUltimoSuperadmin) {
+				http.Error(w, "Admin is the last active superadmin and must keep that rol", http.StatusConflict)
+				return
+			}
+			http.Error(w, "Failed to update admin: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(newAdminResponse(admin))
+	}
+}
+
+// DeleteAdminEndpoint handles DELETE /v1/admins/{id} using Go 1.22 routing
+// @Summary      Delete Admin
+// @Description  Deletes an admin account permanently. Reports and webhooks it created are kept. The last active
+// @Description  superadmin and admins that still own reporte programaciones cannot be deleted; deactivate them instead.
+// @Tags         Admins
+// @Security     AdminBearer
+// @Param        id path int true "Admin ID"
+// @Success      204 "Admin deleted"
+// @Failure      400 {object} ErrorResponse "Invalid admin ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      403 {object} ErrorResponse "Superadmin rol required"
+// @Failure      404 {object} ErrorResponse "Admin not found"
+// @Failure      409 {object} ErrorResponse "Admin is the last active superadmin or owns reporte programaciones"
+// @Failure      500 {object} ErrorResponse "Failed to delete admin"
+// @Router       /v1/admins/{id} [delete]
+func DeleteAdminEndpoint(pool *pgxpool.Pool, queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid admin ID", http.StatusBadRequest)
+			return
+		}
+
+		err = withTx(r.Context(), pool, queries, func(q *db.Queries) error {
+			superadmins, err := q.LockSuperadminsActivos(r.Context())
+			if err != nil {
+				return err
+			}
+
+			actual, err := q.SelectAdminById(r.Context(), int32(id))
+			if err != nil {
+				return err
+			}
+			if esSuperadminActivo(actual.Rol, actual.Activo) && len(superadmins) <= 1 {
+				return errUltimoSuperadmin
+			}
+
+			return q.DeleteAdmin(r.Context(), actual.AdminID)
+		})
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Admin not found", http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, errUltimoSuperadmin) {
+				http.Error(w, "Admin is the last active superadmin and cannot be deleted", http.StatusConflict)
+				return
+			}
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				http.Error(w, "Admin still owns reporte programaciones; deactivate it instead", http.StatusConflict)
+				return
+			}
+			http.Error(w, "Failed to delete admin: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// UpdateAdminPasswordEndpoint handles PUT /v1/admins/{id}/password using Go 1.22 routing
+// @Summary      Change Admin Password
+// @Description  Changes the password of the authenticated admin, who must provide the current one. Superadmins reset
+// @Description  the password of other admins through PUT /v1/admins/{id}.
+// @Tags         Admins
+// @Accept       json
+// @Security     AdminBearer
+// @Param        id path int true "Admin ID, which must be the authenticated admin"
+// @Param        password body UpdateAdminPasswordRequest true "Current and new password"
+// @Success      204 "Password changed"
+// @Failure      400 {object} ErrorResponse "Invalid request body or wrong current password"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      403 {object} ErrorResponse "Admins can only change their own password"
+// @Failure      500 {object} ErrorResponse "Failed to change password"
+// @Router       /v1/admins/{id}/password [put]
+func UpdateAdminPasswordEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid admin ID", http.StatusBadRequest)
+			return
+		}
+
+		admin, ok := adminFromContext(r.Context())
+		if !ok || admin.AdminID != int32(id) {
+			http.Error(w, "Admins can only change their own password", http.StatusForbidden)
+			return
+		}
+
+		var req UpdateAdminPasswordRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := bcrypt.CompareHashAndPassword([]byte(admin.PasswordHash), []byte(req.PasswordActual)); err != nil {
+			http.Error(w, "Current password is incorrect", http.StatusBadRequest)
+			return
+		}
+
+		passwordHash, err := hashAdminPassword(req.PasswordNueva)
+		if err != nil {
+			http.Error(w, "Invalid password: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_, err = queries.UpdateAdminPassword(r.Context(), db.UpdateAdminPasswordParams{
+			AdminID:      admin.AdminID,
+			PasswordHash: passwordHash,
+		})
+		if err != nil {
+			http.Error(w, "Failed to change password: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
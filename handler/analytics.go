@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// Period of the analytics endpoints: the last defaultAnalyticsDias days unless ?desde= and
+// ?hasta= say otherwise, and never longer than maxAnalyticsDias days.
+const (
+	defaultAnalyticsDias = 30
+	maxAnalyticsDias     = 731
+)
+
+// analyticsAgrupaciones maps ?agrupacion= to the date_trunc field AnalyticsSesiones takes.
+var analyticsAgrupaciones = map[string]string{
+	"dia":    "day",
+	"semana": "week",
+}
+
+// parseAnalyticsPeriodo reads the ?desde= and ?hasta= dates (YYYY-MM-DD) of an analytics request.
+func parseAnalyticsPeriodo(r *http.Request) (desde, hasta pgtype.Date, err error) {
+	hoy := time.Now()
+	hasta = pgtype.Date{Time: time.Date(hoy.Year(), hoy.Month(), hoy.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+	if hastaStr := r.URL.Query().Get("hasta"); hastaStr != "" {
+		if hasta, err = parseDateString(hastaStr); err != nil {
+			return desde, hasta, fmt.Errorf("invalid hasta format (use YYYY-MM-DD)")
+		}
+	}
+
+	desde = pgtype.Date{Time: hasta.Time.AddDate(0, 0, -(defaultAnalyticsDias - 1)), Valid: true}
+	if desdeStr := r.URL.Query().Get("desde"); desdeStr != "" {
+		if desde, err = parseDateString(desdeStr); err != nil {
+			return desde, hasta, fmt.Errorf("invalid desde format (use YYYY-MM-DD)")
+		}
+	}
+
+	if hasta.Time.Before(desde.Time) {
+		return desde, hasta, fmt.Errorf("hasta must not be before desde")
+	}
+	if hasta.Time.Sub(desde.Time) >= maxAnalyticsDias*24*time.Hour {
+		return desde, hasta, fmt.Errorf("the period must not be longer than %d days", maxAnalyticsDias)
+	}
+	return desde, hasta, nil
+}
+
+// AnalyticsSesionesEndpoint handles GET /v1/analytics/sesiones using Go 1.22 routing
+// @Summary      Tutorias per Day or Week
+// @Description  Counts the tutorias dated within the period per day or per week (starting on Monday), by estado.
+// @Description  Periods without tutorias are included with zero counts.
+// @Tags         Analytics
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     AdminBearer
+// @Param        desde query string false "Start of the period (YYYY-MM-DD); defaults to 29 days before hasta"
+// @Param        hasta query string false "End of the period (YYYY-MM-DD); defaults to today"
+// @Param        agrupacion query string false "Length of each period" Enums(dia, semana) default(dia)
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
+// @Success      200 {array} db.AnalyticsSesionesRow "Tutorias per period"
+// @Failure      400 {object} ErrorResponse "Invalid period or agrupacion"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to compute sesiones"
+// @Router       /v1/analytics/sesiones [get]
+func AnalyticsSesionesEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		desde, hasta, err := parseAnalyticsPeriodo(r)
+		if err != nil {
+			http.Error(w, "Invalid period: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		agrupacion := r.URL.Query().Get("agrupacion")
+		if agrupacion == "" {
+			agrupacion = "dia"
+		}
+		campo, ok := analyticsAgrupaciones[agrupacion]
+		if !ok {
+			http.Error(w, "Invalid agrupacion, must be dia or semana", http.StatusBadRequest)
+			return
+		}
+
+		sesiones, err := queries.AnalyticsSesiones(r.Context(), db.AnalyticsSesionesParams{
+			Agrupacion: campo,
+			Desde:      desde,
+			Hasta:      hasta,
+		})
+		if err != nil {
+			http.Error(w, "Failed to compute sesiones: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if sesiones == nil {
+			sesiones = []db.AnalyticsSesionesRow{}
+		}
+
+		writeList(w, r, "sesiones", sesiones)
+	}
+}
+
+// AnalyticsDemandaOfertaEndpoint handles GET /v1/analytics/demanda-oferta using Go 1.22 routing
+// @Summary      Demand versus Supply per Materia
+// @Description  Compares, for every active materia, the tutorias requested within the period and their hours with the
+// @Description  number of active tutors qualified to teach it and the hours they are available on the days of the period,
+// @Description  according to DISPONIBILIDAD. A tutor's availability counts for every materia they teach.
+// @Description  Materias whose demand most exceeds their supply come first.
+// @Tags         Analytics
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     AdminBearer
+// @Param        desde query string false "Start of the period (YYYY-MM-DD); defaults to 29 days before hasta"
+// @Param        hasta query string false "End of the period (YYYY-MM-DD); defaults to today"
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
+// @Success      200 {array} db.AnalyticsDemandaOfertaRow "Demand and supply per materia"
+// @Failure      400 {object} ErrorResponse "Invalid period"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to compute demanda-oferta"
+// @Router       /v1/analytics/demanda-oferta [get]
+func AnalyticsDemandaOfertaEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		desde, hasta, err := parseAnalyticsPeriodo(r)
+		if err != nil {
+			http.Error(w, "Invalid period: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		materias, err := queries.AnalyticsDemandaOferta(r.Context(), db.AnalyticsDemandaOfertaParams{Desde: desde, Hasta: hasta})
+		if err != nil {
+			http.Error(w, "Failed to compute demanda-oferta: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if materias == nil {
+			materias = []db.AnalyticsDemandaOfertaRow{}
+		}
+
+		writeList(w, r, "demanda-oferta", materias)
+	}
+}
+
+// AnalyticsHorasPicoEndpoint handles GET /v1/analytics/horas-pico using Go 1.22 routing
+// @Summary      Peak Hours Heatmap
+// @Description  Counts the non-cancelled tutorias dated within the period per day of the week (1 = Monday, 7 = Sunday)
+// @Description  and starting hour (0-23). Every one of the 7 x 24 cells is returned, in day then hour order.
+// @Tags         Analytics
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     AdminBearer
+// @Param        desde query string false "Start of the period (YYYY-MM-DD); defaults to 29 days before hasta"
+// @Param        hasta query string false "End of the period (YYYY-MM-DD); defaults to today"
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
+// @Success      200 {array} db.AnalyticsHorasPicoRow "Tutorias per day of the week and hour"
+// @Failure      400 {object} ErrorResponse "Invalid period"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to compute horas-pico"
+// @Router       /v1/analytics/horas-pico [get]
+func AnalyticsHorasPicoEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		desde, hasta, err := parseAnalyticsPeriodo(r)
+		if err != nil {
+			http.Error(w, "Invalid period: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		celdas, err := queries.AnalyticsHorasPico(r.Context(), db.AnalyticsHorasPicoParams{Desde: desde, Hasta: hasta})
+		if err != nil {
+			http.Error(w, "Failed to compute horas-pico: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeList(w, r, "horas-pico", celdas)
+	}
+}
+
+// parseAnalyticsMateria reads the optional ?materia_id= filter.
+func parseAnalyticsMateria(r *http.Request) (pgtype.Int4, error) {
+	materiaStr := r.URL.Query().Get("materia_id")
+	if materiaStr == "" {
+		return pgtype.Int4{}, nil
+	}
+	id, err := strconv.ParseInt(materiaStr, 10, 32)
+	if err != nil {
+		return pgtype.Int4{}, err
+	}
+	return pgtype.Int4{Int32: int32(id), Valid: true}, nil
+}
+
+// AnalyticsAnticipacionEndpoint handles GET /v1/analytics/anticipacion using Go 1.22 routing
+// @Summary      Booking Lead Time
+// @Description  Median, quartiles and average of the hours between the request of a tutoria (fecha_solicitud) and its start,
+// @Description  for the tutorias dated within the period.
+// @Tags         Analytics
+// @Produce      json
+// @Security     AdminBearer
+// @Param        desde query string false "Start of the period (YYYY-MM-DD); defaults to 29 days before hasta"
+// @Param        hasta query string false "End of the period (YYYY-MM-DD); defaults to today"
+// @Param        materia_id query int false "Only tutorias of this materia"
+// @Success      200 {object} db.AnalyticsAnticipacionRow "Lead time statistics, in hours"
+// @Failure      400 {object} ErrorResponse "Invalid period or materia ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to compute anticipacion"
+// @Router       /v1/analytics/anticipacion [get]
+func AnalyticsAnticipacionEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		desde, hasta, err := parseAnalyticsPeriodo(r)
+		if err != nil {
+			http.Error(w, "Invalid period: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		materiaID, err := parseAnalyticsMateria(r)
+		if err != nil {
+			http.Error(w, "Invalid materia ID", http.StatusBadRequest)
+			return
+		}
+
+		estadisticas, err := queries.AnalyticsAnticipacion(r.Context(), db.AnalyticsAnticipacionParams{
+			Desde:     desde,
+			Hasta:     hasta,
+			MateriaID: materiaID,
+		})
+		if err != nil {
+			http.Error(w, "Failed to compute anticipacion: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(estadisticas)
+	}
+}
+
+// AnalyticsLatenciaConfirmacionEndpoint handles GET /v1/analytics/latencia-confirmacion using Go 1.22 routing
+// @Summary      Confirmation Latency
+// @Description  Median, quartiles and average of the hours between the request of a tutoria and its confirmation by the tutor,
+// @Description  for the confirmed tutorias dated within the period.
+// @Tags         Analytics
+// @Produce      json
+// @Security     AdminBearer
+// @Param        desde query string false "Start of the period (YYYY-MM-DD); defaults to 29 days before hasta"
+// @Param        hasta query string false "End of the period (YYYY-MM-DD); defaults to today"
+// @Param        materia_id query int false "Only tutorias of this materia"
+// @Success      200 {object} db.AnalyticsLatenciaConfirmacionRow "Confirmation latency statistics, in hours"
+// @Failure      400 {object} ErrorResponse "Invalid period or materia ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to compute latencia-confirmacion"
+// @Router       /v1/analytics/latencia-confirmacion [get]
+func AnalyticsLatenciaConfirmacionEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		desde, hasta, err := parseAnalyticsPeriodo(r)
+		if err != nil {
+			http.Error(w, "Invalid period: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		materiaID, err := parseAnalyticsMateria(r)
+		if err != nil {
+			http.Error(w, "Invalid materia ID", http.StatusBadRequest)
+			return
+		}
+
+		estadisticas, err := queries.AnalyticsLatenciaConfirmacion(r.Context(), db.AnalyticsLatenciaConfirmacionParams{
+			Desde:     desde,
+			Hasta:     hasta,
+			MateriaID: materiaID,
+		})
+		if err != nil {
+			http.Error(w, "Failed to compute latencia-confirmacion: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(estadisticas)
+	}
+}
@@ -0,0 +1,455 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// auditoriaActorAnonimo is recorded as the actor of calls made without an admin token.
+const auditoriaActorAnonimo = "anonimo"
+
+// auditoriaMaxBody is how much of a response is kept to find the ID of a created record.
+const auditoriaMaxBody = 64 << 10
+
+// auditoriaMetodos are the HTTP methods that change data and are therefore audited.
+var auditoriaMetodos = []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// auditoriaCamposOcultos are never written to the audit trail.
+var auditoriaCamposOcultos = []string{"PasswordHash", "Secreto", "Token"}
+
+// auditoriaEntidad loads the current state of a record, so that the audit trail can show
+// which fields a call changed.
+type auditoriaEntidad struct {
+	IDCampo string // Field holding the ID of the record in create responses
+	Cargar  func(ctx context.Context, queries *db.Queries, id int32) (any, error)
+}
+
+// auditoriaCargar adapts a SelectXById query to auditoriaEntidad.Cargar.
+func auditoriaCargar[T any](selectByID func(*db.Queries, context.Context, int32) (T, error)) func(context.Context, *db.Queries, int32) (any, error) {
+	return func(ctx context.Context, queries *db.Queries, id int32) (any, error) {
+		return selectByID(queries, ctx, id)
+	}
+}
+
+// auditoriaEntidades are the entities whose changes are recorded field by field, by the
+// first segment of their route. Calls to other routes are recorded without a diff.
+var auditoriaEntidades = map[string]auditoriaEntidad{
+	"estudiantes":            {"EstudianteID", auditoriaCargar((*db.Queries).SelectEstudianteById)},
+	"tutores":                {"TutorID", auditoriaCargar((*db.Queries).SelectTutorById)},
+	"materias":               {"MateriaID", auditoriaCargar((*db.Queries).SelectMateriaById)},
+	"tutorias":               {"TutoriaID", auditoriaCargar((*db.Queries).SelectTutoriaById)},
+	"disponibilidad":         {"DisponibilidadID", auditoriaCargar((*db.Queries).SelectDisponibilidadById)},
+	"tutor-materias":         {"AsignacionID", auditoriaCargar((*db.Queries).SelectTutorMateriaById)},
+	"inscripciones":          {"InscripcionID", auditoriaCargar((*db.Queries).SelectInscripcionById)},
+	"reportes":               {"ReporteID", auditoriaCargar((*db.Queries).SelectReporteById)},
+	"reporte-programaciones": {"ProgramacionID", auditoriaCargar((*db.Queries).SelectReporteProgramacionById)},
+	"webhooks":               {"WebhookID", auditoriaCargar((*db.Queries).SelectWebhookById)},
+	"tutor-postulaciones":    {"PostulacionID", auditoriaCargar((*db.Queries).SelectTutorPostulacionById)},
+	"solicitudes-tutor":      {"SolicitudID", auditoriaCargar((*db.Queries).SelectSolicitudTutorById)},
+	"admins":                 {"AdminID", auditoriaCargar((*db.Queries).SelectAdminById)},
+}
+
+// AuditoriaEventoResponse is an entry of the audit trail.
+type AuditoriaEventoResponse struct {
+	EventoID   int32            `json:"evento_id" example:"120"`
+	AdminID    pgtype.Int4      `json:"admin_id" swaggertype:"integer" example:"1"` // Null for calls made without an admin token
+	Actor      string           `json:"actor" example:"admin@urosario.edu.co"`      // Correo of the admin, or anonimo
+	Metodo     string           `json:"metodo" example:"PUT"`
+	Ruta       string           `json:"ruta" example:"/v1/estudiantes/12"`
+	Entidad    string           `json:"entidad" example:"estudiantes"`
+	EntidadID  pgtype.Int4      `json:"entidad_id" swaggertype:"integer" example:"12"`
+	EstadoHttp int32            `json:"estado_http" example:"200"`
+	Antes      json.RawMessage  `json:"antes" swaggertype:"object"`   // Previous value of the fields that changed
+	Despues    json.RawMessage  `json:"despues" swaggertype:"object"` // New value of the fields that changed
+	Ip         string           `json:"ip" example:"10.0.0.8"`
+	Fecha      pgtype.Timestamp `json:"fecha" swaggertype:"string" example:"2025-03-01T10:00:00"`
+}
+
+// auditoriaWriter captures the status code and the start of the body of a response.
+type auditoriaWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+// WriteHeader captures the status code before writing it to the original ResponseWriter.
+func (aw *auditoriaWriter) WriteHeader(code int) {
+	aw.statusCode = code
+	aw.ResponseWriter.WriteHeader(code)
+}
+
+// Write keeps up to auditoriaMaxBody bytes of the body before writing it to the original ResponseWriter.
+func (aw *auditoriaWriter) Write(b []byte) (int, error) {
+	if restante := auditoriaMaxBody - aw.body.Len(); restante > 0 {
+		aw.body.Write(b[:min(len(b), restante)])
+	}
+	return aw.ResponseWriter.Write(b)
+}
+
+// AuditoriaMiddleware records every POST, PUT, PATCH and DELETE call in AUDITORIA_EVENTOS, with
+// the admin that made it, the affected record and the fields it changed. Failures to record a
+// call are logged and never change the response.
+func AuditoriaMiddleware(queries *db.Queries) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Logins change nothing and their body holds credentials
+			if !slices.Contains(auditoriaMetodos, r.Method) || strings.HasPrefix(r.URL.Path, "/v1/login/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			entidad, entidadID := auditoriaRuta(r.URL.Path)
+			config, conDiff := auditoriaEntidades[entidad]
+
+			var antes any
+			if conDiff && entidadID.Valid {
+				antes = auditoriaEstado(r.Context(), queries, config, entidadID.Int32)
+			}
+
+			writer := &auditoriaWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(writer, r)
+
+			// Record the call even if the client went away before the response was sent
+			ctx := context.WithoutCancel(r.Context())
+			exito := writer.statusCode >= 200 && writer.statusCode < 300
+
+			var despues any
+			if conDiff && exito {
+				if !entidadID.Valid && r.Method == http.MethodPost {
+					entidadID = auditoriaIDCreado(writer.body.Bytes(), config.IDCampo)
+				}
+				if entidadID.Valid {
+					despues = auditoriaEstado(ctx, queries, config, entidadID.Int32)
+				}
+			}
+
+			evento := db.CreateAuditoriaEventoParams{
+				Actor:      auditoriaActorAnonimo,
+				Metodo:     r.Method,
+				Ruta:       truncar(r.URL.Path, 255),
+				Entidad:    truncar(entidad, 50),
+				EntidadID:  entidadID,
+				EstadoHttp: int32(writer.statusCode),
+				Ip:         truncar(clientIP(r), 45),
+			}
+			if exito {
+				evento.Antes, evento.Despues = auditoriaDiff(antes, despues)
+			}
+
+			if token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); found {
+				if adminID, err := parseAdminToken(token); err == nil {
+					evento.AdminID = pgtype.Int4{Int32: adminID, Valid: true}
+					if admin, err := queries.SelectAdminById(ctx, adminID); err == nil {
+						evento.Actor = admin.Correo
+					}
+				}
+			}
+
+			if err := queries.CreateAuditoriaEvento(ctx, evento); err != nil {
+				log.Printf("auditoria: could not record %s %s: %v", r.Method, r.URL.Path, err)
+			}
+		})
+	}
+}
+
+// auditoriaRuta returns the entity a route acts on and, when the route names one, the ID of the record.
+// /v1/estudiantes/12/restaurar acts on estudiante 12; the /v1/admin prefix is skipped.
+func auditoriaRuta(path string) (string, pgtype.Int4) {
+	segmentos := strings.Split(strings.Trim(strings.TrimPrefix(path, "/v1/"), "/"), "/")
+	if segmentos[0] == "admin" && len(segmentos) > 1 {
+		segmentos = segmentos[1:]
+	}
+
+	if len(segmentos) > 1 {
+		if id, err := strconv.ParseInt(segmentos[1], 10, 32); err == nil {
+			return segmentos[0], pgtype.Int4{Int32: int32(id), Valid: true}
+		}
+	}
+	return segmentos[0], pgtype.Int4{}
+}
+
+// auditoriaEstado loads a record for the audit trail, or returns nil if it does not exist.
+func auditoriaEstado(ctx context.Context, queries *db.Queries, config auditoriaEntidad, id int32) any {
+	estado, err := config.Cargar(ctx, queries, id)
+	if err != nil {
+		if err.Error() != "no rows in result set" {
+			log.Printf("auditoria: could not load record %d: %v", id, err)
+		}
+		return nil
+	}
+	return estado
+}
+
+// auditoriaIDCreado finds the ID of the record created by a POST call in its JSON response.
+// Responses use either the Go field name (EstudianteID) or its snake case (estudiante_id).
+func auditoriaIDCreado(body []byte, campo string) pgtype.Int4 {
+	var respuesta map[string]any
+	if err := json.Unmarshal(body, &respuesta); err != nil {
+		return pgtype.Int4{}
+	}
+
+	for clave, valor := range respuesta {
+		if !strings.EqualFold(strings.ReplaceAll(clave, "_", ""), campo) {
+			continue
+		}
+		if id, ok := valor.(float64); ok && id == float64(int32(id)) {
+			return pgtype.Int4{Int32: int32(id), Valid: true}
+		}
+	}
+	return pgtype.Int4{}
+}
+
+// auditoriaDiff returns the fields that differ between two states of a record, with their previous
+// and new values. A created record has no previous state and a deleted one has no new state.
+func auditoriaDiff(antes, despues any) ([]byte, []byte) {
+	camposAntes := auditoriaCampos(antes)
+	camposDespues := auditoriaCampos(despues)
+
+	if camposAntes != nil && camposDespues != nil {
+		for campo, valor := range camposAntes {
+			if reflect.DeepEqual(valor, camposDespues[campo]) {
+				delete(camposAntes, campo)
+				delete(camposDespues, campo)
+			}
+		}
+		if len(camposAntes) == 0 && len(camposDespues) == 0 {
+			return nil, nil
+		}
+	}
+
+	return auditoriaJSON(camposAntes), auditoriaJSON(camposDespues)
+}
+
+// auditoriaCampos returns the JSON fields of a record, without the hidden ones.
+func auditoriaCampos(estado any) map[string]any {
+	if estado == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(estado)
+	if err != nil {
+		return nil
+	}
+	var campos map[string]any
+	if err := json.Unmarshal(data, &campos); err != nil {
+		return nil
+	}
+
+	for _, campo := range auditoriaCamposOcultos {
+		delete(campos, campo)
+	}
+	return campos
+}
+
+// auditoriaJSON encodes the fields of a diff, or returns nil (NULL) if there are none.
+func auditoriaJSON(campos map[string]any) []byte {
+	if campos == nil {
+		return nil
+	}
+	data, err := json.Marshal(campos)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+var (
+	trustedProxiesOnce sync.Once
+	trustedProxies     []netip.Prefix
+)
+
+// getTrustedProxies returns the proxies allowed to set X-Forwarded-For, configured in
+// TRUSTED_PROXIES as a comma-separated list of IP addresses or CIDR ranges.
+func getTrustedProxies() []netip.Prefix {
+	trustedProxiesOnce.Do(func() {
+		for _, entrada := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+			entrada = strings.TrimSpace(entrada)
+			if entrada == "" {
+				continue
+			}
+			prefix, err := netip.ParsePrefix(entrada)
+			if err != nil {
+				addr, addrErr := netip.ParseAddr(entrada)
+				if addrErr != nil {
+					log.Printf("TRUSTED_PROXIES: ignoring invalid entry %q", entrada)
+					continue
+				}
+				prefix = netip.PrefixFrom(addr, addr.BitLen())
+			}
+			trustedProxies = append(trustedProxies, prefix.Masked())
+		}
+	})
+	return trustedProxies
+}
+
+// isTrustedProxy reports whether ip is one of the configured TRUSTED_PROXIES.
+func isTrustedProxy(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range getTrustedProxies() {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the address of the client. X-Forwarded-For is only honoured when the
+// connection comes from a trusted proxy; its entries are read from the right, skipping the
+// ones added by other trusted proxies, since anything further left can be forged by the client.
+func clientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if !isTrustedProxy(ip) {
+		return ip
+	}
+
+	forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(forwarded[i])
+		if hop == "" {
+			continue
+		}
+		ip = hop
+		if !isTrustedProxy(hop) {
+			break
+		}
+	}
+	return ip
+}
+
+// truncar cuts s to at most n bytes, so that it fits its column.
+func truncar(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
+
+// ListAuditoriaEndpoint handles GET /v1/admin/auditoria using Go 1.22 routing
+// @Summary      List Audit Trail
+// @Description  Retrieves the recorded POST, PUT, PATCH and DELETE calls, most recent first. Each entry has the admin
+// @Description  that made the call (or anonimo without an admin token), the affected record and, for successful calls,
+// @Description  the previous and new value of the fields that changed. The audit trail cannot be modified.
+// @Tags         Auditoria
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     AdminBearer
+// @Param        entidad query string false "Only calls on this entity, e.g. estudiantes or materias"
+// @Param        entidad_id query int false "Only calls on this record of the entity"
+// @Param        admin_id query int false "Only calls made by this admin"
+// @Param        metodo query string false "Only calls with this method" Enums(POST, PUT, PATCH, DELETE)
+// @Param        desde query string false "Only calls made on or after this date (YYYY-MM-DD)"
+// @Param        hasta query string false "Only calls made on or before this date (YYYY-MM-DD)"
+// @Param        limit query int false "Maximum number of entries" default(50) minimum(1) maximum(500)
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
+// @Success      200 {array} AuditoriaEventoResponse "Audit trail entries"
+// @Failure      400 {object} ErrorResponse "Invalid filter"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve audit trail"
+// @Router       /v1/admin/auditoria [get]
+func ListAuditoriaEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		params := db.ListAuditoriaEventosParams{RowLimit: defaultListLimit}
+
+		if entidad := query.Get("entidad"); entidad != "" {
+			params.Entidad = pgtype.Text{String: entidad, Valid: true}
+		}
+
+		if entidadStr := query.Get("entidad_id"); entidadStr != "" {
+			id, err := strconv.ParseInt(entidadStr, 10, 32)
+			if err != nil {
+				http.Error(w, "Invalid entidad ID", http.StatusBadRequest)
+				return
+			}
+			params.EntidadID = pgtype.Int4{Int32: int32(id), Valid: true}
+		}
+
+		if adminStr := query.Get("admin_id"); adminStr != "" {
+			id, err := strconv.ParseInt(adminStr, 10, 32)
+			if err != nil {
+				http.Error(w, "Invalid admin ID", http.StatusBadRequest)
+				return
+			}
+			params.AdminID = pgtype.Int4{Int32: int32(id), Valid: true}
+		}
+
+		if metodo := strings.ToUpper(query.Get("metodo")); metodo != "" {
+			if !slices.Contains(auditoriaMetodos, metodo) {
+				http.Error(w, "Invalid metodo (use POST, PUT, PATCH or DELETE)", http.StatusBadRequest)
+				return
+			}
+			params.Metodo = pgtype.Text{String: metodo, Valid: true}
+		}
+
+		var err error
+		if desde := query.Get("desde"); desde != "" {
+			if params.Desde, err = parseDateString(desde); err != nil {
+				http.Error(w, "Invalid desde format (use YYYY-MM-DD)", http.StatusBadRequest)
+				return
+			}
+		}
+		if hasta := query.Get("hasta"); hasta != "" {
+			if params.Hasta, err = parseDateString(hasta); err != nil {
+				http.Error(w, "Invalid hasta format (use YYYY-MM-DD)", http.StatusBadRequest)
+				return
+			}
+		}
+
+		if limitStr := query.Get("limit"); limitStr != "" {
+			limit, err := strconv.ParseInt(limitStr, 10, 32)
+			if err != nil || limit < 1 || limit > maxListLimit {
+				http.Error(w, "Invalid limit, must be between 1 and "+strconv.Itoa(maxListLimit), http.StatusBadRequest)
+				return
+			}
+			params.RowLimit = int32(limit)
+		}
+
+		eventos, err := queries.ListAuditoriaEventos(r.Context(), params)
+		if err != nil {
+			http.Error(w, "Failed to retrieve audit trail: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		response := make([]AuditoriaEventoResponse, len(eventos))
+		for i, evento := range eventos {
+			response[i] = AuditoriaEventoResponse{
+				EventoID:   evento.EventoID,
+				AdminID:    evento.AdminID,
+				Actor:      evento.Actor,
+				Metodo:     evento.Metodo,
+				Ruta:       evento.Ruta,
+				Entidad:    evento.Entidad,
+				EntidadID:  evento.EntidadID,
+				EstadoHttp: evento.EstadoHttp,
+				Antes:      evento.Antes,
+				Despues:    evento.Despues,
+				Ip:         evento.Ip,
+				Fecha:      evento.Fecha,
+			}
+		}
+
+		writeList(w, r, "auditoria", response)
+	}
+}
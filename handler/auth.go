@@ -1,10 +1,20 @@
 package handler
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/crypto/bcrypt"
@@ -26,8 +36,9 @@ type UnifiedLoginRequest struct {
 
 // LoginResponse represents a successful login response.
 type LoginResponse struct {
-	UserType string      `json:"user_type"` // "estudiante", "tutor", or "admin"
-	Data     interface{} `json:"data"`      // The actual user data
+	UserType string      `json:"user_type"`       // "estudiante", "tutor", or "admin"
+	Data     interface{} `json:"data"`            // The actual user data
+	Token    string      `json:"token,omitempty"` // Bearer token, only issued for admins
 }
 
 // StudentLoginRequest represents the request body for the legacy student login endpoint.
@@ -47,6 +58,7 @@ type StudentLoginRequest struct {
 // @Success      200 {object} LoginResponse "Successfully authenticated"
 // @Failure      400 {object} ErrorResponse "Invalid request body or mode"
 // @Failure      401 {object} ErrorResponse "Invalid credentials"
+// @Failure      403 {object} ErrorResponse "Admin account is inactive"
 // @Failure      405 {object} ErrorResponse "Method not allowed"
 // @Failure      500 {object} ErrorResponse "Internal server error"
 // @Router       /v1/login/{mode} [post]
@@ -189,6 +201,11 @@ func handleAdminLogin(
 		return
 	}
 
+	if admin.Activo.Valid && !admin.Activo.Bool {
+		http.Error(w, "Admin account is inactive", http.StatusForbidden)
+		return
+	}
+
 	// Remove password hash from response
 	adminResponse := map[string]interface{}{
 		"admin_id":       admin.AdminID,
@@ -200,13 +217,101 @@ func handleAdminLogin(
 		"activo":         admin.Activo,
 	}
 
+	token, err := issueAdminToken(admin.AdminID)
+	if err != nil {
+		http.Error(w, "Failed to issue admin token", http.StatusInternalServerError)
+		return
+	}
+
 	response := LoginResponse{
 		UserType: "admin",
 		Data:     adminResponse,
+		Token:    token,
 	}
 	json.NewEncoder(w).Encode(response)
 }
 
+// adminTokenTTL is how long an admin bearer token stays valid after login.
+const adminTokenTTL = 12 * time.Hour
+
+var (
+	authSecretOnce sync.Once
+	authSecretKey  []byte
+)
+
+// adminTokenClaims is the signed payload of an admin bearer token.
+type adminTokenClaims struct {
+	AdminID int32 `json:"admin_id"`
+	Exp     int64 `json:"exp"`
+}
+
+// authSecret returns the key used to sign admin tokens. It is read from AUTH_SECRET;
+// if unset a random key is generated, so tokens do not survive a restart.
+func authSecret() []byte {
+	authSecretOnce.Do(func() {
+		if secret := os.Getenv("AUTH_SECRET"); secret != "" {
+			authSecretKey = []byte(secret)
+			return
+		}
+		log.Println("AUTH_SECRET not set, using a random key for admin tokens")
+		authSecretKey = make([]byte, 32)
+		if _, err := rand.Read(authSecretKey); err != nil {
+			log.Fatalf("Could not generate auth secret: %v", err)
+		}
+	})
+	return authSecretKey
+}
+
+// issueAdminToken creates a signed bearer token for the given admin.
+func issueAdminToken(adminID int32) (string, error) {
+	payload, err := json.Marshal(adminTokenClaims{
+		AdminID: adminID,
+		Exp:     time.Now().Add(adminTokenTTL).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, authSecret())
+	mac.Write(payload)
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// parseAdminToken verifies the signature and expiry of a bearer token and returns the admin ID.
+func parseAdminToken(token string) (int32, error) {
+	payloadPart, signaturePart, found := strings.Cut(token, ".")
+	if !found {
+		return 0, errors.New("malformed token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(payloadPart)
+	if err != nil {
+		return 0, errors.New("malformed token")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(signaturePart)
+	if err != nil {
+		return 0, errors.New("malformed token")
+	}
+
+	mac := hmac.New(sha256.New, authSecret())
+	mac.Write(payload)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return 0, errors.New("invalid token signature")
+	}
+
+	var claims adminTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return 0, errors.New("malformed token")
+	}
+	if time.Now().Unix() > claims.Exp {
+		return 0, errors.New("token expired")
+	}
+
+	return claims.AdminID, nil
+}
+
 // LoginHandler is deprecated, UnifiedLoginHandler should be used instead.
 // @Summary      Student Login (Legacy)
 // @Description  Authenticates a student using their email and TI (Tarjeta de Identidad).
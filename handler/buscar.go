@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// Result sizes of GET /v1/buscar, per kind of result.
+const (
+	defaultBuscarLimit = 10
+	maxBuscarLimit     = 50
+)
+
+// BuscarResponse holds the ranked results of a search, best matches first.
+type BuscarResponse struct {
+	Q        string                 `json:"q" example:"calculo"`
+	Materias []db.BuscarMateriasRow `json:"materias"`
+	Tutores  []db.BuscarTutoresRow  `json:"tutores"`
+}
+
+// BuscarEndpoint handles GET /v1/buscar using Go 1.22 routing
+// @Summary      Search Materias and Tutores
+// @Description  Searches active materias (nombre, codigo, descripcion, facultad) and tutors (nombre, apellido, materias taught).
+// @Description  Matching ignores case and accents and tolerates typos; results are ranked by similarity.
+// @Tags         Busqueda
+// @Produce      json
+// @Param        q query string true "Search text, at least 2 characters" example(calculo)
+// @Param        tipo query string false "Only search one kind of result" Enums(materias, tutores)
+// @Param        limit query int false "Maximum number of results of each kind" default(10) minimum(1) maximum(50)
+// @Success      200 {object} BuscarResponse "Ranked results"
+// @Failure      400 {object} ErrorResponse "Invalid search parameters"
+// @Failure      500 {object} ErrorResponse "Failed to search"
+// @Router       /v1/buscar [get]
+func BuscarEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := strings.TrimSpace(r.URL.Query().Get("q"))
+		if utf8.RuneCountInString(q) < 2 {
+			http.Error(w, "Search text must have at least 2 characters", http.StatusBadRequest)
+			return
+		}
+
+		tipo := r.URL.Query().Get("tipo")
+		if tipo != "" && tipo != "materias" && tipo != "tutores" {
+			http.Error(w, "Invalid tipo, must be materias or tutores", http.StatusBadRequest)
+			return
+		}
+
+		limit := int64(defaultBuscarLimit)
+		if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+			var err error
+			limit, err = strconv.ParseInt(limitStr, 10, 32)
+			if err != nil || limit < 1 || limit > maxBuscarLimit {
+				http.Error(w, "Invalid limit, must be between 1 and "+strconv.Itoa(maxBuscarLimit), http.StatusBadRequest)
+				return
+			}
+		}
+
+		response := BuscarResponse{
+			Q:        q,
+			Materias: []db.BuscarMateriasRow{},
+			Tutores:  []db.BuscarTutoresRow{},
+		}
+
+		if tipo != "tutores" {
+			materias, err := queries.BuscarMaterias(r.Context(), db.BuscarMateriasParams{Q: q, RowLimit: int32(limit)})
+			if err != nil {
+				http.Error(w, "Failed to search materias: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if materias != nil {
+				response.Materias = materias
+			}
+		}
+
+		if tipo != "materias" {
+			tutores, err := queries.BuscarTutores(r.Context(), db.BuscarTutoresParams{Q: q, RowLimit: int32(limit)})
+			if err != nil {
+				http.Error(w, "Failed to search tutores: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if tutores != nil {
+				response.Tutores = tutores
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
@@ -0,0 +1,611 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// zonaHoraria is the timezone tutoria fecha/hora values are expressed in (Bogotá, UTC-5, no DST).
+var zonaHoraria = time.FixedZone("America/Bogota", -5*60*60)
+
+// icsProdID identifies this API as the producer of the calendars it generates.
+const icsProdID = "-//URTutorias//Proyecto Datos//ES"
+
+// CalendarioTokenRequest represents the request body for obtaining a calendar feed token.
+// The same credentials as the estudiante/tutor login are required.
+type CalendarioTokenRequest struct {
+	Correo string `json:"correo" example:"juan.perez@urosario.edu.co"`
+	TI     int32  `json:"ti"     example:"1000123456"`
+	Rotar  bool   `json:"rotar"  example:"false"` // Issue a new token, invalidating the previous feed URL
+}
+
+// CalendarioTokenResponse represents the feed token and the URL to subscribe to.
+type CalendarioTokenResponse struct {
+	Token string `json:"token" example:"3f9a..."`
+	URL   string `json:"url"   example:"/v1/estudiantes/1/calendario.ics?token=3f9a..."`
+}
+
+// icsEvent is a single VEVENT of an iCalendar document.
+type icsEvent struct {
+	UID         string
+	Sequence    int
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Location    string
+	Description string
+	Status      string // TENTATIVE, CONFIRMED or CANCELLED
+	Organizer   string // Email of the ORGANIZER, required by invitations
+	Attendees   []icsAttendee
+}
+
+// icsAttendee is a participant invited to an icsEvent.
+type icsAttendee struct {
+	Name  string
+	Email string
+}
+
+// tutoriaUID returns the stable iCalendar UID of a tutoria.
+func tutoriaUID(tutoriaID int32) string {
+	return fmt.Sprintf("tutoria-%d@urtutorias", tutoriaID)
+}
+
+// tutoriaICSStatus maps a tutoria estado to an iCalendar STATUS value.
+func tutoriaICSStatus(estado string) string {
+	switch estado {
+	case "confirmada", "completada":
+		return "CONFIRMED"
+	case "cancelada":
+		return "CANCELLED"
+	default:
+		return "TENTATIVE"
+	}
+}
+
+// tutoriaInterval returns the start and end instants of a tutoria.
+func tutoriaInterval(fecha pgtype.Date, horaInicio, horaFin pgtype.Time) (time.Time, time.Time) {
+	day := time.Date(fecha.Time.Year(), fecha.Time.Month(), fecha.Time.Day(), 0, 0, 0, 0, zonaHoraria)
+	start := day.Add(time.Duration(horaInicio.Microseconds) * time.Microsecond)
+	end := day.Add(time.Duration(horaFin.Microseconds) * time.Microsecond)
+	return start, end
+}
+
+// icsEscape escapes a TEXT property value as required by RFC 5545.
+func icsEscape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+// icsParam quotes a property parameter value, dropping characters it cannot contain.
+func icsParam(value string) string {
+	return `"` + strings.NewReplacer(`"`, "", "\r", "", "\n", " ").Replace(value) + `"`
+}
+
+// icsTime formats an instant as an iCalendar UTC date-time.
+func icsTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// icsFold writes a content line, folding it at 75 octets without splitting UTF-8 characters.
+func icsFold(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // Continuation lines start with a space
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// buildICS renders a VCALENDAR. method is omitted when empty (plain feed).
+func buildICS(nombre, method string, events []icsEvent) string {
+	var b strings.Builder
+	now := icsTime(time.Now())
+
+	icsFold(&b, "BEGIN:VCALENDAR")
+	icsFold(&b, "VERSION:2.0")
+	icsFold(&b, "PRODID:"+icsProdID)
+	icsFold(&b, "CALSCALE:GREGORIAN")
+	if method != "" {
+		icsFold(&b, "METHOD:"+method)
+	}
+	if nombre != "" {
+		icsFold(&b, "X-WR-CALNAME:"+icsEscape(nombre))
+	}
+
+	for _, event := range events {
+		icsFold(&b, "BEGIN:VEVENT")
+		icsFold(&b, "UID:"+event.UID)
+		icsFold(&b, "SEQUENCE:"+strconv.Itoa(event.Sequence))
+		icsFold(&b, "DTSTAMP:"+now)
+		icsFold(&b, "DTSTART:"+icsTime(event.Start))
+		icsFold(&b, "DTEND:"+icsTime(event.End))
+		icsFold(&b, "SUMMARY:"+icsEscape(event.Summary))
+		if event.Location != "" {
+			icsFold(&b, "LOCATION:"+icsEscape(event.Location))
+		}
+		if event.Description != "" {
+			icsFold(&b, "DESCRIPTION:"+icsEscape(event.Description))
+		}
+		icsFold(&b, "STATUS:"+event.Status)
+		if event.Organizer != "" {
+			icsFold(&b, "ORGANIZER;CN=URTutorias:mailto:"+event.Organizer)
+		}
+		for _, attendee := range event.Attendees {
+			line := "ATTENDEE;CN=" + icsParam(attendee.Name) + ";ROLE=REQ-PARTICIPANT"
+			if method == "REQUEST" {
+				line += ";PARTSTAT=NEEDS-ACTION;RSVP=TRUE"
+			}
+			icsFold(&b, line+":mailto:"+attendee.Email)
+		}
+		icsFold(&b, "END:VEVENT")
+	}
+
+	icsFold(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// tutoriaDescription summarizes the state of a tutoria for the event description.
+func tutoriaDescription(estado string, temasTratados pgtype.Text) string {
+	description := "Estado: " + estado
+	if temasTratados.Valid && temasTratados.String != "" {
+		description += "\nTemas tratados: " + temasTratados.String
+	}
+	return description
+}
+
+// generateCalendarioToken returns a random hex-encoded feed token.
+func generateCalendarioToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// checkCalendarioToken reports whether token is the current feed token of the given user.
+func checkCalendarioToken(r *http.Request, queries *db.Queries, tipoUsuario string, usuarioID int32, token string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	stored, err := queries.SelectCalendarioToken(r.Context(), db.SelectCalendarioTokenParams{
+		TipoUsuario: tipoUsuario,
+		UsuarioID:   usuarioID,
+	})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return subtle.ConstantTimeCompare([]byte(stored.Token), []byte(token)) == 1, nil
+}
+
+// CalendarioTokenEndpoint handles POST /v1/calendario/token/{mode} using Go 1.22 routing
+// @Summary      Get Calendar Feed Token
+// @Description  Returns the secret calendar feed token of an estudiante or tutor, creating it on first use. Requires the same correo and TI as the login; set rotar to invalidate the previous feed URL.
+// @Tags         Calendario
+// @Accept       json
+// @Produce      json
+// @Param        mode path string true "User type" Enums(estudiante, tutor)
+// @Param        credenciales body CalendarioTokenRequest true "Login credentials"
+// @Success      200 {object} CalendarioTokenResponse "Feed token and subscription URL"
+// @Failure      400 {object} ErrorResponse "Invalid request body or mode"
+// @Failure      401 {object} ErrorResponse "Invalid credentials"
+// @Failure      500 {object} ErrorResponse "Failed to issue feed token"
+// @Router       /v1/calendario/token/{mode} [post]
+func CalendarioTokenEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CalendarioTokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		mode := r.PathValue("mode")
+		if mode != "estudiante" && mode != "tutor" {
+			http.Error(w, "Invalid mode. Use 'estudiante' or 'tutor'", http.StatusBadRequest)
+			return
+		}
+
+		estudiante, err := queries.LoginEstudiante(r.Context(), db.LoginEstudianteParams{
+			Correo: req.Correo,
+			Ti:     pgtype.Int4{Int32: req.TI, Valid: true},
+		})
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Invalid credentials", http.StatusUnauthorized)
+				return
+			}
+			http.Error(w, "Failed to authenticate: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		usuarioID := estudiante.EstudianteID
+		feedPath := fmt.Sprintf("/v1/estudiantes/%d/calendario.ics", usuarioID)
+		if mode == "tutor" {
+			tutor, err := queries.SelectTutorByCorreo(r.Context(), estudiante.Correo)
+			if err != nil {
+				if err.Error() == "no rows in result set" {
+					http.Error(w, "Tutor record not found for this student", http.StatusUnauthorized)
+					return
+				}
+				http.Error(w, "Failed to fetch tutor data: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			usuarioID = tutor.TutorID
+			feedPath = fmt.Sprintf("/v1/tutores/%d/calendario.ics", usuarioID)
+		}
+
+		calendarioToken, err := queries.SelectCalendarioToken(r.Context(), db.SelectCalendarioTokenParams{
+			TipoUsuario: mode,
+			UsuarioID:   usuarioID,
+		})
+		if err != nil && err.Error() != "no rows in result set" {
+			http.Error(w, "Failed to fetch feed token: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if err != nil || req.Rotar {
+			token, err := generateCalendarioToken()
+			if err != nil {
+				http.Error(w, "Failed to generate feed token", http.StatusInternalServerError)
+				return
+			}
+
+			calendarioToken, err = queries.UpsertCalendarioToken(r.Context(), db.UpsertCalendarioTokenParams{
+				TipoUsuario: mode,
+				UsuarioID:   usuarioID,
+				Token:       token,
+			})
+			if err != nil {
+				http.Error(w, "Failed to store feed token: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CalendarioTokenResponse{
+			Token: calendarioToken.Token,
+			URL:   feedPath + "?token=" + calendarioToken.Token,
+		})
+	}
+}
+
+// EstudianteCalendarioEndpoint handles GET /v1/estudiantes/{id}/calendario.ics using Go 1.22 routing
+// @Summary      Estudiante Calendar Feed
+// @Description  iCalendar feed with every tutoria of a student. Cancelled sessions are included with STATUS:CANCELLED.
+// @Tags         Calendario
+// @Produce      text/calendar
+// @Param        id path int true "Estudiante ID"
+// @Param        token query string true "Feed token from /v1/calendario/token/estudiante"
+// @Success      200 {string} string "iCalendar document"
+// @Failure      400 {object} ErrorResponse "Invalid estudiante ID"
+// @Failure      403 {object} ErrorResponse "Invalid feed token"
+// @Failure      500 {object} ErrorResponse "Failed to build calendar"
+// @Router       /v1/estudiantes/{id}/calendario.ics [get]
+func EstudianteCalendarioEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid estudiante ID", http.StatusBadRequest)
+			return
+		}
+
+		valid, err := checkCalendarioToken(r, queries, "estudiante", int32(id), r.URL.Query().Get("token"))
+		if err != nil {
+			http.Error(w, "Failed to verify feed token: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !valid {
+			http.Error(w, "Invalid feed token", http.StatusForbidden)
+			return
+		}
+
+		tutorias, err := queries.ListTutoriasByEstudiante(r.Context(), int32(id))
+		if err != nil {
+			http.Error(w, "Failed to retrieve tutorias: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		events := make([]icsEvent, 0, len(tutorias))
+		for _, tutoria := range tutorias {
+			start, end := tutoriaInterval(tutoria.Fecha, tutoria.HoraInicio, tutoria.HoraFin)
+			events = append(events, icsEvent{
+				UID:         tutoriaUID(tutoria.TutoriaID),
+				Start:       start,
+				End:         end,
+				Summary:     fmt.Sprintf("Tutoría de %s con %s %s", tutoria.MateriaNombre, tutoria.TutorNombre, tutoria.TutorApellido),
+				Location:    tutoria.Lugar,
+				Description: tutoriaDescription(tutoria.Estado, tutoria.TemasTratados),
+				Status:      tutoriaICSStatus(tutoria.Estado),
+			})
+		}
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		fmt.Fprint(w, buildICS("Mis tutorías", "", events))
+	}
+}
+
+// TutorCalendarioEndpoint handles GET /v1/tutores/{id}/calendario.ics using Go 1.22 routing
+// @Summary      Tutor Calendar Feed
+// @Description  iCalendar feed with every tutoria given by a tutor. Cancelled sessions are included with STATUS:CANCELLED.
+// @Tags         Calendario
+// @Produce      text/calendar
+// @Param        id path int true "Tutor ID"
+// @Param        token query string true "Feed token from /v1/calendario/token/tutor"
+// @Success      200 {string} string "iCalendar document"
+// @Failure      400 {object} ErrorResponse "Invalid tutor ID"
+// @Failure      403 {object} ErrorResponse "Invalid feed token"
+// @Failure      500 {object} ErrorResponse "Failed to build calendar"
+// @Router       /v1/tutores/{id}/calendario.ics [get]
+func TutorCalendarioEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid tutor ID", http.StatusBadRequest)
+			return
+		}
+
+		valid, err := checkCalendarioToken(r, queries, "tutor", int32(id), r.URL.Query().Get("token"))
+		if err != nil {
+			http.Error(w, "Failed to verify feed token: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !valid {
+			http.Error(w, "Invalid feed token", http.StatusForbidden)
+			return
+		}
+
+		tutorias, err := queries.ListTutoriasByTutor(r.Context(), int32(id))
+		if err != nil {
+			http.Error(w, "Failed to retrieve tutorias: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		events := make([]icsEvent, 0, len(tutorias))
+		for _, tutoria := range tutorias {
+			start, end := tutoriaInterval(tutoria.Fecha, tutoria.HoraInicio, tutoria.HoraFin)
+			events = append(events, icsEvent{
+				UID:         tutoriaUID(tutoria.TutoriaID),
+				Start:       start,
+				End:         end,
+				Summary:     fmt.Sprintf("Tutoría de %s con %s %s", tutoria.MateriaNombre, tutoria.EstudianteNombre, tutoria.EstudianteApellido),
+				Location:    tutoria.Lugar,
+				Description: tutoriaDescription(tutoria.Estado, tutoria.TemasTratados),
+				Status:      tutoriaICSStatus(tutoria.Estado),
+			})
+		}
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		fmt.Fprint(w, buildICS("Tutorías asignadas", "", events))
+	}
+}
+
+// defaultOrganizador is the ORGANIZER of tutoria invitations when SMTP_FROM is not set.
+const defaultOrganizador = "tutorias@urosario.edu.co"
+
+// tutoriaInvitacionMetodo returns the iTIP method sent when a tutoria reaches estado,
+// or "" when the estado does not trigger an invitation.
+func tutoriaInvitacionMetodo(estado string) string {
+	switch estado {
+	case "confirmada":
+		return "REQUEST"
+	case "cancelada":
+		return "CANCEL"
+	default:
+		return ""
+	}
+}
+
+// tutoriaInvitacion is a rendered calendar invitation and the people it is addressed to.
+type tutoriaInvitacion struct {
+	Metodo        string
+	ICS           string
+	Asunto        string
+	Cuerpo        string
+	Destinatarios []string
+}
+
+// buildTutoriaInvitacion renders the METHOD:REQUEST or METHOD:CANCEL invitation of a tutoria.
+// Every invitation of a tutoria shares its UID; secuencia must grow with each one sent so
+// calendar clients update the existing event instead of adding a new one. Participants and
+// materia in the papelera are still loaded, so a cancellation reaches everyone invited.
+func buildTutoriaInvitacion(ctx context.Context, queries *db.Queries, tutoria db.Tutoria, metodo string, secuencia int32) (tutoriaInvitacion, error) {
+	estudiante, err := queries.SelectEstudianteByIdConEliminados(ctx, tutoria.EstudianteID)
+	if err != nil {
+		return tutoriaInvitacion{}, err
+	}
+	tutor, err := queries.SelectTutorByIdConEliminados(ctx, tutoria.TutorID)
+	if err != nil {
+		return tutoriaInvitacion{}, err
+	}
+	materia, err := queries.SelectMateriaByIdConEliminadas(ctx, tutoria.MateriaID)
+	if err != nil {
+		return tutoriaInvitacion{}, err
+	}
+
+	organizador := defaultOrganizador
+	if mailer := getMailer(); mailer != nil && mailer.From != "" {
+		organizador = mailer.From
+	}
+
+	start, end := tutoriaInterval(tutoria.Fecha, tutoria.HoraInicio, tutoria.HoraFin)
+	summary := fmt.Sprintf("Tutoría de %s", materia.Nombre)
+	event := icsEvent{
+		UID:         tutoriaUID(tutoria.TutoriaID),
+		Sequence:    int(secuencia),
+		Start:       start,
+		End:         end,
+		Summary:     summary,
+		Location:    tutoria.Lugar,
+		Description: fmt.Sprintf("Tutor: %s %s\nEstudiante: %s %s", tutor.Nombre, tutor.Apellido, estudiante.Nombre, estudiante.Apellido),
+		Status:      tutoriaICSStatus(tutoria.Estado),
+		Organizer:   organizador,
+		Attendees: []icsAttendee{
+			{Name: estudiante.Nombre + " " + estudiante.Apellido, Email: estudiante.Correo},
+			{Name: tutor.Nombre + " " + tutor.Apellido, Email: tutor.Correo},
+		},
+	}
+
+	cuando := start.Format("02/01/2006 15:04") + " - " + end.Format("15:04")
+	invitacion := tutoriaInvitacion{
+		Metodo:        metodo,
+		ICS:           buildICS("", metodo, []icsEvent{event}),
+		Asunto:        "Tutoría confirmada: " + materia.Nombre,
+		Cuerpo:        fmt.Sprintf("La tutoría de %s del %s en %s ha sido confirmada.\n\n%s", materia.Nombre, cuando, tutoria.Lugar, event.Description),
+		Destinatarios: []string{estudiante.Correo, tutor.Correo},
+	}
+	switch {
+	case metodo == "CANCEL":
+		invitacion.Asunto = "Tutoría cancelada: " + materia.Nombre
+		invitacion.Cuerpo = fmt.Sprintf("La tutoría de %s del %s en %s ha sido cancelada.\n\n%s", materia.Nombre, cuando, tutoria.Lugar, event.Description)
+	case secuencia > 0:
+		invitacion.Asunto = "Tutoría actualizada: " + materia.Nombre
+		invitacion.Cuerpo = fmt.Sprintf("La tutoría de %s ha sido actualizada: ahora es el %s en %s.\n\n%s", materia.Nombre, cuando, tutoria.Lugar, event.Description)
+	}
+	return invitacion, nil
+}
+
+// sendTutoriaInvitacion emails the calendar invitation matching the current estado of a
+// tutoria to its estudiante and tutor. A cancellation is only sent when an invitation went
+// out before. Failures are logged and never fail the calling request.
+func sendTutoriaInvitacion(ctx context.Context, queries *db.Queries, tutoria db.Tutoria) {
+	metodo := tutoriaInvitacionMetodo(tutoria.Estado)
+	if metodo == "" {
+		return
+	}
+
+	if metodo == "CANCEL" {
+		if _, err := queries.SelectTutoriaInvitacion(ctx, tutoria.TutoriaID); err != nil {
+			if err.Error() != "no rows in result set" {
+				log.Printf("invitacion: could not check tutoria %d: %v", tutoria.TutoriaID, err)
+			}
+			return
+		}
+	}
+
+	registro, err := queries.UpsertTutoriaInvitacion(ctx, db.UpsertTutoriaInvitacionParams{
+		TutoriaID: tutoria.TutoriaID,
+		Metodo:    metodo,
+	})
+	if err != nil {
+		log.Printf("invitacion: could not record %s for tutoria %d: %v", metodo, tutoria.TutoriaID, err)
+		return
+	}
+
+	invitacion, err := buildTutoriaInvitacion(ctx, queries, tutoria, metodo, registro.Secuencia)
+	if err != nil {
+		log.Printf("invitacion: could not build %s for tutoria %d: %v", metodo, tutoria.TutoriaID, err)
+		return
+	}
+
+	sendMailAsync(mailMessage{
+		To:             invitacion.Destinatarios,
+		Subject:        invitacion.Asunto,
+		Body:           invitacion.Cuerpo,
+		Calendar:       invitacion.ICS,
+		CalendarMethod: metodo,
+		Attachments: []mailAttachment{{
+			Filename:    "invite.ics",
+			ContentType: "application/ics",
+			Data:        []byte(invitacion.ICS),
+		}},
+	})
+}
+
+// TutoriaInvitacionEndpoint handles GET /v1/tutorias/{id}/invitacion.ics using Go 1.22 routing
+// @Summary      Tutoria Calendar Invitation
+// @Description  Returns the last calendar invitation issued for a tutoria: METHOD:REQUEST once it is confirmed, METHOD:CANCEL once it is cancelled. The UID is stable and SEQUENCE grows with every invitation, so importing it updates the existing event. Requires the calendar feed token of the estudiante or tutor of the tutoria, or an admin bearer token.
+// @Tags         Calendario
+// @Produce      text/calendar
+// @Security     AdminBearer
+// @Param        id path int true "Tutoria ID"
+// @Param        token query string false "Feed token of the estudiante or tutor, from /v1/calendario/token/{mode}"
+// @Success      200 {string} string "iCalendar invitation"
+// @Failure      400 {object} ErrorResponse "Invalid tutoria ID"
+// @Failure      403 {object} ErrorResponse "Invalid feed token"
+// @Failure      404 {object} ErrorResponse "Tutoria not found or no invitation issued"
+// @Failure      500 {object} ErrorResponse "Failed to build invitation"
+// @Router       /v1/tutorias/{id}/invitacion.ics [get]
+func TutoriaInvitacionEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid tutoria ID", http.StatusBadRequest)
+			return
+		}
+
+		tutoria, err := queries.SelectTutoriaById(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Tutoria not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get tutoria: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// The invitation lists the names and correos of both participants
+		if _, ok := requestAdmin(r, queries); !ok {
+			token := r.URL.Query().Get("token")
+			valid, err := checkCalendarioToken(r, queries, "estudiante", tutoria.EstudianteID, token)
+			if err == nil && !valid {
+				valid, err = checkCalendarioToken(r, queries, "tutor", tutoria.TutorID, token)
+			}
+			if err != nil {
+				http.Error(w, "Failed to verify feed token: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if !valid {
+				http.Error(w, "Invalid feed token", http.StatusForbidden)
+				return
+			}
+		}
+
+		registro, err := queries.SelectTutoriaInvitacion(r.Context(), tutoria.TutoriaID)
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "No invitation has been issued for this tutoria", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get invitation: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		invitacion, err := buildTutoriaInvitacion(r.Context(), queries, tutoria, registro.Metodo, registro.Secuencia)
+		if err != nil {
+			http.Error(w, "Failed to build invitation: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8; method="+invitacion.Metodo)
+		w.Header().Set("Content-Disposition", `attachment; filename="invite.ics"`)
+		fmt.Fprint(w, invitacion.ICS)
+	}
+}
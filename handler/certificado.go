@@ -0,0 +1,427 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// CertificadoMateria represents the certified tutorias of one materia.
+type CertificadoMateria struct {
+	MateriaID int32  `json:"materia_id" example:"3"`
+	Codigo    string `json:"codigo" example:"MAT101"`
+	Nombre    string `json:"nombre" example:"Cálculo I"`
+	Tutorias  int32  `json:"tutorias" example:"8"`
+	Minutos   int32  `json:"minutos" example:"720"`
+}
+
+// CertificadoVerificacionResponse represents the public details of an issued hours certificate.
+type CertificadoVerificacionResponse struct {
+	Codigo        string               `json:"codigo" example:"K7QF-M2XA-9BTR-C4WE"`
+	Valido        bool                 `json:"valido" example:"true"` // The stored content matches its signature
+	TutorNombre   string               `json:"tutor_nombre" example:"Juan Perez"`
+	Desde         string               `json:"desde" example:"2025-01-01"`
+	Hasta         string               `json:"hasta" example:"2025-06-30"`
+	TotalTutorias int32                `json:"total_tutorias" example:"24"`
+	TotalHoras    float64              `json:"total_horas" example:"31.5"`
+	Materias      []CertificadoMateria `json:"materias"`
+	FechaEmision  string               `json:"fecha_emision" example:"2025-07-01 10:30:00"`
+	Firma         string               `json:"firma"`
+}
+
+// certificadoContenido is the content of a certificate covered by its signature. The tutor is
+// identified by name, since the certificate outlives the tutor's account.
+type certificadoContenido struct {
+	Codigo        string               `json:"codigo"`
+	TutorNombre   string               `json:"tutor_nombre"`
+	Desde         string               `json:"desde"`
+	Hasta         string               `json:"hasta"`
+	TotalTutorias int32                `json:"total_tutorias"`
+	TotalMinutos  int32                `json:"total_minutos"`
+	Materias      []CertificadoMateria `json:"materias"`
+}
+
+// errCertificadoSinSecreto is returned when no persistent key is configured for signing
+// certificates, since a per-process key would invalidate every certificate on restart.
+var errCertificadoSinSecreto = errors.New("certificate signing is not configured: set CERTIFICADO_SECRET or AUTH_SECRET")
+
+// certificadoSecret returns the key used to sign certificates. It is read from CERTIFICADO_SECRET;
+// if unset it is derived from AUTH_SECRET, so that it is never the same key as admin tokens.
+func certificadoSecret() ([]byte, error) {
+	if secret := os.Getenv("CERTIFICADO_SECRET"); secret != "" {
+		return []byte(secret), nil
+	}
+	secret := os.Getenv("AUTH_SECRET")
+	if secret == "" {
+		return nil, errCertificadoSinSecreto
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("certificados"))
+	return mac.Sum(nil), nil
+}
+
+// firmarCertificado returns the hex HMAC-SHA256 of the certificate content, keyed with
+// certificadoSecret.
+func firmarCertificado(contenido certificadoContenido) (string, error) {
+	secret, err := certificadoSecret()
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(contenido)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// certificadoContenidoDe rebuilds the signed content of a stored certificate.
+func certificadoContenidoDe(certificado db.Certificado) (certificadoContenido, error) {
+	contenido := certificadoContenido{
+		Codigo:        certificado.Codigo,
+		TutorNombre:   certificado.TutorNombre,
+		Desde:         certificado.Desde.Time.Format("2006-01-02"),
+		Hasta:         certificado.Hasta.Time.Format("2006-01-02"),
+		TotalTutorias: certificado.TotalTutorias,
+		TotalMinutos:  certificado.TotalMinutos,
+	}
+	err := json.Unmarshal(certificado.Detalle, &contenido.Materias)
+	return contenido, err
+}
+
+// generateCertificadoCodigo returns a random verification code such as K7QF-M2XA-9BTR-C4WE.
+func generateCertificadoCodigo() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	s := base32.StdEncoding.EncodeToString(b)
+	return s[0:4] + "-" + s[4:8] + "-" + s[8:12] + "-" + s[12:16], nil
+}
+
+// formatHoras formats a number of minutes as hours with two decimals.
+func formatHoras(minutos int32) string {
+	return strconv.FormatFloat(float64(minutos)/60, 'f', 2, 64)
+}
+
+// emitirCertificado returns the certificate of the tutor's sessions, reusing the last one issued
+// for the same period when its content has not changed since.
+func emitirCertificado(
+	ctx context.Context,
+	queries *db.Queries,
+	tutor db.Tutore,
+	desde, hasta pgtype.Date,
+	sesiones []db.ListTutoriasCertificablesRow,
+) (db.Certificado, error) {
+	contenido := certificadoContenido{
+		TutorNombre: tutor.Nombre + " " + tutor.Apellido,
+		Desde:       desde.Time.Format("2006-01-02"),
+		Hasta:       hasta.Time.Format("2006-01-02"),
+		Materias:    []CertificadoMateria{},
+	}
+	// Sessions come ordered by materia, so each materia is a run of consecutive rows
+	for _, s := range sesiones {
+		if n := len(contenido.Materias); n == 0 || contenido.Materias[n-1].MateriaID != s.MateriaID {
+			contenido.Materias = append(contenido.Materias, CertificadoMateria{
+				MateriaID: s.MateriaID,
+				Codigo:    s.MateriaCodigo,
+				Nombre:    s.Materia,
+			})
+		}
+		materia := &contenido.Materias[len(contenido.Materias)-1]
+		materia.Tutorias++
+		materia.Minutos += s.Minutos
+		contenido.TotalTutorias++
+		contenido.TotalMinutos += s.Minutos
+	}
+
+	anterior, err := queries.SelectUltimoCertificado(ctx, db.SelectUltimoCertificadoParams{
+		TutorID: pgtype.Int4{Int32: tutor.TutorID, Valid: true},
+		Desde:   desde,
+		Hasta:   hasta,
+	})
+	if err == nil {
+		if previo, err := certificadoContenidoDe(anterior); err == nil {
+			if previo.TutorNombre == contenido.TutorNombre && previo.TotalTutorias == contenido.TotalTutorias &&
+				previo.TotalMinutos == contenido.TotalMinutos && slices.Equal(previo.Materias, contenido.Materias) {
+				return anterior, nil
+			}
+		}
+	} else if err.Error() != "no rows in result set" {
+		return db.Certificado{}, err
+	}
+
+	if contenido.Codigo, err = generateCertificadoCodigo(); err != nil {
+		return db.Certificado{}, err
+	}
+	firma, err := firmarCertificado(contenido)
+	if err != nil {
+		return db.Certificado{}, err
+	}
+	detalle, err := json.Marshal(contenido.Materias)
+	if err != nil {
+		return db.Certificado{}, err
+	}
+
+	return queries.CreateCertificado(ctx, db.CreateCertificadoParams{
+		Codigo:        contenido.Codigo,
+		TutorID:       pgtype.Int4{Int32: tutor.TutorID, Valid: true},
+		TutorNombre:   contenido.TutorNombre,
+		Desde:         desde,
+		Hasta:         hasta,
+		TotalTutorias: contenido.TotalTutorias,
+		TotalMinutos:  contenido.TotalMinutos,
+		Detalle:       detalle,
+		Firma:         firma,
+	})
+}
+
+// renderCertificadoPDF writes the PDF of a certificate, listing the certified sessions.
+func renderCertificadoPDF(w io.Writer, certificado db.Certificado, sesiones []db.ListTutoriasCertificablesRow) error {
+	contenido, err := certificadoContenidoDe(certificado)
+	if err != nil {
+		return fmt.Errorf("could not decode detalle: %w", err)
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	doc := &reportePDF{pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+	pdf.SetMargins(pdfMargen, pdfMargen, pdfMargen)
+	pdf.SetAutoPageBreak(true, pdfMargen+pdfPiePagina)
+	pdf.SetTitle("Certificado de horas de tutoría", true)
+	pdf.SetSubject("Certificado "+certificado.Codigo, true)
+	pdf.SetKeywords("firma:"+certificado.Firma, true)
+	pdf.SetAuthor("URTutorias", true)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargen - pdfPiePagina/2)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(0, 5, doc.tr(fmt.Sprintf("Certificado %s - Página %d de {nb}", certificado.Codigo, pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", pdfTituloSize)
+	pdf.CellFormat(0, 10, doc.tr("Certificado de horas de tutoría"), "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.MultiCell(0, 6, doc.tr(fmt.Sprintf(
+		"Se certifica que %s se desempeñó como tutor(a) entre el %s y el %s, impartiendo %d tutorías "+
+			"completadas con asistencia confirmada, por un total de %s horas.",
+		contenido.TutorNombre, contenido.Desde, contenido.Hasta, contenido.TotalTutorias, formatHoras(contenido.TotalMinutos),
+	)), "", "J", false)
+
+	doc.seccion("Horas por materia")
+	doc.tabla([]pdfColumna{
+		{"Código", 30, "L"}, {"Materia", 90, "L"}, {"Tutorías", 30, "R"}, {"Horas", 30, "R"},
+	}, mapFilas(contenido.Materias, func(m CertificadoMateria) []string {
+		return []string{m.Codigo, m.Nombre, formatEntero(float64(m.Tutorias)), formatHoras(m.Minutos)}
+	}))
+
+	doc.seccion("Detalle de sesiones")
+	doc.tabla([]pdfColumna{
+		{"Fecha", 30, "L"}, {"Horario", 35, "L"}, {"Materia", 95, "L"}, {"Horas", 20, "R"},
+	}, mapFilas(sesiones, func(s db.ListTutoriasCertificablesRow) []string {
+		return []string{
+			exportField(s.Fecha).Text,
+			exportField(s.HoraInicio).Text + " - " + exportField(s.HoraFin).Text,
+			s.Materia,
+			formatHoras(s.Minutos),
+		}
+	}))
+
+	doc.seccion("Verificación")
+	pdf.SetFont("Helvetica", "", 9)
+	verificacion := []string{
+		"Código de verificación: " + certificado.Codigo,
+		"Fecha de emisión: " + exportField(certificado.FechaEmision).Text,
+	}
+	if publicURL := strings.TrimSuffix(os.Getenv("PUBLIC_URL"), "/"); publicURL != "" {
+		verificacion = append(verificacion, "Verifique este certificado en: "+publicURL+"/v1/certificados/"+certificado.Codigo)
+	}
+	for _, linea := range verificacion {
+		pdf.CellFormat(0, 5, doc.tr(linea), "", 1, "L", false, 0, "")
+	}
+	pdf.SetFont("Courier", "", 8)
+	pdf.CellFormat(0, 5, "Firma: "+certificado.Firma, "", 1, "L", false, 0, "")
+
+	return pdf.Output(w)
+}
+
+// TutorCertificadoEndpoint handles GET /v1/tutores/{id}/certificado using Go 1.22 routing
+// @Summary      Get Tutor Hours Certificate
+// @Description  Issues a signed PDF certificate of the tutor's completed tutorias with confirmed attendance within the
+// @Description  period, with the hours per materia. Requesting the same period again returns the same verification
+// @Description  code unless the certified sessions changed. The code can be checked at GET /v1/certificados/{codigo}.
+// @Description  Requires the tutor's calendar feed token or an admin bearer token. Certificates are only issued when
+// @Description  CERTIFICADO_SECRET or AUTH_SECRET is configured, so that their signatures survive a restart.
+// @Tags         Tutores
+// @Produce      application/pdf
+// @Security     AdminBearer
+// @Param        id path int true "Tutor ID"
+// @Param        desde query string true "Start of the period (YYYY-MM-DD)"
+// @Param        hasta query string true "End of the period (YYYY-MM-DD)"
+// @Param        token query string false "Feed token of the tutor, from /v1/calendario/token/{mode}"
+// @Success      200 {file} binary "PDF certificate"
+// @Failure      400 {object} ErrorResponse "Invalid tutor ID or period"
+// @Failure      403 {object} ErrorResponse "Invalid feed token"
+// @Failure      404 {object} ErrorResponse "Tutor not found or no certifiable tutorias in the period"
+// @Failure      500 {object} ErrorResponse "Failed to issue certificate"
+// @Failure      503 {object} ErrorResponse "Certificate signing is not configured"
+// @Router       /v1/tutores/{id}/certificado [get]
+func TutorCertificadoEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid tutor ID", http.StatusBadRequest)
+			return
+		}
+
+		if _, ok := requestAdmin(r, queries); !ok {
+			valid, err := checkCalendarioToken(r, queries, "tutor", int32(id), r.URL.Query().Get("token"))
+			if err != nil {
+				http.Error(w, "Failed to verify feed token: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if !valid {
+				http.Error(w, "Invalid feed token", http.StatusForbidden)
+				return
+			}
+		}
+		if _, err := certificadoSecret(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		desde, err := parseDateString(r.URL.Query().Get("desde"))
+		if err != nil {
+			http.Error(w, "Invalid desde format (use YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		hasta, err := parseDateString(r.URL.Query().Get("hasta"))
+		if err != nil {
+			http.Error(w, "Invalid hasta format (use YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		if hasta.Time.Before(desde.Time) {
+			http.Error(w, "hasta must not be before desde", http.StatusBadRequest)
+			return
+		}
+
+		tutor, err := queries.SelectTutorById(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Tutor not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get tutor: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		sesiones, err := queries.ListTutoriasCertificables(r.Context(), db.ListTutoriasCertificablesParams{
+			TutorID: tutor.TutorID,
+			Desde:   desde,
+			Hasta:   hasta,
+		})
+		if err != nil {
+			http.Error(w, "Failed to retrieve tutorias: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(sesiones) == 0 {
+			http.Error(w, "No completed tutorias with confirmed attendance in the period", http.StatusNotFound)
+			return
+		}
+
+		certificado, err := emitirCertificado(r.Context(), queries, tutor, desde, hasta, sesiones)
+		if err != nil {
+			http.Error(w, "Failed to issue certificate: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// Render into a buffer so that a failure can still be reported as an error
+		var buf bytes.Buffer
+		if err := renderCertificadoPDF(&buf, certificado, sesiones); err != nil {
+			http.Error(w, "Failed to render certificate: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="certificado-%s.pdf"`, certificado.Codigo))
+		w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+		buf.WriteTo(w)
+	}
+}
+
+// VerificarCertificadoEndpoint handles GET /v1/certificados/{codigo} using Go 1.22 routing
+// @Summary      Verify Hours Certificate
+// @Description  Public verification of an hours certificate by the code printed on it. valido is false when the stored
+// @Description  content no longer matches its signature.
+// @Tags         Tutores
+// @Produce      json
+// @Param        codigo path string true "Verification code"
+// @Success      200 {object} CertificadoVerificacionResponse "Certificate details"
+// @Failure      404 {object} ErrorResponse "Certificate not found"
+// @Failure      500 {object} ErrorResponse "Failed to verify certificate"
+// @Failure      503 {object} ErrorResponse "Certificate signing is not configured"
+// @Router       /v1/certificados/{codigo} [get]
+func VerificarCertificadoEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		codigo := strings.ToUpper(strings.TrimSpace(r.PathValue("codigo")))
+
+		certificado, err := queries.SelectCertificadoByCodigo(r.Context(), codigo)
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Certificate not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get certificate: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		contenido, err := certificadoContenidoDe(certificado)
+		if err != nil {
+			http.Error(w, "Failed to verify certificate: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		firma, err := firmarCertificado(contenido)
+		if errors.Is(err, errCertificadoSinSecreto) {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to verify certificate: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(CertificadoVerificacionResponse{
+			Codigo:        certificado.Codigo,
+			Valido:        hmac.Equal([]byte(firma), []byte(certificado.Firma)),
+			TutorNombre:   certificado.TutorNombre,
+			Desde:         contenido.Desde,
+			Hasta:         contenido.Hasta,
+			TotalTutorias: certificado.TotalTutorias,
+			TotalHoras:    float64(certificado.TotalMinutos) / 60,
+			Materias:      contenido.Materias,
+			FechaEmision:  exportField(certificado.FechaEmision).Text,
+			Firma:         certificado.Firma,
+		})
+	}
+}
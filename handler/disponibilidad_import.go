@@ -0,0 +1,710 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // TZIDs of imported calendars must resolve even without system zoneinfo
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// DisponibilidadVentana is a weekly availability window.
+type DisponibilidadVentana struct {
+	DisponibilidadID int32  `json:"disponibilidad_id,omitempty" example:"12"` // Omitted for windows not stored yet
+	DiaSemana        int32  `json:"dia_semana" example:"1"`                   // 1 = Monday, 7 = Sunday
+	HoraInicio       string `json:"hora_inicio" example:"09:00"`
+	HoraFin          string `json:"hora_fin" example:"11:00"`
+}
+
+// ImportDisponibilidadResponse describes how the availability of a tutor changes after an import.
+type ImportDisponibilidadResponse struct {
+	DryRun          bool                    `json:"dry_run" example:"true"`
+	BloquesOcupados int                     `json:"bloques_ocupados" example:"14"` // Busy blocks found in the reference period
+	Agregadas       []DisponibilidadVentana `json:"agregadas"`
+	Eliminadas      []DisponibilidadVentana `json:"eliminadas"`
+	Conservadas     []DisponibilidadVentana `json:"conservadas"`
+}
+
+// icsProperty is an unfolded iCalendar content line.
+type icsProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// icsBusyBlock is an instant range during which the calendar owner is busy.
+type icsBusyBlock struct {
+	Start time.Time
+	End   time.Time
+}
+
+// minuteRange is a half-open range of minutes within a day.
+type minuteRange struct {
+	Start int
+	End   int
+}
+
+// maxICSOccurrences bounds the expansion of a single recurring event.
+const maxICSOccurrences = 5000
+
+// parseICSLines unfolds an iCalendar document and splits it into properties.
+func parseICSLines(data string) []icsProperty {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\n ", "")
+	data = strings.ReplaceAll(data, "\n\t", "")
+
+	var properties []icsProperty
+	for _, line := range strings.Split(data, "\n") {
+		colon := -1
+		quoted := false
+		for i, c := range line {
+			if c == '"' {
+				quoted = !quoted
+			} else if c == ':' && !quoted {
+				colon = i
+				break
+			}
+		}
+		if colon < 0 {
+			continue
+		}
+
+		parts := strings.Split(line[:colon], ";")
+		property := icsProperty{
+			Name:   strings.ToUpper(parts[0]),
+			Params: map[string]string{},
+			Value:  line[colon+1:],
+		}
+		for _, param := range parts[1:] {
+			if key, value, ok := strings.Cut(param, "="); ok {
+				property.Params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+			}
+		}
+		properties = append(properties, property)
+	}
+	return properties
+}
+
+// parseICSDateTime parses a DATE or DATE-TIME value. Floating times are interpreted in
+// zonaHoraria; a TZID that is not a known IANA zone is an error.
+func parseICSDateTime(value string, params map[string]string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, zonaHoraria)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	location := zonaHoraria
+	if tzid := params["TZID"]; tzid != "" {
+		loaded, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown TZID %q", tzid)
+		}
+		location = loaded
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, location)
+	return t, false, err
+}
+
+// parseICSDuration parses a DURATION value such as PT1H30M or P1D.
+func parseICSDuration(value string) (time.Duration, error) {
+	value = strings.TrimPrefix(value, "+")
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+	if !strings.HasPrefix(value, "P") {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	var duration time.Duration
+	inTime := false
+	number := 0
+	for _, c := range value[1:] {
+		switch {
+		case c >= '0' && c <= '9':
+			number = number*10 + int(c-'0')
+			continue
+		case c == 'T':
+			inTime = true
+		case c == 'W' && !inTime:
+			duration += time.Duration(number) * 7 * 24 * time.Hour
+		case c == 'D' && !inTime:
+			duration += time.Duration(number) * 24 * time.Hour
+		case c == 'H' && inTime:
+			duration += time.Duration(number) * time.Hour
+		case c == 'M' && inTime:
+			duration += time.Duration(number) * time.Minute
+		case c == 'S' && inTime:
+			duration += time.Duration(number) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		number = 0
+	}
+
+	if negative {
+		duration = -duration
+	}
+	return duration, nil
+}
+
+// icsWeekdays maps RRULE BYDAY codes to weekdays.
+var icsWeekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// icsRuleParts are the RRULE parts icsOccurrences understands. Any other part would change the
+// occurrences, so rules using one are rejected rather than expanded wrongly.
+var icsRuleParts = map[string]bool{
+	"FREQ": true, "INTERVAL": true, "COUNT": true, "UNTIL": true, "BYDAY": true, "WKST": true,
+}
+
+// icsOccurrences expands the start instants of an event within [desde, hasta). Only DAILY and
+// WEEKLY rules are supported, optionally restricted with BYDAY; other rules are an error.
+func icsOccurrences(start time.Time, rrule string, exdates map[int64]bool, desde, hasta time.Time) ([]time.Time, error) {
+	if rrule == "" {
+		if exdates[start.Unix()] || start.Before(desde) || !start.Before(hasta) {
+			return nil, nil
+		}
+		return []time.Time{start}, nil
+	}
+
+	rule := map[string]string{}
+	for _, part := range strings.Split(rrule, ";") {
+		if key, value, ok := strings.Cut(part, "="); ok {
+			key = strings.ToUpper(key)
+			if !icsRuleParts[key] {
+				return nil, fmt.Errorf("unsupported RRULE part %s in %q", key, rrule)
+			}
+			rule[key] = strings.ToUpper(value)
+		}
+	}
+
+	interval := 1
+	if value, ok := rule["INTERVAL"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid RRULE INTERVAL %q", value)
+		}
+		interval = n
+	}
+
+	count := 0
+	if value, ok := rule["COUNT"]; ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid RRULE COUNT %q", value)
+		}
+		if n > maxICSOccurrences {
+			return nil, fmt.Errorf("RRULE COUNT %d exceeds the supported %d occurrences", n, maxICSOccurrences)
+		}
+		count = n
+	}
+
+	var until time.Time
+	if value, ok := rule["UNTIL"]; ok {
+		t, allDay, err := parseICSDateTime(value, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RRULE UNTIL %q", value)
+		}
+		if allDay {
+			t = t.AddDate(0, 0, 1).Add(-time.Second)
+		}
+		until = t
+	}
+
+	// Weekdays of BYDAY, as days since Monday
+	var byDay []int
+	if value, ok := rule["BYDAY"]; ok {
+		for _, day := range strings.Split(value, ",") {
+			weekday, ok := icsWeekdays[day]
+			if !ok {
+				// Ordinal days such as 1MO only make sense in MONTHLY and YEARLY rules
+				return nil, fmt.Errorf("unsupported RRULE BYDAY %q", value)
+			}
+			byDay = append(byDay, (int(weekday)+6)%7)
+		}
+		sort.Ints(byDay)
+	}
+
+	// candidates yields the instants of the n-th period of the rule, each periodDays long
+	var candidates func(n int) []time.Time
+	var periodDays int
+	switch rule["FREQ"] {
+	case "DAILY":
+		periodDays = interval
+		candidates = func(n int) []time.Time {
+			instant := start.AddDate(0, 0, n*interval)
+			if byDay != nil && !slices.Contains(byDay, (int(instant.Weekday())+6)%7) {
+				return nil
+			}
+			return []time.Time{instant}
+		}
+	case "WEEKLY":
+		if wkst, ok := rule["WKST"]; ok && wkst != "MO" && interval > 1 {
+			return nil, fmt.Errorf("unsupported RRULE WKST %q", wkst)
+		}
+		offsets := byDay
+		if offsets == nil {
+			offsets = []int{(int(start.Weekday()) + 6) % 7}
+		}
+
+		periodDays = 7 * interval
+		monday := start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+		candidates = func(n int) []time.Time {
+			week := monday.AddDate(0, 0, 7*n*interval)
+			instants := make([]time.Time, 0, len(offsets))
+			for _, offset := range offsets {
+				instants = append(instants, week.AddDate(0, 0, offset))
+			}
+			return instants
+		}
+	default:
+		return nil, fmt.Errorf("unsupported RRULE FREQ %q (only DAILY and WEEKLY are supported)", rule["FREQ"])
+	}
+
+	// Without COUNT the periods before desde cannot matter, so the expansion starts one period
+	// before the first one reaching desde. With COUNT every occurrence from DTSTART counts.
+	first := 0
+	if count == 0 && desde.After(start) {
+		first = max(int(desde.Sub(start)/(24*time.Hour))/periodDays-1, 0)
+	}
+
+	var occurrences []time.Time
+	emitted := 0
+	for n := first; n < first+maxICSOccurrences; n++ {
+		for _, instant := range candidates(n) {
+			if instant.Before(start) {
+				continue
+			}
+			if !instant.Before(hasta) || (!until.IsZero() && instant.After(until)) || (count > 0 && emitted >= count) {
+				return occurrences, nil
+			}
+			emitted++
+			if !exdates[instant.Unix()] && !instant.Before(desde) {
+				occurrences = append(occurrences, instant)
+			}
+		}
+	}
+	return nil, fmt.Errorf("RRULE %q has more than %d occurrences in the period", rrule, maxICSOccurrences)
+}
+
+// icsVEvent is an imported VEVENT with the instants excluded from its recurrence.
+type icsVEvent struct {
+	Properties map[string]icsProperty
+	Exdates    map[int64]bool
+}
+
+// parseICSBusyBlocks returns the busy blocks of the VEVENTs of an iCalendar document that
+// overlap [desde, hasta). Cancelled and transparent (free) events are ignored. Occurrences
+// overridden by a VEVENT with a RECURRENCE-ID are replaced by that VEVENT.
+func parseICSBusyBlocks(data string, desde, hasta time.Time) ([]icsBusyBlock, error) {
+	if !strings.Contains(data, "BEGIN:VCALENDAR") {
+		return nil, errors.New("not an iCalendar file")
+	}
+
+	var (
+		events     []icsVEvent
+		components []string
+		event      *icsVEvent
+	)
+	for _, property := range parseICSLines(data) {
+		switch property.Name {
+		case "BEGIN":
+			components = append(components, strings.ToUpper(property.Value))
+			if strings.EqualFold(property.Value, "VEVENT") {
+				event = &icsVEvent{Properties: map[string]icsProperty{}, Exdates: map[int64]bool{}}
+			}
+			continue
+		case "END":
+			if len(components) > 0 {
+				components = components[:len(components)-1]
+			}
+			if strings.EqualFold(property.Value, "VEVENT") && event != nil {
+				events = append(events, *event)
+				event = nil
+			}
+			continue
+		}
+
+		if event == nil || len(components) == 0 || components[len(components)-1] != "VEVENT" {
+			continue
+		}
+		switch property.Name {
+		case "EXDATE":
+			for _, value := range strings.Split(property.Value, ",") {
+				t, _, err := parseICSDateTime(value, property.Params)
+				if err != nil {
+					return nil, fmt.Errorf("invalid EXDATE %q: %w", value, err)
+				}
+				event.Exdates[t.Unix()] = true
+			}
+		case "RDATE":
+			return nil, errors.New("RDATE is not supported")
+		default:
+			event.Properties[property.Name] = property
+		}
+	}
+
+	// Overridden occurrences are excluded from the recurring event with the same UID
+	overrides := map[string][]int64{}
+	for _, event := range events {
+		recurrenceID, ok := event.Properties["RECURRENCE-ID"]
+		if !ok {
+			continue
+		}
+		t, _, err := parseICSDateTime(recurrenceID.Value, recurrenceID.Params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RECURRENCE-ID %q: %w", recurrenceID.Value, err)
+		}
+		uid := event.Properties["UID"].Value
+		overrides[uid] = append(overrides[uid], t.Unix())
+	}
+
+	var blocks []icsBusyBlock
+	for _, event := range events {
+		if _, ok := event.Properties["RECURRENCE-ID"]; !ok {
+			for _, instant := range overrides[event.Properties["UID"].Value] {
+				event.Exdates[instant] = true
+			}
+		}
+		eventBlocks, err := icsEventBusyBlocks(event.Properties, event.Exdates, desde, hasta)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, eventBlocks...)
+	}
+	return blocks, nil
+}
+
+// icsEventBusyBlocks expands a single VEVENT into the busy blocks overlapping [desde, hasta).
+func icsEventBusyBlocks(event map[string]icsProperty, exdates map[int64]bool, desde, hasta time.Time) ([]icsBusyBlock, error) {
+	if strings.EqualFold(event["STATUS"].Value, "CANCELLED") || strings.EqualFold(event["TRANSP"].Value, "TRANSPARENT") {
+		return nil, nil
+	}
+
+	dtstart, ok := event["DTSTART"]
+	if !ok {
+		return nil, nil
+	}
+	start, allDay, err := parseICSDateTime(dtstart.Value, dtstart.Params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DTSTART %q: %w", dtstart.Value, err)
+	}
+
+	var duration time.Duration
+	if dtend, ok := event["DTEND"]; ok {
+		end, _, err := parseICSDateTime(dtend.Value, dtend.Params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DTEND %q: %w", dtend.Value, err)
+		}
+		duration = end.Sub(start)
+	} else if value, ok := event["DURATION"]; ok {
+		duration, err = parseICSDuration(value.Value)
+		if err != nil {
+			return nil, err
+		}
+	} else if allDay {
+		duration = 24 * time.Hour
+	}
+	if duration <= 0 {
+		return nil, nil
+	}
+
+	// Occurrences starting before desde still count when they run into it
+	occurrences, err := icsOccurrences(start, event["RRULE"].Value, exdates, desde.Add(-duration+time.Nanosecond), hasta)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]icsBusyBlock, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		blocks = append(blocks, icsBusyBlock{Start: occurrence, End: occurrence.Add(duration)})
+	}
+	return blocks, nil
+}
+
+// busyMinutesByDay projects busy blocks onto the days of the week (1 = Monday) in zonaHoraria,
+// clipped to [desde, hasta).
+func busyMinutesByDay(blocks []icsBusyBlock, desde, hasta time.Time) map[int32][]minuteRange {
+	busy := map[int32][]minuteRange{}
+	for _, block := range blocks {
+		start := block.Start.In(zonaHoraria)
+		if start.Before(desde) {
+			start = desde
+		}
+		end := block.End.In(zonaHoraria)
+		if end.After(hasta) {
+			end = hasta
+		}
+
+		for start.Before(end) {
+			midnight := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, zonaHoraria)
+			nextMidnight := midnight.AddDate(0, 0, 1)
+			segmentEnd := end
+			if segmentEnd.After(nextMidnight) {
+				segmentEnd = nextMidnight
+			}
+
+			from := int(start.Sub(midnight) / time.Minute)
+			to := int((segmentEnd.Sub(midnight) + time.Minute - 1) / time.Minute)
+			dia := getDayOfWeek(start)
+			busy[dia] = append(busy[dia], minuteRange{Start: from, End: to})
+
+			start = segmentEnd
+		}
+	}
+	return busy
+}
+
+// freeWindows returns the gaps of at least minLength minutes left by busy inside frame.
+func freeWindows(frame minuteRange, busy []minuteRange, minLength int) []minuteRange {
+	sort.Slice(busy, func(i, j int) bool { return busy[i].Start < busy[j].Start })
+
+	var windows []minuteRange
+	cursor := frame.Start
+	for _, block := range busy {
+		if block.End <= cursor {
+			continue
+		}
+		if block.Start >= frame.End {
+			break
+		}
+		if block.Start-cursor >= minLength {
+			windows = append(windows, minuteRange{Start: cursor, End: block.Start})
+		}
+		cursor = block.End
+	}
+	if frame.End-cursor >= minLength {
+		windows = append(windows, minuteRange{Start: cursor, End: frame.End})
+	}
+	return windows
+}
+
+// formatMinutes formats minutes since midnight as HH:MM.
+func formatMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// timeToMinutes converts a pgtype.Time to minutes since midnight.
+func timeToMinutes(t pgtype.Time) int {
+	return int(t.Microseconds / (60 * 1000000))
+}
+
+// ImportDisponibilidadEndpoint handles POST /v1/tutores/{id}/disponibilidad/import using Go 1.22 routing
+// @Summary      Import Disponibilidad from iCalendar
+// @Description  Computes a tutor's weekly availability from the busy blocks of an uploaded .ics file (multipart field "archivo" or raw text/calendar body). Free windows are the gaps between busy blocks inside the working-hours frame, over every occurrence within [desde, hasta). The tutor's DISPONIBILIDAD rows are replaced in one transaction; unchanged windows keep their IDs. With dry_run=true only the diff is returned. Recurring events may use DAILY and WEEKLY rules with INTERVAL, COUNT, UNTIL and BYDAY; files with other rules, RDATE or an unknown TZID are rejected.
+// @Tags         Disponibilidad
+// @Accept       multipart/form-data
+// @Accept       text/calendar
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Tutor ID"
+// @Param        archivo formData file false "iCalendar file with the tutor's schedule"
+// @Param        dry_run query bool false "Only compute the diff, without saving"
+// @Param        desde query string false "First day of the reference period (YYYY-MM-DD), defaults to today"
+// @Param        hasta query string false "Day after the reference period (YYYY-MM-DD), defaults to desde + 7 days"
+// @Param        hora_inicio query string false "Start of the working-hours frame (HH:MM)" default(07:00)
+// @Param        hora_fin query string false "End of the working-hours frame (HH:MM)" default(19:00)
+// @Param        dias query string false "Comma-separated working days (1=Monday, 7=Sunday)" default(1,2,3,4,5)
+// @Param        duracion_minima query int false "Shortest free window kept, in minutes" default(30)
+// @Success      200 {object} ImportDisponibilidadResponse "Availability diff"
+// @Failure      400 {object} ErrorResponse "Invalid file or parameters"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Tutor not found"
+// @Failure      500 {object} ErrorResponse "Failed to import disponibilidad"
+// @Router       /v1/tutores/{id}/disponibilidad/import [post]
+func ImportDisponibilidadEndpoint(pool *pgxpool.Pool, queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tutorID, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid tutor ID", http.StatusBadRequest)
+			return
+		}
+
+		query := r.URL.Query()
+		now := time.Now().In(zonaHoraria)
+		desde := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, zonaHoraria)
+		if value := query.Get("desde"); value != "" {
+			desde, err = time.ParseInLocation("2006-01-02", value, zonaHoraria)
+			if err != nil {
+				http.Error(w, "Invalid desde format (use YYYY-MM-DD)", http.StatusBadRequest)
+				return
+			}
+		}
+		hasta := desde.AddDate(0, 0, 7)
+		if value := query.Get("hasta"); value != "" {
+			hasta, err = time.ParseInLocation("2006-01-02", value, zonaHoraria)
+			if err != nil {
+				http.Error(w, "Invalid hasta format (use YYYY-MM-DD)", http.StatusBadRequest)
+				return
+			}
+		}
+		if !hasta.After(desde) || hasta.Sub(desde) > 366*24*time.Hour {
+			http.Error(w, "hasta must be after desde and within one year of it", http.StatusBadRequest)
+			return
+		}
+
+		frame := minuteRange{Start: 7 * 60, End: 19 * 60}
+		if value := query.Get("hora_inicio"); value != "" {
+			horaInicio, err := parseTimeString(value)
+			if err != nil {
+				http.Error(w, "Invalid hora_inicio format (use HH:MM)", http.StatusBadRequest)
+				return
+			}
+			frame.Start = timeToMinutes(horaInicio)
+		}
+		if value := query.Get("hora_fin"); value != "" {
+			horaFin, err := parseTimeString(value)
+			if err != nil {
+				http.Error(w, "Invalid hora_fin format (use HH:MM)", http.StatusBadRequest)
+				return
+			}
+			frame.End = timeToMinutes(horaFin)
+		}
+		if frame.End <= frame.Start {
+			http.Error(w, "hora_fin must be after hora_inicio", http.StatusBadRequest)
+			return
+		}
+
+		dias := []int32{1, 2, 3, 4, 5}
+		if value := query.Get("dias"); value != "" {
+			dias = nil
+			for _, part := range strings.Split(value, ",") {
+				dia, err := strconv.ParseInt(strings.TrimSpace(part), 10, 32)
+				if err != nil || dia < 1 || dia > 7 {
+					http.Error(w, "Invalid dias (use comma-separated days 1-7)", http.StatusBadRequest)
+					return
+				}
+				dias = append(dias, int32(dia))
+			}
+		}
+
+		duracionMinima := 30
+		if value := query.Get("duracion_minima"); value != "" {
+			duracionMinima, err = strconv.Atoi(value)
+			if err != nil || duracionMinima < 1 {
+				http.Error(w, "Invalid duracion_minima (use a positive number of minutes)", http.StatusBadRequest)
+				return
+			}
+		}
+
+		if _, err := queries.SelectTutorById(r.Context(), int32(tutorID)); err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Tutor not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get tutor: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		data, err := readImportFile(w, r)
+		if err != nil {
+			http.Error(w, "Failed to read iCalendar file: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		blocks, err := parseICSBusyBlocks(string(data), desde, hasta)
+		if err != nil {
+			http.Error(w, "Invalid iCalendar file: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Desired windows, keyed like the stored rows
+		busy := busyMinutesByDay(blocks, desde, hasta)
+		desired := map[DisponibilidadVentana]bool{}
+		for _, dia := range dias {
+			for _, window := range freeWindows(frame, busy[dia], duracionMinima) {
+				desired[DisponibilidadVentana{
+					DiaSemana:  dia,
+					HoraInicio: formatMinutes(window.Start),
+					HoraFin:    formatMinutes(window.End),
+				}] = true
+			}
+		}
+
+		existing, err := queries.ListDisponibilidadByTutor(r.Context(), int32(tutorID))
+		if err != nil {
+			http.Error(w, "Failed to retrieve disponibilidad: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		response := ImportDisponibilidadResponse{
+			DryRun:          isDryRun(r),
+			BloquesOcupados: len(blocks),
+			Agregadas:       []DisponibilidadVentana{},
+			Eliminadas:      []DisponibilidadVentana{},
+			Conservadas:     []DisponibilidadVentana{},
+		}
+		for _, disponibilidad := range existing {
+			key := DisponibilidadVentana{
+				DiaSemana:  disponibilidad.DiaSemana,
+				HoraInicio: formatMinutes(timeToMinutes(disponibilidad.HoraInicio)),
+				HoraFin:    formatMinutes(timeToMinutes(disponibilidad.HoraFin)),
+			}
+			ventana := key
+			ventana.DisponibilidadID = disponibilidad.DisponibilidadID
+			if desired[key] {
+				delete(desired, key)
+				response.Conservadas = append(response.Conservadas, ventana)
+			} else {
+				response.Eliminadas = append(response.Eliminadas, ventana)
+			}
+		}
+		for ventana := range desired {
+			response.Agregadas = append(response.Agregadas, ventana)
+		}
+		sort.Slice(response.Agregadas, func(i, j int) bool {
+			a, b := response.Agregadas[i], response.Agregadas[j]
+			if a.DiaSemana != b.DiaSemana {
+				return a.DiaSemana < b.DiaSemana
+			}
+			return a.HoraInicio < b.HoraInicio
+		})
+
+		if !response.DryRun {
+			err = withTx(r.Context(), pool, queries, func(q *db.Queries) error {
+				for _, ventana := range response.Eliminadas {
+					if err := q.DeleteDisponibilidad(r.Context(), ventana.DisponibilidadID); err != nil {
+						return err
+					}
+				}
+				for i, ventana := range response.Agregadas {
+					horaInicio, _ := parseTimeString(ventana.HoraInicio)
+					horaFin, _ := parseTimeString(ventana.HoraFin)
+					disponibilidad, err := q.CreateDisponibilidad(r.Context(), db.CreateDisponibilidadParams{
+						TutorID:    int32(tutorID),
+						DiaSemana:  ventana.DiaSemana,
+						HoraInicio: horaInicio,
+						HoraFin:    horaFin,
+					})
+					if err != nil {
+						return err
+					}
+					response.Agregadas[i].DisponibilidadID = disponibilidad.DisponibilidadID
+				}
+				return nil
+			})
+			if err != nil {
+				http.Error(w, "Failed to import disponibilidad: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// icsCalendar wraps VEVENT bodies into an iCalendar document.
+func icsCalendar(events ...string) string {
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\nVERSION:2.0\r\n")
+	for _, event := range events {
+		b.WriteString("BEGIN:VEVENT\r\n")
+		b.WriteString(strings.ReplaceAll(strings.TrimSpace(event), "\n", "\r\n"))
+		b.WriteString("\r\nEND:VEVENT\r\n")
+	}
+	b.WriteString("END:VCALENDAR\r\n")
+	return b.String()
+}
+
+// bogota returns an instant in zonaHoraria.
+func bogota(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, zonaHoraria)
+}
+
+func TestParseICSBusyBlocks(t *testing.T) {
+	// Monday 2025-03-03 to Monday 2025-03-10
+	desde := bogota(2025, time.March, 3, 0, 0)
+	hasta := bogota(2025, time.March, 10, 0, 0)
+
+	tests := []struct {
+		name   string
+		events []string
+		want   []time.Time // Start of each block, earliest first
+	}{
+		{
+			name: "single event",
+			events: []string{`
+UID:1
+DTSTART;TZID=America/Bogota:20250304T090000
+DTEND;TZID=America/Bogota:20250304T110000`},
+			want: []time.Time{bogota(2025, time.March, 4, 9, 0)},
+		},
+		{
+			name: "event outside the period",
+			events: []string{`
+UID:1
+DTSTART:20250310T140000Z
+DURATION:PT1H`},
+			want: nil,
+		},
+		{
+			name: "weekly by day",
+			events: []string{`
+UID:1
+DTSTART;TZID=America/Bogota:20250303T080000
+DTEND;TZID=America/Bogota:20250303T100000
+RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR`},
+			want: []time.Time{
+				bogota(2025, time.March, 3, 8, 0),
+				bogota(2025, time.March, 5, 8, 0),
+				bogota(2025, time.March, 7, 8, 0),
+			},
+		},
+		{
+			name: "daily started long before desde",
+			events: []string{`
+UID:1
+DTSTART;TZID=America/Bogota:20200101T120000
+DURATION:PT1H
+RRULE:FREQ=DAILY;INTERVAL=2`},
+			want: []time.Time{
+				bogota(2025, time.March, 3, 12, 0),
+				bogota(2025, time.March, 5, 12, 0),
+				bogota(2025, time.March, 7, 12, 0),
+				bogota(2025, time.March, 9, 12, 0),
+			},
+		},
+		{
+			name: "daily restricted by day",
+			events: []string{`
+UID:1
+DTSTART;TZID=America/Bogota:20250301T070000
+DURATION:PT30M
+RRULE:FREQ=DAILY;BYDAY=TU,TH`},
+			want: []time.Time{
+				bogota(2025, time.March, 4, 7, 0),
+				bogota(2025, time.March, 6, 7, 0),
+			},
+		},
+		{
+			name: "exdate",
+			events: []string{`
+UID:1
+DTSTART;TZID=America/Bogota:20250303T080000
+DURATION:PT1H
+RRULE:FREQ=DAILY
+EXDATE;TZID=America/Bogota:20250304T080000,20250306T080000`},
+			want: []time.Time{
+				bogota(2025, time.March, 3, 8, 0),
+				bogota(2025, time.March, 5, 8, 0),
+				bogota(2025, time.March, 7, 8, 0),
+				bogota(2025, time.March, 8, 8, 0),
+				bogota(2025, time.March, 9, 8, 0),
+			},
+		},
+		{
+			name: "until",
+			events: []string{`
+UID:1
+DTSTART;TZID=America/Bogota:20250303T080000
+DURATION:PT1H
+RRULE:FREQ=DAILY;UNTIL=20250305T130000Z`},
+			want: []time.Time{
+				bogota(2025, time.March, 3, 8, 0),
+				bogota(2025, time.March, 4, 8, 0),
+				bogota(2025, time.March, 5, 8, 0),
+			},
+		},
+		{
+			name: "count counted from dtstart",
+			events: []string{`
+UID:1
+DTSTART;TZID=America/Bogota:20250301T080000
+DURATION:PT1H
+RRULE:FREQ=DAILY;COUNT=4`},
+			want: []time.Time{
+				bogota(2025, time.March, 3, 8, 0),
+				bogota(2025, time.March, 4, 8, 0),
+			},
+		},
+		{
+			name: "tzid converted to bogota",
+			events: []string{`
+UID:1
+DTSTART;TZID=Europe/Madrid:20250304T150000
+DURATION:PT1H`},
+			want: []time.Time{bogota(2025, time.March, 4, 9, 0)},
+		},
+		{
+			name: "overlapping the start of the period",
+			events: []string{`
+UID:1
+DTSTART;TZID=America/Bogota:20250302T230000
+DURATION:PT2H`},
+			want: []time.Time{bogota(2025, time.March, 2, 23, 0)},
+		},
+		{
+			name: "recurrence-id replaces an occurrence",
+			events: []string{`
+UID:serie
+DTSTART;TZID=America/Bogota:20250303T080000
+DURATION:PT1H
+RRULE:FREQ=DAILY;COUNT=3`, `
+UID:serie
+RECURRENCE-ID;TZID=America/Bogota:20250304T080000
+DTSTART;TZID=America/Bogota:20250304T160000
+DURATION:PT1H`},
+			want: []time.Time{
+				bogota(2025, time.March, 3, 8, 0),
+				bogota(2025, time.March, 4, 16, 0),
+				bogota(2025, time.March, 5, 8, 0),
+			},
+		},
+		{
+			name: "cancelled and transparent events are free",
+			events: []string{`
+UID:1
+DTSTART;TZID=America/Bogota:20250304T090000
+DURATION:PT1H
+STATUS:CANCELLED`, `
+UID:2
+DTSTART;TZID=America/Bogota:20250305T090000
+DURATION:PT1H
+TRANSP:TRANSPARENT`},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks, err := parseICSBusyBlocks(icsCalendar(tt.events...), desde, hasta)
+			if err != nil {
+				t.Fatalf("parseICSBusyBlocks: %v", err)
+			}
+			var got []time.Time
+			for _, block := range blocks {
+				got = append(got, block.Start)
+			}
+			sort.Slice(got, func(i, j int) bool { return got[i].Before(got[j]) })
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d blocks %v, want %d %v", len(got), got, len(tt.want), tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("block %d starts at %v, want %v", i, got[i].In(zonaHoraria), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseICSBusyBlocksRejectsUnsupported(t *testing.T) {
+	desde := bogota(2025, time.March, 3, 0, 0)
+	hasta := bogota(2025, time.March, 10, 0, 0)
+
+	tests := []struct {
+		name  string
+		event string
+		error string
+	}{
+		{"unknown tzid", "UID:1\nDTSTART;TZID=Hora de Marte:20250304T090000\nDURATION:PT1H", `unknown TZID "Hora de Marte"`},
+		{"monthly", "UID:1\nDTSTART:20250304T140000Z\nDURATION:PT1H\nRRULE:FREQ=MONTHLY;BYMONTHDAY=4", "BYMONTHDAY"},
+		{"yearly", "UID:1\nDTSTART:20250304T140000Z\nDURATION:PT1H\nRRULE:FREQ=YEARLY", `FREQ "YEARLY"`},
+		{"ordinal byday", "UID:1\nDTSTART:20250304T140000Z\nDURATION:PT1H\nRRULE:FREQ=WEEKLY;BYDAY=1TU", "BYDAY"},
+		{"rdate", "UID:1\nDTSTART:20250304T140000Z\nDURATION:PT1H\nRDATE:20250305T140000Z", "RDATE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseICSBusyBlocks(icsCalendar(tt.event), desde, hasta)
+			if err == nil || !strings.Contains(err.Error(), tt.error) {
+				t.Errorf("error = %v, want one mentioning %s", err, tt.error)
+			}
+		})
+	}
+}
@@ -2,11 +2,14 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/mail"
 	"strconv"
 	"strings"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/matwate/proyecto-datos/db"
 )
@@ -121,27 +124,76 @@ func handleEstudianteGET(w http.ResponseWriter, r *http.Request, queries *db.Que
 	http.Error(w, "Invalid path", http.StatusBadRequest)
 }
 
+// estudianteSorts are the sort fields of GET /v1/estudiantes, the first one being the default.
+var estudianteSorts = []string{"apellido", "nombre", "correo", "programa_academico", "semestre", "fecha_registro", "id"}
+
+// estudianteSortKey returns the sort key ListEstudiantes computes for an estudiante.
+func estudianteSortKey(sort string, estudiante db.Estudiante) string {
+	switch sort {
+	case "nombre":
+		return estudiante.Nombre + " " + estudiante.Apellido
+	case "correo":
+		return estudiante.Correo
+	case "programa_academico":
+		return estudiante.ProgramaAcademico
+	case "semestre":
+		return sortKeyInt(estudiante.Semestre.Int32)
+	case "fecha_registro":
+		return sortKeyTimestamp(estudiante.FechaRegistro)
+	case "id":
+		return sortKeyInt(estudiante.EstudianteID)
+	default:
+		return estudiante.Apellido + " " + estudiante.Nombre
+	}
+}
+
 // listEstudiantesHandler handles GET /v1/estudiantes
 // @Summary      List All Estudiantes
 // @Description  Retrieves a list of all students.
 // @Tags         Estudiantes
 // @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        programa query string false "Only estudiantes of this academic program; the result is not paginated"
+// @Param        limit query int false "Maximum number of items per page" default(50) minimum(1) maximum(500)
+// @Param        after query string false "Cursor of the next page, as returned in X-Next-Cursor"
+// @Param        sort query string false "Sort field" Enums(apellido, nombre, correo, programa_academico, semestre, fecha_registro, id) default(apellido)
+// @Param        direction query string false "Sort direction" Enums(asc, desc) default(asc)
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
 // @Success      200 {array} db.Estudiante "Successfully retrieved estudiantes"
+// @Header       200 {integer} X-Total-Count "Total number of estudiantes"
+// @Header       200 {string} X-Next-Cursor "Cursor of the next page; absent on the last page"
+// @Failure      400 {object} ErrorResponse "Invalid pagination parameters"
 // @Failure      500 {object} ErrorResponse "Failed to retrieve estudiantes"
 // @Router       /v1/estudiantes [get]
 func listEstudiantesHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
-	estudiantes, err := queries.ListEstudiantes(r.Context())
+	page, err := parseListPage(r, estudianteSorts, false)
 	if err != nil {
-		http.Error(
-			w,
-			"Failed to retrieve estudiantes: "+err.Error(),
-			http.StatusInternalServerError,
-		)
+		http.Error(w, "Invalid pagination parameters: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(estudiantes)
+	estudiantes, err := queries.ListEstudiantes(r.Context(), db.ListEstudiantesParams{
+		Sort:       page.Sort,
+		AfterID:    page.AfterID,
+		Descending: page.Descending,
+		AfterValor: page.AfterValor,
+		RowLimit:   page.Limit + 1,
+	})
+	if err != nil {
+		http.Error(w, "Failed to retrieve estudiantes: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	total, err := queries.CountEstudiantes(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to count estudiantes: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writePage(w, r, "estudiantes", page, estudiantes, total, func(row db.Estudiante) (string, int32) {
+		return estudianteSortKey(page.Sort, row), row.EstudianteID
+	})
 }
 
 // getEstudianteByIDHandler handles GET /v1/estudiantes/{id}
@@ -182,14 +234,6 @@ func getEstudianteByIDHandler(
 }
 
 // listEstudiantesByProgramaHandler handles GET /v1/estudiantes?programa={programa}
-// @Summary      List Estudiantes by Program
-// @Description  Retrieves students filtered by academic program.
-// @Tags         Estudiantes
-// @Produce      json
-// @Param        programa query string true "Academic program"
-// @Success      200 {array} db.Estudiante "Successfully retrieved estudiantes"
-// @Failure      500 {object} ErrorResponse "Failed to retrieve estudiantes"
-// @Router       /v1/estudiantes [get]
 func listEstudiantesByProgramaHandler(
 	w http.ResponseWriter,
 	r *http.Request,
@@ -206,8 +250,7 @@ func listEstudiantesByProgramaHandler(
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(estudiantes)
+	writeList(w, r, "estudiantes", estudiantes)
 }
 
 // updateEstudianteHandler handles PUT /v1/estudiantes/{id}
@@ -263,11 +306,14 @@ func updateEstudianteHandler(w http.ResponseWriter, r *http.Request, queries *db
 
 // deleteEstudianteHandler handles DELETE /v1/estudiantes/{id}
 // @Summary      Delete Estudiante
-// @Description  Deletes a student by their ID.
+// @Description  Moves a student to the papelera, keeping their tutorias and inscripciones. The student is no longer
+// @Description  listed and can be restored by an admin until the retention period ends, when they are deleted
+// @Description  permanently.
 // @Tags         Estudiantes
 // @Param        id path int true "Estudiante ID"
 // @Success      204 "Successfully deleted estudiante"
 // @Failure      400 {object} ErrorResponse "Invalid estudiante ID"
+// @Failure      404 {object} ErrorResponse "Estudiante not found"
 // @Failure      500 {object} ErrorResponse "Failed to delete estudiante"
 // @Router       /v1/estudiantes/{id} [delete]
 func deleteEstudianteHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
@@ -278,11 +324,195 @@ func deleteEstudianteHandler(w http.ResponseWriter, r *http.Request, queries *db
 		return
 	}
 
-	err = queries.DeleteEstudiante(r.Context(), int32(id))
+	deleted, err := queries.SoftDeleteEstudiante(r.Context(), int32(id))
 	if err != nil {
 		http.Error(w, "Failed to delete estudiante: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if deleted == 0 {
+		http.Error(w, "Estudiante not found", http.StatusNotFound)
+		return
+	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// estudianteImportColumns are the columns of the registrar CSV accepted by the import.
+var estudianteImportColumns = []string{"nombre", "apellido", "correo", "programa_academico", "semestre", "ti"}
+
+// ImportEstudianteFila is the outcome of one row of an estudiantes import.
+type ImportEstudianteFila struct {
+	Fila         int      `json:"fila"                    example:"2"` // Line number in the CSV file
+	Correo       string   `json:"correo"                  example:"juan.perez@urosario.edu.co"`
+	Estado       string   `json:"estado"                  example:"creado"` // creado, actualizado or rechazado
+	EstudianteID int32    `json:"estudiante_id,omitempty" example:"1"`
+	Errores      []string `json:"errores,omitempty"`
+}
+
+// ImportEstudiantesResponse summarizes an estudiantes import.
+type ImportEstudiantesResponse struct {
+	DryRun       bool                   `json:"dry_run"       example:"false"`
+	Creados      int                    `json:"creados"       example:"120"`
+	Actualizados int                    `json:"actualizados"  example:"35"`
+	Rechazados   int                    `json:"rechazados"    example:"2"`
+	Filas        []ImportEstudianteFila `json:"filas"`
+}
+
+// validateEstudianteImportRow checks a CSV row and returns the upsert parameters it maps to,
+// or the reasons it is rejected.
+func validateEstudianteImportRow(row csvImportRow) (db.UpsertEstudianteByCorreoParams, []string) {
+	var errores []string
+	params := db.UpsertEstudianteByCorreoParams{
+		Nombre:            row.Values["nombre"],
+		Apellido:          row.Values["apellido"],
+		Correo:            row.Values["correo"],
+		ProgramaAcademico: row.Values["programa_academico"],
+	}
+
+	for _, field := range []struct{ name, value string }{
+		{"nombre", params.Nombre},
+		{"apellido", params.Apellido},
+		{"correo", params.Correo},
+		{"programa_academico", params.ProgramaAcademico},
+	} {
+		if field.value == "" {
+			errores = append(errores, field.name+" is required")
+		} else if len([]rune(field.value)) > 100 {
+			errores = append(errores, field.name+" must be at most 100 characters")
+		}
+	}
+
+	if params.Correo != "" {
+		if address, err := mail.ParseAddress(params.Correo); err != nil || address.Address != params.Correo {
+			errores = append(errores, "correo is not a valid email address")
+		}
+	}
+
+	semestre, err := strconv.ParseInt(row.Values["semestre"], 10, 32)
+	if err != nil || semestre < 1 || semestre > 12 {
+		errores = append(errores, "semestre must be a number between 1 and 12")
+	}
+	params.Semestre = pgtype.Int4{Int32: int32(semestre), Valid: true}
+
+	ti, err := strconv.ParseInt(row.Values["ti"], 10, 32)
+	if err != nil || ti <= 0 {
+		errores = append(errores, "ti must be a positive number")
+	}
+	params.Ti = pgtype.Int4{Int32: int32(ti), Valid: true}
+
+	return params, errores
+}
+
+// ImportEstudiantesEndpoint handles POST /v1/estudiantes/import using Go 1.22 routing
+// @Summary      Import Estudiantes from CSV
+// @Description  Bulk-loads students from a registrar CSV (multipart field "archivo" or raw text/csv body) with the columns nombre, apellido, correo, programa_academico, semestre and ti. Every row is validated; valid rows are upserted by correo in a single transaction, and invalid rows are reported with their reasons. With dry_run=true nothing is saved.
+// @Tags         Estudiantes
+// @Accept       multipart/form-data
+// @Accept       text/csv
+// @Produce      json
+// @Security     AdminBearer
+// @Param        archivo formData file false "CSV file"
+// @Param        dry_run query bool false "Only validate and report, without saving"
+// @Success      200 {object} ImportEstudiantesResponse "Per-row import report"
+// @Failure      400 {object} ErrorResponse "Invalid CSV file"
+// @Failure      401 {object} ErrorResponse "Missing or invalid admin token"
+// @Failure      500 {object} ErrorResponse "Failed to import estudiantes"
+// @Router       /v1/estudiantes/import [post]
+func ImportEstudiantesEndpoint(pool *pgxpool.Pool, queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := readImportFile(w, r)
+		if err != nil {
+			http.Error(w, "Failed to read CSV file: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rows, err := parseCSVImport(data, estudianteImportColumns)
+		if err != nil {
+			http.Error(w, "Invalid CSV file: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		existing, err := queries.ListEstudianteCorreos(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve estudiantes: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		existingIDs := make(map[string]int32, len(existing))
+		for _, estudiante := range existing {
+			existingIDs[estudiante.Correo] = estudiante.EstudianteID
+		}
+
+		response := ImportEstudiantesResponse{
+			DryRun: isDryRun(r),
+			Filas:  make([]ImportEstudianteFila, 0, len(rows)),
+		}
+		valid := make([]db.UpsertEstudianteByCorreoParams, 0, len(rows))
+		validFilas := make([]int, 0, len(rows))
+		seen := map[string]int{}
+
+		for _, row := range rows {
+			params, errores := validateEstudianteImportRow(row)
+			if line, ok := seen[params.Correo]; ok && params.Correo != "" {
+				errores = append(errores, fmt.Sprintf("correo is repeated, first seen on line %d", line))
+			} else {
+				seen[params.Correo] = row.Line
+			}
+
+			fila := ImportEstudianteFila{Fila: row.Line, Correo: params.Correo}
+			if len(errores) > 0 {
+				fila.Estado = "rechazado"
+				fila.Errores = errores
+				response.Rechazados++
+			} else if id, ok := existingIDs[params.Correo]; ok {
+				fila.Estado = "actualizado"
+				fila.EstudianteID = id
+				response.Actualizados++
+			} else {
+				fila.Estado = "creado"
+				response.Creados++
+			}
+
+			if fila.Estado != "rechazado" {
+				valid = append(valid, params)
+				validFilas = append(validFilas, len(response.Filas))
+			}
+			response.Filas = append(response.Filas, fila)
+		}
+
+		if !response.DryRun && len(valid) > 0 {
+			err = withTx(r.Context(), pool, queries, func(q *db.Queries) error {
+				for i, params := range valid {
+					result, err := q.UpsertEstudianteByCorreo(r.Context(), params)
+					if err != nil {
+						return fmt.Errorf("line %d: %w", response.Filas[validFilas[i]].Fila, err)
+					}
+					fila := &response.Filas[validFilas[i]]
+					fila.EstudianteID = result.EstudianteID
+					fila.Estado = "actualizado"
+					if result.Creado {
+						fila.Estado = "creado"
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				http.Error(w, "Failed to import estudiantes: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			// Recount in case the table changed since the existing correos were listed
+			response.Creados, response.Actualizados = 0, 0
+			for _, fila := range response.Filas {
+				switch fila.Estado {
+				case "creado":
+					response.Creados++
+				case "actualizado":
+					response.Actualizados++
+				}
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
@@ -0,0 +1,444 @@
+package handler
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Export formats accepted by list endpoints through ?format= or the Accept header.
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+	formatXLSX = "xlsx"
+)
+
+// xlsxContentType is the media type of Excel workbooks.
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// exportHeaders are the Spanish column headers of exported fields, by Go field name.
+// Fields not listed get their name split into words.
+var exportHeaders = map[string]string{
+	"AsignacionID":         "ID asignación",
+	"EstudianteID":         "ID estudiante",
+	"MateriaID":            "ID materia",
+	"ReporteID":            "ID reporte",
+	"TutorID":              "ID tutor",
+	"TutoriaID":            "ID tutoría",
+	"Nombre":               "Nombre",
+	"Apellido":             "Apellido",
+	"Correo":               "Correo",
+	"ProgramaAcademico":    "Programa académico",
+	"Semestre":             "Semestre",
+	"Ti":                   "Documento (TI)",
+	"FechaRegistro":        "Fecha de registro",
+	"DeletedAt":            "Fecha de eliminación",
+	"Codigo":               "Código",
+	"Facultad":             "Facultad",
+	"Descripcion":          "Descripción",
+	"Creditos":             "Créditos",
+	"Activo":               "Activo",
+	"Fecha":                "Fecha",
+	"HoraInicio":           "Hora de inicio",
+	"HoraFin":              "Hora de fin",
+	"Estado":               "Estado",
+	"Lugar":                "Lugar",
+	"FechaSolicitud":       "Fecha de solicitud",
+	"FechaConfirmacion":    "Fecha de confirmación",
+	"TemasTratados":        "Temas tratados",
+	"AsistenciaConfirmada": "Asistencia confirmada",
+	"Materia":              "Materia",
+	"MateriaNombre":        "Materia",
+	"EstudianteNombre":     "Nombre del estudiante",
+	"EstudianteApellido":   "Apellido del estudiante",
+	"NombreEstudiante":     "Nombre del estudiante",
+	"ApellidoEstudiante":   "Apellido del estudiante",
+	"TutorNombre":          "Nombre del tutor",
+	"TutorApellido":        "Apellido del tutor",
+	"NombreTutor":          "Nombre del tutor",
+	"ApellidoTutor":        "Apellido del tutor",
+	"FechaAsignacion":      "Fecha de asignación",
+	"TipoReporte":          "Tipo de reporte",
+	"FechaGeneracion":      "Fecha de generación",
+	"PeriodoInicio":        "Inicio del periodo",
+	"PeriodoFin":           "Fin del periodo",
+	"GeneradoPor":          "Generado por (ID admin)",
+	"Datos":                "Datos",
+	"EventoID":             "ID evento",
+	"AdminID":              "ID admin",
+	"EntidadID":            "ID entidad",
+	"EstadoHttp":           "Estado HTTP",
+	"Despues":              "Después",
+	"Ip":                   "IP",
+}
+
+// exportKind tells how an exported value is written to a spreadsheet cell.
+type exportKind int
+
+const (
+	exportText exportKind = iota
+	exportNumber
+	exportBool
+	exportDate
+	exportTime
+	exportTimestamp
+)
+
+// exportValue is a single exported cell. Empty values (NULLs) have Kind exportText and no Text.
+type exportValue struct {
+	Kind   exportKind
+	Text   string    // Formatted value, used for CSV and text cells
+	Number float64   // exportNumber, and exportTime as a fraction of a day
+	Time   time.Time // exportDate and exportTimestamp
+}
+
+// exportFormat returns the export format requested through ?format= or the Accept header.
+func exportFormat(r *http.Request) (string, error) {
+	switch format := strings.ToLower(r.URL.Query().Get("format")); format {
+	case formatJSON, formatCSV, formatXLSX:
+		return format, nil
+	case "":
+	default:
+		return "", fmt.Errorf("invalid format %q (use json, csv or xlsx)", format)
+	}
+
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "text/csv"):
+		return formatCSV, nil
+	case strings.Contains(accept, xlsxContentType):
+		return formatXLSX, nil
+	default:
+		return formatJSON, nil
+	}
+}
+
+// writeList writes the result of a list endpoint as JSON, CSV or XLSX depending on the
+// requested format. rows must be a slice of structs; nombre names the download and the sheet.
+func writeList(w http.ResponseWriter, r *http.Request, nombre string, rows any) {
+	format, err := exportFormat(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if format == formatJSON {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(rows)
+		return
+	}
+
+	headers, records := exportRecords(rows)
+	filename := nombre + "." + format
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	if format == formatCSV {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		writeCSV(w, headers, records)
+		return
+	}
+
+	w.Header().Set("Content-Type", xlsxContentType)
+	if err := writeXLSX(w, nombre, headers, records); err != nil {
+		// The response has already started, so the client just gets a truncated file
+		log.Printf("export: could not write %s: %v", filename, err)
+	}
+}
+
+// exportRecords flattens a slice of structs into headers and cell values.
+func exportRecords(rows any) ([]string, [][]exportValue) {
+	value := reflect.ValueOf(rows)
+	elemType := value.Type().Elem()
+
+	var fields []int
+	var headers []string
+	for i := 0; i < elemType.NumField(); i++ {
+		field := elemType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fields = append(fields, i)
+		header, ok := exportHeaders[field.Name]
+		if !ok {
+			header = splitFieldName(field.Name)
+		}
+		headers = append(headers, header)
+	}
+
+	records := make([][]exportValue, value.Len())
+	for i := range records {
+		row := value.Index(i)
+		record := make([]exportValue, len(fields))
+		for j, field := range fields {
+			record[j] = exportField(row.Field(field).Interface())
+		}
+		records[i] = record
+	}
+	return headers, records
+}
+
+// splitFieldName turns a Go field name such as ProgramaAcademico into "Programa academico".
+func splitFieldName(name string) string {
+	var b strings.Builder
+	for i, c := range name {
+		if i > 0 && unicode.IsUpper(c) {
+			b.WriteRune(' ')
+			c = unicode.ToLower(c)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// exportField converts a database field into a cell value.
+func exportField(field any) exportValue {
+	switch v := field.(type) {
+	case string:
+		return exportValue{Kind: exportText, Text: v}
+	case int32:
+		return exportValue{Kind: exportNumber, Text: strconv.Itoa(int(v)), Number: float64(v)}
+	case int64:
+		return exportValue{Kind: exportNumber, Text: strconv.FormatInt(v, 10), Number: float64(v)}
+	case float64:
+		return exportValue{Kind: exportNumber, Text: strconv.FormatFloat(v, 'f', -1, 64), Number: v}
+	case bool:
+		return exportBoolValue(v)
+	case []byte:
+		return exportValue{Kind: exportText, Text: string(v)}
+	case json.RawMessage:
+		return exportValue{Kind: exportText, Text: string(v)}
+	case []string:
+		return exportValue{Kind: exportText, Text: strings.Join(v, ", ")}
+	case nil:
+		return exportValue{}
+	case pgtype.Text:
+		return exportValue{Kind: exportText, Text: v.String}
+	case pgtype.Int4:
+		if !v.Valid {
+			return exportValue{}
+		}
+		return exportField(v.Int32)
+	case pgtype.Int8:
+		if !v.Valid {
+			return exportValue{}
+		}
+		return exportField(v.Int64)
+	case pgtype.Float8:
+		if !v.Valid {
+			return exportValue{}
+		}
+		return exportField(v.Float64)
+	case pgtype.Numeric:
+		f, err := v.Float64Value()
+		if err != nil || !f.Valid {
+			return exportValue{}
+		}
+		return exportField(f.Float64)
+	case pgtype.Bool:
+		if !v.Valid {
+			return exportValue{}
+		}
+		return exportBoolValue(v.Bool)
+	case pgtype.Date:
+		if !v.Valid {
+			return exportValue{}
+		}
+		return exportValue{Kind: exportDate, Text: v.Time.Format("2006-01-02"), Time: v.Time}
+	case pgtype.Time:
+		if !v.Valid {
+			return exportValue{}
+		}
+		minutes := v.Microseconds / (60 * 1000000)
+		return exportValue{
+			Kind:   exportTime,
+			Text:   fmt.Sprintf("%02d:%02d", minutes/60, minutes%60),
+			Number: float64(v.Microseconds) / float64(24*time.Hour/time.Microsecond),
+		}
+	case pgtype.Timestamp:
+		if !v.Valid {
+			return exportValue{}
+		}
+		return exportValue{Kind: exportTimestamp, Text: v.Time.Format("2006-01-02 15:04:05"), Time: v.Time}
+	case pgtype.Timestamptz:
+		if !v.Valid {
+			return exportValue{}
+		}
+		return exportField(pgtype.Timestamp{Time: v.Time, Valid: true})
+	default:
+		// Exporting the Go representation would leak struct internals into the file
+		log.Printf("export: unsupported field type %T", v)
+		return exportValue{}
+	}
+}
+
+// exportBoolValue converts a boolean into a Sí/No cell.
+func exportBoolValue(v bool) exportValue {
+	if v {
+		return exportValue{Kind: exportBool, Text: "Sí", Number: 1}
+	}
+	return exportValue{Kind: exportBool, Text: "No"}
+}
+
+// csvCell returns the CSV text of a cell. Text starting with a character that spreadsheets read
+// as the start of a formula is prefixed with a quote, so that user input such as a tutoria's
+// lugar cannot run formulas when the file is opened.
+func csvCell(value exportValue) string {
+	if value.Kind == exportText && value.Text != "" && strings.ContainsRune("=+-@\t\r", rune(value.Text[0])) {
+		return "'" + value.Text
+	}
+	return value.Text
+}
+
+// writeCSV streams records as CSV with a header row.
+func writeCSV(w io.Writer, headers []string, records [][]exportValue) {
+	writer := csv.NewWriter(w)
+	writer.Write(headers)
+	line := make([]string, len(headers))
+	for _, record := range records {
+		for i, value := range record {
+			line[i] = csvCell(value)
+		}
+		writer.Write(line)
+	}
+	writer.Flush()
+}
+
+// xlsxStatic are the workbook parts that do not depend on the exported data.
+var xlsxStatic = map[string]string{
+	"[Content_Types].xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`,
+	"_rels/.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`,
+	"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`,
+	// Cell styles: 0 default, 1 bold header, 2 date, 3 time, 4 date-time
+	"xl/styles.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<numFmts count="3"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/><numFmt numFmtId="165" formatCode="hh:mm"/><numFmt numFmtId="166" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="5">` +
+		`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+		`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`<xf numFmtId="166" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`</cellXfs></styleSheet>`,
+}
+
+// xlsxEpoch is day zero of Excel date serial numbers.
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxSerial converts a wall-clock time into an Excel date serial number.
+func xlsxSerial(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	return wall.Sub(xlsxEpoch).Hours() / 24
+}
+
+// xlsxColumn returns the spreadsheet column name of a 0-based index (A, B, ..., AA, ...).
+func xlsxColumn(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// writeXLSX streams a single-sheet Excel workbook with a bold header row. Dates and times
+// are written as real spreadsheet dates so they sort and filter correctly.
+func writeXLSX(w io.Writer, nombre string, headers []string, records [][]exportValue) error {
+	archive := zip.NewWriter(w)
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels", "xl/styles.xml"} {
+		part, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(part, xlsxStatic[name]); err != nil {
+			return err
+		}
+	}
+
+	part, err := archive.Create("xl/workbook.xml")
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(part, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="`)
+	xml.EscapeText(part, []byte(nombre))
+	fmt.Fprint(part, `" sheetId="1" r:id="rId1"/></sheets></workbook>`)
+
+	part, err = archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	sheet := bufio.NewWriter(part)
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	sheet.WriteString(`<row r="1">`)
+	for i, header := range headers {
+		fmt.Fprintf(sheet, `<c r="%s1" t="inlineStr" s="1"><is><t>`, xlsxColumn(i))
+		xml.EscapeText(sheet, []byte(header))
+		sheet.WriteString(`</t></is></c>`)
+	}
+	sheet.WriteString(`</row>`)
+
+	for i, record := range records {
+		row := i + 2
+		fmt.Fprintf(sheet, `<row r="%d">`, row)
+		for j, value := range record {
+			ref := xlsxColumn(j) + strconv.Itoa(row)
+			switch {
+			case value.Kind == exportNumber:
+				fmt.Fprintf(sheet, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(value.Number, 'f', -1, 64))
+			case value.Kind == exportBool:
+				fmt.Fprintf(sheet, `<c r="%s" t="b"><v>%d</v></c>`, ref, int(value.Number))
+			case value.Kind == exportDate:
+				fmt.Fprintf(sheet, `<c r="%s" s="2"><v>%s</v></c>`, ref, strconv.FormatFloat(xlsxSerial(value.Time), 'f', -1, 64))
+			case value.Kind == exportTime:
+				fmt.Fprintf(sheet, `<c r="%s" s="3"><v>%s</v></c>`, ref, strconv.FormatFloat(value.Number, 'f', -1, 64))
+			case value.Kind == exportTimestamp:
+				fmt.Fprintf(sheet, `<c r="%s" s="4"><v>%s</v></c>`, ref, strconv.FormatFloat(xlsxSerial(value.Time), 'f', -1, 64))
+			case value.Text != "":
+				fmt.Fprintf(sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+				xml.EscapeText(sheet, []byte(value.Text))
+				sheet.WriteString(`</t></is></c>`)
+			}
+		}
+		sheet.WriteString(`</row>`)
+	}
+
+	sheet.WriteString(`</sheetData></worksheet>`)
+	if err := sheet.Flush(); err != nil {
+		return err
+	}
+	return archive.Close()
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	records := [][]exportValue{{
+		exportField("=HYPERLINK(\"http://example.com\")"),
+		exportField("+57 300"),
+		exportField("-1+1"),
+		exportField("@SUM(A1)"),
+		exportField("Edificio Cabal 301"),
+		exportField(int32(-4)),
+		exportField(pgtype.Text{String: "=1+1", Valid: true}),
+	}}
+
+	var buf bytes.Buffer
+	writeCSV(&buf, []string{"a", "b", "c", "d", "e", "f", "g"}, records)
+
+	lines, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("could not read CSV: %v", err)
+	}
+	want := []string{
+		"'=HYPERLINK(\"http://example.com\")",
+		"'+57 300",
+		"'-1+1",
+		"'@SUM(A1)",
+		"Edificio Cabal 301",
+		"-4",
+		"'=1+1",
+	}
+	for i, cell := range lines[1] {
+		if cell != want[i] {
+			t.Errorf("cell %d = %q, want %q", i, cell, want[i])
+		}
+	}
+}
+
+func TestExportFieldPgtypes(t *testing.T) {
+	var numeric pgtype.Numeric
+	if err := numeric.Scan("87.5"); err != nil {
+		t.Fatalf("could not scan numeric: %v", err)
+	}
+
+	tests := []struct {
+		field any
+		kind  exportKind
+		text  string
+	}{
+		{nil, exportText, ""},
+		{numeric, exportNumber, "87.5"},
+		{pgtype.Numeric{}, exportText, ""},
+		{pgtype.Int8{Int64: 12, Valid: true}, exportNumber, "12"},
+		{pgtype.Float8{Float64: 1.25, Valid: true}, exportNumber, "1.25"},
+		{pgtype.Int4{}, exportText, ""},
+		{struct{ X int }{1}, exportText, ""},
+	}
+	for _, tt := range tests {
+		got := exportField(tt.field)
+		if got.Kind != tt.kind || got.Text != tt.text {
+			t.Errorf("exportField(%#v) = %v %q, want %v %q", tt.field, got.Kind, got.Text, tt.kind, tt.text)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxImportSize bounds the size of uploaded import files.
+const maxImportSize = 5 << 20 // 5 MiB
+
+// readImportFile returns the uploaded file of an import request. The file is read from the
+// "archivo" field of a multipart/form-data body, or from the raw body otherwise.
+func readImportFile(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		file, _, err := r.FormFile("archivo")
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+
+	return io.ReadAll(r.Body)
+}
+
+// isDryRun reports whether the dry_run query parameter of an import request is set.
+func isDryRun(r *http.Request) bool {
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+	return dryRun
+}
+
+// csvImportRow is a data row of an imported CSV file, keyed by lower-case column name.
+type csvImportRow struct {
+	Line   int // Line number in the file, the header being line 1
+	Values map[string]string
+}
+
+// parseCSVImport parses a CSV file with a header row. Columns may come in any order and
+// the delimiter may be a comma or a semicolon (as exported by spreadsheets in Spanish
+// locales). It fails when one of the required columns is missing.
+func parseCSVImport(data []byte, required []string) ([]csvImportRow, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // UTF-8 BOM
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	if header, _, _ := bytes.Cut(data, []byte("\n")); bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read header: %w", err)
+	}
+	columns := make([]string, len(header))
+	present := map[string]bool{}
+	for i, name := range header {
+		columns[i] = strings.ToLower(strings.TrimSpace(name))
+		present[columns[i]] = true
+	}
+	for _, name := range required {
+		if !present[name] {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var rows []csvImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := csvImportRow{Line: line, Values: map[string]string{}}
+		empty := true
+		for i, value := range record {
+			if i < len(columns) {
+				row.Values[columns[i]] = strings.TrimSpace(value)
+				empty = empty && row.Values[columns[i]] == ""
+			}
+		}
+		if !empty {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
@@ -0,0 +1,473 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// CreateInscripcionRequest represents the request body for enrolling a student in a materia.
+type CreateInscripcionRequest struct {
+	EstudianteID int32  `json:"estudiante_id" example:"1"`
+	MateriaID    int32  `json:"materia_id" example:"3"`
+	Periodo      string `json:"periodo" example:"2025-1"` // Defaults to the current academic period
+}
+
+// inscripcionImportColumns are the columns of the registrar CSV accepted by the enrollment import.
+var inscripcionImportColumns = []string{"correo", "codigo", "periodo"}
+
+// ImportInscripcionFila is the outcome of one row of an inscripciones import.
+type ImportInscripcionFila struct {
+	Fila          int      `json:"fila"                     example:"2"` // Line number in the CSV file
+	Correo        string   `json:"correo"                   example:"juan.perez@urosario.edu.co"`
+	Codigo        string   `json:"codigo"                   example:"MATH101"`
+	Periodo       string   `json:"periodo"                  example:"2025-1"`
+	Estado        string   `json:"estado"                   example:"creada"` // creada, existente or rechazada
+	InscripcionID int32    `json:"inscripcion_id,omitempty" example:"1"`
+	Errores       []string `json:"errores,omitempty"`
+}
+
+// ImportInscripcionesResponse summarizes an inscripciones import.
+type ImportInscripcionesResponse struct {
+	DryRun     bool                    `json:"dry_run"    example:"false"`
+	Creadas    int                     `json:"creadas"    example:"240"`
+	Existentes int                     `json:"existentes" example:"12"`
+	Rechazadas int                     `json:"rechazadas" example:"1"`
+	Filas      []ImportInscripcionFila `json:"filas"`
+}
+
+// periodoAcademico returns the academic period of t: YYYY-1 from January to June and YYYY-2
+// from July to December.
+func periodoAcademico(t time.Time) string {
+	semestre := 1
+	if t.Month() > time.June {
+		semestre = 2
+	}
+	return fmt.Sprintf("%d-%d", t.Year(), semestre)
+}
+
+// validPeriodoAcademico reports whether periodo has the YYYY-1 or YYYY-2 format.
+func validPeriodoAcademico(periodo string) bool {
+	var anio, semestre int
+	if _, err := fmt.Sscanf(periodo, "%4d-%1d", &anio, &semestre); err != nil {
+		return false
+	}
+	return len(periodo) == 6 && anio >= 1000 && (semestre == 1 || semestre == 2)
+}
+
+// CreateInscripcionEndpoint handles POST /v1/inscripciones using Go 1.22 routing
+// @Summary      Enroll Estudiante in Materia
+// @Description  Enrolls a student in a materia for an academic period (YYYY-1 or YYYY-2, the current one by default).
+// @Description  Students can only book tutorias of the materias they are enrolled in.
+// @Tags         Inscripciones
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        inscripcion body CreateInscripcionRequest true "Inscripcion Data"
+// @Success      201 {object} db.Inscripcione "Successfully created inscripcion"
+// @Failure      400 {object} ErrorResponse "Invalid request body, estudiante, materia or periodo"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      409 {object} ErrorResponse "The student is already enrolled"
+// @Failure      500 {object} ErrorResponse "Failed to create inscripcion"
+// @Router       /v1/inscripciones [post]
+func CreateInscripcionEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CreateInscripcionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		if req.Periodo == "" {
+			req.Periodo = periodoAcademico(time.Now())
+		}
+		if !validPeriodoAcademico(req.Periodo) {
+			http.Error(w, "Invalid periodo format (use YYYY-1 or YYYY-2)", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := queries.SelectEstudianteById(r.Context(), req.EstudianteID); err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Estudiante not found", http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "Failed to get estudiante: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		materia, err := queries.SelectMateriaById(r.Context(), req.MateriaID)
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Materia not found", http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "Failed to get materia: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !materia.Activo {
+			http.Error(w, "Materia is no longer offered", http.StatusBadRequest)
+			return
+		}
+
+		inscrito, err := queries.EstudianteInscrito(r.Context(), db.EstudianteInscritoParams{
+			EstudianteID: req.EstudianteID,
+			MateriaID:    req.MateriaID,
+			Periodo:      req.Periodo,
+		})
+		if err != nil {
+			http.Error(w, "Failed to check enrollment: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if inscrito {
+			http.Error(w, "Estudiante is already enrolled in this materia for periodo "+req.Periodo, http.StatusConflict)
+			return
+		}
+
+		inscripcion, err := queries.CreateInscripcion(r.Context(), db.CreateInscripcionParams{
+			EstudianteID: req.EstudianteID,
+			MateriaID:    req.MateriaID,
+			Periodo:      req.Periodo,
+		})
+		if err != nil {
+			http.Error(w, "Failed to create inscripcion: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(inscripcion)
+	}
+}
+
+// GetInscripcionEndpoint handles GET /v1/inscripciones/{id} using Go 1.22 routing
+// @Summary      Get Inscripcion
+// @Description  Retrieves an enrollment by its ID.
+// @Tags         Inscripciones
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Inscripcion ID"
+// @Success      200 {object} db.Inscripcione "Inscripcion"
+// @Failure      400 {object} ErrorResponse "Invalid inscripcion ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Inscripcion not found"
+// @Failure      500 {object} ErrorResponse "Failed to get inscripcion"
+// @Router       /v1/inscripciones/{id} [get]
+func GetInscripcionEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid inscripcion ID", http.StatusBadRequest)
+			return
+		}
+
+		inscripcion, err := queries.SelectInscripcionById(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Inscripcion not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get inscripcion: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(inscripcion)
+	}
+}
+
+// ListInscripcionesEndpoint handles GET /v1/inscripciones using Go 1.22 routing
+// @Summary      List Inscripciones
+// @Description  Retrieves enrollments, most recent period first, optionally filtered by estudiante, materia and period.
+// @Tags         Inscripciones
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     AdminBearer
+// @Param        estudiante_id query int false "Only inscripciones of this estudiante"
+// @Param        materia_id query int false "Only inscripciones in this materia"
+// @Param        periodo query string false "Only inscripciones in this academic period (YYYY-1 or YYYY-2)"
+// @Param        limit query int false "Maximum number of inscripciones" default(50) minimum(1) maximum(500)
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
+// @Success      200 {array} db.ListInscripcionesRow "Inscripciones"
+// @Failure      400 {object} ErrorResponse "Invalid filter"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve inscripciones"
+// @Router       /v1/inscripciones [get]
+func ListInscripcionesEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		params := db.ListInscripcionesParams{RowLimit: defaultListLimit}
+
+		if estudianteStr := query.Get("estudiante_id"); estudianteStr != "" {
+			id, err := strconv.ParseInt(estudianteStr, 10, 32)
+			if err != nil {
+				http.Error(w, "Invalid estudiante ID", http.StatusBadRequest)
+				return
+			}
+			params.EstudianteID = pgtype.Int4{Int32: int32(id), Valid: true}
+		}
+
+		if materiaStr := query.Get("materia_id"); materiaStr != "" {
+			id, err := strconv.ParseInt(materiaStr, 10, 32)
+			if err != nil {
+				http.Error(w, "Invalid materia ID", http.StatusBadRequest)
+				return
+			}
+			params.MateriaID = pgtype.Int4{Int32: int32(id), Valid: true}
+		}
+
+		if periodo := query.Get("periodo"); periodo != "" {
+			if !validPeriodoAcademico(periodo) {
+				http.Error(w, "Invalid periodo format (use YYYY-1 or YYYY-2)", http.StatusBadRequest)
+				return
+			}
+			params.Periodo = pgtype.Text{String: periodo, Valid: true}
+		}
+
+		if limitStr := query.Get("limit"); limitStr != "" {
+			limit, err := strconv.ParseInt(limitStr, 10, 32)
+			if err != nil || limit < 1 || limit > maxListLimit {
+				http.Error(w, "Invalid limit, must be between 1 and "+strconv.Itoa(maxListLimit), http.StatusBadRequest)
+				return
+			}
+			params.RowLimit = int32(limit)
+		}
+
+		inscripciones, err := queries.ListInscripciones(r.Context(), params)
+		if err != nil {
+			http.Error(w, "Failed to retrieve inscripciones: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if inscripciones == nil {
+			inscripciones = []db.ListInscripcionesRow{}
+		}
+
+		writeList(w, r, "inscripciones", inscripciones)
+	}
+}
+
+// DeleteInscripcionEndpoint handles DELETE /v1/inscripciones/{id} using Go 1.22 routing
+// @Summary      Delete Inscripcion
+// @Description  Removes an enrollment. Tutorias already booked for the materia are kept.
+// @Tags         Inscripciones
+// @Security     AdminBearer
+// @Param        id path int true "Inscripcion ID"
+// @Success      204 "Successfully deleted inscripcion"
+// @Failure      400 {object} ErrorResponse "Invalid inscripcion ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Inscripcion not found"
+// @Failure      500 {object} ErrorResponse "Failed to delete inscripcion"
+// @Router       /v1/inscripciones/{id} [delete]
+func DeleteInscripcionEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid inscripcion ID", http.StatusBadRequest)
+			return
+		}
+
+		deleted, err := queries.DeleteInscripcion(r.Context(), int32(id))
+		if err != nil {
+			http.Error(w, "Failed to delete inscripcion: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if deleted == 0 {
+			http.Error(w, "Inscripcion not found", http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ImportInscripcionesEndpoint handles POST /v1/inscripciones/import using Go 1.22 routing
+// @Summary      Import Inscripciones from CSV
+// @Description  Bulk-loads enrollments from a registrar CSV (multipart field "archivo" or raw text/csv body) with the columns correo (of the estudiante), codigo (of the materia) and periodo (YYYY-1 or YYYY-2). Every row is validated; valid rows are saved in a single transaction, enrollments that already exist are left unchanged, and invalid rows are reported with their reasons. With dry_run=true nothing is saved.
+// @Tags         Inscripciones
+// @Accept       multipart/form-data
+// @Accept       text/csv
+// @Produce      json
+// @Security     AdminBearer
+// @Param        archivo formData file false "CSV file"
+// @Param        dry_run query bool false "Only validate and report, without saving"
+// @Success      200 {object} ImportInscripcionesResponse "Per-row import report"
+// @Failure      400 {object} ErrorResponse "Invalid CSV file"
+// @Failure      401 {object} ErrorResponse "Missing or invalid admin token"
+// @Failure      500 {object} ErrorResponse "Failed to import inscripciones"
+// @Router       /v1/inscripciones/import [post]
+func ImportInscripcionesEndpoint(pool *pgxpool.Pool, queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := readImportFile(w, r)
+		if err != nil {
+			http.Error(w, "Failed to read CSV file: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rows, err := parseCSVImport(data, inscripcionImportColumns)
+		if err != nil {
+			http.Error(w, "Invalid CSV file: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		estudiantes, err := queries.ListEstudianteCorreos(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve estudiantes: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		estudianteIDs := make(map[string]int32, len(estudiantes))
+		for _, estudiante := range estudiantes {
+			estudianteIDs[estudiante.Correo] = estudiante.EstudianteID
+		}
+
+		materias, err := queries.ListMateriasCatalogo(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve materias: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		materiasByCodigo := make(map[string]db.Materia, len(materias))
+		for _, materia := range materias {
+			materiasByCodigo[materia.Codigo] = materia
+		}
+
+		response := ImportInscripcionesResponse{
+			DryRun: isDryRun(r),
+			Filas:  make([]ImportInscripcionFila, 0, len(rows)),
+		}
+		valid := make([]db.UpsertInscripcionParams, 0, len(rows))
+		validFilas := make([]int, 0, len(rows))
+		seen := map[db.UpsertInscripcionParams]int{}
+
+		for _, row := range rows {
+			fila := ImportInscripcionFila{
+				Fila:    row.Line,
+				Correo:  row.Values["correo"],
+				Codigo:  row.Values["codigo"],
+				Periodo: row.Values["periodo"],
+			}
+
+			params := db.UpsertInscripcionParams{Periodo: fila.Periodo}
+			if id, ok := estudianteIDs[fila.Correo]; ok {
+				params.EstudianteID = id
+			} else {
+				fila.Errores = append(fila.Errores, "no estudiante has this correo")
+			}
+			if materia, ok := materiasByCodigo[fila.Codigo]; !ok {
+				fila.Errores = append(fila.Errores, "no materia has this codigo")
+			} else if !materia.Activo {
+				fila.Errores = append(fila.Errores, "materia is no longer offered")
+			} else {
+				params.MateriaID = materia.MateriaID
+			}
+			if !validPeriodoAcademico(fila.Periodo) {
+				fila.Errores = append(fila.Errores, "periodo must have the format YYYY-1 or YYYY-2")
+			}
+			if len(fila.Errores) == 0 {
+				if line, ok := seen[params]; ok {
+					fila.Errores = append(fila.Errores, fmt.Sprintf("inscripcion is repeated, first seen on line %d", line))
+				} else {
+					seen[params] = row.Line
+				}
+			}
+
+			if len(fila.Errores) > 0 {
+				fila.Estado = "rechazada"
+				response.Rechazadas++
+			} else {
+				valid = append(valid, params)
+				validFilas = append(validFilas, len(response.Filas))
+			}
+			response.Filas = append(response.Filas, fila)
+		}
+
+		if !response.DryRun && len(valid) > 0 {
+			err = withTx(r.Context(), pool, queries, func(q *db.Queries) error {
+				for i, params := range valid {
+					result, err := q.UpsertInscripcion(r.Context(), params)
+					if err != nil {
+						return fmt.Errorf("line %d: %w", response.Filas[validFilas[i]].Fila, err)
+					}
+					fila := &response.Filas[validFilas[i]]
+					fila.InscripcionID = result.InscripcionID
+					fila.Estado = "existente"
+					if result.Creado {
+						fila.Estado = "creada"
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				http.Error(w, "Failed to import inscripciones: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+		} else {
+			// Without saving, every valid row is reported as it would be created
+			for _, i := range validFilas {
+				response.Filas[i].Estado = "creada"
+			}
+		}
+
+		for _, fila := range response.Filas {
+			switch fila.Estado {
+			case "creada":
+				response.Creadas++
+			case "existente":
+				response.Existentes++
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// EstudianteMateriasEndpoint handles GET /v1/estudiantes/{id}/materias using Go 1.22 routing
+// @Summary      List Materias of Estudiante
+// @Description  Retrieves the active materias a student is enrolled in for an academic period, which are the ones
+// @Description  they can book tutorias for.
+// @Tags         Inscripciones
+// @Produce      json
+// @Param        id path int true "Estudiante ID"
+// @Param        periodo query string false "Academic period (YYYY-1 or YYYY-2); defaults to the current one"
+// @Success      200 {array} db.Materia "Enrolled materias"
+// @Failure      400 {object} ErrorResponse "Invalid estudiante ID or periodo"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve materias"
+// @Router       /v1/estudiantes/{id}/materias [get]
+func EstudianteMateriasEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid estudiante ID", http.StatusBadRequest)
+			return
+		}
+
+		periodo := r.URL.Query().Get("periodo")
+		if periodo == "" {
+			periodo = periodoAcademico(time.Now())
+		}
+		if !validPeriodoAcademico(periodo) {
+			http.Error(w, "Invalid periodo format (use YYYY-1 or YYYY-2)", http.StatusBadRequest)
+			return
+		}
+
+		materias, err := queries.SelectMateriasByEstudiante(r.Context(), db.SelectMateriasByEstudianteParams{
+			EstudianteID: int32(id),
+			Periodo:      periodo,
+		})
+		if err != nil {
+			http.Error(w, "Failed to retrieve materias: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if materias == nil {
+			materias = []db.Materia{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(materias)
+	}
+}
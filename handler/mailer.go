@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mailer sends notification emails through an SMTP relay.
+type Mailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// mailAttachment is a file attached to a mailMessage.
+type mailAttachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// mailMessage is a plain-text email with an optional calendar invitation and attachments.
+type mailMessage struct {
+	To             []string
+	Subject        string
+	Body           string
+	Calendar       string // Sent as a text/calendar alternative so clients show it as an invitation
+	CalendarMethod string // iCalendar METHOD of Calendar, e.g. REQUEST or CANCEL
+	Attachments    []mailAttachment
+}
+
+var (
+	mailerOnce    sync.Once
+	defaultMailer *Mailer
+)
+
+// getMailer returns the Mailer configured through SMTP_HOST, SMTP_PORT, SMTP_USER,
+// SMTP_PASSWORD and SMTP_FROM, or nil when SMTP_HOST is not set.
+func getMailer() *Mailer {
+	mailerOnce.Do(func() {
+		host := os.Getenv("SMTP_HOST")
+		if host == "" {
+			log.Println("SMTP_HOST not set, email notifications are disabled")
+			return
+		}
+
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+
+		defaultMailer = &Mailer{
+			Host:     host,
+			Port:     port,
+			Username: os.Getenv("SMTP_USER"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+		}
+		if defaultMailer.From == "" {
+			defaultMailer.From = defaultMailer.Username
+		}
+	})
+	return defaultMailer
+}
+
+// sendMailAsync sends msg in the background with the configured mailer, logging failures.
+// It does nothing when email is not configured.
+func sendMailAsync(msg mailMessage) {
+	mailer := getMailer()
+	if mailer == nil || len(msg.To) == 0 {
+		return
+	}
+
+	go func() {
+		if err := mailer.Send(msg); err != nil {
+			log.Printf("mailer: could not send %q to %v: %v", msg.Subject, msg.To, err)
+		}
+	}()
+}
+
+// Send delivers msg to its recipients.
+func (m *Mailer) Send(msg mailMessage) error {
+	body, err := m.build(msg)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	return smtp.SendMail(m.Host+":"+m.Port, auth, m.From, msg.To, body)
+}
+
+// build renders msg as a MIME message: multipart/mixed with a multipart/alternative
+// text (and calendar) body followed by the attachments.
+func (m *Mailer) build(msg mailMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	mixed := multipart.NewWriter(&buf)
+
+	header := textproto.MIMEHeader{}
+	header.Set("From", m.From)
+	header.Set("To", strings.Join(msg.To, ", "))
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header.Set("MIME-Version", "1.0")
+	header.Set("Content-Type", "multipart/mixed; boundary="+mixed.Boundary())
+	for key, values := range header {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, values[0])
+	}
+	buf.WriteString("\r\n")
+
+	var alternativeBuf bytes.Buffer
+	alternative := multipart.NewWriter(&alternativeBuf)
+	if err := writeMailPart(alternative, "text/plain; charset=utf-8", "", []byte(msg.Body)); err != nil {
+		return nil, err
+	}
+	if msg.Calendar != "" {
+		contentType := "text/calendar; charset=utf-8; method=" + msg.CalendarMethod
+		if err := writeMailPart(alternative, contentType, "", []byte(msg.Calendar)); err != nil {
+			return nil, err
+		}
+	}
+	if err := alternative.Close(); err != nil {
+		return nil, err
+	}
+
+	alternativeHeader := textproto.MIMEHeader{}
+	alternativeHeader.Set("Content-Type", "multipart/alternative; boundary="+alternative.Boundary())
+	part, err := mixed.CreatePart(alternativeHeader)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(alternativeBuf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range msg.Attachments {
+		if err := writeMailPart(mixed, attachment.ContentType, attachment.Filename, attachment.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeMailPart writes a base64-encoded part, as an attachment when filename is set.
+func writeMailPart(w *multipart.Writer, contentType, filename string, data []byte) error {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "base64")
+	if filename != "" {
+		header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	}
+
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		if _, err := part.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err = part.Write([]byte(encoded + "\r\n"))
+	return err
+}
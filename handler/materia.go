@@ -1,12 +1,15 @@
 package handler
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/matwate/proyecto-datos/db"
 )
 
@@ -137,23 +140,72 @@ func handleMateriaGET(w http.ResponseWriter, r *http.Request, queries *db.Querie
 	http.Error(w, "Invalid path", http.StatusBadRequest)
 }
 
+// materiaSorts are the sort fields of GET /v1/materias, the first one being the default.
+var materiaSorts = []string{"codigo", "nombre", "facultad", "creditos", "id"}
+
+// materiaSortKey returns the sort key ListMaterias computes for a materia.
+func materiaSortKey(sort string, materia db.Materia) string {
+	switch sort {
+	case "nombre":
+		return materia.Nombre
+	case "facultad":
+		return materia.Facultad
+	case "creditos":
+		return sortKeyInt(materia.Creditos)
+	case "id":
+		return sortKeyInt(materia.MateriaID)
+	default:
+		return materia.Codigo
+	}
+}
+
 // listMateriasHandler handles GET /v1/materias
 // @Summary      List All Materias
 // @Description  Retrieves a list of all materias.
 // @Tags         Materias
 // @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        nombres query bool false "Only return the names of active materias, as a list of strings"
+// @Param        limit query int false "Maximum number of items per page" default(50) minimum(1) maximum(500)
+// @Param        after query string false "Cursor of the next page, as returned in X-Next-Cursor"
+// @Param        sort query string false "Sort field" Enums(codigo, nombre, facultad, creditos, id) default(codigo)
+// @Param        direction query string false "Sort direction" Enums(asc, desc) default(asc)
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
 // @Success      200 {array} db.Materia "Successfully retrieved materias"
+// @Header       200 {integer} X-Total-Count "Total number of materias"
+// @Header       200 {string} X-Next-Cursor "Cursor of the next page; absent on the last page"
+// @Failure      400 {object} ErrorResponse "Invalid pagination parameters"
 // @Failure      500 {object} ErrorResponse "Failed to retrieve materias"
 // @Router       /v1/materias [get]
 func listMateriasHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
-	materias, err := queries.ListMaterias(r.Context())
+	page, err := parseListPage(r, materiaSorts, false)
+	if err != nil {
+		http.Error(w, "Invalid pagination parameters: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	materias, err := queries.ListMaterias(r.Context(), db.ListMateriasParams{
+		Sort:       page.Sort,
+		AfterID:    page.AfterID,
+		Descending: page.Descending,
+		AfterValor: page.AfterValor,
+		RowLimit:   page.Limit + 1,
+	})
 	if err != nil {
 		http.Error(w, "Failed to retrieve materias: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(materias)
+	total, err := queries.CountMaterias(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to count materias: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writePage(w, r, "materias", page, materias, total, func(row db.Materia) (string, int32) {
+		return materiaSortKey(page.Sort, row), row.MateriaID
+	})
 }
 
 // getMateriaByIDHandler handles GET /v1/materias/{id}
@@ -214,15 +266,6 @@ func getMateriaByCodigoHandler(w http.ResponseWriter, r *http.Request, queries *
 }
 
 // getMateriaIdByNameHandler handles GET /v1/materias?nombre={nombre}
-// @Summary      Get Materia ID by Name
-// @Description  Retrieves a materia ID by its name.
-// @Tags         Materias
-// @Produce      json
-// @Param        nombre query string true "Materia Name"
-// @Success      200 {object} object{materia_id=int32} "Successfully retrieved materia ID"
-// @Failure      404 {object} ErrorResponse "Materia not found"
-// @Failure      500 {object} ErrorResponse "Failed to retrieve materia"
-// @Router       /v1/materias [get]
 func getMateriaIdByNameHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, nombre string) {
 	materiaID, err := queries.GetMateriaIdByName(r.Context(), nombre)
 	if err != nil {
@@ -243,14 +286,6 @@ func getMateriaIdByNameHandler(w http.ResponseWriter, r *http.Request, queries *
 }
 
 // listMateriasByFacultadHandler handles GET /v1/materias?facultad={facultad}
-// @Summary      List Materias by Faculty
-// @Description  Retrieves materias filtered by faculty.
-// @Tags         Materias
-// @Produce      json
-// @Param        facultad query string true "Faculty name"
-// @Success      200 {array} db.Materia "Successfully retrieved materias"
-// @Failure      500 {object} ErrorResponse "Failed to retrieve materias"
-// @Router       /v1/materias [get]
 func listMateriasByFacultadHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, facultad string) {
 	materias, err := queries.ListMateriasByFacultad(r.Context(), facultad)
 	if err != nil {
@@ -258,8 +293,7 @@ func listMateriasByFacultadHandler(w http.ResponseWriter, r *http.Request, queri
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(materias)
+	writeList(w, r, "materias", materias)
 }
 
 // updateMateriaHandler handles PUT /v1/materias/{id}
@@ -314,11 +348,13 @@ func updateMateriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Qu
 
 // deleteMateriaHandler handles DELETE /v1/materias/{id}
 // @Summary      Delete Materia
-// @Description  Deletes a materia by its ID.
+// @Description  Moves a materia to the papelera, keeping its tutorias and tutor assignments. It is no longer listed
+// @Description  and can be restored by an admin until the retention period ends, when it is deleted permanently.
 // @Tags         Materias
 // @Param        id path int true "Materia ID"
 // @Success      204 "Successfully deleted materia"
 // @Failure      400 {object} ErrorResponse "Invalid materia ID"
+// @Failure      404 {object} ErrorResponse "Materia not found"
 // @Failure      500 {object} ErrorResponse "Failed to delete materia"
 // @Router       /v1/materias/{id} [delete]
 func deleteMateriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
@@ -329,23 +365,20 @@ func deleteMateriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Qu
 		return
 	}
 
-	err = queries.DeleteMateria(r.Context(), int32(id))
+	deleted, err := queries.SoftDeleteMateria(r.Context(), int32(id))
 	if err != nil {
 		http.Error(w, "Failed to delete materia: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if deleted == 0 {
+		http.Error(w, "Materia not found", http.StatusNotFound)
+		return
+	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
 // listMateriaNombresHandler handles GET /v1/materias?nombres=true
-// @Summary      List Materia Names
-// @Description  Retrieves a list of all materia names.
-// @Tags         Materias
-// @Produce      json
-// @Success      200 {array} string "Successfully retrieved materia names"
-// @Failure      500 {object} ErrorResponse "Failed to retrieve materia names"
-// @Router       /v1/materias [get]
 func listMateriaNombresHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
 	materias, err := queries.ListMateriaNombres(r.Context())
 	if err != nil {
@@ -372,3 +405,291 @@ func listMateriaNombresHandler(w http.ResponseWriter, r *http.Request, queries *
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// materiaImportColumns are the columns required in a CSV materia catalog; descripcion is optional.
+var materiaImportColumns = []string{"codigo", "nombre", "facultad", "creditos"}
+
+// MateriaCatalogoItem is one materia of an imported catalog, keyed by codigo.
+type MateriaCatalogoItem struct {
+	Codigo      string      `json:"codigo"                example:"MATH101"`
+	Nombre      string      `json:"nombre"                example:"Cálculo I"`
+	Facultad    string      `json:"facultad"              example:"Ingeniería"`
+	Creditos    json.Number `json:"creditos"              example:"4" swaggertype:"integer"`
+	Descripcion *string     `json:"descripcion,omitempty" example:"Introducción al cálculo diferencial e integral"` // Left untouched when absent
+}
+
+// MateriaCambio is a field changed by a catalog import.
+type MateriaCambio struct {
+	Campo    string `json:"campo"    example:"creditos"`
+	Anterior string `json:"anterior" example:"3"`
+	Nuevo    string `json:"nuevo"    example:"4"`
+}
+
+// ImportMateriaFila is the outcome of one materia of a catalog import.
+type ImportMateriaFila struct {
+	Fila      int             `json:"fila,omitempty"       example:"2"` // CSV line or 1-based JSON index; omitted for desactivada
+	Codigo    string          `json:"codigo"               example:"MATH101"`
+	Estado    string          `json:"estado"               example:"actualizada"` // creada, actualizada, sin_cambios, desactivada or rechazada
+	MateriaID int32           `json:"materia_id,omitempty" example:"1"`
+	Cambios   []MateriaCambio `json:"cambios,omitempty"`
+	Errores   []string        `json:"errores,omitempty"`
+}
+
+// ImportMateriasResponse summarizes a materia catalog import.
+type ImportMateriasResponse struct {
+	DryRun       bool                `json:"dry_run"      example:"true"`
+	Creadas      int                 `json:"creadas"      example:"3"`
+	Actualizadas int                 `json:"actualizadas" example:"5"`
+	SinCambios   int                 `json:"sin_cambios"  example:"80"`
+	Desactivadas int                 `json:"desactivadas" example:"1"`
+	Rechazadas   int                 `json:"rechazadas"   example:"0"`
+	Materias     []ImportMateriaFila `json:"materias"`
+}
+
+// parseMateriaCatalogo reads a catalog given either as a JSON array or as CSV. It returns
+// the items together with their CSV line or 1-based JSON index.
+func parseMateriaCatalogo(data []byte) ([]MateriaCatalogoItem, []int, error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		var items []MateriaCatalogoItem
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, nil, err
+		}
+		filas := make([]int, len(items))
+		for i := range items {
+			filas[i] = i + 1
+		}
+		return items, filas, nil
+	}
+
+	rows, err := parseCSVImport(data, materiaImportColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+	items := make([]MateriaCatalogoItem, len(rows))
+	filas := make([]int, len(rows))
+	for i, row := range rows {
+		items[i] = MateriaCatalogoItem{
+			Codigo:   row.Values["codigo"],
+			Nombre:   row.Values["nombre"],
+			Facultad: row.Values["facultad"],
+			Creditos: json.Number(row.Values["creditos"]),
+		}
+		if descripcion, ok := row.Values["descripcion"]; ok {
+			items[i].Descripcion = &descripcion
+		}
+		filas[i] = row.Line
+	}
+	return items, filas, nil
+}
+
+// validateMateriaCatalogoItem trims an item and returns its creditos, or the reasons it is rejected.
+func validateMateriaCatalogoItem(item *MateriaCatalogoItem) (int32, []string) {
+	var errores []string
+	item.Codigo = strings.TrimSpace(item.Codigo)
+	item.Nombre = strings.TrimSpace(item.Nombre)
+	item.Facultad = strings.TrimSpace(item.Facultad)
+
+	for _, field := range []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"codigo", item.Codigo, 50},
+		{"nombre", item.Nombre, 200},
+		{"facultad", item.Facultad, 100},
+	} {
+		if field.value == "" {
+			errores = append(errores, field.name+" is required")
+		} else if len([]rune(field.value)) > field.max {
+			errores = append(errores, fmt.Sprintf("%s must be at most %d characters", field.name, field.max))
+		}
+	}
+
+	creditos, err := strconv.ParseInt(strings.TrimSpace(item.Creditos.String()), 10, 32)
+	if err != nil || creditos <= 0 {
+		errores = append(errores, "creditos must be a positive number")
+	}
+	return int32(creditos), errores
+}
+
+// diffMateria lists the fields of materia that an import of item changes.
+func diffMateria(materia db.Materia, item MateriaCatalogoItem, creditos int32, descripcion pgtype.Text) []MateriaCambio {
+	var cambios []MateriaCambio
+	if materia.Nombre != item.Nombre {
+		cambios = append(cambios, MateriaCambio{Campo: "nombre", Anterior: materia.Nombre, Nuevo: item.Nombre})
+	}
+	if materia.Facultad != item.Facultad {
+		cambios = append(cambios, MateriaCambio{Campo: "facultad", Anterior: materia.Facultad, Nuevo: item.Facultad})
+	}
+	if materia.Creditos != creditos {
+		cambios = append(cambios, MateriaCambio{Campo: "creditos", Anterior: strconv.Itoa(int(materia.Creditos)), Nuevo: strconv.Itoa(int(creditos))})
+	}
+	if materia.Descripcion != descripcion {
+		cambios = append(cambios, MateriaCambio{Campo: "descripcion", Anterior: materia.Descripcion.String, Nuevo: descripcion.String})
+	}
+	if !materia.Activo {
+		cambios = append(cambios, MateriaCambio{Campo: "activo", Anterior: "false", Nuevo: "true"})
+	}
+	return cambios
+}
+
+// ImportMateriasEndpoint handles POST /v1/materias/import using Go 1.22 routing
+// @Summary      Import Materia Catalog
+// @Description  Syncs MATERIAS with a catalog keyed by codigo, given as CSV (codigo, nombre, facultad, creditos and optional descripcion) or as a JSON array, in the multipart field "archivo" or the raw body. New codigos are created; existing ones get their nombre, facultad, creditos and descripcion updated and are reactivated. With desactivar_faltantes=true, active materias missing from the catalog are deactivated (never deleted, so their tutorias are kept). Everything runs in one transaction; with dry_run=true only the diff is returned.
+// @Tags         Materias
+// @Accept       multipart/form-data
+// @Accept       text/csv
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        archivo formData file false "Catalog file (CSV or JSON)"
+// @Param        dry_run query bool false "Only compute the diff, without saving"
+// @Param        desactivar_faltantes query bool false "Deactivate materias missing from the catalog"
+// @Success      200 {object} ImportMateriasResponse "Catalog diff"
+// @Failure      400 {object} ErrorResponse "Invalid catalog file"
+// @Failure      401 {object} ErrorResponse "Missing or invalid admin token"
+// @Failure      500 {object} ErrorResponse "Failed to import materias"
+// @Router       /v1/materias/import [post]
+func ImportMateriasEndpoint(pool *pgxpool.Pool, queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := readImportFile(w, r)
+		if err != nil {
+			http.Error(w, "Failed to read catalog file: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		items, filas, err := parseMateriaCatalogo(data)
+		if err != nil {
+			http.Error(w, "Invalid catalog file: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		existing, err := queries.ListMateriasCatalogo(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve materias: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		byCodigo := make(map[string]db.Materia, len(existing))
+		for _, materia := range existing {
+			byCodigo[materia.Codigo] = materia
+		}
+
+		desactivarFaltantes, _ := strconv.ParseBool(r.URL.Query().Get("desactivar_faltantes"))
+		response := ImportMateriasResponse{
+			DryRun:   isDryRun(r),
+			Materias: make([]ImportMateriaFila, 0, len(items)),
+		}
+
+		// Pending writes, applied in one transaction unless dry_run is set
+		var (
+			creates     []db.CreateMateriaParams
+			updates     []db.UpdateMateriaByCodigoParams
+			deactivates []int32
+			pending     = map[string]int{}  // codigo -> index in response.Materias
+			enCatalogo  = map[string]bool{} // every codigo in the file, even rejected ones
+		)
+
+		for i, item := range items {
+			creditos, errores := validateMateriaCatalogoItem(&item)
+			if enCatalogo[item.Codigo] && item.Codigo != "" {
+				errores = append(errores, "codigo is repeated in the catalog")
+			}
+
+			enCatalogo[item.Codigo] = true
+
+			fila := ImportMateriaFila{Fila: filas[i], Codigo: item.Codigo}
+			if len(errores) > 0 {
+				fila.Estado = "rechazada"
+				fila.Errores = errores
+				response.Rechazadas++
+				response.Materias = append(response.Materias, fila)
+				continue
+			}
+
+			materia, exists := byCodigo[item.Codigo]
+			descripcion := materia.Descripcion
+			if item.Descripcion != nil {
+				descripcion = pgtype.Text{String: strings.TrimSpace(*item.Descripcion), Valid: strings.TrimSpace(*item.Descripcion) != ""}
+			}
+
+			if !exists {
+				fila.Estado = "creada"
+				response.Creadas++
+				creates = append(creates, db.CreateMateriaParams{
+					Nombre:      item.Nombre,
+					Codigo:      item.Codigo,
+					Facultad:    item.Facultad,
+					Descripcion: descripcion,
+					Creditos:    creditos,
+				})
+			} else {
+				fila.MateriaID = materia.MateriaID
+				fila.Cambios = diffMateria(materia, item, creditos, descripcion)
+				if len(fila.Cambios) == 0 {
+					fila.Estado = "sin_cambios"
+					response.SinCambios++
+				} else {
+					fila.Estado = "actualizada"
+					response.Actualizadas++
+					updates = append(updates, db.UpdateMateriaByCodigoParams{
+						Codigo:      item.Codigo,
+						Nombre:      item.Nombre,
+						Facultad:    item.Facultad,
+						Descripcion: descripcion,
+						Creditos:    creditos,
+					})
+				}
+			}
+
+			pending[item.Codigo] = len(response.Materias)
+			response.Materias = append(response.Materias, fila)
+		}
+
+		if desactivarFaltantes {
+			for _, materia := range existing {
+				if enCatalogo[materia.Codigo] || !materia.Activo {
+					continue
+				}
+				deactivates = append(deactivates, materia.MateriaID)
+				response.Desactivadas++
+				response.Materias = append(response.Materias, ImportMateriaFila{
+					Codigo:    materia.Codigo,
+					Estado:    "desactivada",
+					MateriaID: materia.MateriaID,
+					Cambios:   []MateriaCambio{{Campo: "activo", Anterior: "true", Nuevo: "false"}},
+				})
+			}
+		}
+
+		if !response.DryRun {
+			err = withTx(r.Context(), pool, queries, func(q *db.Queries) error {
+				for _, params := range creates {
+					materia, err := q.CreateMateria(r.Context(), params)
+					if err != nil {
+						return fmt.Errorf("codigo %s: %w", params.Codigo, err)
+					}
+					response.Materias[pending[params.Codigo]].MateriaID = materia.MateriaID
+				}
+				for _, params := range updates {
+					if _, err := q.UpdateMateriaByCodigo(r.Context(), params); err != nil {
+						return fmt.Errorf("codigo %s: %w", params.Codigo, err)
+					}
+				}
+				for _, materiaID := range deactivates {
+					if _, err := q.SetMateriaActivo(r.Context(), db.SetMateriaActivoParams{MateriaID: materiaID, Activo: false}); err != nil {
+						return fmt.Errorf("materia %d: %w", materiaID, err)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				http.Error(w, "Failed to import materias: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"strings"
+
+	"github.com/matwate/proyecto-datos/db"
 )
 
 // responseWriterInterceptor is a custom ResponseWriter to capture the status code.
@@ -51,6 +55,7 @@ func CORSMiddleware(next http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Origin", "*") // Allow any origin
 		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE, PATCH")
 		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		w.Header().Set("Access-Control-Expose-Headers", "Content-Disposition, X-Total-Count, X-Next-Cursor")
 
 		// If it's an OPTIONS request, send a 200 OK response
 		if r.Method == "OPTIONS" {
@@ -62,3 +67,86 @@ func CORSMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// adminContextKey is the context key under which AdminAuthMiddleware stores the admin.
+type adminContextKey struct{}
+
+// AdminAuthMiddleware only lets requests through that carry a valid admin bearer token
+// (issued by POST /v1/login/admin) for an active admin. The admin is stored in the request context.
+func AdminAuthMiddleware(queries *db.Queries) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !found || token == "" {
+				http.Error(w, "Admin authentication required", http.StatusUnauthorized)
+				return
+			}
+
+			adminID, err := parseAdminToken(token)
+			if err != nil {
+				http.Error(w, "Invalid admin token: "+err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			admin, err := queries.SelectAdminById(r.Context(), adminID)
+			if err != nil {
+				if err.Error() == "no rows in result set" {
+					http.Error(w, "Admin not found", http.StatusUnauthorized)
+					return
+				}
+				http.Error(w, "Failed to verify admin: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			if admin.Activo.Valid && !admin.Activo.Bool {
+				http.Error(w, "Admin account is inactive", http.StatusForbidden)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), adminContextKey{}, admin)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// SuperadminMiddleware only lets through admins with the superadmin rol. It must run after
+// AdminAuthMiddleware.
+func SuperadminMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		admin, ok := adminFromContext(r.Context())
+		if !ok || admin.Rol != RolSuperadmin {
+			http.Error(w, "Superadmin rol required", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestAdmin returns the active admin authenticated by the bearer token of r, if any. Endpoints that
+// also accept other credentials use it instead of AdminAuthMiddleware.
+func requestAdmin(r *http.Request, queries *db.Queries) (db.Admin, bool) {
+	if admin, ok := adminFromContext(r.Context()); ok {
+		return admin, true
+	}
+
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		return db.Admin{}, false
+	}
+	adminID, err := parseAdminToken(token)
+	if err != nil {
+		return db.Admin{}, false
+	}
+	admin, err := queries.SelectAdminById(r.Context(), adminID)
+	if err != nil || (admin.Activo.Valid && !admin.Activo.Bool) {
+		return db.Admin{}, false
+	}
+	return admin, true
+}
+
+// adminFromContext returns the admin authenticated by AdminAuthMiddleware, if any.
+func adminFromContext(ctx context.Context) (db.Admin, bool) {
+	admin, ok := ctx.Value(adminContextKey{}).(db.Admin)
+	return admin, ok
+}
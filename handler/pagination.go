@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Page sizes of paginated list endpoints.
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
+)
+
+// listPage holds the pagination parameters of a list request (?limit=, ?after=, ?sort=
+// and ?direction=), in the form the List queries take them.
+type listPage struct {
+	Limit      int32
+	Sort       string
+	Descending bool
+	AfterValor pgtype.Text
+	AfterID    pgtype.Int4
+}
+
+// listCursor is the decoded ?after= cursor: the sort key and ID of the last row of the
+// previous page. It records the ordering it was issued for so that it cannot be reused
+// with a different one.
+type listCursor struct {
+	Sort       string `json:"s"`
+	Descending bool   `json:"d"`
+	Valor      string `json:"v"`
+	ID         int32  `json:"id"`
+}
+
+// parseListPage reads the pagination parameters of a list request. sorts are the accepted
+// sort fields, the first one being the default; defaultDesc is the direction used when
+// neither sort nor direction are given. Explicit sorts default to ascending order.
+func parseListPage(r *http.Request, sorts []string, defaultDesc bool) (listPage, error) {
+	query := r.URL.Query()
+	page := listPage{Limit: defaultListLimit, Sort: sorts[0], Descending: defaultDesc}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || limit < 1 || limit > maxListLimit {
+			return page, fmt.Errorf("limit must be between 1 and %d", maxListLimit)
+		}
+		page.Limit = int32(limit)
+	}
+
+	if sort := query.Get("sort"); sort != "" {
+		if !slices.Contains(sorts, sort) {
+			return page, fmt.Errorf("unknown sort field %q", sort)
+		}
+		page.Sort = sort
+		page.Descending = false
+	}
+
+	switch query.Get("direction") {
+	case "":
+	case "asc":
+		page.Descending = false
+	case "desc":
+		page.Descending = true
+	default:
+		return page, errors.New("direction must be asc or desc")
+	}
+
+	if after := query.Get("after"); after != "" {
+		data, err := base64.RawURLEncoding.DecodeString(after)
+		var cursor listCursor
+		if err != nil || json.Unmarshal(data, &cursor) != nil {
+			return page, errors.New("malformed cursor")
+		}
+		if cursor.Sort != page.Sort || cursor.Descending != page.Descending {
+			return page, errors.New("cursor was issued for a different sort")
+		}
+		page.AfterValor = pgtype.Text{String: cursor.Valor, Valid: true}
+		page.AfterID = pgtype.Int4{Int32: cursor.ID, Valid: true}
+	}
+
+	return page, nil
+}
+
+// writePage writes a page of a paginated list. rows must have been fetched with a limit of
+// page.Limit+1, so that the extra row tells whether a next page exists. key returns the
+// sort key of a row, as computed by the query, and its ID. The total count and the next
+// cursor are sent in the X-Total-Count and X-Next-Cursor headers, which keeps the body a
+// plain list in every export format.
+func writePage[T any](
+	w http.ResponseWriter,
+	r *http.Request,
+	nombre string,
+	page listPage,
+	rows []T,
+	total int64,
+	key func(T) (string, int32),
+) {
+	w.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+
+	if len(rows) > int(page.Limit) {
+		rows = rows[:page.Limit]
+		valor, id := key(rows[len(rows)-1])
+		data, _ := json.Marshal(listCursor{Sort: page.Sort, Descending: page.Descending, Valor: valor, ID: id})
+		w.Header().Set("X-Next-Cursor", base64.RawURLEncoding.EncodeToString(data))
+	}
+
+	if rows == nil {
+		rows = []T{}
+	}
+	writeList(w, r, nombre, rows)
+}
+
+// The sortKey helpers format values the way the List queries build their text sort keys.
+
+func sortKeyInt(v int32) string {
+	return fmt.Sprintf("%010d", v)
+}
+
+func sortKeyDate(d pgtype.Date) string {
+	if !d.Valid {
+		return ""
+	}
+	return d.Time.Format("2006-01-02")
+}
+
+func sortKeyTimestamp(ts pgtype.Timestamp) string {
+	if !ts.Valid {
+		return ""
+	}
+	return ts.Time.Format("2006-01-02 15:04:05.000000")
+}
+
+// sortKeyFechaHora matches to_char(fecha + hora, 'YYYY-MM-DD HH24:MI:SS').
+func sortKeyFechaHora(fecha pgtype.Date, hora pgtype.Time) string {
+	if !fecha.Valid || !hora.Valid {
+		return ""
+	}
+	return fecha.Time.Add(time.Duration(hora.Microseconds) * time.Microsecond).Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,338 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// defaultPapeleraRetencionDias is how long deleted estudiantes, tutores and materias stay in
+// the papelera before an admin can purge them, when PAPELERA_RETENCION_DIAS is not set.
+const defaultPapeleraRetencionDias = 30
+
+// Types of the records in the papelera.
+const (
+	PapeleraEstudiante = "estudiante"
+	PapeleraTutor      = "tutor"
+	PapeleraMateria    = "materia"
+)
+
+// PapeleraItem is a deleted estudiante, tutor or materia, which can be restored until an admin purges it.
+type PapeleraItem struct {
+	Tipo             string           `json:"tipo" example:"estudiante"` // estudiante, tutor or materia
+	ID               int32            `json:"id" example:"12"`
+	Nombre           string           `json:"nombre" example:"Juan Pérez"`
+	Detalle          string           `json:"detalle" example:"juan.perez@urosario.edu.co"` // Correo of estudiantes and tutores, codigo of materias
+	FechaEliminacion pgtype.Timestamp `json:"fecha_eliminacion" swaggertype:"string" example:"2025-03-01T10:00:00"`
+	PurgableDesde    pgtype.Timestamp `json:"purgable_desde" swaggertype:"string" example:"2025-03-31T10:00:00"` // From when a purge deletes it permanently, unless it has tutorias
+}
+
+// PurgePapeleraResponse counts the records deleted permanently by a purge.
+type PurgePapeleraResponse struct {
+	Antes       string `json:"antes" example:"2025-03-01T10:00:00Z"` // Records deleted before this time were purged, unless they have tutorias or failed requests
+	Estudiantes int64  `json:"estudiantes" example:"3"`
+	Tutores     int64  `json:"tutores" example:"1"`
+	Materias    int64  `json:"materias" example:"0"`
+}
+
+// papeleraRetencion returns how long deleted records are kept before they can be purged, from PAPELERA_RETENCION_DIAS.
+func papeleraRetencion() time.Duration {
+	dias, err := strconv.Atoi(os.Getenv("PAPELERA_RETENCION_DIAS"))
+	if err != nil || dias < 1 {
+		dias = defaultPapeleraRetencionDias
+	}
+	return time.Duration(dias) * 24 * time.Hour
+}
+
+// isUniqueViolation reports whether err is a unique constraint violation (SQLSTATE 23505).
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// purgarPapelera permanently deletes the records moved to the papelera before antes. Records with
+// tutorias are kept, so that purging never deletes tutoria history.
+func purgarPapelera(ctx context.Context, queries *db.Queries, antes time.Time) (PurgePapeleraResponse, error) {
+	response := PurgePapeleraResponse{Antes: antes.Format(time.RFC3339)}
+	cutoff := pgtype.Timestamp{Time: antes, Valid: true}
+
+	var err error
+	if response.Estudiantes, err = queries.PurgeEstudiantes(ctx, cutoff); err != nil {
+		return response, fmt.Errorf("could not purge estudiantes: %w", err)
+	}
+	if response.Tutores, err = queries.PurgeTutores(ctx, cutoff); err != nil {
+		return response, fmt.Errorf("could not purge tutores: %w", err)
+	}
+	if response.Materias, err = queries.PurgeMaterias(ctx, cutoff); err != nil {
+		return response, fmt.Errorf("could not purge materias: %w", err)
+	}
+	return response, nil
+}
+
+// ListPapeleraEndpoint handles GET /v1/admin/papelera using Go 1.22 routing
+// @Summary      List Papelera
+// @Description  Lists the deleted estudiantes, tutores and materias, most recently deleted first, with the time from
+// @Description  which a purge may delete each one permanently. They can be restored until they are purged.
+// @Tags         Papelera
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     AdminBearer
+// @Param        tipo query string false "Only records of this type" Enums(estudiante, tutor, materia)
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
+// @Success      200 {array} PapeleraItem "Deleted records"
+// @Failure      400 {object} ErrorResponse "Invalid tipo"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve papelera"
+// @Router       /v1/admin/papelera [get]
+func ListPapeleraEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tipo := r.URL.Query().Get("tipo")
+		if tipo != "" && tipo != PapeleraEstudiante && tipo != PapeleraTutor && tipo != PapeleraMateria {
+			http.Error(w, "Invalid tipo", http.StatusBadRequest)
+			return
+		}
+
+		eliminados, err := queries.ListPapelera(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to retrieve papelera: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		retencion := papeleraRetencion()
+		items := make([]PapeleraItem, 0, len(eliminados))
+		for _, eliminado := range eliminados {
+			if tipo != "" && eliminado.Tipo != tipo {
+				continue
+			}
+			items = append(items, PapeleraItem{
+				Tipo:             eliminado.Tipo,
+				ID:               eliminado.ID,
+				Nombre:           eliminado.Nombre,
+				Detalle:          eliminado.Detalle,
+				FechaEliminacion: eliminado.DeletedAt,
+				PurgableDesde:    pgtype.Timestamp{Time: eliminado.DeletedAt.Time.Add(retencion), Valid: true},
+			})
+		}
+
+		writeList(w, r, "papelera", items)
+	}
+}
+
+// PurgePapeleraEndpoint handles POST /v1/admin/papelera/purgar using Go 1.22 routing
+// @Summary      Purge Papelera
+// @Description  Permanently deletes the estudiantes, tutores and materias that have been in the papelera longer than
+// @Description  the retention period (PAPELERA_RETENCION_DIAS, 30 days by default). Records with tutorias are never
+// @Description  purged, so the tutoria history is kept; they stay in the papelera. Neither are materias with failed
+// @Description  tutoria requests, which the demanda insatisfecha reporte counts. Nothing is purged automatically.
+// @Tags         Papelera
+// @Produce      json
+// @Security     AdminBearer
+// @Success      200 {object} PurgePapeleraResponse "Purged records"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to purge papelera"
+// @Router       /v1/admin/papelera/purgar [post]
+func PurgePapeleraEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, err := purgarPapelera(r.Context(), queries, time.Now().Add(-papeleraRetencion()))
+		if err != nil {
+			http.Error(w, "Failed to purge papelera: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// RestoreEstudianteEndpoint handles POST /v1/estudiantes/{id}/restaurar using Go 1.22 routing
+// @Summary      Restore Estudiante
+// @Description  Takes a deleted student out of the papelera.
+// @Tags         Papelera
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Estudiante ID"
+// @Success      200 {object} db.Estudiante "Restored estudiante"
+// @Failure      400 {object} ErrorResponse "Invalid estudiante ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Estudiante not found in papelera"
+// @Failure      409 {object} ErrorResponse "Another estudiante now uses the same correo"
+// @Failure      500 {object} ErrorResponse "Failed to restore estudiante"
+// @Router       /v1/estudiantes/{id}/restaurar [post]
+func RestoreEstudianteEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid estudiante ID", http.StatusBadRequest)
+			return
+		}
+
+		eliminado, err := queries.SelectEstudianteEliminado(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Estudiante not found in papelera", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get estudiante: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// The correo may have been registered again while the estudiante was deleted
+		if _, err := queries.SelectEstudianteByCorreo(r.Context(), eliminado.Correo); err == nil {
+			http.Error(w, "Another estudiante now uses correo "+eliminado.Correo, http.StatusConflict)
+			return
+		} else if err.Error() != "no rows in result set" {
+			http.Error(w, "Failed to check correo: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		estudiante, err := queries.RestoreEstudiante(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Estudiante not found in papelera", http.StatusNotFound)
+				return
+			}
+			// Lost a race with a new estudiante taking the same correo
+			if isUniqueViolation(err) {
+				http.Error(w, "Another estudiante now uses correo "+eliminado.Correo, http.StatusConflict)
+				return
+			}
+			http.Error(w, "Failed to restore estudiante: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(estudiante)
+	}
+}
+
+// RestoreTutorEndpoint handles POST /v1/tutores/{id}/restaurar using Go 1.22 routing
+// @Summary      Restore Tutor
+// @Description  Takes a deleted tutor out of the papelera, along with their availability and materias.
+// @Tags         Papelera
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Tutor ID"
+// @Success      200 {object} db.Tutore "Restored tutor"
+// @Failure      400 {object} ErrorResponse "Invalid tutor ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Tutor not found in papelera"
+// @Failure      409 {object} ErrorResponse "Another tutor now uses the same correo"
+// @Failure      500 {object} ErrorResponse "Failed to restore tutor"
+// @Router       /v1/tutores/{id}/restaurar [post]
+func RestoreTutorEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid tutor ID", http.StatusBadRequest)
+			return
+		}
+
+		eliminado, err := queries.SelectTutorEliminado(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Tutor not found in papelera", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get tutor: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// The correo may have been registered again while the tutor was deleted
+		if _, err := queries.SelectTutorByCorreo(r.Context(), eliminado.Correo); err == nil {
+			http.Error(w, "Another tutor now uses correo "+eliminado.Correo, http.StatusConflict)
+			return
+		} else if err.Error() != "no rows in result set" {
+			http.Error(w, "Failed to check correo: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		tutor, err := queries.RestoreTutor(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Tutor not found in papelera", http.StatusNotFound)
+				return
+			}
+			// Lost a race with a new tutor taking the same correo
+			if isUniqueViolation(err) {
+				http.Error(w, "Another tutor now uses correo "+eliminado.Correo, http.StatusConflict)
+				return
+			}
+			http.Error(w, "Failed to restore tutor: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tutor)
+	}
+}
+
+// RestoreMateriaEndpoint handles POST /v1/materias/{id}/restaurar using Go 1.22 routing
+// @Summary      Restore Materia
+// @Description  Takes a deleted materia out of the papelera, along with its tutor assignments.
+// @Tags         Papelera
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Materia ID"
+// @Success      200 {object} db.Materia "Restored materia"
+// @Failure      400 {object} ErrorResponse "Invalid materia ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Materia not found in papelera"
+// @Failure      409 {object} ErrorResponse "Another materia now uses the same codigo"
+// @Failure      500 {object} ErrorResponse "Failed to restore materia"
+// @Router       /v1/materias/{id}/restaurar [post]
+func RestoreMateriaEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid materia ID", http.StatusBadRequest)
+			return
+		}
+
+		eliminada, err := queries.SelectMateriaEliminada(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Materia not found in papelera", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get materia: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// The codigo may have been reused by the catalog while the materia was deleted
+		if _, err := queries.SelectMateriaByCodigo(r.Context(), eliminada.Codigo); err == nil {
+			http.Error(w, "Another materia now uses codigo "+eliminada.Codigo, http.StatusConflict)
+			return
+		} else if err.Error() != "no rows in result set" {
+			http.Error(w, "Failed to check codigo: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		materia, err := queries.RestoreMateria(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Materia not found in papelera", http.StatusNotFound)
+				return
+			}
+			// Lost a race with a new materia taking the same codigo
+			if isUniqueViolation(err) {
+				http.Error(w, "Another materia now uses codigo "+eliminada.Codigo, http.StatusConflict)
+				return
+			}
+			http.Error(w, "Failed to restore materia: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(materia)
+	}
+}
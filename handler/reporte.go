@@ -1,33 +1,28 @@
 package handler
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/matwate/proyecto-datos/db"
 )
 
-// CreateReporteRequest represents the request body for creating a reporte.
+// CreateReporteRequest represents the request body for generating a reporte.
 type CreateReporteRequest struct {
-	TipoReporte   string `json:"tipo_reporte" example:"tutoria_performance"`
+	TipoReporte   string `json:"tipo_reporte" example:"desempeno_tutores" enums:"desempeno_tutores,uso_materias,asistencia_programas,cancelaciones,demanda_insatisfecha"`
 	PeriodoInicio string `json:"periodo_inicio" example:"2024-01-01"`
 	PeriodoFin    string `json:"periodo_fin" example:"2024-12-31"`
-	GeneradoPor   int32  `json:"generado_por" example:"1"`
-	Datos         []byte `json:"datos,omitempty" example:"{}"`
 }
 
-// CreateReporteResponse represents the response after creating a reporte.
+// CreateReporteResponse represents the response after queueing a reporte.
 type CreateReporteResponse struct {
-	ReporteID int32 `json:"reporte_id"`
-}
-
-// UpdateReporteRequest represents the request body for updating reporte data.
-type UpdateReporteRequest struct {
-	Datos []byte `json:"datos" example:"{}"`
+	ReporteID int32  `json:"reporte_id"`
+	Estado    string `json:"estado" example:"pendiente"`
 }
 
 // ReporteHandlers handles all reporte-related endpoints using Go 1.24 routing patterns.
@@ -41,8 +36,6 @@ func ReporteHandlers(queries *db.Queries) http.HandlerFunc {
 			createReporteHandler(w, r, queries)
 		case http.MethodGet:
 			handleReporteGET(w, r, queries)
-		case http.MethodPut:
-			updateReporteHandler(w, r, queries)
 		case http.MethodDelete:
 			deleteReporteHandler(w, r, queries)
 		default:
@@ -52,17 +45,31 @@ func ReporteHandlers(queries *db.Queries) http.HandlerFunc {
 }
 
 // createReporteHandler handles POST /v1/reportes
-// @Summary      Create Reporte
-// @Description  Creates a new report.
+// @Summary      Generate Reporte
+// @Description  Queues the generation of a report of the given type from the tutorias dated within the period.
+// @Description  The report is generated in the background; poll GET /v1/reportes/{id} (also sent in the Location header)
+// @Description  until estado is listo, fallido or cancelado.
+// @Description  desempeno_tutores: attendance and cancellations per tutor and materia; uso_materias: demand and hours per materia;
+// @Description  asistencia_programas: attendance per academic program; cancelaciones: the cancelled tutorias;
+// @Description  demanda_insatisfecha: failed booking attempts per materia.
 // @Tags         Reportes
 // @Accept       json
 // @Produce      json
-// @Param        reporte body CreateReporteRequest true "Reporte Data"
-// @Success      201 {object} CreateReporteResponse "Successfully created reporte"
-// @Failure      400 {object} ErrorResponse "Invalid request body"
-// @Failure      500 {object} ErrorResponse "Failed to create reporte"
+// @Security     AdminBearer
+// @Param        reporte body CreateReporteRequest true "Report type and period"
+// @Success      202 {object} CreateReporteResponse "Reporte queued for generation"
+// @Header       202 {string} Location "URL of the queued reporte"
+// @Failure      400 {object} ErrorResponse "Invalid request body, period or report type"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to queue reporte"
 // @Router       /v1/reportes [post]
 func createReporteHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	admin, ok := adminFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Admin authentication required", http.StatusUnauthorized)
+		return
+	}
+
 	var req CreateReporteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
@@ -81,24 +88,31 @@ func createReporteHandler(w http.ResponseWriter, r *http.Request, queries *db.Qu
 		return
 	}
 
-	params := db.CreateReporteParams{
-		TipoReporte:     req.TipoReporte,
-		FechaGeneracion: pgtype.Timestamp{Time: time.Now(), Valid: true},
-		PeriodoInicio:   periodoInicio,
-		PeriodoFin:      periodoFin,
-		GeneradoPor:     req.GeneradoPor,
-		Datos:           req.Datos,
+	if periodoFin.Time.Before(periodoInicio.Time) {
+		http.Error(w, "periodo_fin must not be before periodo_inicio", http.StatusBadRequest)
+		return
+	}
+
+	if err := validarReporteTipo(req.TipoReporte); err != nil {
+		http.Error(w, "Invalid tipo_reporte, must be one of: "+strings.Join(reporteTipos(), ", "), http.StatusBadRequest)
+		return
 	}
 
-	reporte, err := queries.CreateReporte(r.Context(), params)
+	reporte, err := queries.EnqueueReporte(r.Context(), db.EnqueueReporteParams{
+		TipoReporte:   req.TipoReporte,
+		PeriodoInicio: periodoInicio,
+		PeriodoFin:    periodoFin,
+		GeneradoPor:   admin.AdminID,
+	})
 	if err != nil {
-		http.Error(w, "Failed to create reporte: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Failed to queue reporte: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(CreateReporteResponse{ReporteID: reporte.ReporteID})
+	w.Header().Set("Location", "/v1/reportes/"+strconv.Itoa(int(reporte.ReporteID)))
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(CreateReporteResponse{ReporteID: reporte.ReporteID, Estado: reporte.Estado})
 }
 
 // handleReporteGET handles GET requests for reportes
@@ -129,6 +143,10 @@ func handleReporteGET(w http.ResponseWriter, r *http.Request, queries *db.Querie
 
 	// Parse ID from path: /v1/reportes/{id}
 	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathParts) == 1 && strings.HasSuffix(pathParts[0], ".pdf") {
+		getReportePDFHandler(w, r, queries, strings.TrimSuffix(pathParts[0], ".pdf"))
+		return
+	}
 	if len(pathParts) == 1 && pathParts[0] != "" {
 		getReporteByIDHandler(w, r, queries, pathParts[0])
 		return
@@ -137,28 +155,78 @@ func handleReporteGET(w http.ResponseWriter, r *http.Request, queries *db.Querie
 	http.Error(w, "Invalid path", http.StatusBadRequest)
 }
 
+// reporteSorts are the sort fields of GET /v1/reportes, the first one being the default.
+var reporteSorts = []string{"fecha_generacion", "tipo_reporte", "periodo_inicio", "id"}
+
+// reporteSortKey returns the sort key ListReportes computes for a reporte.
+func reporteSortKey(sort string, reporte db.Reporte) string {
+	switch sort {
+	case "tipo_reporte":
+		return reporte.TipoReporte
+	case "periodo_inicio":
+		return sortKeyDate(reporte.PeriodoInicio)
+	case "id":
+		return sortKeyInt(reporte.ReporteID)
+	default:
+		return sortKeyTimestamp(reporte.FechaGeneracion)
+	}
+}
+
 // listReportesHandler handles GET /v1/reportes
 // @Summary      List All Reportes
 // @Description  Retrieves a list of all reports.
 // @Tags         Reportes
 // @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        tipo query string false "Only reportes of this type; the result is not paginated"
+// @Param        periodo_inicio query string false "With periodo_fin, only reportes within the period (YYYY-MM-DD); the result is not paginated"
+// @Param        periodo_fin query string false "End of the period (YYYY-MM-DD)"
+// @Param        limit query int false "Maximum number of items per page" default(50) minimum(1) maximum(500)
+// @Param        after query string false "Cursor of the next page, as returned in X-Next-Cursor"
+// @Param        sort query string false "Sort field" Enums(fecha_generacion, tipo_reporte, periodo_inicio, id) default(fecha_generacion)
+// @Param        direction query string false "Sort direction; defaults to desc for the default sort and to asc otherwise" Enums(asc, desc)
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
 // @Success      200 {array} db.Reporte "Successfully retrieved reportes"
+// @Header       200 {integer} X-Total-Count "Total number of reportes"
+// @Header       200 {string} X-Next-Cursor "Cursor of the next page; absent on the last page"
+// @Failure      400 {object} ErrorResponse "Invalid pagination parameters"
 // @Failure      500 {object} ErrorResponse "Failed to retrieve reportes"
 // @Router       /v1/reportes [get]
 func listReportesHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
-	reportes, err := queries.ListReportes(r.Context())
+	page, err := parseListPage(r, reporteSorts, true)
+	if err != nil {
+		http.Error(w, "Invalid pagination parameters: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	reportes, err := queries.ListReportes(r.Context(), db.ListReportesParams{
+		Sort:       page.Sort,
+		AfterID:    page.AfterID,
+		Descending: page.Descending,
+		AfterValor: page.AfterValor,
+		RowLimit:   page.Limit + 1,
+	})
 	if err != nil {
 		http.Error(w, "Failed to retrieve reportes: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(reportes)
+	total, err := queries.CountReportes(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to count reportes: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writePage(w, r, "reportes", page, reportes, total, func(row db.Reporte) (string, int32) {
+		return reporteSortKey(page.Sort, row), row.ReporteID
+	})
 }
 
 // getReporteByIDHandler handles GET /v1/reportes/{id}
 // @Summary      Get Reporte by ID
-// @Description  Retrieves a specific report by its ID.
+// @Description  Retrieves a specific report by its ID. Estado and progreso (0-100) track the generation of a queued
+// @Description  report; datos is filled in once estado is listo, and ultimo_error describes the last failed attempt.
 // @Tags         Reportes
 // @Produce      json
 // @Param        id path int true "Reporte ID"
@@ -188,15 +256,63 @@ func getReporteByIDHandler(w http.ResponseWriter, r *http.Request, queries *db.Q
 	json.NewEncoder(w).Encode(reporte)
 }
 
-// listReportesByTipoHandler handles GET /v1/reportes?tipo={tipo}
-// @Summary      List Reportes by Type
-// @Description  Retrieves reports filtered by type.
+// getReportePDFHandler handles GET /v1/reportes/{id}.pdf
+// @Summary      Get Reporte as PDF
+// @Description  Renders a generated report as a printable PDF: a header with the period and the admin who generated it,
+// @Description  followed by a bar chart and the detail table of its tipo_reporte.
 // @Tags         Reportes
-// @Produce      json
-// @Param        tipo query string true "Report type"
-// @Success      200 {array} db.Reporte "Successfully retrieved reportes"
-// @Failure      500 {object} ErrorResponse "Failed to retrieve reportes"
-// @Router       /v1/reportes [get]
+// @Produce      application/pdf
+// @Param        id path int true "Reporte ID"
+// @Success      200 {file} file "PDF document"
+// @Failure      400 {object} ErrorResponse "Invalid reporte ID"
+// @Failure      404 {object} ErrorResponse "Reporte not found"
+// @Failure      409 {object} ErrorResponse "Reporte is not listo yet"
+// @Failure      500 {object} ErrorResponse "Failed to render reporte"
+// @Router       /v1/reportes/{id}.pdf [get]
+func getReportePDFHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, idStr string) {
+	id, err := strconv.ParseInt(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid reporte ID", http.StatusBadRequest)
+		return
+	}
+
+	reporte, err := queries.SelectReporteById(r.Context(), int32(id))
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			http.Error(w, "Reporte not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to retrieve reporte: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if reporte.Estado != "listo" {
+		http.Error(w, "Reporte is "+reporte.Estado+", expected listo", http.StatusConflict)
+		return
+	}
+
+	// Render into a buffer so that a failure can still be answered with an error status
+	var buf bytes.Buffer
+	if err := renderReportePDF(&buf, reporte, reporteAutor(r.Context(), queries, reporte)); err != nil {
+		http.Error(w, "Failed to render reporte: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="reporte-%d.pdf"`, reporte.ReporteID))
+	w.Write(buf.Bytes())
+}
+
+// reporteAutor returns the name of the admin who generated a reporte.
+func reporteAutor(ctx context.Context, queries *db.Queries, reporte db.Reporte) string {
+	admin, err := queries.SelectAdminById(ctx, reporte.GeneradoPor)
+	if err != nil {
+		return "Admin #" + strconv.Itoa(int(reporte.GeneradoPor))
+	}
+	return admin.Nombre + " " + admin.Apellido
+}
+
+// listReportesByTipoHandler handles GET /v1/reportes?tipo={tipo}
 func listReportesByTipoHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, tipo string) {
 	reportes, err := queries.ListReportesByTipo(r.Context(), tipo)
 	if err != nil {
@@ -204,21 +320,10 @@ func listReportesByTipoHandler(w http.ResponseWriter, r *http.Request, queries *
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(reportes)
+	writeList(w, r, "reportes", reportes)
 }
 
 // listReportesByPeriodoHandler handles GET /v1/reportes?periodo_inicio={inicio}&periodo_fin={fin}
-// @Summary      List Reportes by Period
-// @Description  Retrieves reports filtered by date period.
-// @Tags         Reportes
-// @Produce      json
-// @Param        periodo_inicio query string true "Start date (YYYY-MM-DD)"
-// @Param        periodo_fin query string true "End date (YYYY-MM-DD)"
-// @Success      200 {array} db.Reporte "Successfully retrieved reportes"
-// @Failure      400 {object} ErrorResponse "Invalid date format"
-// @Failure      500 {object} ErrorResponse "Failed to retrieve reportes"
-// @Router       /v1/reportes [get]
 func listReportesByPeriodoHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, periodoInicioStr, periodoFinStr string) {
 	periodoInicio, err := parseDateString(periodoInicioStr)
 	if err != nil {
@@ -243,24 +348,26 @@ func listReportesByPeriodoHandler(w http.ResponseWriter, r *http.Request, querie
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(reportes)
+	writeList(w, r, "reportes", reportes)
 }
 
-// updateReporteHandler handles PUT /v1/reportes/{id}
-// @Summary      Update Reporte
-// @Description  Updates the data of an existing report.
+// deleteReporteHandler handles DELETE /v1/reportes/{id}
+// @Summary      Delete Reporte
+// @Description  Deletes a report by its ID.
 // @Tags         Reportes
-// @Accept       json
-// @Produce      json
+// @Security     AdminBearer
 // @Param        id path int true "Reporte ID"
-// @Param        reporte body UpdateReporteRequest true "Updated Reporte Data"
-// @Success      200 {object} db.Reporte "Successfully updated reporte"
-// @Failure      400 {object} ErrorResponse "Invalid request body or reporte ID"
-// @Failure      404 {object} ErrorResponse "Reporte not found"
-// @Failure      500 {object} ErrorResponse "Failed to update reporte"
-// @Router       /v1/reportes/{id} [put]
-func updateReporteHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+// @Success      204 "Successfully deleted reporte"
+// @Failure      400 {object} ErrorResponse "Invalid reporte ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to delete reporte"
+// @Router       /v1/reportes/{id} [delete]
+func deleteReporteHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	if _, ok := adminFromContext(r.Context()); !ok {
+		http.Error(w, "Admin authentication required", http.StatusUnauthorized)
+		return
+	}
+
 	path := strings.TrimPrefix(r.URL.Path, "/v1/reportes/")
 	id, err := strconv.ParseInt(path, 10, 32)
 	if err != nil {
@@ -268,53 +375,101 @@ func updateReporteHandler(w http.ResponseWriter, r *http.Request, queries *db.Qu
 		return
 	}
 
-	var req UpdateReporteRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	err = queries.DeleteReporte(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, "Failed to delete reporte: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	params := db.UpdateReporteParams{
-		ReporteID: int32(id),
-		Datos:     req.Datos,
-	}
+	w.WriteHeader(http.StatusNoContent)
+}
 
-	reporte, err := queries.UpdateReporte(r.Context(), params)
-	if err != nil {
-		if err.Error() == "no rows in result set" {
-			http.Error(w, "Reporte not found", http.StatusNotFound)
+// CancelReporteEndpoint handles POST /v1/reportes/{id}/cancelar using Go 1.22 routing
+// @Summary      Cancel Reporte
+// @Description  Cancels a queued reporte. A reporte being generated stops within a few seconds.
+// @Tags         Reportes
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Reporte ID"
+// @Success      200 {object} db.Reporte "Reporte cancelled"
+// @Failure      400 {object} ErrorResponse "Invalid reporte ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Reporte not found"
+// @Failure      409 {object} ErrorResponse "Reporte is not pendiente or en_progreso"
+// @Failure      500 {object} ErrorResponse "Failed to cancel reporte"
+// @Router       /v1/reportes/{id}/cancelar [post]
+func CancelReporteEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid reporte ID", http.StatusBadRequest)
 			return
 		}
-		http.Error(w, "Failed to update reporte: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(reporte)
+		reporte, err := queries.CancelReporte(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				reporteEstadoConflict(w, r, queries, int32(id), "pendiente or en_progreso")
+				return
+			}
+			http.Error(w, "Failed to cancel reporte: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(reporte)
+	}
 }
 
-// deleteReporteHandler handles DELETE /v1/reportes/{id}
-// @Summary      Delete Reporte
-// @Description  Deletes a report by its ID.
+// RetryReporteEndpoint handles POST /v1/reportes/{id}/reintentar using Go 1.22 routing
+// @Summary      Retry Reporte
+// @Description  Queues a failed or cancelled reporte again, resetting its attempt count.
 // @Tags         Reportes
+// @Produce      json
+// @Security     AdminBearer
 // @Param        id path int true "Reporte ID"
-// @Success      204 "Successfully deleted reporte"
+// @Success      202 {object} db.Reporte "Reporte queued for generation"
 // @Failure      400 {object} ErrorResponse "Invalid reporte ID"
-// @Failure      500 {object} ErrorResponse "Failed to delete reporte"
-// @Router       /v1/reportes/{id} [delete]
-func deleteReporteHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
-	path := strings.TrimPrefix(r.URL.Path, "/v1/reportes/")
-	id, err := strconv.ParseInt(path, 10, 32)
-	if err != nil {
-		http.Error(w, "Invalid reporte ID", http.StatusBadRequest)
-		return
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Reporte not found"
+// @Failure      409 {object} ErrorResponse "Reporte is not fallido or cancelado"
+// @Failure      500 {object} ErrorResponse "Failed to queue reporte"
+// @Router       /v1/reportes/{id}/reintentar [post]
+func RetryReporteEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid reporte ID", http.StatusBadRequest)
+			return
+		}
+
+		reporte, err := queries.RetryReporte(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				reporteEstadoConflict(w, r, queries, int32(id), "fallido or cancelado")
+				return
+			}
+			http.Error(w, "Failed to queue reporte: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(reporte)
 	}
+}
 
-	err = queries.DeleteReporte(r.Context(), int32(id))
+// reporteEstadoConflict answers a state transition that matched no reporte: 404 when the
+// reporte does not exist, 409 when it is not in one of the estados the transition expects.
+func reporteEstadoConflict(w http.ResponseWriter, r *http.Request, queries *db.Queries, id int32, esperados string) {
+	estado, err := queries.SelectReporteEstado(r.Context(), id)
 	if err != nil {
-		http.Error(w, "Failed to delete reporte: "+err.Error(), http.StatusInternalServerError)
+		if err.Error() == "no rows in result set" {
+			http.Error(w, "Reporte not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to retrieve reporte: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
-
-	w.WriteHeader(http.StatusNoContent)
+	http.Error(w, "Reporte is "+estado+", expected "+esperados, http.StatusConflict)
 }
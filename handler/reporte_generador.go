@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// ReporteDatos is the content stored in REPORTES.datos by the report generators.
+type ReporteDatos struct {
+	Tipo          string `json:"tipo" example:"desempeno_tutores"`
+	PeriodoInicio string `json:"periodo_inicio" example:"2024-01-01"`
+	PeriodoFin    string `json:"periodo_fin" example:"2024-06-30"`
+	Filas         any    `json:"filas"`
+}
+
+// reporteGenerador computes the rows of a report for the tutorias dated within a period.
+type reporteGenerador func(ctx context.Context, queries *db.Queries, inicio, fin pgtype.Date) (any, error)
+
+// reporteGeneradores are the report generators, keyed by tipo_reporte.
+var reporteGeneradores = map[string]reporteGenerador{
+	"desempeno_tutores": func(ctx context.Context, queries *db.Queries, inicio, fin pgtype.Date) (any, error) {
+		return reporteFilas(queries.ReporteDesempenoTutores(ctx, db.ReporteDesempenoTutoresParams{PeriodoInicio: inicio, PeriodoFin: fin}))
+	},
+	"uso_materias": func(ctx context.Context, queries *db.Queries, inicio, fin pgtype.Date) (any, error) {
+		return reporteFilas(queries.ReporteUsoMaterias(ctx, db.ReporteUsoMateriasParams{PeriodoInicio: inicio, PeriodoFin: fin}))
+	},
+	"asistencia_programas": func(ctx context.Context, queries *db.Queries, inicio, fin pgtype.Date) (any, error) {
+		return reporteFilas(queries.ReporteAsistenciaProgramas(ctx, db.ReporteAsistenciaProgramasParams{PeriodoInicio: inicio, PeriodoFin: fin}))
+	},
+	"cancelaciones": func(ctx context.Context, queries *db.Queries, inicio, fin pgtype.Date) (any, error) {
+		return reporteFilas(queries.ReporteCancelaciones(ctx, db.ReporteCancelacionesParams{PeriodoInicio: inicio, PeriodoFin: fin}))
+	},
+	"demanda_insatisfecha": func(ctx context.Context, queries *db.Queries, inicio, fin pgtype.Date) (any, error) {
+		return reporteFilas(queries.ReporteDemandaInsatisfecha(ctx, db.ReporteDemandaInsatisfechaParams{PeriodoInicio: inicio, PeriodoFin: fin}))
+	},
+}
+
+// reporteFilas passes on the result of a report query, turning no rows into an empty list.
+func reporteFilas[T any](rows []T, err error) (any, error) {
+	if rows == nil {
+		rows = []T{}
+	}
+	return rows, err
+}
+
+// errReporteTipo is returned by validarReporteTipo for an unknown tipo_reporte.
+var errReporteTipo = errors.New("unknown tipo_reporte")
+
+// validarReporteTipo checks that a report generator exists for tipo.
+func validarReporteTipo(tipo string) error {
+	if _, ok := reporteGeneradores[tipo]; !ok {
+		return fmt.Errorf("%w %q", errReporteTipo, tipo)
+	}
+	return nil
+}
+
+// reporteTipos returns the supported report types, sorted.
+func reporteTipos() []string {
+	tipos := make([]string, 0, len(reporteGeneradores))
+	for tipo := range reporteGeneradores {
+		tipos = append(tipos, tipo)
+	}
+	sort.Strings(tipos)
+	return tipos
+}
+
+// reporteDatosJSON encodes the rows computed by a report generator as REPORTES.datos.
+func reporteDatosJSON(tipo string, periodoInicio, periodoFin pgtype.Date, filas any) ([]byte, error) {
+	return json.Marshal(ReporteDatos{
+		Tipo:          tipo,
+		PeriodoInicio: periodoInicio.Time.Format("2006-01-02"),
+		PeriodoFin:    periodoFin.Time.Format("2006-01-02"),
+		Filas:         filas,
+	})
+}
@@ -0,0 +1,368 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// Layout of rendered reportes, in millimetres on an A4 page.
+const (
+	pdfMargen     = 15.0
+	pdfFila       = 6.0  // Height of a table row
+	pdfBarra      = 5.0  // Height of a chart bar
+	pdfEtiqueta   = 55.0 // Width of the labels of a chart
+	pdfMaxBarras  = 15   // Bars drawn per chart; the rest are left to the table
+	pdfPiePagina  = 12.0 // Space reserved for the page footer
+	pdfTituloSize = 16.0
+)
+
+// reportePlantilla is the PDF template of a tipo_reporte: its title and how its filas are
+// drawn below the common header.
+type reportePlantilla struct {
+	Titulo string
+	Render func(doc *reportePDF, filas json.RawMessage) error
+}
+
+// reportePlantillas are the PDF templates, keyed by tipo_reporte like reporteGeneradores.
+var reportePlantillas = map[string]reportePlantilla{
+	"desempeno_tutores": {
+		Titulo: "Desempeño de tutores",
+		Render: func(doc *reportePDF, raw json.RawMessage) error {
+			filas, err := decodeFilas[db.ReporteDesempenoTutoresRow](raw)
+			if err != nil {
+				return err
+			}
+
+			porTutor := map[string]float64{}
+			for _, f := range filas {
+				porTutor[f.Tutor] += float64(f.TotalTutorias)
+			}
+			doc.barras("Tutorías por tutor", porTutor, formatEntero)
+
+			doc.seccion("Detalle por tutor y materia")
+			doc.tabla([]pdfColumna{
+				{"Tutor", 45, "L"}, {"Materia", 55, "L"}, {"Total", 18, "R"},
+				{"Completadas", 22, "R"}, {"Canceladas", 20, "R"}, {"% Asist.", 20, "R"},
+			}, mapFilas(filas, func(f db.ReporteDesempenoTutoresRow) []string {
+				return []string{
+					f.Tutor, f.Materia, formatEntero(float64(f.TotalTutorias)),
+					formatEntero(float64(f.TutoriasCompletadas)), formatEntero(float64(f.TutoriasCanceladas)),
+					formatPorcentaje(f.PorcentajeAsistencia),
+				}
+			}))
+			return nil
+		},
+	},
+	"uso_materias": {
+		Titulo: "Uso de materias",
+		Render: func(doc *reportePDF, raw json.RawMessage) error {
+			filas, err := decodeFilas[db.ReporteUsoMateriasRow](raw)
+			if err != nil {
+				return err
+			}
+
+			porMateria := map[string]float64{}
+			for _, f := range filas {
+				porMateria[f.Codigo+" "+f.Nombre] += float64(f.TotalTutorias)
+			}
+			doc.barras("Tutorías por materia", porMateria, formatEntero)
+
+			doc.seccion("Detalle por materia")
+			doc.tabla([]pdfColumna{
+				{"Código", 20, "L"}, {"Materia", 50, "L"}, {"Facultad", 36, "L"}, {"Tutorías", 17, "R"},
+				{"Estudiantes", 20, "R"}, {"Tutores", 16, "R"}, {"Horas", 21, "R"},
+			}, mapFilas(filas, func(f db.ReporteUsoMateriasRow) []string {
+				return []string{
+					f.Codigo, f.Nombre, f.Facultad, formatEntero(float64(f.TotalTutorias)),
+					formatEntero(float64(f.Estudiantes)), formatEntero(float64(f.Tutores)),
+					strconv.FormatFloat(f.Horas, 'f', 1, 64),
+				}
+			}))
+			return nil
+		},
+	},
+	"asistencia_programas": {
+		Titulo: "Asistencia por programa académico",
+		Render: func(doc *reportePDF, raw json.RawMessage) error {
+			filas, err := decodeFilas[db.ReporteAsistenciaProgramasRow](raw)
+			if err != nil {
+				return err
+			}
+
+			porPrograma := map[string]float64{}
+			for _, f := range filas {
+				porPrograma[f.ProgramaAcademico] = f.PorcentajeAsistencia
+			}
+			doc.barras("Porcentaje de asistencia", porPrograma, formatPorcentaje)
+
+			doc.seccion("Detalle por programa")
+			doc.tabla([]pdfColumna{
+				{"Programa", 52, "L"}, {"Estudiantes", 20, "R"}, {"Tutorías", 18, "R"}, {"Asistencias", 20, "R"},
+				{"Inasistencias", 22, "R"}, {"Sin registro", 22, "R"}, {"% Asist.", 26, "R"},
+			}, mapFilas(filas, func(f db.ReporteAsistenciaProgramasRow) []string {
+				return []string{
+					f.ProgramaAcademico, formatEntero(float64(f.Estudiantes)), formatEntero(float64(f.TotalTutorias)),
+					formatEntero(float64(f.Asistencias)), formatEntero(float64(f.Inasistencias)),
+					formatEntero(float64(f.SinRegistro)), formatPorcentaje(f.PorcentajeAsistencia),
+				}
+			}))
+			return nil
+		},
+	},
+	"cancelaciones": {
+		Titulo: "Tutorías canceladas",
+		Render: func(doc *reportePDF, raw json.RawMessage) error {
+			filas, err := decodeFilas[db.ReporteCancelacionesRow](raw)
+			if err != nil {
+				return err
+			}
+
+			porMateria := map[string]float64{}
+			for _, f := range filas {
+				porMateria[f.MateriaCodigo+" "+f.Materia]++
+			}
+			doc.barras("Cancelaciones por materia", porMateria, formatEntero)
+
+			doc.seccion("Tutorías canceladas")
+			doc.tabla([]pdfColumna{
+				{"Fecha", 20, "L"}, {"Hora", 12, "L"}, {"Estudiante", 40, "L"},
+				{"Tutor", 40, "L"}, {"Materia", 43, "L"}, {"Lugar", 25, "L"},
+			}, mapFilas(filas, func(f db.ReporteCancelacionesRow) []string {
+				return []string{
+					exportField(f.Fecha).Text, exportField(f.HoraInicio).Text, f.Estudiante,
+					f.Tutor, f.MateriaCodigo + " " + f.Materia, f.Lugar,
+				}
+			}))
+			return nil
+		},
+	},
+	"demanda_insatisfecha": {
+		Titulo: "Demanda no satisfecha",
+		Render: func(doc *reportePDF, raw json.RawMessage) error {
+			filas, err := decodeFilas[db.ReporteDemandaInsatisfechaRow](raw)
+			if err != nil {
+				return err
+			}
+
+			porMateria := map[string]float64{}
+			for _, f := range filas {
+				porMateria[f.Codigo+" "+f.Nombre] = float64(f.Intentos)
+			}
+			doc.barras("Solicitudes no atendidas por materia", porMateria, formatEntero)
+
+			doc.seccion("Detalle por materia")
+			doc.tabla([]pdfColumna{
+				{"Código", 18, "L"}, {"Materia", 48, "L"}, {"Intentos", 16, "R"}, {"Estudiantes", 20, "R"},
+				{"Horas", 14, "R"}, {"Sin tutores", 20, "R"}, {"Sin horario", 20, "R"}, {"Tutores", 24, "R"},
+			}, mapFilas(filas, func(f db.ReporteDemandaInsatisfechaRow) []string {
+				return []string{
+					f.Codigo, f.Nombre, formatEntero(float64(f.Intentos)), formatEntero(float64(f.Estudiantes)),
+					strconv.FormatFloat(f.HorasSolicitadas, 'f', 1, 64), formatEntero(float64(f.SinTutores)),
+					formatEntero(float64(f.SinHorario)), formatEntero(float64(f.TutoresActivos)),
+				}
+			}))
+			return nil
+		},
+	},
+}
+
+// pdfColumna describes a table column: its title, width and alignment ("L" or "R").
+type pdfColumna struct {
+	Titulo string
+	Ancho  float64
+	Alinea string
+}
+
+// reportePDF is a PDF document being rendered from a reporte. Text goes through tr, which
+// maps UTF-8 to the encoding of the core fonts so that accents are printed.
+type reportePDF struct {
+	pdf *fpdf.Fpdf
+	tr  func(string) string
+}
+
+// renderReportePDF writes the PDF of a reporte in estado 'listo', generated by autor.
+func renderReportePDF(w io.Writer, reporte db.Reporte, autor string) error {
+	plantilla, ok := reportePlantillas[reporte.TipoReporte]
+	if !ok {
+		return fmt.Errorf("%w %q", errReporteTipo, reporte.TipoReporte)
+	}
+
+	var datos struct {
+		Filas json.RawMessage `json:"filas"`
+	}
+	if err := json.Unmarshal(reporte.Datos, &datos); err != nil {
+		return fmt.Errorf("could not decode datos: %w", err)
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	doc := &reportePDF{pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+	pdf.SetMargins(pdfMargen, pdfMargen, pdfMargen)
+	pdf.SetAutoPageBreak(true, pdfMargen+pdfPiePagina)
+	pdf.SetTitle(plantilla.Titulo, true)
+	pdf.SetAuthor(autor, true)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargen - pdfPiePagina/2)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(0, 5, doc.tr(fmt.Sprintf("Reporte #%d - Página %d de {nb}", reporte.ReporteID, pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", pdfTituloSize)
+	pdf.CellFormat(0, 10, doc.tr(plantilla.Titulo), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.SetTextColor(80, 80, 80)
+	for _, linea := range []string{
+		"Periodo: " + exportField(reporte.PeriodoInicio).Text + " a " + exportField(reporte.PeriodoFin).Text,
+		"Generado por: " + autor,
+		"Fecha de generación: " + exportField(reporte.FechaGeneracion).Text,
+	} {
+		pdf.CellFormat(0, 5, doc.tr(linea), "", 1, "L", false, 0, "")
+	}
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(4)
+
+	if err := plantilla.Render(doc, datos.Filas); err != nil {
+		return fmt.Errorf("could not decode filas: %w", err)
+	}
+
+	return pdf.Output(w)
+}
+
+// seccion starts a titled section.
+func (d *reportePDF) seccion(titulo string) {
+	d.pdf.Ln(4)
+	d.pdf.SetFont("Helvetica", "B", 12)
+	d.pdf.CellFormat(0, 8, d.tr(titulo), "", 1, "L", false, 0, "")
+}
+
+// tabla draws a table, repeating the header row on every page it spans.
+func (d *reportePDF) tabla(columnas []pdfColumna, filas [][]string) {
+	encabezado := func() {
+		d.pdf.SetFont("Helvetica", "B", 8)
+		d.pdf.SetFillColor(41, 84, 140)
+		d.pdf.SetTextColor(255, 255, 255)
+		for _, c := range columnas {
+			d.pdf.CellFormat(c.Ancho, pdfFila, d.tr(c.Titulo), "1", 0, c.Alinea, true, 0, "")
+		}
+		d.pdf.Ln(-1)
+		d.pdf.SetFont("Helvetica", "", 8)
+		d.pdf.SetTextColor(0, 0, 0)
+	}
+
+	if len(filas) == 0 {
+		d.pdf.SetFont("Helvetica", "I", 9)
+		d.pdf.CellFormat(0, pdfFila, d.tr("Sin datos en el periodo."), "", 1, "L", false, 0, "")
+		return
+	}
+
+	_, alto := d.pdf.GetPageSize()
+	encabezado()
+	for i, fila := range filas {
+		if d.pdf.GetY()+pdfFila > alto-pdfMargen-pdfPiePagina {
+			d.pdf.AddPage()
+			encabezado()
+		}
+		d.pdf.SetFillColor(235, 240, 247)
+		for j, c := range columnas {
+			texto := d.ajustar(fila[j], c.Ancho-2)
+			d.pdf.CellFormat(c.Ancho, pdfFila, texto, "1", 0, c.Alinea, i%2 == 1, 0, "")
+		}
+		d.pdf.Ln(-1)
+	}
+}
+
+// barras draws a horizontal bar chart of valores, largest first, with at most pdfMaxBarras bars.
+func (d *reportePDF) barras(titulo string, valores map[string]float64, formato func(float64) string) {
+	d.seccion(titulo)
+	if len(valores) == 0 {
+		d.pdf.SetFont("Helvetica", "I", 9)
+		d.pdf.CellFormat(0, pdfFila, d.tr("Sin datos en el periodo."), "", 1, "L", false, 0, "")
+		return
+	}
+
+	etiquetas := make([]string, 0, len(valores))
+	for etiqueta := range valores {
+		etiquetas = append(etiquetas, etiqueta)
+	}
+	sort.Slice(etiquetas, func(i, j int) bool {
+		if valores[etiquetas[i]] != valores[etiquetas[j]] {
+			return valores[etiquetas[i]] > valores[etiquetas[j]]
+		}
+		return etiquetas[i] < etiquetas[j]
+	})
+	if len(etiquetas) > pdfMaxBarras {
+		etiquetas = etiquetas[:pdfMaxBarras]
+	}
+
+	maximo := valores[etiquetas[0]]
+	ancho, _ := d.pdf.GetPageSize()
+	anchoBarras := ancho - 2*pdfMargen - pdfEtiqueta - 20
+
+	d.pdf.SetFont("Helvetica", "", 8)
+	d.pdf.SetFillColor(66, 133, 196)
+	for _, etiqueta := range etiquetas {
+		x, y := d.pdf.GetXY()
+		d.pdf.CellFormat(pdfEtiqueta, pdfBarra, d.ajustar(etiqueta, pdfEtiqueta-2), "", 0, "R", false, 0, "")
+
+		largo := 0.0
+		if maximo > 0 {
+			largo = anchoBarras * valores[etiqueta] / maximo
+		}
+		if largo > 0 {
+			d.pdf.Rect(x+pdfEtiqueta+1, y+0.75, largo, pdfBarra-1.5, "F")
+		}
+		d.pdf.SetX(x + pdfEtiqueta + 2 + largo)
+		d.pdf.CellFormat(20, pdfBarra, formato(valores[etiqueta]), "", 1, "L", false, 0, "")
+		d.pdf.SetY(y + pdfBarra + 1)
+	}
+}
+
+// ajustar translates s and shortens it with an ellipsis to fit in ancho millimetres
+// at the current font.
+func (d *reportePDF) ajustar(s string, ancho float64) string {
+	texto := d.tr(s)
+	if d.pdf.GetStringWidth(texto) <= ancho {
+		return texto
+	}
+	runas := []rune(s)
+	for len(runas) > 0 {
+		runas = runas[:len(runas)-1]
+		texto = d.tr(string(runas) + "...")
+		if d.pdf.GetStringWidth(texto) <= ancho {
+			break
+		}
+	}
+	return texto
+}
+
+// decodeFilas decodes the filas stored by a report generator into its row type.
+func decodeFilas[T any](raw json.RawMessage) ([]T, error) {
+	var filas []T
+	err := json.Unmarshal(raw, &filas)
+	return filas, err
+}
+
+// mapFilas converts report rows into table cells.
+func mapFilas[T any](filas []T, celdas func(T) []string) [][]string {
+	out := make([][]string, len(filas))
+	for i, f := range filas {
+		out[i] = celdas(f)
+	}
+	return out
+}
+
+func formatEntero(v float64) string {
+	return strconv.FormatFloat(v, 'f', 0, 64)
+}
+
+func formatPorcentaje(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64) + "%"
+}
@@ -0,0 +1,482 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/mail"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/matwate/proyecto-datos/db"
+	"github.com/robfig/cron/v3"
+)
+
+// reportePeriodos are the periods a programacion can cover, relative to the time it runs.
+var reportePeriodos = []string{"dia_anterior", "semana_anterior", "mes_anterior"}
+
+// CreateReporteProgramacionRequest represents the request body for creating a report schedule.
+// Cron is a standard 5-field expression in Bogotá time (UTC-5); Destinatarios receive a link to every
+// generated reporte, or the PDF itself when AdjuntarPDF is set.
+type CreateReporteProgramacionRequest struct {
+	Nombre        string   `json:"nombre" example:"Desempeño semanal"`
+	TipoReporte   string   `json:"tipo_reporte" example:"desempeno_tutores" enums:"desempeno_tutores,uso_materias,asistencia_programas,cancelaciones,demanda_insatisfecha"`
+	Cron          string   `json:"cron" example:"0 7 * * 1"`
+	Periodo       string   `json:"periodo" example:"semana_anterior" enums:"dia_anterior,semana_anterior,mes_anterior"`
+	Destinatarios []string `json:"destinatarios" example:"decanatura@urosario.edu.co"`
+	AdjuntarPDF   bool     `json:"adjuntar_pdf" example:"false"`
+	Activo        *bool    `json:"activo,omitempty" example:"true"`
+}
+
+// UpdateReporteProgramacionRequest represents the request body for updating a report schedule.
+type UpdateReporteProgramacionRequest struct {
+	Nombre        string   `json:"nombre" example:"Desempeño semanal"`
+	TipoReporte   string   `json:"tipo_reporte" example:"desempeno_tutores" enums:"desempeno_tutores,uso_materias,asistencia_programas,cancelaciones,demanda_insatisfecha"`
+	Cron          string   `json:"cron" example:"0 7 * * 1"`
+	Periodo       string   `json:"periodo" example:"semana_anterior" enums:"dia_anterior,semana_anterior,mes_anterior"`
+	Destinatarios []string `json:"destinatarios" example:"decanatura@urosario.edu.co"`
+	AdjuntarPDF   bool     `json:"adjuntar_pdf" example:"false"`
+	Activo        bool     `json:"activo" example:"true"`
+}
+
+// ReporteProgramacionHandlers handles all report schedule endpoints using Go 1.24 routing patterns.
+// @Summary      Handle Report Schedule Operations
+// @Description  Admin-managed CRUD operations for recurring report schedules.
+// @Tags         Reportes
+func ReporteProgramacionHandlers(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			createReporteProgramacionHandler(w, r, queries)
+		case http.MethodGet:
+			handleReporteProgramacionGET(w, r, queries)
+		case http.MethodPut:
+			updateReporteProgramacionHandler(w, r, queries)
+		case http.MethodDelete:
+			deleteReporteProgramacionHandler(w, r, queries)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// validateReporteProgramacion checks a schedule and returns its next run after now.
+// Cron expressions are evaluated in zonaHoraria, the zone proxima_ejecucion is stored in.
+func validateReporteProgramacion(tipo, expresion, periodo string, destinatarios []string, now time.Time) (time.Time, error) {
+	now = now.In(zonaHoraria)
+
+	if err := validarReporteTipo(tipo); err != nil {
+		return time.Time{}, fmt.Errorf("tipo_reporte must be one of: %s", strings.Join(reporteTipos(), ", "))
+	}
+
+	schedule, err := cron.ParseStandard(expresion)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression: %v", err)
+	}
+
+	if _, ok := reportePeriodo(periodo, now); !ok {
+		return time.Time{}, fmt.Errorf("periodo must be one of: %s", strings.Join(reportePeriodos, ", "))
+	}
+
+	for _, destinatario := range destinatarios {
+		if _, err := mail.ParseAddress(destinatario); err != nil {
+			return time.Time{}, fmt.Errorf("invalid destinatario: %s", destinatario)
+		}
+	}
+
+	return schedule.Next(now), nil
+}
+
+// reportePeriodo returns the period a programacion running at ejecucion covers: the
+// previous day, the previous Monday-to-Sunday week or the previous calendar month.
+func reportePeriodo(periodo string, ejecucion time.Time) ([2]time.Time, bool) {
+	hoy := time.Date(ejecucion.Year(), ejecucion.Month(), ejecucion.Day(), 0, 0, 0, 0, time.UTC)
+	switch periodo {
+	case "dia_anterior":
+		ayer := hoy.AddDate(0, 0, -1)
+		return [2]time.Time{ayer, ayer}, true
+	case "semana_anterior":
+		lunes := hoy.AddDate(0, 0, -(int(hoy.Weekday())+6)%7)
+		return [2]time.Time{lunes.AddDate(0, 0, -7), lunes.AddDate(0, 0, -1)}, true
+	case "mes_anterior":
+		primero := time.Date(hoy.Year(), hoy.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return [2]time.Time{primero.AddDate(0, -1, 0), primero.AddDate(0, 0, -1)}, true
+	default:
+		return [2]time.Time{}, false
+	}
+}
+
+// createReporteProgramacionHandler handles POST /v1/reporte-programaciones
+// @Summary      Create Report Schedule
+// @Description  Schedules a report to be generated on every match of a cron expression, covering the period before the run.
+// @Description  For example cron "0 7 * * 1" with periodo semana_anterior generates every Monday at 7:00 the report of the previous week.
+// @Tags         Reportes
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        programacion body CreateReporteProgramacionRequest true "Schedule Data"
+// @Success      201 {object} db.ReporteProgramacione "Successfully created schedule"
+// @Failure      400 {object} ErrorResponse "Invalid request body"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to create schedule"
+// @Router       /v1/reporte-programaciones [post]
+func createReporteProgramacionHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	admin, ok := adminFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Admin authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	var req CreateReporteProgramacionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.Nombre) == "" {
+		http.Error(w, "nombre is required", http.StatusBadRequest)
+		return
+	}
+
+	proxima, err := validateReporteProgramacion(req.TipoReporte, req.Cron, req.Periodo, req.Destinatarios, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	destinatarios := req.Destinatarios
+	if destinatarios == nil {
+		destinatarios = []string{}
+	}
+
+	programacion, err := queries.CreateReporteProgramacion(r.Context(), db.CreateReporteProgramacionParams{
+		Nombre:           req.Nombre,
+		TipoReporte:      req.TipoReporte,
+		Cron:             req.Cron,
+		Periodo:          req.Periodo,
+		Destinatarios:    destinatarios,
+		AdjuntarPdf:      req.AdjuntarPDF,
+		Activo:           req.Activo == nil || *req.Activo,
+		CreadoPor:        admin.AdminID,
+		ProximaEjecucion: pgtype.Timestamp{Time: proxima, Valid: true},
+	})
+	if err != nil {
+		http.Error(w, "Failed to create schedule: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(programacion)
+}
+
+// handleReporteProgramacionGET handles GET requests for report schedules
+func handleReporteProgramacionGET(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/reporte-programaciones"), "/")
+
+	if path == "" {
+		// GET /v1/reporte-programaciones - List all schedules
+		listReporteProgramacionesHandler(w, r, queries)
+		return
+	}
+
+	// Parse ID from path: /v1/reporte-programaciones/{id}
+	if !strings.Contains(path, "/") {
+		getReporteProgramacionByIDHandler(w, r, queries, path)
+		return
+	}
+
+	http.Error(w, "Invalid path", http.StatusBadRequest)
+}
+
+// listReporteProgramacionesHandler handles GET /v1/reporte-programaciones
+// @Summary      List All Report Schedules
+// @Description  Retrieves every report schedule with its next and last run.
+// @Tags         Reportes
+// @Produce      json
+// @Security     AdminBearer
+// @Success      200 {array} db.ReporteProgramacione "Successfully retrieved schedules"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve schedules"
+// @Router       /v1/reporte-programaciones [get]
+func listReporteProgramacionesHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	programaciones, err := queries.ListReporteProgramaciones(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to retrieve schedules: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if programaciones == nil {
+		programaciones = []db.ReporteProgramacione{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(programaciones)
+}
+
+// getReporteProgramacionByIDHandler handles GET /v1/reporte-programaciones/{id}
+// @Summary      Get Report Schedule by ID
+// @Description  Retrieves a specific report schedule by its ID.
+// @Tags         Reportes
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Schedule ID"
+// @Success      200 {object} db.ReporteProgramacione "Successfully retrieved schedule"
+// @Failure      400 {object} ErrorResponse "Invalid schedule ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Schedule not found"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve schedule"
+// @Router       /v1/reporte-programaciones/{id} [get]
+func getReporteProgramacionByIDHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, idStr string) {
+	id, err := strconv.ParseInt(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid schedule ID", http.StatusBadRequest)
+		return
+	}
+
+	programacion, err := queries.SelectReporteProgramacionById(r.Context(), int32(id))
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			http.Error(w, "Schedule not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to retrieve schedule: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(programacion)
+}
+
+// updateReporteProgramacionHandler handles PUT /v1/reporte-programaciones/{id}
+// @Summary      Update Report Schedule
+// @Description  Replaces a report schedule. Its next run is recomputed from the cron expression.
+// @Tags         Reportes
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Schedule ID"
+// @Param        programacion body UpdateReporteProgramacionRequest true "Updated Schedule Data"
+// @Success      200 {object} db.ReporteProgramacione "Successfully updated schedule"
+// @Failure      400 {object} ErrorResponse "Invalid request body or schedule ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Schedule not found"
+// @Failure      500 {object} ErrorResponse "Failed to update schedule"
+// @Router       /v1/reporte-programaciones/{id} [put]
+func updateReporteProgramacionHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/reporte-programaciones/")
+	id, err := strconv.ParseInt(path, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid schedule ID", http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateReporteProgramacionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(req.Nombre) == "" {
+		http.Error(w, "nombre is required", http.StatusBadRequest)
+		return
+	}
+
+	proxima, err := validateReporteProgramacion(req.TipoReporte, req.Cron, req.Periodo, req.Destinatarios, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	destinatarios := req.Destinatarios
+	if destinatarios == nil {
+		destinatarios = []string{}
+	}
+
+	programacion, err := queries.UpdateReporteProgramacion(r.Context(), db.UpdateReporteProgramacionParams{
+		ProgramacionID:   int32(id),
+		Nombre:           req.Nombre,
+		TipoReporte:      req.TipoReporte,
+		Cron:             req.Cron,
+		Periodo:          req.Periodo,
+		Destinatarios:    destinatarios,
+		AdjuntarPdf:      req.AdjuntarPDF,
+		Activo:           req.Activo,
+		ProximaEjecucion: pgtype.Timestamp{Time: proxima, Valid: true},
+	})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			http.Error(w, "Schedule not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to update schedule: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(programacion)
+}
+
+// deleteReporteProgramacionHandler handles DELETE /v1/reporte-programaciones/{id}
+// @Summary      Delete Report Schedule
+// @Description  Deletes a report schedule. Reportes it already generated are kept.
+// @Tags         Reportes
+// @Security     AdminBearer
+// @Param        id path int true "Schedule ID"
+// @Success      204 "Successfully deleted schedule"
+// @Failure      400 {object} ErrorResponse "Invalid schedule ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to delete schedule"
+// @Router       /v1/reporte-programaciones/{id} [delete]
+func deleteReporteProgramacionHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/reporte-programaciones/")
+	id, err := strconv.ParseInt(path, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid schedule ID", http.StatusBadRequest)
+		return
+	}
+
+	err = queries.DeleteReporteProgramacion(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, "Failed to delete schedule: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReporteScheduler queues the reportes of the programaciones that are due. The reportes
+// themselves are generated by the ReporteWorker.
+type ReporteScheduler struct {
+	Queries      *db.Queries
+	PollInterval time.Duration // How often due programaciones are looked up
+}
+
+// NewReporteScheduler creates a ReporteScheduler that checks for due programaciones every minute.
+func NewReporteScheduler(queries *db.Queries) *ReporteScheduler {
+	return &ReporteScheduler{
+		Queries:      queries,
+		PollInterval: time.Minute,
+	}
+}
+
+// Run polls for due programaciones until ctx is cancelled.
+func (s *ReporteScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunDue(ctx, time.Now()); err != nil {
+			log.Printf("programaciones: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue queues a reporte for every programacion due at now and schedules its next run.
+// A run covers the period before the time it was due, so a run delayed by downtime still
+// reports the right period; runs missed entirely while the server was down are skipped.
+// A period that already has a reporte from the programacion is not generated again.
+// Like validateReporteProgramacion, it works on zonaHoraria wall-clock times.
+func (s *ReporteScheduler) RunDue(ctx context.Context, now time.Time) error {
+	now = now.In(zonaHoraria)
+
+	programaciones, err := s.Queries.ListReporteProgramacionesPendientes(ctx, pgtype.Timestamp{Time: now, Valid: true})
+	if err != nil {
+		return fmt.Errorf("could not list due schedules: %w", err)
+	}
+
+	for _, programacion := range programaciones {
+		schedule, err := cron.ParseStandard(programacion.Cron)
+		if err != nil {
+			log.Printf("programaciones: schedule %d has an invalid cron expression: %v", programacion.ProgramacionID, err)
+			continue
+		}
+
+		ejecucion := programacion.ProximaEjecucion.Time
+		periodo, ok := reportePeriodo(programacion.Periodo, ejecucion)
+		if !ok {
+			log.Printf("programaciones: schedule %d has an unknown periodo %q", programacion.ProgramacionID, programacion.Periodo)
+			continue
+		}
+
+		reporte, err := s.Queries.EnqueueReporteProgramado(ctx, db.EnqueueReporteProgramadoParams{
+			TipoReporte:    programacion.TipoReporte,
+			PeriodoInicio:  pgtype.Date{Time: periodo[0], Valid: true},
+			PeriodoFin:     pgtype.Date{Time: periodo[1], Valid: true},
+			GeneradoPor:    programacion.CreadoPor,
+			ProgramacionID: pgtype.Int4{Int32: programacion.ProgramacionID, Valid: true},
+		})
+		switch {
+		case err == nil:
+			log.Printf("programaciones: schedule %d queued reporte %d", programacion.ProgramacionID, reporte.ReporteID)
+		case err.Error() == "no rows in result set":
+			log.Printf("programaciones: schedule %d already has a reporte for %s to %s, skipping",
+				programacion.ProgramacionID, periodo[0].Format("2006-01-02"), periodo[1].Format("2006-01-02"))
+		default:
+			// Leave the run due so that it is retried on the next poll
+			log.Printf("programaciones: could not queue reporte of schedule %d: %v", programacion.ProgramacionID, err)
+			continue
+		}
+
+		err = s.Queries.AdvanceReporteProgramacion(ctx, db.AdvanceReporteProgramacionParams{
+			ProgramacionID:   programacion.ProgramacionID,
+			UltimaEjecucion:  pgtype.Timestamp{Time: ejecucion, Valid: true},
+			ProximaEjecucion: pgtype.Timestamp{Time: schedule.Next(now), Valid: true},
+		})
+		if err != nil {
+			log.Printf("programaciones: could not schedule the next run of %d: %v", programacion.ProgramacionID, err)
+		}
+	}
+
+	return nil
+}
+
+// notificarReporteProgramado emails a reporte generated by a programacion to its
+// destinatarios: a link to the PDF, or the PDF itself when the programacion asks for it.
+// Set PUBLIC_URL to the public address of the API so that the link is absolute.
+func notificarReporteProgramado(ctx context.Context, queries *db.Queries, reporteID int32) {
+	reporte, err := queries.SelectReporteById(ctx, reporteID)
+	if err != nil || !reporte.ProgramacionID.Valid {
+		return
+	}
+
+	programacion, err := queries.SelectReporteProgramacionById(ctx, reporte.ProgramacionID.Int32)
+	if err != nil || len(programacion.Destinatarios) == 0 {
+		return
+	}
+
+	enlace := strings.TrimSuffix(os.Getenv("PUBLIC_URL"), "/") + fmt.Sprintf("/v1/reportes/%d.pdf", reporte.ReporteID)
+	periodo := exportField(reporte.PeriodoInicio).Text + " a " + exportField(reporte.PeriodoFin).Text
+	msg := mailMessage{
+		To:      programacion.Destinatarios,
+		Subject: fmt.Sprintf("%s (%s)", programacion.Nombre, periodo),
+		Body: fmt.Sprintf("Se generó el reporte programado \"%s\" del periodo %s.\n\nPuede consultarlo en: %s\n",
+			programacion.Nombre, periodo, enlace),
+	}
+
+	if programacion.AdjuntarPdf {
+		var buf bytes.Buffer
+		if err := renderReportePDF(&buf, reporte, reporteAutor(ctx, queries, reporte)); err != nil {
+			log.Printf("programaciones: could not render reporte %d for email: %v", reporte.ReporteID, err)
+		} else {
+			msg.Attachments = []mailAttachment{{
+				Filename:    fmt.Sprintf("reporte-%d.pdf", reporte.ReporteID),
+				ContentType: "application/pdf",
+				Data:        buf.Bytes(),
+			}}
+		}
+	}
+
+	sendMailAsync(msg)
+}
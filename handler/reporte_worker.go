@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// errReporteCancelado is the cause of a job context cancelled because an admin cancelled the reporte.
+var errReporteCancelado = errors.New("reporte cancelled")
+
+// ReporteWorker generates queued reportes with a pool of workers, retrying failures with
+// exponential backoff. Every reporte row is its own job: estado moves from 'pendiente' to
+// 'en_progreso' and then to 'listo', back to 'pendiente' for a retry, or to 'fallido'.
+type ReporteWorker struct {
+	Queries        *db.Queries
+	Workers        int           // Reportes generated concurrently
+	MaxIntentos    int32         // Attempts before a reporte is marked 'fallido'
+	BaseBackoff    time.Duration // Delay before the first retry, doubled on every further attempt
+	PollInterval   time.Duration // How often pending reportes are looked up
+	CancelInterval time.Duration // How often a running job checks whether it was cancelled
+	Timeout        time.Duration // Maximum duration of one attempt
+}
+
+// NewReporteWorker creates a ReporteWorker with the default pool size and retry policy.
+func NewReporteWorker(queries *db.Queries) *ReporteWorker {
+	return &ReporteWorker{
+		Queries:        queries,
+		Workers:        2,
+		MaxIntentos:    3,
+		BaseBackoff:    30 * time.Second,
+		PollInterval:   2 * time.Second,
+		CancelInterval: time.Second,
+		Timeout:        10 * time.Minute,
+	}
+}
+
+// Run starts the workers and, until ctx is cancelled, puts back in the queue the jobs
+// abandoned by a worker that stopped, such as those running when the server restarted.
+func (rw *ReporteWorker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range rw.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rw.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(rw.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// A running attempt is bounded by Timeout, so anything older was abandoned.
+		if n, err := rw.Queries.RequeueReportesEstancados(ctx, (2 * rw.Timeout).Seconds()); err != nil {
+			log.Printf("reportes: could not requeue stalled jobs: %v", err)
+		} else if n > 0 {
+			log.Printf("reportes: requeued %d stalled jobs", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// work processes due jobs one at a time until the queue is empty, then waits for the next poll.
+func (rw *ReporteWorker) work(ctx context.Context) {
+	ticker := time.NewTicker(rw.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			procesado, err := rw.ProcessNext(ctx)
+			if err != nil {
+				log.Printf("reportes: %v", err)
+			}
+			if !procesado {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessNext claims the next due job and generates its reporte. It reports whether a job
+// was claimed; the error describes a job that could not be completed.
+func (rw *ReporteWorker) ProcessNext(ctx context.Context) (bool, error) {
+	reporte, err := rw.Queries.ClaimReportePendiente(ctx)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not claim a pending job: %w", err)
+	}
+
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	jobCtx, stop := context.WithTimeout(jobCtx, rw.Timeout)
+	defer stop()
+	go rw.watchCancelacion(jobCtx, reporte.ReporteID, cancel)
+
+	datos, err := rw.generar(jobCtx, reporte)
+	if errors.Is(context.Cause(jobCtx), errReporteCancelado) {
+		log.Printf("reportes: job %d was cancelled", reporte.ReporteID)
+		return true, nil
+	}
+	if err != nil {
+		return true, rw.fail(ctx, reporte, err)
+	}
+
+	n, err := rw.Queries.CompleteReporte(ctx, db.CompleteReporteParams{ReporteID: reporte.ReporteID, Datos: datos})
+	if err != nil {
+		return true, fmt.Errorf("could not store job %d: %w", reporte.ReporteID, err)
+	}
+	if n == 0 {
+		log.Printf("reportes: job %d was cancelled before it could be stored", reporte.ReporteID)
+		return true, nil
+	}
+
+	if reporte.ProgramacionID.Valid {
+		notificarReporteProgramado(ctx, rw.Queries, reporte.ReporteID)
+	}
+	return true, nil
+}
+
+// generar runs the report generator of a job, recording its progress along the way.
+func (rw *ReporteWorker) generar(ctx context.Context, reporte db.Reporte) ([]byte, error) {
+	generador, ok := reporteGeneradores[reporte.TipoReporte]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", errReporteTipo, reporte.TipoReporte)
+	}
+
+	rw.progreso(ctx, reporte.ReporteID, 10)
+	filas, err := generador(ctx, rw.Queries, reporte.PeriodoInicio, reporte.PeriodoFin)
+	if err != nil {
+		return nil, err
+	}
+
+	rw.progreso(ctx, reporte.ReporteID, 70)
+	datos, err := reporteDatosJSON(reporte.TipoReporte, reporte.PeriodoInicio, reporte.PeriodoFin, filas)
+	if err != nil {
+		return nil, err
+	}
+
+	rw.progreso(ctx, reporte.ReporteID, 90)
+	return datos, nil
+}
+
+// progreso records the progress of a running job. Failures are only logged.
+func (rw *ReporteWorker) progreso(ctx context.Context, reporteID, progreso int32) {
+	params := db.UpdateReporteProgresoParams{ReporteID: reporteID, Progreso: progreso}
+	if err := rw.Queries.UpdateReporteProgreso(ctx, params); err != nil && ctx.Err() == nil {
+		log.Printf("reportes: could not record progress of job %d: %v", reporteID, err)
+	}
+}
+
+// watchCancelacion cancels a running job once its estado is no longer 'en_progreso',
+// which happens when an admin cancels it.
+func (rw *ReporteWorker) watchCancelacion(ctx context.Context, reporteID int32, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(rw.CancelInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		estado, err := rw.Queries.SelectReporteEstado(ctx, reporteID)
+		if err != nil && err.Error() == "no rows in result set" {
+			estado = "eliminado"
+		} else if err != nil {
+			continue
+		}
+		if estado != "en_progreso" {
+			cancel(errReporteCancelado)
+			return
+		}
+	}
+}
+
+// fail records a failed attempt, queueing a retry unless the job ran out of attempts.
+// An unknown tipo_reporte is never retried.
+func (rw *ReporteWorker) fail(ctx context.Context, reporte db.Reporte, cause error) error {
+	params := db.FailReporteParams{
+		ReporteID:   reporte.ReporteID,
+		Estado:      "fallido",
+		UltimoError: pgtype.Text{String: cause.Error(), Valid: true},
+	}
+	if reporte.Intentos < rw.MaxIntentos && !errors.Is(cause, errReporteTipo) {
+		params.Estado = "pendiente"
+		backoff := rw.BaseBackoff << (reporte.Intentos - 1)
+		params.EsperaSegundos = backoff.Seconds()
+	}
+
+	if _, err := rw.Queries.FailReporte(ctx, params); err != nil {
+		return fmt.Errorf("could not record failure of job %d (%v): %w", reporte.ReporteID, cause, err)
+	}
+	return fmt.Errorf("job %d failed on attempt %d: %w", reporte.ReporteID, reporte.Intentos, cause)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// Reasons a booking attempt could not be served, as stored in SOLICITUDES_FALLIDAS.motivo.
+const (
+	MotivoMateriaInactiva   = "materia_inactiva"
+	MotivoSinTutores        = "sin_tutores"         // No active tutor of the materia is available that day
+	MotivoSinHorario        = "sin_horario"         // Tutors are available that day, but all are busy at that time
+	MotivoTutorNoCalificado = "tutor_no_calificado" // The requested tutor does not teach the materia
+	MotivoTutorOcupado      = "tutor_ocupado"
+	MotivoTutorNoDisponible = "tutor_no_disponible" // The requested tutor has no availability at that time
+	MotivoTutorSinCupo      = "tutor_sin_cupo"      // The tutor would exceed their weekly or monthly hour limit
+)
+
+// solicitudMotivos lists every motivo of a failed booking attempt.
+var solicitudMotivos = []string{
+	MotivoMateriaInactiva,
+	MotivoSinTutores,
+	MotivoSinHorario,
+	MotivoTutorNoCalificado,
+	MotivoTutorOcupado,
+	MotivoTutorNoDisponible,
+	MotivoTutorSinCupo,
+}
+
+// registrarSolicitudFallida records a booking attempt that could not be served, so that the
+// unmet demand can be reported. Failures are logged and never change the response.
+func registrarSolicitudFallida(
+	ctx context.Context,
+	queries *db.Queries,
+	req CreateTutoriaRequest,
+	fecha pgtype.Date,
+	horaInicio, horaFin pgtype.Time,
+	motivo string,
+) {
+	err := queries.CreateSolicitudFallida(ctx, db.CreateSolicitudFallidaParams{
+		EstudianteID: req.EstudianteID,
+		MateriaID:    req.MateriaID,
+		TutorID:      pgtype.Int4{Int32: req.TutorID, Valid: req.TutorID != 0},
+		Fecha:        fecha,
+		HoraInicio:   horaInicio,
+		HoraFin:      horaFin,
+		Motivo:       motivo,
+	})
+	if err != nil {
+		log.Printf("solicitudes: could not record failed booking of materia %d (%s): %v", req.MateriaID, motivo, err)
+	}
+}
+
+// ListSolicitudesFallidasEndpoint handles GET /v1/solicitudes-fallidas using Go 1.22 routing
+// @Summary      List Failed Booking Attempts
+// @Description  Retrieves the booking attempts that could not be served, most recent first. The demanda_insatisfecha
+// @Description  reporte ranks the materias with the most unmet demand.
+// @Tags         Tutorias
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     AdminBearer
+// @Param        materia_id query int false "Only attempts for this materia"
+// @Param        motivo query string false "Only attempts that failed for this reason" Enums(materia_inactiva, sin_tutores, sin_horario, tutor_no_calificado, tutor_ocupado, tutor_no_disponible, tutor_sin_cupo)
+// @Param        desde query string false "Only attempts made on or after this date (YYYY-MM-DD)"
+// @Param        hasta query string false "Only attempts made on or before this date (YYYY-MM-DD)"
+// @Param        limit query int false "Maximum number of attempts" default(50) minimum(1) maximum(500)
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
+// @Success      200 {array} db.ListSolicitudesFallidasRow "Failed booking attempts"
+// @Failure      400 {object} ErrorResponse "Invalid filter"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve failed booking attempts"
+// @Router       /v1/solicitudes-fallidas [get]
+func ListSolicitudesFallidasEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		params := db.ListSolicitudesFallidasParams{RowLimit: defaultListLimit}
+
+		if materiaStr := query.Get("materia_id"); materiaStr != "" {
+			id, err := strconv.ParseInt(materiaStr, 10, 32)
+			if err != nil {
+				http.Error(w, "Invalid materia ID", http.StatusBadRequest)
+				return
+			}
+			params.MateriaID = pgtype.Int4{Int32: int32(id), Valid: true}
+		}
+
+		if motivo := query.Get("motivo"); motivo != "" {
+			if !slices.Contains(solicitudMotivos, motivo) {
+				http.Error(w, "Invalid motivo", http.StatusBadRequest)
+				return
+			}
+			params.Motivo = pgtype.Text{String: motivo, Valid: true}
+		}
+
+		var err error
+		if desde := query.Get("desde"); desde != "" {
+			if params.Desde, err = parseDateString(desde); err != nil {
+				http.Error(w, "Invalid desde format (use YYYY-MM-DD)", http.StatusBadRequest)
+				return
+			}
+		}
+		if hasta := query.Get("hasta"); hasta != "" {
+			if params.Hasta, err = parseDateString(hasta); err != nil {
+				http.Error(w, "Invalid hasta format (use YYYY-MM-DD)", http.StatusBadRequest)
+				return
+			}
+		}
+
+		if limitStr := query.Get("limit"); limitStr != "" {
+			limit, err := strconv.ParseInt(limitStr, 10, 32)
+			if err != nil || limit < 1 || limit > maxListLimit {
+				http.Error(w, "Invalid limit, must be between 1 and "+strconv.Itoa(maxListLimit), http.StatusBadRequest)
+				return
+			}
+			params.RowLimit = int32(limit)
+		}
+
+		solicitudes, err := queries.ListSolicitudesFallidas(r.Context(), params)
+		if err != nil {
+			http.Error(w, "Failed to retrieve failed booking attempts: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if solicitudes == nil {
+			solicitudes = []db.ListSolicitudesFallidasRow{}
+		}
+
+		writeList(w, r, "solicitudes-fallidas", solicitudes)
+	}
+}
@@ -0,0 +1,487 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// maxMotivacionLength caps the motivation letter of a solicitud.
+const maxMotivacionLength = 4000
+
+// errSolicitudRevisada is returned when a solicitud is reviewed twice.
+var errSolicitudRevisada = errors.New("solicitud was already reviewed")
+
+// CreateSolicitudTutorRequest represents the request body for a student applying to become a tutor.
+type CreateSolicitudTutorRequest struct {
+	EstudianteID int32   `json:"estudiante_id" example:"1"`
+	Motivacion   string  `json:"motivacion" example:"Me gustaría apoyar a mis compañeros en las materias de primer semestre"`
+	Materias     []int32 `json:"materias" example:"3,7"` // IDs of the materias the student wants to teach
+}
+
+// RejectSolicitudTutorRequest represents the request body for rejecting a solicitud.
+type RejectSolicitudTutorRequest struct {
+	Motivo string `json:"motivo" example:"Por ahora no hay cupos para nuevos tutores"`
+}
+
+// ApproveSolicitudTutorResponse represents the outcome of approving a solicitud.
+type ApproveSolicitudTutorResponse struct {
+	Solicitud         db.SolicitudesTutor `json:"solicitud"`
+	TutorID           int32               `json:"tutor_id" example:"12"`
+	MateriasAsignadas []int32             `json:"materias_asignadas" example:"3"`
+	MateriasOmitidas  []string            `json:"materias_omitidas" example:"materia 7: semestre 2 is below the minimum of 4"` // Requested materias the student is not eligible for
+}
+
+// notificarSolicitudTutor emails the student the outcome of their solicitud.
+func notificarSolicitudTutor(ctx context.Context, queries *db.Queries, solicitud db.SolicitudesTutor, omitidas []string) {
+	estudiante, err := queries.SelectEstudianteById(ctx, solicitud.EstudianteID)
+	if err != nil {
+		return
+	}
+
+	msg := mailMessage{To: []string{estudiante.Correo}}
+	switch solicitud.Estado {
+	case RevisionAprobada:
+		msg.Subject = "Tu solicitud para ser tutor fue aprobada"
+		msg.Body = fmt.Sprintf("Hola %s,\n\nTu solicitud para ser tutor fue aprobada. Ya puedes iniciar sesión como tutor "+
+			"y registrar tu disponibilidad.\n", estudiante.Nombre)
+		if len(omitidas) > 0 {
+			msg.Body += "\nNo se te asignaron las siguientes materias porque no cumples sus requisitos:\n- " +
+				strings.Join(omitidas, "\n- ") + "\n"
+		}
+	case RevisionRechazada:
+		msg.Subject = "Tu solicitud para ser tutor fue rechazada"
+		msg.Body = fmt.Sprintf("Hola %s,\n\nTu solicitud para ser tutor fue rechazada.\n\nMotivo: %s\n",
+			estudiante.Nombre, solicitud.MotivoRechazo.String)
+	default:
+		return
+	}
+	sendMailAsync(msg)
+}
+
+// CreateSolicitudTutorEndpoint handles POST /v1/solicitudes-tutor using Go 1.22 routing
+// @Summary      Apply to Become a Tutor
+// @Description  A student applies to become a tutor, with a motivation and the materias they want to teach. Admins
+// @Description  review the solicitud through /v1/admin/solicitudes-tutor.
+// @Tags         Tutores
+// @Accept       json
+// @Produce      json
+// @Param        solicitud body CreateSolicitudTutorRequest true "Solicitud Data"
+// @Success      201 {object} db.SolicitudesTutor "Successfully created solicitud"
+// @Failure      400 {object} ErrorResponse "Invalid request body, estudiante or materias"
+// @Failure      409 {object} ErrorResponse "The student is already a tutor or has a pending solicitud"
+// @Failure      500 {object} ErrorResponse "Failed to create solicitud"
+// @Router       /v1/solicitudes-tutor [post]
+func CreateSolicitudTutorEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CreateSolicitudTutorRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		req.Motivacion = strings.TrimSpace(req.Motivacion)
+		if req.Motivacion == "" {
+			http.Error(w, "motivacion is required", http.StatusBadRequest)
+			return
+		}
+		if len(req.Motivacion) > maxMotivacionLength {
+			http.Error(w, fmt.Sprintf("motivacion must be at most %d characters", maxMotivacionLength), http.StatusBadRequest)
+			return
+		}
+
+		slices.Sort(req.Materias)
+		req.Materias = slices.Compact(req.Materias)
+		if len(req.Materias) == 0 {
+			http.Error(w, "At least one materia is required", http.StatusBadRequest)
+			return
+		}
+
+		estudiante, err := queries.SelectEstudianteById(r.Context(), req.EstudianteID)
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Estudiante not found", http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "Failed to get estudiante: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if _, err := queries.SelectTutorByCorreo(r.Context(), estudiante.Correo); err == nil {
+			http.Error(w, "Estudiante is already a tutor", http.StatusConflict)
+			return
+		} else if err.Error() != "no rows in result set" {
+			http.Error(w, "Failed to check tutor: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		pendientes, err := queries.ListSolicitudesTutor(r.Context(), db.ListSolicitudesTutorParams{
+			Estado:       pgtype.Text{String: RevisionPendiente, Valid: true},
+			EstudianteID: pgtype.Int4{Int32: estudiante.EstudianteID, Valid: true},
+			RowLimit:     1,
+		})
+		if err != nil {
+			http.Error(w, "Failed to check pending solicitudes: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(pendientes) > 0 {
+			http.Error(w, fmt.Sprintf("Estudiante already has pending solicitud %d", pendientes[0].SolicitudID), http.StatusConflict)
+			return
+		}
+
+		var invalid []string
+		for _, materiaID := range req.Materias {
+			materia, err := queries.SelectMateriaById(r.Context(), materiaID)
+			if err != nil {
+				if err.Error() != "no rows in result set" {
+					http.Error(w, "Failed to get materia: "+err.Error(), http.StatusInternalServerError)
+					return
+				}
+				invalid = append(invalid, fmt.Sprintf("materia %d not found", materiaID))
+				continue
+			}
+			if !materia.Activo {
+				invalid = append(invalid, fmt.Sprintf("materia %d is no longer offered", materiaID))
+			}
+		}
+		if len(invalid) > 0 {
+			http.Error(w, "Invalid materias: "+strings.Join(invalid, "; "), http.StatusBadRequest)
+			return
+		}
+
+		solicitud, err := queries.CreateSolicitudTutor(r.Context(), db.CreateSolicitudTutorParams{
+			EstudianteID: estudiante.EstudianteID,
+			Motivacion:   req.Motivacion,
+			Materias:     req.Materias,
+		})
+		if err != nil {
+			http.Error(w, "Failed to create solicitud: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(solicitud)
+	}
+}
+
+// GetSolicitudTutorEndpoint handles GET /v1/solicitudes-tutor/{id} using Go 1.22 routing
+// @Summary      Get Solicitud to Become a Tutor
+// @Description  Retrieves a solicitud, so that the student can follow its review.
+// @Tags         Tutores
+// @Produce      json
+// @Param        id path int true "Solicitud ID"
+// @Success      200 {object} db.SolicitudesTutor "Solicitud"
+// @Failure      400 {object} ErrorResponse "Invalid solicitud ID"
+// @Failure      404 {object} ErrorResponse "Solicitud not found"
+// @Failure      500 {object} ErrorResponse "Failed to get solicitud"
+// @Router       /v1/solicitudes-tutor/{id} [get]
+func GetSolicitudTutorEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid solicitud ID", http.StatusBadRequest)
+			return
+		}
+
+		solicitud, err := queries.SelectSolicitudTutorById(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Solicitud not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get solicitud: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(solicitud)
+	}
+}
+
+// ListSolicitudesTutorEndpoint handles GET /v1/admin/solicitudes-tutor using Go 1.22 routing
+// @Summary      List Solicitudes to Become a Tutor
+// @Description  Retrieves the solicitudes of students to become tutors, most recent first, with the student's data.
+// @Description  Use estado=pendiente for the review queue.
+// @Tags         Tutores
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     AdminBearer
+// @Param        estado query string false "Only solicitudes in this estado" Enums(pendiente, aprobada, rechazada)
+// @Param        estudiante_id query int false "Only solicitudes of this estudiante"
+// @Param        limit query int false "Maximum number of solicitudes" default(50) minimum(1) maximum(500)
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
+// @Success      200 {array} db.ListSolicitudesTutorRow "Solicitudes"
+// @Failure      400 {object} ErrorResponse "Invalid filter"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve solicitudes"
+// @Router       /v1/admin/solicitudes-tutor [get]
+func ListSolicitudesTutorEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		params := db.ListSolicitudesTutorParams{RowLimit: defaultListLimit}
+
+		if estado := query.Get("estado"); estado != "" {
+			if estado != RevisionPendiente && estado != RevisionAprobada && estado != RevisionRechazada {
+				http.Error(w, "Invalid estado", http.StatusBadRequest)
+				return
+			}
+			params.Estado = pgtype.Text{String: estado, Valid: true}
+		}
+
+		if estudianteStr := query.Get("estudiante_id"); estudianteStr != "" {
+			id, err := strconv.ParseInt(estudianteStr, 10, 32)
+			if err != nil {
+				http.Error(w, "Invalid estudiante ID", http.StatusBadRequest)
+				return
+			}
+			params.EstudianteID = pgtype.Int4{Int32: int32(id), Valid: true}
+		}
+
+		if limitStr := query.Get("limit"); limitStr != "" {
+			limit, err := strconv.ParseInt(limitStr, 10, 32)
+			if err != nil || limit < 1 || limit > maxListLimit {
+				http.Error(w, "Invalid limit, must be between 1 and "+strconv.Itoa(maxListLimit), http.StatusBadRequest)
+				return
+			}
+			params.RowLimit = int32(limit)
+		}
+
+		solicitudes, err := queries.ListSolicitudesTutor(r.Context(), params)
+		if err != nil {
+			http.Error(w, "Failed to retrieve solicitudes: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if solicitudes == nil {
+			solicitudes = []db.ListSolicitudesTutorRow{}
+		}
+
+		writeList(w, r, "solicitudes-tutor", solicitudes)
+	}
+}
+
+// ApproveSolicitudTutorEndpoint handles POST /v1/admin/solicitudes-tutor/{id}/aprobar using Go 1.22 routing
+// @Summary      Approve Solicitud to Become a Tutor
+// @Description  Approves a pending solicitud: creates the tutor from the student's data, like POST /v1/tutores, and
+// @Description  assigns the requested materias whose eligibility rules the student meets. The rest are reported in
+// @Description  materias_omitidas. The student is notified by email.
+// @Tags         Tutores
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Solicitud ID"
+// @Success      200 {object} ApproveSolicitudTutorResponse "Approved solicitud"
+// @Failure      400 {object} ErrorResponse "Invalid solicitud ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Solicitud not found"
+// @Failure      409 {object} ErrorResponse "Solicitud was already reviewed"
+// @Failure      500 {object} ErrorResponse "Failed to approve solicitud"
+// @Router       /v1/admin/solicitudes-tutor/{id}/aprobar [post]
+func ApproveSolicitudTutorEndpoint(pool *pgxpool.Pool, queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid solicitud ID", http.StatusBadRequest)
+			return
+		}
+
+		solicitud, err := queries.SelectSolicitudTutorById(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Solicitud not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get solicitud: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if solicitud.Estado != RevisionPendiente {
+			http.Error(w, "Solicitud was already "+solicitud.Estado, http.StatusConflict)
+			return
+		}
+
+		estudiante, err := queries.SelectEstudianteById(r.Context(), solicitud.EstudianteID)
+		if err != nil {
+			http.Error(w, "Failed to get estudiante: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// The eligibility rules are checked against the estudiante record, which the tutor shares by correo
+		response := ApproveSolicitudTutorResponse{MateriasAsignadas: []int32{}, MateriasOmitidas: []string{}}
+		var elegibles []int32
+		for _, materiaID := range solicitud.Materias {
+			materia, err := queries.SelectMateriaById(r.Context(), materiaID)
+			if err != nil {
+				if err.Error() != "no rows in result set" {
+					http.Error(w, "Failed to get materia: "+err.Error(), http.StatusInternalServerError)
+					return
+				}
+				response.MateriasOmitidas = append(response.MateriasOmitidas, fmt.Sprintf("materia %d: not found", materiaID))
+				continue
+			}
+			if !materia.Activo {
+				response.MateriasOmitidas = append(response.MateriasOmitidas, fmt.Sprintf("materia %d: no longer offered", materiaID))
+				continue
+			}
+			requisitos, err := materiaRequisitos(r.Context(), queries, materiaID)
+			if err != nil {
+				http.Error(w, "Failed to get eligibility rules: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			incumplidos, err := requisitosIncumplidos(r.Context(), queries, db.Tutore{Correo: estudiante.Correo}, requisitos)
+			if err != nil {
+				http.Error(w, "Failed to check eligibility: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if len(incumplidos) > 0 {
+				response.MateriasOmitidas = append(response.MateriasOmitidas,
+					fmt.Sprintf("materia %d: %s", materiaID, strings.Join(incumplidos, "; ")))
+				continue
+			}
+			elegibles = append(elegibles, materiaID)
+		}
+
+		var revisadoPor pgtype.Int4
+		if admin, ok := adminFromContext(r.Context()); ok {
+			revisadoPor = pgtype.Int4{Int32: admin.AdminID, Valid: true}
+		}
+
+		var asignadas []db.TutorMateria
+		err = withTx(r.Context(), pool, queries, func(q *db.Queries) error {
+			// The student may have been made a tutor by other means since applying
+			tutor, err := q.SelectTutorByCorreo(r.Context(), estudiante.Correo)
+			if err != nil {
+				if err.Error() != "no rows in result set" {
+					return err
+				}
+				if tutor, err = q.CreateTutor(r.Context(), tutorParamsFromEstudiante(estudiante)); err != nil {
+					return fmt.Errorf("create tutor: %w", err)
+				}
+			}
+			response.TutorID = tutor.TutorID
+
+			for _, materiaID := range elegibles {
+				assignment, err := q.UpsertTutorMateria(r.Context(), db.UpsertTutorMateriaParams{
+					TutorID:   tutor.TutorID,
+					MateriaID: materiaID,
+				})
+				if err != nil {
+					if err.Error() == "no rows in result set" {
+						continue // Already assigned
+					}
+					return fmt.Errorf("assign materia %d: %w", materiaID, err)
+				}
+				response.MateriasAsignadas = append(response.MateriasAsignadas, materiaID)
+				asignadas = append(asignadas, db.TutorMateria{
+					AsignacionID:    assignment.AsignacionID,
+					TutorID:         assignment.TutorID,
+					MateriaID:       assignment.MateriaID,
+					FechaAsignacion: assignment.FechaAsignacion,
+					Activo:          assignment.Activo,
+				})
+			}
+
+			response.Solicitud, err = q.ReviewSolicitudTutor(r.Context(), db.ReviewSolicitudTutorParams{
+				Estado:      RevisionAprobada,
+				RevisadoPor: revisadoPor,
+				TutorID:     pgtype.Int4{Int32: tutor.TutorID, Valid: true},
+				SolicitudID: solicitud.SolicitudID,
+			})
+			if err != nil && err.Error() == "no rows in result set" {
+				return errSolicitudRevisada
+			}
+			return err
+		})
+		if err != nil {
+			if errors.Is(err, errSolicitudRevisada) {
+				http.Error(w, "Solicitud was already reviewed", http.StatusConflict)
+				return
+			}
+			http.Error(w, "Failed to approve solicitud: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		for _, assignment := range asignadas {
+			emitWebhookEvent(r.Context(), queries, EventoTutorMateriaAsignada, assignment)
+		}
+		notificarSolicitudTutor(r.Context(), queries, response.Solicitud, response.MateriasOmitidas)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// RejectSolicitudTutorEndpoint handles POST /v1/admin/solicitudes-tutor/{id}/rechazar using Go 1.22 routing
+// @Summary      Reject Solicitud to Become a Tutor
+// @Description  Rejects a pending solicitud with a reason. The student is notified by email.
+// @Tags         Tutores
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Solicitud ID"
+// @Param        rechazo body RejectSolicitudTutorRequest true "Rejection reason"
+// @Success      200 {object} db.SolicitudesTutor "Rejected solicitud"
+// @Failure      400 {object} ErrorResponse "Invalid solicitud ID or missing motivo"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Solicitud not found"
+// @Failure      409 {object} ErrorResponse "Solicitud was already reviewed"
+// @Failure      500 {object} ErrorResponse "Failed to reject solicitud"
+// @Router       /v1/admin/solicitudes-tutor/{id}/rechazar [post]
+func RejectSolicitudTutorEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid solicitud ID", http.StatusBadRequest)
+			return
+		}
+
+		var req RejectSolicitudTutorRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		req.Motivo = strings.TrimSpace(req.Motivo)
+		if req.Motivo == "" {
+			http.Error(w, "motivo is required", http.StatusBadRequest)
+			return
+		}
+
+		var revisadoPor pgtype.Int4
+		if admin, ok := adminFromContext(r.Context()); ok {
+			revisadoPor = pgtype.Int4{Int32: admin.AdminID, Valid: true}
+		}
+
+		solicitud, err := queries.ReviewSolicitudTutor(r.Context(), db.ReviewSolicitudTutorParams{
+			Estado:        RevisionRechazada,
+			MotivoRechazo: pgtype.Text{String: req.Motivo, Valid: true},
+			RevisadoPor:   revisadoPor,
+			SolicitudID:   int32(id),
+		})
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				// Either the solicitud does not exist or it is no longer pending
+				if _, err := queries.SelectSolicitudTutorById(r.Context(), int32(id)); err != nil {
+					http.Error(w, "Solicitud not found", http.StatusNotFound)
+					return
+				}
+				http.Error(w, "Solicitud was already reviewed", http.StatusConflict)
+				return
+			}
+			http.Error(w, "Failed to reject solicitud: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		notificarSolicitudTutor(r.Context(), queries, solicitud, nil)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(solicitud)
+	}
+}
@@ -3,11 +3,13 @@ package handler
 import (
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/matwate/proyecto-datos/db"
 )
 
@@ -75,17 +77,26 @@ func TutorHandlers(queries *db.Queries) http.HandlerFunc {
 
 // createTutorHandler handles POST /v1/tutores
 // @Summary      Create Tutor from Estudiante
-// @Description  Creates a new tutor record using data from an existing student.
+// @Description  Creates a new tutor record using data from an existing student. Requires an admin bearer token;
+// @Description  students apply through POST /v1/solicitudes-tutor instead.
 // @Tags         Tutores
 // @Accept       json
 // @Produce      json
+// @Security     AdminBearer
 // @Param        tutor body CreateTutorRequest true "Estudiante ID to become Tutor"
 // @Success      201 {object} CreateTutorResponse "Successfully created tutor"
 // @Failure      400 {object} ErrorResponse "Invalid request body or Estudiante ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
 // @Failure      404 {object} ErrorResponse "Estudiante not found"
 // @Failure      500 {object} ErrorResponse "Failed to create tutor"
 // @Router       /v1/tutores [post]
 func createTutorHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	// Only POST /v1/tutores goes through AdminAuthMiddleware; POSTs under /v1/tutores/ reach here without it
+	if _, ok := adminFromContext(r.Context()); !ok {
+		http.Error(w, "Admin authentication required", http.StatusUnauthorized)
+		return
+	}
+
 	var req CreateTutorRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
@@ -109,14 +120,7 @@ func createTutorHandler(w http.ResponseWriter, r *http.Request, queries *db.Quer
 	}
 
 	// 2. Create Tutor using Estudiante's data
-	tutorParams := db.CreateTutorParams{
-		Nombre:            estudiante.Nombre,
-		Apellido:          estudiante.Apellido,
-		Correo:            estudiante.Correo,
-		ProgramaAcademico: pgtype.Text{estudiante.ProgramaAcademico, estudiante.ProgramaAcademico != ""},
-	}
-
-	tutorID, err := queries.CreateTutor(r.Context(), tutorParams)
+	tutorID, err := queries.CreateTutor(r.Context(), tutorParamsFromEstudiante(estudiante))
 	if err != nil {
 		http.Error(w, "Failed to create tutor: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -127,6 +131,17 @@ func createTutorHandler(w http.ResponseWriter, r *http.Request, queries *db.Quer
 	json.NewEncoder(w).Encode(CreateTutorResponse{TutorID: tutorID.TutorID})
 }
 
+// tutorParamsFromEstudiante copies the data of an estudiante into a new tutor. The tutor keeps the
+// estudiante's correo, which links both records.
+func tutorParamsFromEstudiante(estudiante db.Estudiante) db.CreateTutorParams {
+	return db.CreateTutorParams{
+		Nombre:            estudiante.Nombre,
+		Apellido:          estudiante.Apellido,
+		Correo:            estudiante.Correo,
+		ProgramaAcademico: pgtype.Text{String: estudiante.ProgramaAcademico, Valid: estudiante.ProgramaAcademico != ""},
+	}
+}
+
 // handleTutorGET handles GET requests for tutores
 func handleTutorGET(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
 	path := strings.TrimPrefix(r.URL.Path, "/v1/tutores")
@@ -160,23 +175,73 @@ func handleTutorGET(w http.ResponseWriter, r *http.Request, queries *db.Queries)
 	http.Error(w, "Invalid path", http.StatusBadRequest)
 }
 
+// tutorSorts are the sort fields of GET /v1/tutores, the first one being the default.
+var tutorSorts = []string{"apellido", "nombre", "correo", "programa_academico", "fecha_registro", "id"}
+
+// tutorSortKey returns the sort key ListTutores computes for a tutor.
+func tutorSortKey(sort string, tutor db.Tutore) string {
+	switch sort {
+	case "nombre":
+		return tutor.Nombre + " " + tutor.Apellido
+	case "correo":
+		return tutor.Correo
+	case "programa_academico":
+		return tutor.ProgramaAcademico.String
+	case "fecha_registro":
+		return sortKeyTimestamp(tutor.FechaRegistro)
+	case "id":
+		return sortKeyInt(tutor.TutorID)
+	default:
+		return tutor.Apellido + " " + tutor.Nombre
+	}
+}
+
 // listTutoresHandler handles GET /v1/tutores
 // @Summary      List All Tutores
 // @Description  Retrieves a list of all tutors.
 // @Tags         Tutores
 // @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        limit query int false "Maximum number of items per page" default(50) minimum(1) maximum(500)
+// @Param        after query string false "Cursor of the next page, as returned in X-Next-Cursor"
+// @Param        sort query string false "Sort field" Enums(apellido, nombre, correo, programa_academico, fecha_registro, id) default(apellido)
+// @Param        direction query string false "Sort direction" Enums(asc, desc) default(asc)
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
 // @Success      200 {array} db.Tutore "Successfully retrieved tutores"
+// @Header       200 {integer} X-Total-Count "Total number of tutores"
+// @Header       200 {string} X-Next-Cursor "Cursor of the next page; absent on the last page"
+// @Failure      400 {object} ErrorResponse "Invalid pagination parameters"
 // @Failure      500 {object} ErrorResponse "Failed to retrieve tutores"
 // @Router       /v1/tutores [get]
 func listTutoresHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
-	tutores, err := queries.ListTutores(r.Context())
+	page, err := parseListPage(r, tutorSorts, false)
+	if err != nil {
+		http.Error(w, "Invalid pagination parameters: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tutores, err := queries.ListTutores(r.Context(), db.ListTutoresParams{
+		Sort:       page.Sort,
+		AfterID:    page.AfterID,
+		Descending: page.Descending,
+		AfterValor: page.AfterValor,
+		RowLimit:   page.Limit + 1,
+	})
 	if err != nil {
 		http.Error(w, "Failed to retrieve tutores: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tutores)
+	total, err := queries.CountTutores(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to count tutores: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writePage(w, r, "tutores", page, tutores, total, func(row db.Tutore) (string, int32) {
+		return tutorSortKey(page.Sort, row), row.TutorID
+	})
 }
 
 // getTutorByIDHandler handles GET /v1/tutores/{id}
@@ -297,11 +362,14 @@ func updateTutorHandler(w http.ResponseWriter, r *http.Request, queries *db.Quer
 
 // deleteTutorHandler handles DELETE /v1/tutores/{id}
 // @Summary      Delete Tutor
-// @Description  Deletes a tutor by their ID.
+// @Description  Moves a tutor to the papelera, keeping their tutorias, availability and materias. It is no longer
+// @Description  listed and can be restored by an admin until the retention period ends, when it is deleted
+// @Description  permanently.
 // @Tags         Tutores
 // @Param        id path int true "Tutor ID"
 // @Success      204 "Successfully deleted tutor"
 // @Failure      400 {object} ErrorResponse "Invalid tutor ID"
+// @Failure      404 {object} ErrorResponse "Tutor not found"
 // @Failure      500 {object} ErrorResponse "Failed to delete tutor"
 // @Router       /v1/tutores/{id} [delete]
 func deleteTutorHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
@@ -312,11 +380,15 @@ func deleteTutorHandler(w http.ResponseWriter, r *http.Request, queries *db.Quer
 		return
 	}
 
-	err = queries.DeleteTutor(r.Context(), int32(id))
+	deleted, err := queries.SoftDeleteTutor(r.Context(), int32(id))
 	if err != nil {
 		http.Error(w, "Failed to delete tutor: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if deleted == 0 {
+		http.Error(w, "Tutor not found", http.StatusNotFound)
+		return
+	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -349,7 +421,10 @@ func countTutorsWithMateriasHandler(w http.ResponseWriter, r *http.Request, quer
 // @Description  Retrieves all materias taught by a specific tutor.
 // @Tags         Tutores
 // @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param        id path int true "Tutor ID"
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
 // @Success      200 {array} db.Materia "Successfully retrieved materias"
 // @Failure      400 {object} ErrorResponse "Invalid tutor ID"
 // @Failure      404 {object} ErrorResponse "Tutor not found or no materias assigned"
@@ -385,11 +460,287 @@ func GetTutorMateriasHandler(w http.ResponseWriter, r *http.Request, queries *db
 		// Send a 200 with an empty array if tutor exists but has no active materias,
 		// or a 404 if you prefer to indicate "no resources found" for this specific sub-resource.
 		// For consistency with how other list endpoints might behave, 200 with empty is often preferred.
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode([]db.Materia{}) // Return empty array
-		return
+		materias = []db.Materia{} // Return empty array
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(materias)
+	writeList(w, r, "materias", materias)
+}
+
+// OnboardTutorMaterias selects the materias assigned by an onboarding. The three selectors
+// are combined; only active materias can be assigned.
+type OnboardTutorMaterias struct {
+	IDs      []int32  `json:"ids,omitempty"      example:"18,19"`
+	Codigos  []string `json:"codigos,omitempty"  example:"MATH101"`
+	Facultad string   `json:"facultad,omitempty" example:"Ingeniería"` // Every materia of a facultad, or "*" for the whole catalog
+}
+
+// OnboardTutorBloque is a weekly availability block repeated on each of its dias.
+type OnboardTutorBloque struct {
+	Dias       []int32 `json:"dias"        example:"1,2,3,4,5"` // 1 = Monday, 7 = Sunday
+	HoraInicio string  `json:"hora_inicio" example:"08:00"`
+	HoraFin    string  `json:"hora_fin"    example:"10:00"`
+}
+
+// OnboardTutorRequest represents the request body for onboarding a tutor in one call.
+type OnboardTutorRequest struct {
+	EstudianteID   int32                `json:"estudiante_id" example:"6"`
+	Materias       OnboardTutorMaterias `json:"materias"`
+	Disponibilidad []OnboardTutorBloque `json:"disponibilidad"`
+}
+
+// OnboardTutorResponse summarizes what an onboarding created. Re-running the same request
+// creates nothing new.
+type OnboardTutorResponse struct {
+	TutorID                 int32    `json:"tutor_id"                 example:"6"`
+	TutorCreado             bool     `json:"tutor_creado"             example:"true"`
+	MateriasAsignadas       int      `json:"materias_asignadas"       example:"75"` // New or reactivated assignments
+	MateriasExistentes      int      `json:"materias_existentes"      example:"0"`  // Assignments that were already active
+	DisponibilidadCreada    int      `json:"disponibilidad_creada"    example:"42"`
+	DisponibilidadExistente int      `json:"disponibilidad_existente" example:"0"`
+	MateriasOmitidas        []string `json:"materias_omitidas"        example:"materia 12: semestre 3 is below the minimum of 5"` // Materias whose eligibility rules the student does not meet
+}
+
+// resolveOnboardMaterias returns the IDs of the materias selected by an onboarding request,
+// without duplicates, along with the selectors that do not match an active materia.
+func resolveOnboardMaterias(r *http.Request, queries *db.Queries, selector OnboardTutorMaterias) ([]int32, []string, error) {
+	var (
+		ids      []int32
+		seen     = map[int32]bool{}
+		invalids []string
+	)
+	add := func(materia db.Materia, ref string) {
+		if !materia.Activo {
+			invalids = append(invalids, ref+" is not active")
+			return
+		}
+		if !seen[materia.MateriaID] {
+			seen[materia.MateriaID] = true
+			ids = append(ids, materia.MateriaID)
+		}
+	}
+
+	for _, id := range selector.IDs {
+		materia, err := queries.SelectMateriaById(r.Context(), id)
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				invalids = append(invalids, fmt.Sprintf("materia %d not found", id))
+				continue
+			}
+			return nil, nil, err
+		}
+		add(materia, fmt.Sprintf("materia %d", id))
+	}
+
+	for _, codigo := range selector.Codigos {
+		materia, err := queries.SelectMateriaByCodigo(r.Context(), codigo)
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				invalids = append(invalids, "codigo "+codigo+" not found")
+				continue
+			}
+			return nil, nil, err
+		}
+		add(materia, "codigo "+codigo)
+	}
+
+	if selector.Facultad != "" {
+		var (
+			materias []db.Materia
+			err      error
+		)
+		if selector.Facultad == "*" {
+			materias, err = queries.ListMateriasCatalogo(r.Context())
+		} else {
+			materias, err = queries.ListMateriasByFacultad(r.Context(), selector.Facultad)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, materia := range materias {
+			if materia.Activo {
+				add(materia, "")
+			}
+		}
+		if len(materias) == 0 {
+			invalids = append(invalids, "facultad "+selector.Facultad+" has no materias")
+		}
+	}
+
+	return ids, invalids, nil
+}
+
+// OnboardTutorEndpoint handles POST /v1/tutores/onboard using Go 1.22 routing
+// @Summary      Onboard Tutor
+// @Description  Turns an estudiante into a tutor, assigns the selected materias (by ID, codigo or facultad, "*" meaning every active materia) and creates the weekly availability template, all in one transaction. Materias whose eligibility rules the student does not meet are skipped and listed in materias_omitidas. It is idempotent: an existing tutor with the student's correo is reused, active assignments and identical availability slots are left alone, and inactive assignments are reactivated.
+// @Tags         Tutores
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        onboarding body OnboardTutorRequest true "Onboarding data"
+// @Success      200 {object} OnboardTutorResponse "Onboarding summary"
+// @Failure      400 {object} ErrorResponse "Invalid request body, materias or availability"
+// @Failure      401 {object} ErrorResponse "Missing or invalid admin token"
+// @Failure      404 {object} ErrorResponse "Estudiante not found"
+// @Failure      500 {object} ErrorResponse "Failed to onboard tutor"
+// @Router       /v1/tutores/onboard [post]
+func OnboardTutorEndpoint(pool *pgxpool.Pool, queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req OnboardTutorRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		if req.EstudianteID <= 0 {
+			http.Error(w, "Invalid Estudiante ID", http.StatusBadRequest)
+			return
+		}
+
+		// Expand the availability template into slots, validating it up front
+		type slot struct {
+			dia        int32
+			horaInicio pgtype.Time
+			horaFin    pgtype.Time
+		}
+		var slots []slot
+		for i, bloque := range req.Disponibilidad {
+			horaInicio, err := parseTimeString(bloque.HoraInicio)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Invalid hora_inicio in disponibilidad[%d] (use HH:MM)", i), http.StatusBadRequest)
+				return
+			}
+			horaFin, err := parseTimeString(bloque.HoraFin)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Invalid hora_fin in disponibilidad[%d] (use HH:MM)", i), http.StatusBadRequest)
+				return
+			}
+			if horaFin.Microseconds <= horaInicio.Microseconds {
+				http.Error(w, fmt.Sprintf("hora_fin must be after hora_inicio in disponibilidad[%d]", i), http.StatusBadRequest)
+				return
+			}
+			for _, dia := range bloque.Dias {
+				if dia < 1 || dia > 7 {
+					http.Error(w, fmt.Sprintf("Invalid day in disponibilidad[%d] (must be 1-7)", i), http.StatusBadRequest)
+					return
+				}
+				slots = append(slots, slot{dia: dia, horaInicio: horaInicio, horaFin: horaFin})
+			}
+		}
+
+		estudiante, err := queries.SelectEstudianteById(r.Context(), req.EstudianteID)
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Estudiante not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to fetch student data: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		materiaIDs, invalid, err := resolveOnboardMaterias(r, queries, req.Materias)
+		if err != nil {
+			http.Error(w, "Failed to resolve materias: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(invalid) > 0 {
+			http.Error(w, "Invalid materias: "+strings.Join(invalid, "; "), http.StatusBadRequest)
+			return
+		}
+
+		response := OnboardTutorResponse{MateriasOmitidas: []string{}}
+		elegibles := make([]int32, 0, len(materiaIDs))
+		for _, materiaID := range materiaIDs {
+			requisitos, err := materiaRequisitos(r.Context(), queries, materiaID)
+			if err != nil {
+				http.Error(w, "Failed to get eligibility rules: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			incumplidos, err := requisitosIncumplidos(r.Context(), queries, db.Tutore{Correo: estudiante.Correo}, requisitos)
+			if err != nil {
+				http.Error(w, "Failed to check eligibility: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if len(incumplidos) > 0 {
+				response.MateriasOmitidas = append(response.MateriasOmitidas,
+					fmt.Sprintf("materia %d: %s", materiaID, strings.Join(incumplidos, "; ")))
+				continue
+			}
+			elegibles = append(elegibles, materiaID)
+		}
+
+		var asignadas []db.TutorMateria
+		err = withTx(r.Context(), pool, queries, func(q *db.Queries) error {
+			tutor, err := q.SelectTutorByCorreo(r.Context(), estudiante.Correo)
+			if err != nil {
+				if err.Error() != "no rows in result set" {
+					return err
+				}
+				tutor, err = q.CreateTutor(r.Context(), tutorParamsFromEstudiante(estudiante))
+				if err != nil {
+					return fmt.Errorf("create tutor: %w", err)
+				}
+				response.TutorCreado = true
+			}
+			response.TutorID = tutor.TutorID
+
+			for _, materiaID := range elegibles {
+				assignment, err := q.UpsertTutorMateria(r.Context(), db.UpsertTutorMateriaParams{
+					TutorID:   tutor.TutorID,
+					MateriaID: materiaID,
+				})
+				if err != nil {
+					if err.Error() == "no rows in result set" {
+						response.MateriasExistentes++
+						continue
+					}
+					return fmt.Errorf("assign materia %d: %w", materiaID, err)
+				}
+				response.MateriasAsignadas++
+				asignadas = append(asignadas, db.TutorMateria{
+					AsignacionID:    assignment.AsignacionID,
+					TutorID:         assignment.TutorID,
+					MateriaID:       assignment.MateriaID,
+					FechaAsignacion: assignment.FechaAsignacion,
+					Activo:          assignment.Activo,
+				})
+			}
+
+			existing, err := q.ListDisponibilidadByTutor(r.Context(), tutor.TutorID)
+			if err != nil {
+				return err
+			}
+			present := map[slot]bool{}
+			for _, disponibilidad := range existing {
+				present[slot{disponibilidad.DiaSemana, disponibilidad.HoraInicio, disponibilidad.HoraFin}] = true
+			}
+			for _, s := range slots {
+				if present[s] {
+					response.DisponibilidadExistente++
+					continue
+				}
+				present[s] = true
+				if _, err := q.CreateDisponibilidad(r.Context(), db.CreateDisponibilidadParams{
+					TutorID:    tutor.TutorID,
+					DiaSemana:  s.dia,
+					HoraInicio: s.horaInicio,
+					HoraFin:    s.horaFin,
+				}); err != nil {
+					return fmt.Errorf("create disponibilidad: %w", err)
+				}
+				response.DisponibilidadCreada++
+			}
+			return nil
+		})
+		if err != nil {
+			http.Error(w, "Failed to onboard tutor: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		for _, assignment := range asignadas {
+			emitWebhookEvent(r.Context(), queries, EventoTutorMateriaAsignada, assignment)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
 }
@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// UpdateTutorLimitesRequest represents the request body for setting a tutor's workload limits.
+type UpdateTutorLimitesRequest struct {
+	HorasSemana *int32 `json:"horas_semana" example:"10"` // null or absent removes the weekly limit
+	HorasMes    *int32 `json:"horas_mes" example:"32"`    // null or absent removes the monthly limit
+}
+
+// TutorHorasResponse represents the hours of a tutor's tutorias within a week or month.
+type TutorHorasResponse struct {
+	TutorID             int32   `json:"tutor_id" example:"1"`
+	Periodo             string  `json:"periodo" example:"2025-03"`
+	Desde               string  `json:"desde" example:"2025-03-01"`
+	Hasta               string  `json:"hasta" example:"2025-03-31"`
+	HorasCompletadas    float64 `json:"horas_completadas" example:"12.5"`
+	HorasConfirmadas    float64 `json:"horas_confirmadas" example:"4"`
+	HorasPendientes     float64 `json:"horas_pendientes" example:"2"` // Requested but not yet confirmed
+	TutoriasCompletadas int64   `json:"tutorias_completadas" example:"9"`
+	TutoriasConfirmadas int64   `json:"tutorias_confirmadas" example:"3"`
+	TutoriasPendientes  int64   `json:"tutorias_pendientes" example:"2"`
+	LimiteHoras         *int32  `json:"limite_horas,omitempty" example:"32"` // Weekly or monthly limit, matching the periodo
+}
+
+// Errors returned by reservarTutor.
+var (
+	errTutorSinCupo = errors.New("tutor has reached their weekly or monthly hour limit")
+	errTutorOcupado = errors.New("tutor has a scheduling conflict at the requested time")
+)
+
+// tutorSemana returns the Monday and Sunday of the week of t.
+func tutorSemana(t time.Time) [2]time.Time {
+	dia := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	lunes := dia.AddDate(0, 0, -(int(dia.Weekday())+6)%7)
+	return [2]time.Time{lunes, lunes.AddDate(0, 0, 6)}
+}
+
+// tutorMes returns the first and last day of the month of t.
+func tutorMes(t time.Time) [2]time.Time {
+	primero := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return [2]time.Time{primero, primero.AddDate(0, 1, -1)}
+}
+
+// parseTutorPeriodo reads a month (YYYY-MM) or an ISO week (YYYY-Www). It reports whether the
+// periodo is a week, so that the matching limit can be applied.
+func parseTutorPeriodo(periodo string) (rango [2]time.Time, semanal bool, err error) {
+	var anio, semana int
+	if _, scanErr := fmt.Sscanf(periodo, "%4d-W%2d", &anio, &semana); scanErr == nil && len(periodo) == 8 {
+		// January 4th always falls in the first ISO week of the year
+		rango = tutorSemana(time.Date(anio, time.January, 4, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7*(semana-1)))
+		if y, w := rango[0].ISOWeek(); y != anio || w != semana {
+			return rango, true, fmt.Errorf("invalid periodo week %q", periodo)
+		}
+		return rango, true, nil
+	}
+
+	mes, err := time.Parse("2006-01", periodo)
+	if err != nil {
+		return rango, false, fmt.Errorf("invalid periodo format (use YYYY-MM or YYYY-Www)")
+	}
+	return tutorMes(mes), false, nil
+}
+
+// tutorHoras sums the hours of the tutor's tutorias that are not cancelled within rango.
+func tutorHoras(ctx context.Context, queries *db.Queries, tutorID int32, rango [2]time.Time) (db.SelectTutorHorasRow, error) {
+	return queries.SelectTutorHoras(ctx, db.SelectTutorHorasParams{
+		TutorID: tutorID,
+		Desde:   pgtype.Date{Time: rango[0], Valid: true},
+		Hasta:   pgtype.Date{Time: rango[1], Valid: true},
+	})
+}
+
+// tutoriaHoras returns the length of a session in hours.
+func tutoriaHoras(horaInicio, horaFin pgtype.Time) float64 {
+	return float64(horaFin.Microseconds-horaInicio.Microseconds) / float64(time.Hour/time.Microsecond)
+}
+
+// tutorExcedeLimite reports whether booking horaInicio-horaFin on fecha would take the tutor past
+// their weekly or monthly hour limit. Completed, confirmed and pending tutorias all count, except
+// excluir, the tutoria being rescheduled (nil for a new booking). Bookings must call it through
+// reservarTutor, within the transaction that writes the tutoria.
+func tutorExcedeLimite(ctx context.Context, queries *db.Queries, tutorID int32, fecha pgtype.Date, horaInicio, horaFin pgtype.Time, excluir *db.Tutoria) (bool, error) {
+	limites, err := queries.SelectTutorLimites(ctx, tutorID)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return false, nil // Tutors without limits can always be booked
+		}
+		return false, err
+	}
+
+	horas := tutoriaHoras(horaInicio, horaFin)
+	for _, limite := range []struct {
+		horas pgtype.Int4
+		rango [2]time.Time
+	}{
+		{limites.HorasSemana, tutorSemana(fecha.Time)},
+		{limites.HorasMes, tutorMes(fecha.Time)},
+	} {
+		if !limite.horas.Valid {
+			continue
+		}
+		reservadas, err := tutorHoras(ctx, queries, tutorID, limite.rango)
+		if err != nil {
+			return false, err
+		}
+		total := reservadas.HorasCompletadas + reservadas.HorasConfirmadas + reservadas.HorasPendientes
+		if excluir != nil && excluir.TutorID == tutorID && excluir.Estado != "cancelada" &&
+			!excluir.Fecha.Time.Before(limite.rango[0]) && !excluir.Fecha.Time.After(limite.rango[1]) {
+			total -= tutoriaHoras(excluir.HoraInicio, excluir.HoraFin)
+		}
+		if total+horas > float64(limite.horas.Int32) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// reservarTutor locks the tutor and checks that the session neither overlaps another of their
+// tutorias nor takes them past their hour limits, so that concurrent bookings are checked one at
+// a time. It must run within the transaction that writes the tutoria; excluir is the tutoria
+// being rescheduled, or nil for a new booking.
+func reservarTutor(ctx context.Context, q *db.Queries, tutorID int32, fecha pgtype.Date, horaInicio, horaFin pgtype.Time, excluir *db.Tutoria) error {
+	if _, err := q.LockTutor(ctx, tutorID); err != nil {
+		return err
+	}
+
+	var excluirID int32
+	if excluir != nil {
+		excluirID = excluir.TutoriaID
+	}
+	conflicto, err := checkTutorConflicts(ctx, q, tutorID, fecha, horaInicio, horaFin, excluirID)
+	if err != nil {
+		return fmt.Errorf("could not check tutor availability: %w", err)
+	}
+	if conflicto {
+		return errTutorOcupado
+	}
+
+	excede, err := tutorExcedeLimite(ctx, q, tutorID, fecha, horaInicio, horaFin, excluir)
+	if err != nil {
+		return fmt.Errorf("could not check tutor hour limits: %w", err)
+	}
+	if excede {
+		return errTutorSinCupo
+	}
+	return nil
+}
+
+// TutorHorasEndpoint handles GET /v1/tutores/{id}/horas using Go 1.22 routing
+// @Summary      Get Tutor Hours
+// @Description  Sums the hours of the tutor's completed, confirmed and pending tutorias dated within a month or an ISO
+// @Description  week, for payroll and credit certification. Cancelled tutorias are not counted.
+// @Tags         Tutores
+// @Produce      json
+// @Param        id path int true "Tutor ID"
+// @Param        periodo query string false "Month (YYYY-MM) or ISO week (YYYY-Www); defaults to the current month"
+// @Success      200 {object} TutorHorasResponse "Tutor hours"
+// @Failure      400 {object} ErrorResponse "Invalid tutor ID or periodo"
+// @Failure      404 {object} ErrorResponse "Tutor not found"
+// @Failure      500 {object} ErrorResponse "Failed to compute tutor hours"
+// @Router       /v1/tutores/{id}/horas [get]
+func TutorHorasEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid tutor ID", http.StatusBadRequest)
+			return
+		}
+
+		periodo := r.URL.Query().Get("periodo")
+		if periodo == "" {
+			periodo = time.Now().Format("2006-01")
+		}
+		rango, semanal, err := parseTutorPeriodo(periodo)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if _, err := queries.SelectTutorById(r.Context(), int32(id)); err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Tutor not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get tutor: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		horas, err := tutorHoras(r.Context(), queries, int32(id), rango)
+		if err != nil {
+			http.Error(w, "Failed to compute tutor hours: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		response := TutorHorasResponse{
+			TutorID:             int32(id),
+			Periodo:             periodo,
+			Desde:               rango[0].Format("2006-01-02"),
+			Hasta:               rango[1].Format("2006-01-02"),
+			HorasCompletadas:    horas.HorasCompletadas,
+			HorasConfirmadas:    horas.HorasConfirmadas,
+			HorasPendientes:     horas.HorasPendientes,
+			TutoriasCompletadas: horas.TutoriasCompletadas,
+			TutoriasConfirmadas: horas.TutoriasConfirmadas,
+			TutoriasPendientes:  horas.TutoriasPendientes,
+		}
+
+		limites, err := queries.SelectTutorLimites(r.Context(), int32(id))
+		if err != nil && err.Error() != "no rows in result set" {
+			http.Error(w, "Failed to get tutor limits: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		limite := limites.HorasMes
+		if semanal {
+			limite = limites.HorasSemana
+		}
+		if limite.Valid {
+			response.LimiteHoras = &limite.Int32
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
+// UpdateTutorLimitesEndpoint handles PUT /v1/tutores/{id}/limites using Go 1.22 routing
+// @Summary      Set Tutor Workload Limits
+// @Description  Sets the maximum hours a tutor can be booked per week (Monday to Sunday) and per calendar month.
+// @Description  New tutorias that would exceed a limit are rejected, and auto-assignment skips the tutor.
+// @Tags         Tutores
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Tutor ID"
+// @Param        limites body UpdateTutorLimitesRequest true "Workload Limits"
+// @Success      200 {object} db.TutorLimite "Successfully updated limits"
+// @Failure      400 {object} ErrorResponse "Invalid request body or tutor ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Tutor not found"
+// @Failure      500 {object} ErrorResponse "Failed to update tutor limits"
+// @Router       /v1/tutores/{id}/limites [put]
+func UpdateTutorLimitesEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid tutor ID", http.StatusBadRequest)
+			return
+		}
+
+		var req UpdateTutorLimitesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if (req.HorasSemana != nil && *req.HorasSemana <= 0) || (req.HorasMes != nil && *req.HorasMes <= 0) {
+			http.Error(w, "horas_semana and horas_mes must be positive", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := queries.SelectTutorById(r.Context(), int32(id)); err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Tutor not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get tutor: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		params := db.UpsertTutorLimitesParams{TutorID: int32(id)}
+		if req.HorasSemana != nil {
+			params.HorasSemana = pgtype.Int4{Int32: *req.HorasSemana, Valid: true}
+		}
+		if req.HorasMes != nil {
+			params.HorasMes = pgtype.Int4{Int32: *req.HorasMes, Valid: true}
+		}
+
+		limites, err := queries.UpsertTutorLimites(r.Context(), params)
+		if err != nil {
+			http.Error(w, "Failed to update tutor limits: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(limites)
+	}
+}
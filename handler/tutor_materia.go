@@ -65,16 +65,24 @@ func parseDateStringTM(dateStr string) (pgtype.Date, error) {
 
 // createTutorMateriaHandler handles POST /v1/tutor-materias
 // @Summary      Create TutorMateria Assignment
-// @Description  Creates a new tutor-materia assignment.
+// @Description  Creates a new tutor-materia assignment. Requires an admin bearer token; tutors apply through POST
+// @Description  /v1/tutor-postulaciones instead. The tutor must meet the materia's eligibility rules.
 // @Tags         TutorMaterias
 // @Accept       json
 // @Produce      json
+// @Security     AdminBearer
 // @Param        assignment body CreateTutorMateriaRequest true "TutorMateria Assignment Data"
 // @Success      201 {object} CreateTutorMateriaResponse "Successfully created assignment"
-// @Failure      400 {object} ErrorResponse "Invalid request body"
+// @Failure      400 {object} ErrorResponse "Invalid request body or tutor not eligible"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
 // @Failure      500 {object} ErrorResponse "Failed to create assignment"
 // @Router       /v1/tutor-materias [post]
 func createTutorMateriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	if _, ok := adminFromContext(r.Context()); !ok {
+		http.Error(w, "Admin authentication required", http.StatusUnauthorized)
+		return
+	}
+
 	var req CreateTutorMateriaRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
@@ -87,6 +95,31 @@ func createTutorMateriaHandler(w http.ResponseWriter, r *http.Request, queries *
 		return
 	}
 
+	// Admins assign directly without a postulacion, but not past the materia's eligibility rules
+	tutor, err := queries.SelectTutorById(r.Context(), req.TutorID)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			http.Error(w, "Tutor not found", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to get tutor: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	requisitos, err := materiaRequisitos(r.Context(), queries, req.MateriaID)
+	if err != nil {
+		http.Error(w, "Failed to get eligibility rules: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	incumplidos, err := requisitosIncumplidos(r.Context(), queries, tutor, requisitos)
+	if err != nil {
+		http.Error(w, "Failed to check eligibility: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(incumplidos) > 0 {
+		http.Error(w, "Tutor is not eligible: "+strings.Join(incumplidos, "; "), http.StatusBadRequest)
+		return
+	}
+
 	params := db.CreateTutorMateriaParams{
 		TutorID:         req.TutorID,
 		MateriaID:       req.MateriaID,
@@ -100,6 +133,8 @@ func createTutorMateriaHandler(w http.ResponseWriter, r *http.Request, queries *
 		return
 	}
 
+	emitWebhookEvent(r.Context(), queries, EventoTutorMateriaAsignada, assignment)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(CreateTutorMateriaResponse{AsignacionID: assignment.AsignacionID})
@@ -223,18 +258,26 @@ func listTutoresByMateriaHandler(w http.ResponseWriter, r *http.Request, queries
 
 // updateTutorMateriaHandler handles PUT /v1/tutor-materias/{id}
 // @Summary      Update TutorMateria Assignment
-// @Description  Updates an existing tutor-materia assignment.
+// @Description  Updates an existing tutor-materia assignment. Requires an admin bearer token. Reactivating an
+// @Description  assignment checks the materia's eligibility rules again.
 // @Tags         TutorMaterias
 // @Accept       json
 // @Produce      json
+// @Security     AdminBearer
 // @Param        id path int true "Assignment ID"
 // @Param        assignment body UpdateTutorMateriaRequest true "Updated Assignment Data"
 // @Success      200 {object} db.TutorMateria "Successfully updated assignment"
-// @Failure      400 {object} ErrorResponse "Invalid request body or assignment ID"
+// @Failure      400 {object} ErrorResponse "Invalid request body or assignment ID, or tutor not eligible"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
 // @Failure      404 {object} ErrorResponse "Assignment not found"
 // @Failure      500 {object} ErrorResponse "Failed to update assignment"
 // @Router       /v1/tutor-materias/{id} [put]
 func updateTutorMateriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	if _, ok := adminFromContext(r.Context()); !ok {
+		http.Error(w, "Admin authentication required", http.StatusUnauthorized)
+		return
+	}
+
 	path := strings.TrimPrefix(r.URL.Path, "/v1/tutor-materias/")
 	id, err := strconv.ParseInt(path, 10, 32)
 	if err != nil {
@@ -248,6 +291,43 @@ func updateTutorMateriaHandler(w http.ResponseWriter, r *http.Request, queries *
 		return
 	}
 
+	existing, err := queries.SelectTutorMateriaById(r.Context(), int32(id))
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			http.Error(w, "Assignment not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to get assignment: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Reactivating an assignment is a new assignment as far as the eligibility rules are concerned
+	if req.Activo && !existing.Activo {
+		tutor, err := queries.SelectTutorById(r.Context(), existing.TutorID)
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Tutor not found", http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "Failed to get tutor: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		requisitos, err := materiaRequisitos(r.Context(), queries, existing.MateriaID)
+		if err != nil {
+			http.Error(w, "Failed to get eligibility rules: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		incumplidos, err := requisitosIncumplidos(r.Context(), queries, tutor, requisitos)
+		if err != nil {
+			http.Error(w, "Failed to check eligibility: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(incumplidos) > 0 {
+			http.Error(w, "Tutor is not eligible: "+strings.Join(incumplidos, "; "), http.StatusBadRequest)
+			return
+		}
+	}
+
 	params := db.UpdateTutorMateriaParams{
 		AsignacionID: int32(id),
 		Activo:       req.Activo,
@@ -263,20 +343,30 @@ func updateTutorMateriaHandler(w http.ResponseWriter, r *http.Request, queries *
 		return
 	}
 
+	emitWebhookEvent(r.Context(), queries, EventoTutorMateriaActualizada, assignment)
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(assignment)
 }
 
 // deleteTutorMateriaHandler handles DELETE /v1/tutor-materias/{id}
 // @Summary      Delete TutorMateria Assignment
-// @Description  Deletes a tutor-materia assignment by its ID.
+// @Description  Deletes a tutor-materia assignment by its ID. Requires an admin bearer token.
 // @Tags         TutorMaterias
+// @Security     AdminBearer
 // @Param        id path int true "Assignment ID"
 // @Success      204 "Successfully deleted assignment"
 // @Failure      400 {object} ErrorResponse "Invalid assignment ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Assignment not found"
 // @Failure      500 {object} ErrorResponse "Failed to delete assignment"
 // @Router       /v1/tutor-materias/{id} [delete]
 func deleteTutorMateriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	if _, ok := adminFromContext(r.Context()); !ok {
+		http.Error(w, "Admin authentication required", http.StatusUnauthorized)
+		return
+	}
+
 	path := strings.TrimPrefix(r.URL.Path, "/v1/tutor-materias/")
 	id, err := strconv.ParseInt(path, 10, 32)
 	if err != nil {
@@ -284,11 +374,24 @@ func deleteTutorMateriaHandler(w http.ResponseWriter, r *http.Request, queries *
 		return
 	}
 
+	// Keep the assignment data so the deletion event can describe it
+	existingAssignment, err := queries.SelectTutorMateriaById(r.Context(), int32(id))
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			http.Error(w, "Assignment not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to get assignment: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	err = queries.DeleteTutorMateria(r.Context(), int32(id))
 	if err != nil {
 		http.Error(w, "Failed to delete assignment: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	emitWebhookEvent(r.Context(), queries, EventoTutorMateriaEliminada, existingAssignment)
+
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -0,0 +1,667 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// Estados of the applications reviewed by admins, TUTOR_POSTULACIONES and SOLICITUDES_TUTOR.
+const (
+	RevisionPendiente = "pendiente"
+	RevisionAprobada  = "aprobada"
+	RevisionRechazada = "rechazada"
+)
+
+// errPostulacionRevisada is returned when a postulacion is reviewed twice.
+var errPostulacionRevisada = errors.New("postulacion was already reviewed")
+
+// UpdateMateriaRequisitosRequest represents the request body for setting the eligibility rules of a materia.
+type UpdateMateriaRequisitosRequest struct {
+	SemestreMinimo     *int32   `json:"semestre_minimo,omitempty" example:"5"`                // Absent: any semestre
+	Programas          []string `json:"programas,omitempty" example:"Matemáticas,Ingeniería"` // Empty: any programa_academico
+	RequiereAprobacion *bool    `json:"requiere_aprobacion,omitempty" example:"true"`         // Defaults to true
+}
+
+// CreateTutorPostulacionRequest represents the request body for a tutor applying to teach a materia.
+type CreateTutorPostulacionRequest struct {
+	TutorID    int32  `json:"tutor_id" example:"1"`
+	MateriaID  int32  `json:"materia_id" example:"3"`
+	Comentario string `json:"comentario,omitempty" example:"Fui monitor de esta materia el semestre pasado"`
+}
+
+// RejectTutorPostulacionRequest represents the request body for rejecting a postulacion.
+type RejectTutorPostulacionRequest struct {
+	Motivo string `json:"motivo" example:"Se requiere haber aprobado la materia con nota superior a 4.0"`
+}
+
+// materiaRequisitos returns the eligibility rules of a materia. Materias without rules accept
+// tutors of any semestre and programa, with admin approval.
+func materiaRequisitos(ctx context.Context, queries *db.Queries, materiaID int32) (db.MateriaRequisito, error) {
+	requisitos, err := queries.SelectMateriaRequisitos(ctx, materiaID)
+	if err != nil && err.Error() == "no rows in result set" {
+		return db.MateriaRequisito{MateriaID: materiaID, Programas: []string{}, RequiereAprobacion: true}, nil
+	}
+	return requisitos, err
+}
+
+// requisitosIncumplidos checks the semestre and programa rules of a materia against the tutor's
+// estudiante record, matched by correo. It returns the unmet rules, empty when the tutor is eligible.
+func requisitosIncumplidos(ctx context.Context, queries *db.Queries, tutor db.Tutore, requisitos db.MateriaRequisito) ([]string, error) {
+	if !requisitos.SemestreMinimo.Valid && len(requisitos.Programas) == 0 {
+		return nil, nil
+	}
+
+	estudiante, err := queries.SelectEstudianteByCorreo(ctx, tutor.Correo)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return []string{"the tutor has no estudiante record to check semestre and programa_academico"}, nil
+		}
+		return nil, err
+	}
+
+	var incumplidos []string
+	if requisitos.SemestreMinimo.Valid {
+		if !estudiante.Semestre.Valid {
+			incumplidos = append(incumplidos, fmt.Sprintf("semestre is unknown and the minimum is %d", requisitos.SemestreMinimo.Int32))
+		} else if estudiante.Semestre.Int32 < requisitos.SemestreMinimo.Int32 {
+			incumplidos = append(incumplidos, fmt.Sprintf("semestre %d is below the minimum of %d",
+				estudiante.Semestre.Int32, requisitos.SemestreMinimo.Int32))
+		}
+	}
+	if len(requisitos.Programas) > 0 && !slices.ContainsFunc(requisitos.Programas, func(programa string) bool {
+		return strings.EqualFold(strings.TrimSpace(programa), strings.TrimSpace(estudiante.ProgramaAcademico))
+	}) {
+		incumplidos = append(incumplidos, fmt.Sprintf("programa_academico %q is not one of %s",
+			estudiante.ProgramaAcademico, strings.Join(requisitos.Programas, ", ")))
+	}
+	return incumplidos, nil
+}
+
+// aprobarTutorPostulacion marks a pending postulacion as approved and assigns the materia to the
+// tutor, in a single transaction. revisadoPor is NULL for automatic approvals. It returns the
+// new assignment, or nil when the tutor already taught the materia.
+func aprobarTutorPostulacion(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	queries *db.Queries,
+	postulacionID int32,
+	revisadoPor pgtype.Int4,
+) (db.TutorPostulacione, *db.TutorMateria, error) {
+	var (
+		postulacion db.TutorPostulacione
+		asignada    *db.TutorMateria
+	)
+	err := withTx(ctx, pool, queries, func(q *db.Queries) error {
+		var err error
+		postulacion, err = q.ReviewTutorPostulacion(ctx, db.ReviewTutorPostulacionParams{
+			Estado:        RevisionAprobada,
+			RevisadoPor:   revisadoPor,
+			PostulacionID: postulacionID,
+		})
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				return errPostulacionRevisada
+			}
+			return err
+		}
+
+		assignment, err := q.UpsertTutorMateria(ctx, db.UpsertTutorMateriaParams{
+			TutorID:   postulacion.TutorID,
+			MateriaID: postulacion.MateriaID,
+		})
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				return nil // Already assigned
+			}
+			return fmt.Errorf("assign materia: %w", err)
+		}
+		asignada = &db.TutorMateria{
+			AsignacionID:    assignment.AsignacionID,
+			TutorID:         assignment.TutorID,
+			MateriaID:       assignment.MateriaID,
+			FechaAsignacion: assignment.FechaAsignacion,
+			Activo:          assignment.Activo,
+		}
+		return nil
+	})
+	return postulacion, asignada, err
+}
+
+// notificarTutorPostulacion emails the tutor the outcome of their postulacion.
+func notificarTutorPostulacion(ctx context.Context, queries *db.Queries, postulacion db.TutorPostulacione) {
+	tutor, err := queries.SelectTutorById(ctx, postulacion.TutorID)
+	if err != nil {
+		return
+	}
+	materia, err := queries.SelectMateriaById(ctx, postulacion.MateriaID)
+	if err != nil {
+		return
+	}
+
+	msg := mailMessage{To: []string{tutor.Correo}}
+	switch postulacion.Estado {
+	case RevisionAprobada:
+		msg.Subject = "Postulación aprobada: " + materia.Nombre
+		msg.Body = fmt.Sprintf("Hola %s,\n\nTu postulación para ser tutor de %s (%s) fue aprobada. "+
+			"Desde ahora los estudiantes pueden reservar tutorías de esta materia contigo.\n",
+			tutor.Nombre, materia.Nombre, materia.Codigo)
+	case RevisionRechazada:
+		msg.Subject = "Postulación rechazada: " + materia.Nombre
+		msg.Body = fmt.Sprintf("Hola %s,\n\nTu postulación para ser tutor de %s (%s) fue rechazada.\n\nMotivo: %s\n",
+			tutor.Nombre, materia.Nombre, materia.Codigo, postulacion.MotivoRechazo.String)
+	default:
+		return
+	}
+	sendMailAsync(msg)
+}
+
+// MateriaRequisitosEndpoint handles GET /v1/materias/{id}/requisitos using Go 1.22 routing
+// @Summary      Get Materia Eligibility Rules
+// @Description  Retrieves the rules a tutor must meet to teach the materia. Materias without rules accept tutors of any
+// @Description  semestre and programa_academico, with admin approval.
+// @Tags         Materias
+// @Produce      json
+// @Param        id path int true "Materia ID"
+// @Success      200 {object} db.MateriaRequisito "Eligibility rules"
+// @Failure      400 {object} ErrorResponse "Invalid materia ID"
+// @Failure      404 {object} ErrorResponse "Materia not found"
+// @Failure      500 {object} ErrorResponse "Failed to get eligibility rules"
+// @Router       /v1/materias/{id}/requisitos [get]
+func MateriaRequisitosEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid materia ID", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := queries.SelectMateriaById(r.Context(), int32(id)); err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Materia not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get materia: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		requisitos, err := materiaRequisitos(r.Context(), queries, int32(id))
+		if err != nil {
+			http.Error(w, "Failed to get eligibility rules: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(requisitos)
+	}
+}
+
+// UpdateMateriaRequisitosEndpoint handles PUT /v1/materias/{id}/requisitos using Go 1.22 routing
+// @Summary      Set Materia Eligibility Rules
+// @Description  Replaces the rules a tutor must meet to teach the materia: a minimum semestre and the accepted
+// @Description  programas académicos of the tutor's estudiante record, and whether postulaciones need admin approval.
+// @Description  Existing assignments are not affected.
+// @Tags         Materias
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Materia ID"
+// @Param        requisitos body UpdateMateriaRequisitosRequest true "Eligibility rules"
+// @Success      200 {object} db.MateriaRequisito "Successfully updated eligibility rules"
+// @Failure      400 {object} ErrorResponse "Invalid request body or materia ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Materia not found"
+// @Failure      500 {object} ErrorResponse "Failed to update eligibility rules"
+// @Router       /v1/materias/{id}/requisitos [put]
+func UpdateMateriaRequisitosEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid materia ID", http.StatusBadRequest)
+			return
+		}
+
+		var req UpdateMateriaRequisitosRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.SemestreMinimo != nil && (*req.SemestreMinimo < 1 || *req.SemestreMinimo > 12) {
+			http.Error(w, "semestre_minimo must be between 1 and 12", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := queries.SelectMateriaById(r.Context(), int32(id)); err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Materia not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get materia: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		params := db.UpsertMateriaRequisitosParams{
+			MateriaID:          int32(id),
+			Programas:          []string{},
+			RequiereAprobacion: req.RequiereAprobacion == nil || *req.RequiereAprobacion,
+		}
+		if req.SemestreMinimo != nil {
+			params.SemestreMinimo = pgtype.Int4{Int32: *req.SemestreMinimo, Valid: true}
+		}
+		for _, programa := range req.Programas {
+			if programa = strings.TrimSpace(programa); programa != "" {
+				params.Programas = append(params.Programas, programa)
+			}
+		}
+
+		requisitos, err := queries.UpsertMateriaRequisitos(r.Context(), params)
+		if err != nil {
+			http.Error(w, "Failed to update eligibility rules: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(requisitos)
+	}
+}
+
+// CreateTutorPostulacionEndpoint handles POST /v1/tutor-postulaciones using Go 1.22 routing
+// @Summary      Apply to Teach a Materia
+// @Description  A tutor applies to teach a materia. The tutor must meet the materia's eligibility rules. The
+// @Description  postulacion waits for admin review, unless the materia does not require approval, in which case it is
+// @Description  approved at once and the materia is assigned to the tutor. Requires the tutor's calendar feed token
+// @Description  or an admin bearer token.
+// @Tags         TutorMaterias
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        postulacion body CreateTutorPostulacionRequest true "Postulacion Data"
+// @Param        token query string false "Feed token of the tutor, from /v1/calendario/token/{mode}"
+// @Success      201 {object} db.TutorPostulacione "Successfully created postulacion"
+// @Failure      400 {object} ErrorResponse "Invalid request body, unknown tutor or materia, or tutor not eligible"
+// @Failure      403 {object} ErrorResponse "Invalid feed token"
+// @Failure      409 {object} ErrorResponse "The tutor already teaches the materia or has a pending postulacion for it"
+// @Failure      500 {object} ErrorResponse "Failed to create postulacion"
+// @Router       /v1/tutor-postulaciones [post]
+func CreateTutorPostulacionEndpoint(pool *pgxpool.Pool, queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req CreateTutorPostulacionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.TutorID <= 0 || req.MateriaID <= 0 {
+			http.Error(w, "tutor_id and materia_id are required", http.StatusBadRequest)
+			return
+		}
+
+		if _, ok := requestAdmin(r, queries); !ok {
+			valid, err := checkCalendarioToken(r, queries, "tutor", req.TutorID, r.URL.Query().Get("token"))
+			if err != nil {
+				http.Error(w, "Failed to verify feed token: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if !valid {
+				http.Error(w, "Invalid feed token", http.StatusForbidden)
+				return
+			}
+		}
+
+		tutor, err := queries.SelectTutorById(r.Context(), req.TutorID)
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Tutor not found", http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "Failed to get tutor: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		materia, err := queries.SelectMateriaById(r.Context(), req.MateriaID)
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Materia not found", http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "Failed to get materia: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !materia.Activo {
+			http.Error(w, "Materia is no longer offered", http.StatusBadRequest)
+			return
+		}
+
+		materiasByTutor, err := queries.ListMateriasByTutor(r.Context(), tutor.TutorID)
+		if err != nil {
+			http.Error(w, "Failed to get tutor materias: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, m := range materiasByTutor {
+			if m.MateriaID == materia.MateriaID {
+				http.Error(w, "Tutor already teaches this materia", http.StatusConflict)
+				return
+			}
+		}
+
+		pendientes, err := queries.ListTutorPostulaciones(r.Context(), db.ListTutorPostulacionesParams{
+			Estado:    pgtype.Text{String: RevisionPendiente, Valid: true},
+			TutorID:   pgtype.Int4{Int32: tutor.TutorID, Valid: true},
+			MateriaID: pgtype.Int4{Int32: materia.MateriaID, Valid: true},
+			RowLimit:  1,
+		})
+		if err != nil {
+			http.Error(w, "Failed to check pending postulaciones: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(pendientes) > 0 {
+			http.Error(w, fmt.Sprintf("Tutor already has pending postulacion %d for this materia", pendientes[0].PostulacionID), http.StatusConflict)
+			return
+		}
+
+		requisitos, err := materiaRequisitos(r.Context(), queries, materia.MateriaID)
+		if err != nil {
+			http.Error(w, "Failed to get eligibility rules: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		incumplidos, err := requisitosIncumplidos(r.Context(), queries, tutor, requisitos)
+		if err != nil {
+			http.Error(w, "Failed to check eligibility: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(incumplidos) > 0 {
+			http.Error(w, "Tutor is not eligible: "+strings.Join(incumplidos, "; "), http.StatusBadRequest)
+			return
+		}
+
+		postulacion, err := queries.CreateTutorPostulacion(r.Context(), db.CreateTutorPostulacionParams{
+			TutorID:    tutor.TutorID,
+			MateriaID:  materia.MateriaID,
+			Comentario: pgtype.Text{String: req.Comentario, Valid: req.Comentario != ""},
+		})
+		if err != nil {
+			http.Error(w, "Failed to create postulacion: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if !requisitos.RequiereAprobacion {
+			var asignada *db.TutorMateria
+			postulacion, asignada, err = aprobarTutorPostulacion(r.Context(), pool, queries, postulacion.PostulacionID, pgtype.Int4{})
+			if err != nil {
+				http.Error(w, "Failed to approve postulacion: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if asignada != nil {
+				emitWebhookEvent(r.Context(), queries, EventoTutorMateriaAsignada, *asignada)
+			}
+			notificarTutorPostulacion(r.Context(), queries, postulacion)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(postulacion)
+	}
+}
+
+// GetTutorPostulacionEndpoint handles GET /v1/tutor-postulaciones/{id} using Go 1.22 routing
+// @Summary      Get Postulacion
+// @Description  Retrieves a postulacion, so that the tutor can follow its review.
+// @Tags         TutorMaterias
+// @Produce      json
+// @Param        id path int true "Postulacion ID"
+// @Success      200 {object} db.TutorPostulacione "Postulacion"
+// @Failure      400 {object} ErrorResponse "Invalid postulacion ID"
+// @Failure      404 {object} ErrorResponse "Postulacion not found"
+// @Failure      500 {object} ErrorResponse "Failed to get postulacion"
+// @Router       /v1/tutor-postulaciones/{id} [get]
+func GetTutorPostulacionEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid postulacion ID", http.StatusBadRequest)
+			return
+		}
+
+		postulacion, err := queries.SelectTutorPostulacionById(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Postulacion not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get postulacion: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(postulacion)
+	}
+}
+
+// ListTutorPostulacionesEndpoint handles GET /v1/tutor-postulaciones using Go 1.22 routing
+// @Summary      List Postulaciones
+// @Description  Retrieves the postulaciones of tutors to teach materias, most recent first. Use estado=pendiente for
+// @Description  the review queue.
+// @Tags         TutorMaterias
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security     AdminBearer
+// @Param        estado query string false "Only postulaciones in this estado" Enums(pendiente, aprobada, rechazada)
+// @Param        tutor_id query int false "Only postulaciones of this tutor"
+// @Param        materia_id query int false "Only postulaciones for this materia"
+// @Param        limit query int false "Maximum number of postulaciones" default(50) minimum(1) maximum(500)
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
+// @Success      200 {array} db.ListTutorPostulacionesRow "Postulaciones"
+// @Failure      400 {object} ErrorResponse "Invalid filter"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve postulaciones"
+// @Router       /v1/tutor-postulaciones [get]
+func ListTutorPostulacionesEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		params := db.ListTutorPostulacionesParams{RowLimit: defaultListLimit}
+
+		if estado := query.Get("estado"); estado != "" {
+			if estado != RevisionPendiente && estado != RevisionAprobada && estado != RevisionRechazada {
+				http.Error(w, "Invalid estado", http.StatusBadRequest)
+				return
+			}
+			params.Estado = pgtype.Text{String: estado, Valid: true}
+		}
+
+		if tutorStr := query.Get("tutor_id"); tutorStr != "" {
+			id, err := strconv.ParseInt(tutorStr, 10, 32)
+			if err != nil {
+				http.Error(w, "Invalid tutor ID", http.StatusBadRequest)
+				return
+			}
+			params.TutorID = pgtype.Int4{Int32: int32(id), Valid: true}
+		}
+
+		if materiaStr := query.Get("materia_id"); materiaStr != "" {
+			id, err := strconv.ParseInt(materiaStr, 10, 32)
+			if err != nil {
+				http.Error(w, "Invalid materia ID", http.StatusBadRequest)
+				return
+			}
+			params.MateriaID = pgtype.Int4{Int32: int32(id), Valid: true}
+		}
+
+		if limitStr := query.Get("limit"); limitStr != "" {
+			limit, err := strconv.ParseInt(limitStr, 10, 32)
+			if err != nil || limit < 1 || limit > maxListLimit {
+				http.Error(w, "Invalid limit, must be between 1 and "+strconv.Itoa(maxListLimit), http.StatusBadRequest)
+				return
+			}
+			params.RowLimit = int32(limit)
+		}
+
+		postulaciones, err := queries.ListTutorPostulaciones(r.Context(), params)
+		if err != nil {
+			http.Error(w, "Failed to retrieve postulaciones: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if postulaciones == nil {
+			postulaciones = []db.ListTutorPostulacionesRow{}
+		}
+
+		writeList(w, r, "tutor-postulaciones", postulaciones)
+	}
+}
+
+// ApproveTutorPostulacionEndpoint handles POST /v1/tutor-postulaciones/{id}/aprobar using Go 1.22 routing
+// @Summary      Approve Postulacion
+// @Description  Approves a pending postulacion and assigns the materia to the tutor. The eligibility rules are checked
+// @Description  again, in case they changed since the tutor applied. The tutor is notified by email.
+// @Tags         TutorMaterias
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Postulacion ID"
+// @Success      200 {object} db.TutorPostulacione "Approved postulacion"
+// @Failure      400 {object} ErrorResponse "Invalid postulacion ID or tutor no longer eligible"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Postulacion not found"
+// @Failure      409 {object} ErrorResponse "Postulacion was already reviewed"
+// @Failure      500 {object} ErrorResponse "Failed to approve postulacion"
+// @Router       /v1/tutor-postulaciones/{id}/aprobar [post]
+func ApproveTutorPostulacionEndpoint(pool *pgxpool.Pool, queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid postulacion ID", http.StatusBadRequest)
+			return
+		}
+
+		existing, err := queries.SelectTutorPostulacionById(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Postulacion not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to get postulacion: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if existing.Estado != RevisionPendiente {
+			http.Error(w, "Postulacion was already "+existing.Estado, http.StatusConflict)
+			return
+		}
+
+		tutor, err := queries.SelectTutorById(r.Context(), existing.TutorID)
+		if err != nil {
+			http.Error(w, "Failed to get tutor: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		requisitos, err := materiaRequisitos(r.Context(), queries, existing.MateriaID)
+		if err != nil {
+			http.Error(w, "Failed to get eligibility rules: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		incumplidos, err := requisitosIncumplidos(r.Context(), queries, tutor, requisitos)
+		if err != nil {
+			http.Error(w, "Failed to check eligibility: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(incumplidos) > 0 {
+			http.Error(w, "Tutor is no longer eligible: "+strings.Join(incumplidos, "; "), http.StatusBadRequest)
+			return
+		}
+
+		var revisadoPor pgtype.Int4
+		if admin, ok := adminFromContext(r.Context()); ok {
+			revisadoPor = pgtype.Int4{Int32: admin.AdminID, Valid: true}
+		}
+
+		postulacion, asignada, err := aprobarTutorPostulacion(r.Context(), pool, queries, int32(id), revisadoPor)
+		if err != nil {
+			if errors.Is(err, errPostulacionRevisada) {
+				http.Error(w, "Postulacion was already reviewed", http.StatusConflict)
+				return
+			}
+			http.Error(w, "Failed to approve postulacion: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if asignada != nil {
+			emitWebhookEvent(r.Context(), queries, EventoTutorMateriaAsignada, *asignada)
+		}
+		notificarTutorPostulacion(r.Context(), queries, postulacion)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(postulacion)
+	}
+}
+
+// RejectTutorPostulacionEndpoint handles POST /v1/tutor-postulaciones/{id}/rechazar using Go 1.22 routing
+// @Summary      Reject Postulacion
+// @Description  Rejects a pending postulacion with a reason. The tutor is notified by email.
+// @Tags         TutorMaterias
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Postulacion ID"
+// @Param        rechazo body RejectTutorPostulacionRequest true "Rejection reason"
+// @Success      200 {object} db.TutorPostulacione "Rejected postulacion"
+// @Failure      400 {object} ErrorResponse "Invalid postulacion ID or missing motivo"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Postulacion not found"
+// @Failure      409 {object} ErrorResponse "Postulacion was already reviewed"
+// @Failure      500 {object} ErrorResponse "Failed to reject postulacion"
+// @Router       /v1/tutor-postulaciones/{id}/rechazar [post]
+func RejectTutorPostulacionEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid postulacion ID", http.StatusBadRequest)
+			return
+		}
+
+		var req RejectTutorPostulacionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		req.Motivo = strings.TrimSpace(req.Motivo)
+		if req.Motivo == "" {
+			http.Error(w, "motivo is required", http.StatusBadRequest)
+			return
+		}
+
+		var revisadoPor pgtype.Int4
+		if admin, ok := adminFromContext(r.Context()); ok {
+			revisadoPor = pgtype.Int4{Int32: admin.AdminID, Valid: true}
+		}
+
+		postulacion, err := queries.ReviewTutorPostulacion(r.Context(), db.ReviewTutorPostulacionParams{
+			Estado:        RevisionRechazada,
+			MotivoRechazo: pgtype.Text{String: req.Motivo, Valid: true},
+			RevisadoPor:   revisadoPor,
+			PostulacionID: int32(id),
+		})
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				// Either the postulacion does not exist or it is no longer pending
+				if _, err := queries.SelectTutorPostulacionById(r.Context(), int32(id)); err != nil {
+					http.Error(w, "Postulacion not found", http.StatusNotFound)
+					return
+				}
+				http.Error(w, "Postulacion was already reviewed", http.StatusConflict)
+				return
+			}
+			http.Error(w, "Failed to reject postulacion: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		notificarTutorPostulacion(r.Context(), queries, postulacion)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(postulacion)
+	}
+}
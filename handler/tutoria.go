@@ -3,12 +3,16 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/matwate/proyecto-datos/db"
 )
 
@@ -55,15 +59,15 @@ type UpdateTutoriaAsistenciaRequest struct {
 // @Summary      Handle Tutoria Operations
 // @Description  Comprehensive CRUD operations for tutorias (tutoring sessions).
 // @Tags         Tutorias
-func TutoriaHandlers(queries *db.Queries) http.HandlerFunc {
+func TutoriaHandlers(pool *pgxpool.Pool, queries *db.Queries) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case http.MethodPost:
-			createTutoriaHandler(w, r, queries)
+			createTutoriaHandler(w, r, pool, queries)
 		case http.MethodGet:
 			handleTutoriaGET(w, r, queries)
 		case http.MethodPut:
-			handleTutoriaPUT(w, r, queries)
+			handleTutoriaPUT(w, r, pool, queries)
 		case http.MethodPatch:
 			handleTutoriaPATCH(w, r, queries)
 		case http.MethodDelete:
@@ -96,16 +100,16 @@ func getDayOfWeek(date time.Time) int32 {
 	return int32(dayOfWeek)
 }
 
-// checkTutorConflicts checks if tutor has conflicting tutoring sessions
-func checkTutorConflicts(ctx context.Context, queries *db.Queries, tutorID int32, fecha pgtype.Date, horaInicio, horaFin pgtype.Time) (bool, error) {
+// checkTutorConflicts checks if tutor has conflicting tutoring sessions, other than excluir
+func checkTutorConflicts(ctx context.Context, queries *db.Queries, tutorID int32, fecha pgtype.Date, horaInicio, horaFin pgtype.Time, excluir int32) (bool, error) {
 	tutorTutorias, err := queries.ListTutoriasByTutor(ctx, tutorID)
 	if err != nil {
 		return false, err
 	}
 
 	for _, tutoria := range tutorTutorias {
-		// Skip cancelled tutorias
-		if tutoria.Estado == "cancelada" {
+		// Skip cancelled tutorias and the one being rescheduled
+		if tutoria.Estado == "cancelada" || tutoria.TutoriaID == excluir {
 			continue
 		}
 
@@ -129,7 +133,8 @@ func checkTutorConflicts(ctx context.Context, queries *db.Queries, tutorID int32
 
 // createTutoriaHandler handles POST /v1/tutorias
 // @Summary      Create Tutoria
-// @Description  Creates a new tutoring session with intelligent tutor assignment and validation.
+// @Description  Creates a new tutoring session with intelligent tutor assignment and validation. The estudiante must be
+// @Description  enrolled in the materia for the academic period of the fecha.
 // @Tags         Tutorias
 // @Accept       json
 // @Produce      json
@@ -138,7 +143,7 @@ func checkTutorConflicts(ctx context.Context, queries *db.Queries, tutorID int32
 // @Failure      400 {object} ErrorResponse "Invalid request body or validation failed"
 // @Failure      500 {object} ErrorResponse "Failed to create tutoria"
 // @Router       /v1/tutorias [post]
-func createTutoriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+func createTutoriaHandler(w http.ResponseWriter, r *http.Request, pool *pgxpool.Pool, queries *db.Queries) {
 	var req CreateTutoriaRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
@@ -177,6 +182,48 @@ func createTutoriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Qu
 		return
 	}
 
+	// Deactivated materias are kept for history but can no longer be booked
+	materia, err := queries.SelectMateriaById(r.Context(), req.MateriaID)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			http.Error(w, "Materia not found", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to get materia: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !materia.Activo {
+		registrarSolicitudFallida(r.Context(), queries, req, fecha, horaInicio, horaFin, MotivoMateriaInactiva)
+		http.Error(w, "Materia is no longer offered", http.StatusBadRequest)
+		return
+	}
+
+	// Deleted estudiantes keep their history but can no longer book
+	if _, err := queries.SelectEstudianteById(r.Context(), req.EstudianteID); err != nil {
+		if err.Error() == "no rows in result set" {
+			http.Error(w, "Estudiante not found", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to get estudiante: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Students can only book tutorias of the materias they are enrolled in for that period
+	periodo := periodoAcademico(fecha.Time)
+	inscrito, err := queries.EstudianteInscrito(r.Context(), db.EstudianteInscritoParams{
+		EstudianteID: req.EstudianteID,
+		MateriaID:    req.MateriaID,
+		Periodo:      periodo,
+	})
+	if err != nil {
+		http.Error(w, "Failed to verify enrollment: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !inscrito {
+		http.Error(w, "Estudiante is not enrolled in this materia for periodo "+periodo, http.StatusBadRequest)
+		return
+	}
+
 	var assignedTutorID int32
 
 	// If no tutor specified, find an available qualified tutor
@@ -195,27 +242,43 @@ func createTutoriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Qu
 		}
 
 		if len(availableTutors) == 0 {
+			registrarSolicitudFallida(r.Context(), queries, req, fecha, horaInicio, horaFin, MotivoSinTutores)
 			http.Error(w, "No qualified tutors available for the requested subject and day", http.StatusBadRequest)
 			return
 		}
 
-		// Find a tutor who is available at the requested time and has no conflicts
+		// Find a tutor who is available at the requested time, has no conflicts and is within their hour limits
 		var selectedTutor *db.ListTutoresDisponiblesByMateriaAndDiaRow
+		sinCupo := false
 		for _, tutor := range availableTutors {
 
-			hasConflicts, err := checkTutorConflicts(r.Context(), queries, tutor.TutorID, fecha, horaInicio, horaFin)
+			hasConflicts, err := checkTutorConflicts(r.Context(), queries, tutor.TutorID, fecha, horaInicio, horaFin, 0)
 			if err != nil {
 				continue // Skip this tutor and try the next one
 			}
+			if hasConflicts {
+				continue
+			}
 
-			if !hasConflicts {
-				selectedTutor = &tutor
-				break
+			excede, err := tutorExcedeLimite(r.Context(), queries, tutor.TutorID, fecha, horaInicio, horaFin, nil)
+			if err != nil {
+				continue
+			}
+			if excede {
+				sinCupo = true
+				continue
 			}
 
+			selectedTutor = &tutor
+			break
 		}
 
 		if selectedTutor == nil {
+			motivo := MotivoSinHorario
+			if sinCupo {
+				motivo = MotivoTutorSinCupo
+			}
+			registrarSolicitudFallida(r.Context(), queries, req, fecha, horaInicio, horaFin, motivo)
 			http.Error(w, "No available tutors found for the requested time slot. Please try a different time or day.", http.StatusBadRequest)
 			return
 		}
@@ -225,6 +288,15 @@ func createTutoriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Qu
 		// If tutor is specified, validate that they are qualified and available
 		assignedTutorID = req.TutorID
 
+		if _, err := queries.SelectTutorById(r.Context(), assignedTutorID); err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Tutor not found", http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "Failed to get tutor: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
 		// Check if tutor is qualified for this materia
 		materiasByTutor, err := queries.ListMateriasByTutor(r.Context(), assignedTutorID)
 		if err != nil {
@@ -241,22 +313,11 @@ func createTutoriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Qu
 		}
 
 		if !isQualified {
+			registrarSolicitudFallida(r.Context(), queries, req, fecha, horaInicio, horaFin, MotivoTutorNoCalificado)
 			http.Error(w, "Specified tutor is not qualified to teach the requested subject", http.StatusBadRequest)
 			return
 		}
 
-		// Check for scheduling conflicts
-		hasConflicts, err := checkTutorConflicts(r.Context(), queries, assignedTutorID, fecha, horaInicio, horaFin)
-		if err != nil {
-			http.Error(w, "Failed to check tutor availability: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-
-		if hasConflicts {
-			http.Error(w, "Tutor has a scheduling conflict at the requested time", http.StatusBadRequest)
-			return
-		}
-
 		// Check if tutor is available on the requested day and time
 		dayOfWeek := getDayOfWeek(fecha.Time)
 		tutorAvailability, err := queries.ListDisponibilidadByTutor(r.Context(), assignedTutorID)
@@ -281,6 +342,7 @@ func createTutoriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Qu
 		}
 
 		if !isAvailable {
+			registrarSolicitudFallida(r.Context(), queries, req, fecha, horaInicio, horaFin, MotivoTutorNoDisponible)
 			http.Error(w, "Tutor is not available at the requested day and time", http.StatusBadRequest)
 			return
 		}
@@ -299,10 +361,30 @@ func createTutoriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Qu
 		Lugar:          req.Lugar,
 	}
 
-	tutoria, err := queries.CreateTutoria(r.Context(), params)
+	// Conflicts and hour limits are checked with the tutor locked, so that concurrent bookings
+	// cannot both take the same slot or together take the tutor past their limits
+	var tutoria db.Tutoria
+	err = withTx(r.Context(), pool, queries, func(q *db.Queries) error {
+		if err := reservarTutor(r.Context(), q, assignedTutorID, fecha, horaInicio, horaFin, nil); err != nil {
+			return err
+		}
+		tutoria, err = q.CreateTutoria(r.Context(), params)
+		return err
+	})
 	if err != nil {
+		if errors.Is(err, errTutorOcupado) {
+			registrarSolicitudFallida(r.Context(), queries, req, fecha, horaInicio, horaFin, MotivoTutorOcupado)
+			http.Error(w, "Tutor has a scheduling conflict at the requested time", http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, errTutorSinCupo) {
+			registrarSolicitudFallida(r.Context(), queries, req, fecha, horaInicio, horaFin, MotivoTutorSinCupo)
+			http.Error(w, "Tutor has reached their weekly or monthly hour limit", http.StatusBadRequest)
+			return
+		}
 		// The database trigger will also validate tutor-materia assignment
 		if strings.Contains(err.Error(), "tutor no está asignado") {
+			registrarSolicitudFallida(r.Context(), queries, req, fecha, horaInicio, horaFin, MotivoTutorNoCalificado)
 			http.Error(w, "Tutor is not qualified to teach the requested subject", http.StatusBadRequest)
 			return
 		}
@@ -310,6 +392,8 @@ func createTutoriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Qu
 		return
 	}
 
+	emitWebhookEvent(r.Context(), queries, EventoTutoriaCreada, tutoria)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(CreateTutoriaResponse{TutoriaID: tutoria.TutoriaID})
@@ -320,34 +404,18 @@ func handleTutoriaGET(w http.ResponseWriter, r *http.Request, queries *db.Querie
 	path := strings.TrimPrefix(r.URL.Path, "/v1/tutorias")
 
 	if path == "" || path == "/" {
-		// Check for query parameters
-		if estudianteID := r.URL.Query().Get("estudiante_id"); estudianteID != "" {
-			// GET /v1/tutorias?estudiante_id={estudiante_id}
-			listTutoriasByEstudianteHandler(w, r, queries, estudianteID)
-			return
-		}
-		if tutorID := r.URL.Query().Get("tutor_id"); tutorID != "" {
-			// GET /v1/tutorias?tutor_id={tutor_id}
-			listTutoriasByTutorHandler(w, r, queries, tutorID)
-			return
-		}
-		if estado := r.URL.Query().Get("estado"); estado != "" {
-			// GET /v1/tutorias?estado={estado}
-			listTutoriasByEstadoHandler(w, r, queries, estado)
-			return
-		}
 		if r.URL.Query().Get("activas") == "true" {
 			// GET /v1/tutorias?activas=true
 			listTutoriasActivasHandler(w, r, queries)
 			return
 		}
-		// Add this new case for proximas tutorias by estudiante_id
 		if estudianteID := r.URL.Query().Get("proximas_estudiante_id"); estudianteID != "" {
 			// GET /v1/tutorias?proximas_estudiante_id={estudiante_id}
 			getProximasTutoriasByEstudianteHandler(w, r, queries, estudianteID)
 			return
 		}
-		http.Error(w, "Missing required query parameter: estudiante_id, tutor_id, estado, activas=true, or proximas_estudiante_id", http.StatusBadRequest)
+		// GET /v1/tutorias?estudiante_id=&tutor_id=&materia_id=&estado=&fecha_desde=&fecha_hasta=&lugar=&asistencia=
+		searchTutoriasHandler(w, r, queries)
 		return
 	}
 
@@ -358,6 +426,14 @@ func handleTutoriaGET(w http.ResponseWriter, r *http.Request, queries *db.Querie
 		return
 	}
 
+	// GET /v1/tutorias/{id}/invitacion.ics is dispatched here: as a mux pattern it would
+	// conflict with GET /v1/tutorias/tutor/{tutor_id}
+	if len(pathParts) == 2 && pathParts[1] == "invitacion.ics" {
+		r.SetPathValue("id", pathParts[0])
+		TutoriaInvitacionEndpoint(queries)(w, r)
+		return
+	}
+
 	http.Error(w, "Invalid path", http.StatusBadRequest)
 }
 
@@ -393,88 +469,165 @@ func getTutoriaByIDHandler(w http.ResponseWriter, r *http.Request, queries *db.Q
 	json.NewEncoder(w).Encode(tutoria)
 }
 
-// listTutoriasByEstudianteHandler handles GET /v1/tutorias?estudiante_id={estudiante_id}
-// @Summary      List Tutorias by Student
-// @Description  Retrieves tutorias for a specific student.
-// @Tags         Tutorias
-// @Produce      json
-// @Param        estudiante_id query int true "Student ID"
-// @Success      200 {array} db.ListTutoriasByEstudianteRow "Successfully retrieved tutorias"
-// @Failure      400 {object} ErrorResponse "Invalid student ID"
-// @Failure      500 {object} ErrorResponse "Failed to retrieve tutorias"
-// @Router       /v1/tutorias [get]
-func listTutoriasByEstudianteHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, estudianteIDStr string) {
-	estudianteID, err := strconv.ParseInt(estudianteIDStr, 10, 32)
-	if err != nil {
-		http.Error(w, "Invalid estudiante ID", http.StatusBadRequest)
-		return
+// tutoriaEstados are the values TUTORIAS.estado can take.
+var tutoriaEstados = []string{"solicitada", "confirmada", "cancelada", "completada"}
+
+// parseTutoriaFiltros reads the filters of GET /v1/tutorias. Filters that are not given stay
+// NULL and match every tutoria. estado may be repeated or comma-separated.
+func parseTutoriaFiltros(r *http.Request) (db.CountTutoriasParams, error) {
+	query := r.URL.Query()
+	var filtros db.CountTutoriasParams
+
+	for _, id := range []struct {
+		name   string
+		target *pgtype.Int4
+	}{
+		{"estudiante_id", &filtros.EstudianteID},
+		{"tutor_id", &filtros.TutorID},
+		{"materia_id", &filtros.MateriaID},
+	} {
+		if value := query.Get(id.name); value != "" {
+			parsed, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return filtros, fmt.Errorf("invalid %s", id.name)
+			}
+			*id.target = pgtype.Int4{Int32: int32(parsed), Valid: true}
+		}
 	}
 
-	tutorias, err := queries.ListTutoriasByEstudiante(r.Context(), int32(estudianteID))
-	if err != nil {
-		http.Error(w, "Failed to retrieve tutorias: "+err.Error(), http.StatusInternalServerError)
-		return
+	for _, value := range query["estado"] {
+		for _, estado := range strings.Split(value, ",") {
+			estado = strings.TrimSpace(estado)
+			if estado == "" {
+				continue
+			}
+			if !slices.Contains(tutoriaEstados, estado) {
+				return filtros, fmt.Errorf("invalid estado %q", estado)
+			}
+			filtros.Estados = append(filtros.Estados, estado)
+		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tutorias)
+	for _, fecha := range []struct {
+		name   string
+		target *pgtype.Date
+	}{
+		{"fecha_desde", &filtros.FechaDesde},
+		{"fecha_hasta", &filtros.FechaHasta},
+	} {
+		if value := query.Get(fecha.name); value != "" {
+			parsed, err := parseDateString(value)
+			if err != nil {
+				return filtros, fmt.Errorf("invalid %s, expected YYYY-MM-DD", fecha.name)
+			}
+			*fecha.target = parsed
+		}
+	}
+
+	if lugar := strings.TrimSpace(query.Get("lugar")); lugar != "" {
+		filtros.Lugar = pgtype.Text{String: lugar, Valid: true}
+	}
+
+	if value := query.Get("asistencia"); value != "" {
+		asistencia, err := strconv.ParseBool(value)
+		if err != nil {
+			return filtros, errors.New("invalid asistencia, expected true or false")
+		}
+		filtros.Asistencia = pgtype.Bool{Bool: asistencia, Valid: true}
+	}
+
+	return filtros, nil
 }
 
-// listTutoriasByTutorHandler handles GET /v1/tutorias?tutor_id={tutor_id}
-// @Summary      List Tutorias by Tutor
-// @Description  Retrieves tutorias for a specific tutor.
+// tutoriaSorts are the sort fields of GET /v1/tutorias, the first one being the default.
+var tutoriaSorts = []string{"fecha", "fecha_solicitud", "id"}
+
+// tutoriaSortKey returns the sort key SearchTutorias computes for a tutoria.
+func tutoriaSortKey(sort string, tutoria db.SearchTutoriasRow) string {
+	switch sort {
+	case "fecha_solicitud":
+		return sortKeyTimestamp(tutoria.FechaSolicitud)
+	case "id":
+		return sortKeyInt(tutoria.TutoriaID)
+	default:
+		return sortKeyFechaHora(tutoria.Fecha, tutoria.HoraInicio)
+	}
+}
+
+// searchTutoriasHandler handles GET /v1/tutorias with any combination of filters
+// @Summary      Search Tutorias
+// @Description  Lists tutorias matching every given filter, with the names of their estudiante, tutor and materia.
+// @Description  activas=true and proximas_estudiante_id take precedence over the filters and return unpaginated lists.
 // @Tags         Tutorias
 // @Produce      json
-// @Param        tutor_id query int true "Tutor ID"
-// @Success      200 {array} db.ListTutoriasByTutorRow "Successfully retrieved tutorias"
-// @Failure      400 {object} ErrorResponse "Invalid tutor ID"
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        estudiante_id query int false "Student ID"
+// @Param        tutor_id query int false "Tutor ID"
+// @Param        materia_id query int false "Materia ID"
+// @Param        estado query []string false "Tutoria status; repeat or comma-separate for several" collectionFormat(multi) Enums(solicitada, confirmada, cancelada, completada)
+// @Param        fecha_desde query string false "Earliest date (YYYY-MM-DD)"
+// @Param        fecha_hasta query string false "Latest date (YYYY-MM-DD)"
+// @Param        lugar query string false "Case-insensitive substring of the place; % and _ match literally"
+// @Param        asistencia query bool false "Whether attendance was confirmed"
+// @Param        activas query bool false "Only active (solicitada or confirmada) tutorias"
+// @Param        proximas_estudiante_id query int false "Only the upcoming tutorias of this student"
+// @Param        limit query int false "Maximum number of items per page" default(50) minimum(1) maximum(500)
+// @Param        after query string false "Cursor of the next page, as returned in X-Next-Cursor"
+// @Param        sort query string false "Sort field" Enums(fecha, fecha_solicitud, id) default(fecha)
+// @Param        direction query string false "Sort direction; defaults to desc for the default sort and to asc otherwise" Enums(asc, desc)
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
+// @Success      200 {array} db.SearchTutoriasRow "Successfully retrieved tutorias"
+// @Header       200 {integer} X-Total-Count "Total number of matching tutorias"
+// @Header       200 {string} X-Next-Cursor "Cursor of the next page; absent on the last page"
+// @Failure      400 {object} ErrorResponse "Invalid filter or pagination parameters"
 // @Failure      500 {object} ErrorResponse "Failed to retrieve tutorias"
 // @Router       /v1/tutorias [get]
-func listTutoriasByTutorHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, tutorIDStr string) {
-	tutorID, err := strconv.ParseInt(tutorIDStr, 10, 32)
+func searchTutoriasHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	filtros, err := parseTutoriaFiltros(r)
 	if err != nil {
-		http.Error(w, "Invalid tutor ID", http.StatusBadRequest)
+		http.Error(w, "Invalid filter: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	tutorias, err := queries.ListTutoriasByTutor(r.Context(), int32(tutorID))
+	page, err := parseListPage(r, tutoriaSorts, true)
 	if err != nil {
-		http.Error(w, "Failed to retrieve tutorias: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Invalid pagination parameters: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tutorias)
-}
-
-// listTutoriasByEstadoHandler handles GET /v1/tutorias?estado={estado}
-// @Summary      List Tutorias by Status
-// @Description  Retrieves tutorias filtered by status.
-// @Tags         Tutorias
-// @Produce      json
-// @Param        estado query string true "Tutoria status"
-// @Success      200 {array} db.ListTutoriasByEstadoRow "Successfully retrieved tutorias"
-// @Failure      500 {object} ErrorResponse "Failed to retrieve tutorias"
-// @Router       /v1/tutorias [get]
-func listTutoriasByEstadoHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, estado string) {
-	tutorias, err := queries.ListTutoriasByEstado(r.Context(), estado)
+	tutorias, err := queries.SearchTutorias(r.Context(), db.SearchTutoriasParams{
+		Sort:         page.Sort,
+		EstudianteID: filtros.EstudianteID,
+		TutorID:      filtros.TutorID,
+		MateriaID:    filtros.MateriaID,
+		Estados:      filtros.Estados,
+		FechaDesde:   filtros.FechaDesde,
+		FechaHasta:   filtros.FechaHasta,
+		Lugar:        filtros.Lugar,
+		Asistencia:   filtros.Asistencia,
+		AfterID:      page.AfterID,
+		Descending:   page.Descending,
+		AfterValor:   page.AfterValor,
+		RowLimit:     page.Limit + 1,
+	})
 	if err != nil {
 		http.Error(w, "Failed to retrieve tutorias: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tutorias)
+	total, err := queries.CountTutorias(r.Context(), filtros)
+	if err != nil {
+		http.Error(w, "Failed to count tutorias: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writePage(w, r, "tutorias", page, tutorias, total, func(row db.SearchTutoriasRow) (string, int32) {
+		return tutoriaSortKey(page.Sort, row), row.TutoriaID
+	})
 }
 
 // listTutoriasActivasHandler handles GET /v1/tutorias?activas=true
-// @Summary      List Active Tutorias
-// @Description  Retrieves all active tutorias.
-// @Tags         Tutorias
-// @Produce      json
-// @Success      200 {array} db.Tutoriasactiva "Successfully retrieved active tutorias"
-// @Failure      500 {object} ErrorResponse "Failed to retrieve tutorias"
-// @Router       /v1/tutorias [get] // Note: Swagger might not differentiate this well from other GET /v1/tutorias based on query params alone
 func listTutoriasActivasHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
 	tutorias, err := queries.ListTutoriasActivas(r.Context())
 	if err != nil {
@@ -482,20 +635,10 @@ func listTutoriasActivasHandler(w http.ResponseWriter, r *http.Request, queries
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tutorias)
+	writeList(w, r, "tutorias", tutorias)
 }
 
 // getProximasTutoriasByEstudianteHandler handles GET /v1/tutorias?proximas_estudiante_id={estudiante_id}
-// @Summary      Get Upcoming Tutorias for Student
-// @Description  Retrieves upcoming tutorias for a specific student that have not started yet.
-// @Tags         Tutorias
-// @Produce      json
-// @Param        proximas_estudiante_id query int true "Student ID"
-// @Success      200 {array} db.Tutoria "Successfully retrieved upcoming tutorias"
-// @Failure      400 {object} ErrorResponse "Invalid student ID"
-// @Failure      500 {object} ErrorResponse "Failed to retrieve upcoming tutorias"
-// @Router       /v1/tutorias [get] // Note: Swagger might not differentiate this well
 func getProximasTutoriasByEstudianteHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, estudianteIDStr string) {
 	estudianteID, err := strconv.ParseInt(estudianteIDStr, 10, 32)
 	if err != nil {
@@ -508,8 +651,7 @@ func getProximasTutoriasByEstudianteHandler(w http.ResponseWriter, r *http.Reque
 		http.Error(w, "Failed to retrieve upcoming tutorias: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tutorias)
+	writeList(w, r, "tutorias", tutorias)
 }
 
 // SelectTutoriaByTutorIDHandler handles GET /v1/tutorias/tutor/{tutor_id}
@@ -517,8 +659,11 @@ func getProximasTutoriasByEstudianteHandler(w http.ResponseWriter, r *http.Reque
 // @Description  Retrieves all tutorias for a specific tutor.
 // @Tags         Tutorias
 // @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param        tutor_id path int true "Tutor ID"
-// @Success      200 {array} db.SelectTutoriaByTutorIdRow "Successfully retrieved tutorias"
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
+// @Success      200 {array} db.Tutoria "Successfully retrieved tutorias"
 // @Failure      400 {object} ErrorResponse "Invalid tutor ID"
 // @Failure      500 {object} ErrorResponse "Failed to retrieve tutorias"
 // @Router       /v1/tutorias/tutor/{tutor_id} [get]
@@ -536,8 +681,7 @@ func SelectTutoriaByTutorIDHandler(w http.ResponseWriter, r *http.Request, queri
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tutorias)
+	writeList(w, r, "tutorias", tutorias)
 }
 
 // SelectTutoriaByEstudianteIDHandler handles GET /v1/tutorias/estudiante/{estudiante_id}
@@ -545,8 +689,11 @@ func SelectTutoriaByTutorIDHandler(w http.ResponseWriter, r *http.Request, queri
 // @Description  Retrieves all tutorias for a specific student.
 // @Tags         Tutorias
 // @Produce      json
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param        estudiante_id path int true "Estudiante ID"
-// @Success      200 {array} db.SelectTutoriaByEstudianteIdRow "Successfully retrieved tutorias"
+// @Param        format query string false "Response format; Accept: text/csv is also honored" Enums(json, csv, xlsx)
+// @Success      200 {array} db.Tutoria "Successfully retrieved tutorias"
 // @Failure      400 {object} ErrorResponse "Invalid estudiante ID"
 // @Failure      500 {object} ErrorResponse "Failed to retrieve tutorias"
 // @Router       /v1/tutorias/estudiante/{estudiante_id} [get]
@@ -564,12 +711,11 @@ func SelectTutoriaByEstudianteIDHandler(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tutorias)
+	writeList(w, r, "tutorias", tutorias)
 }
 
 // handleTutoriaPUT handles PUT requests for tutorias
-func handleTutoriaPUT(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+func handleTutoriaPUT(w http.ResponseWriter, r *http.Request, pool *pgxpool.Pool, queries *db.Queries) {
 	path := strings.TrimPrefix(r.URL.Path, "/v1/tutorias")
 	pathParts := strings.Split(strings.Trim(path, "/"), "/")
 
@@ -586,19 +732,54 @@ func handleTutoriaPUT(w http.ResponseWriter, r *http.Request, queries *db.Querie
 
 	// Check if this is a status update: /v1/tutorias/{id}/estado
 	if len(pathParts) == 2 && pathParts[1] == "estado" {
-		updateTutoriaEstadoHandler(w, r, queries, int32(tutoriaID))
+		updateTutoriaEstadoHandler(w, r, pool, queries, int32(tutoriaID))
 		return
 	}
 
 	// Regular tutoria update: /v1/tutorias/{id}
 	if len(pathParts) == 1 {
-		updateTutoriaHandler(w, r, queries, int32(tutoriaID))
+		updateTutoriaHandler(w, r, pool, queries, int32(tutoriaID))
 		return
 	}
 
 	http.Error(w, "Invalid path", http.StatusBadRequest)
 }
 
+// actualizarTutoria writes params over the existing tutoria. When the update moves the tutoria
+// or makes a cancelled one active again, the tutor is reserved through reservarTutor first.
+func actualizarTutoria(ctx context.Context, pool *pgxpool.Pool, queries *db.Queries, existing db.Tutoria, params db.UpdateTutoriaParams) (db.Tutoria, error) {
+	reserva := params.Estado != "cancelada" && (existing.Estado == "cancelada" || params.Fecha != existing.Fecha ||
+		params.HoraInicio != existing.HoraInicio || params.HoraFin != existing.HoraFin)
+	if !reserva {
+		return queries.UpdateTutoria(ctx, params)
+	}
+
+	var tutoria db.Tutoria
+	err := withTx(ctx, pool, queries, func(q *db.Queries) error {
+		if err := reservarTutor(ctx, q, existing.TutorID, params.Fecha, params.HoraInicio, params.HoraFin, &existing); err != nil {
+			return err
+		}
+		var err error
+		tutoria, err = q.UpdateTutoria(ctx, params)
+		return err
+	})
+	return tutoria, err
+}
+
+// writeTutoriaUpdateError reports an error returned by actualizarTutoria.
+func writeTutoriaUpdateError(w http.ResponseWriter, err error, accion string) {
+	switch {
+	case errors.Is(err, errTutorOcupado):
+		http.Error(w, "Tutor has a scheduling conflict at the requested time", http.StatusConflict)
+	case errors.Is(err, errTutorSinCupo):
+		http.Error(w, "Tutor has reached their weekly or monthly hour limit", http.StatusConflict)
+	case err.Error() == "no rows in result set":
+		http.Error(w, "Tutoria not found", http.StatusNotFound)
+	default:
+		http.Error(w, "Failed to "+accion+": "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
 // handleTutoriaDELETE handles DELETE requests for tutorias
 func handleTutoriaDELETE(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
 	path := strings.TrimPrefix(r.URL.Path, "/v1/tutorias")
@@ -629,9 +810,10 @@ func handleTutoriaDELETE(w http.ResponseWriter, r *http.Request, queries *db.Que
 // @Success      200 {object} db.Tutoria "Successfully updated tutoria status"
 // @Failure      400 {object} ErrorResponse "Invalid request body or tutoria ID"
 // @Failure      404 {object} ErrorResponse "Tutoria not found"
+// @Failure      409 {object} ErrorResponse "Tutor has a scheduling conflict or has reached their hour limit"
 // @Failure      500 {object} ErrorResponse "Failed to update tutoria status"
 // @Router       /v1/tutorias/{id}/estado [put]
-func updateTutoriaEstadoHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, tutoriaID int32) {
+func updateTutoriaEstadoHandler(w http.ResponseWriter, r *http.Request, pool *pgxpool.Pool, queries *db.Queries, tutoriaID int32) {
 	var req UpdateTutoriaEstadoRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
@@ -661,12 +843,17 @@ func updateTutoriaEstadoHandler(w http.ResponseWriter, r *http.Request, queries
 		TemasTratados:        existingTutoria.TemasTratados,
 	}
 
-	tutoria, err := queries.UpdateTutoria(r.Context(), params)
+	tutoria, err := actualizarTutoria(r.Context(), pool, queries, existingTutoria, params)
 	if err != nil {
-		http.Error(w, "Failed to update tutoria status: "+err.Error(), http.StatusInternalServerError)
+		writeTutoriaUpdateError(w, err, "update tutoria status")
 		return
 	}
 
+	if tutoria.Estado != existingTutoria.Estado {
+		emitWebhookEvent(r.Context(), queries, tutoriaEstadoEvento(tutoria.Estado), tutoria)
+		sendTutoriaInvitacion(r.Context(), queries, tutoria)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(tutoria)
 }
@@ -682,9 +869,10 @@ func updateTutoriaEstadoHandler(w http.ResponseWriter, r *http.Request, queries
 // @Success      200 {object} db.Tutoria "Successfully updated tutoria"
 // @Failure      400 {object} ErrorResponse "Invalid request body or tutoria ID"
 // @Failure      404 {object} ErrorResponse "Tutoria not found"
+// @Failure      409 {object} ErrorResponse "Tutor has a scheduling conflict or has reached their hour limit"
 // @Failure      500 {object} ErrorResponse "Failed to update tutoria"
 // @Router       /v1/tutorias/{id} [put]
-func updateTutoriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, tutoriaID int32) {
+func updateTutoriaHandler(w http.ResponseWriter, r *http.Request, pool *pgxpool.Pool, queries *db.Queries, tutoriaID int32) {
 	var req UpdateTutoriaRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
@@ -737,23 +925,32 @@ func updateTutoriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Qu
 		TemasTratados:        pgtype.Text{String: req.TemasTratados, Valid: req.TemasTratados != ""},
 	}
 
-	tutoria, err := queries.UpdateTutoria(r.Context(), params)
+	tutoria, err := actualizarTutoria(r.Context(), pool, queries, existingTutoria, params)
 	if err != nil {
-		if err.Error() == "no rows in result set" {
-			http.Error(w, "Tutoria not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, "Failed to update tutoria: "+err.Error(), http.StatusInternalServerError)
+		writeTutoriaUpdateError(w, err, "update tutoria")
 		return
 	}
 
+	if tutoria.Estado != existingTutoria.Estado {
+		emitWebhookEvent(r.Context(), queries, tutoriaEstadoEvento(tutoria.Estado), tutoria)
+		sendTutoriaInvitacion(r.Context(), queries, tutoria)
+	} else {
+		emitWebhookEvent(r.Context(), queries, EventoTutoriaActualizada, tutoria)
+		// Reschedule accepted invitations in place when a confirmed session moves
+		if tutoria.Estado == "confirmada" && (tutoria.Fecha != existingTutoria.Fecha ||
+			tutoria.HoraInicio != existingTutoria.HoraInicio || tutoria.HoraFin != existingTutoria.HoraFin ||
+			tutoria.Lugar != existingTutoria.Lugar) {
+			sendTutoriaInvitacion(r.Context(), queries, tutoria)
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(tutoria)
 }
 
 // deleteTutoriaHandler handles DELETE /v1/tutorias/{id}
 // @Summary      Delete Tutoria
-// @Description  Deletes a specific tutoria.
+// @Description  Deletes a specific tutoria. If a calendar invitation was sent for it, a METHOD:CANCEL invitation is emailed to the estudiante and tutor first.
 // @Tags         Tutorias
 // @Produce      json
 // @Param        id path int true "Tutoria ID"
@@ -763,16 +960,32 @@ func updateTutoriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Qu
 // @Failure      500 {object} ErrorResponse "Failed to delete tutoria"
 // @Router       /v1/tutorias/{id} [delete]
 func deleteTutoriaHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, tutoriaID int32) {
-	err := queries.DeleteTutoria(r.Context(), tutoriaID)
+	// Keep the tutoria data so the deletion event can describe it
+	existingTutoria, err := queries.SelectTutoriaById(r.Context(), tutoriaID)
 	if err != nil {
 		if err.Error() == "no rows in result set" {
 			http.Error(w, "Tutoria not found", http.StatusNotFound)
 			return
 		}
+		http.Error(w, "Failed to get tutoria: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// The invitation record is removed with the tutoria, so cancel any sent invitation first
+	if existingTutoria.Estado != "cancelada" {
+		cancelada := existingTutoria
+		cancelada.Estado = "cancelada"
+		sendTutoriaInvitacion(r.Context(), queries, cancelada)
+	}
+
+	err = queries.DeleteTutoria(r.Context(), tutoriaID)
+	if err != nil {
 		http.Error(w, "Failed to delete tutoria: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	emitWebhookEvent(r.Context(), queries, EventoTutoriaEliminada, existingTutoria)
+
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -792,9 +1005,10 @@ func handleTutoriaPATCH(w http.ResponseWriter, r *http.Request, queries *db.Quer
 // @Success      200 {object} db.Tutoria "Successfully updated tutoria estado"
 // @Failure      400 {object} ErrorResponse "Invalid request body or tutoria ID"
 // @Failure      404 {object} ErrorResponse "Tutoria not found"
+// @Failure      409 {object} ErrorResponse "Tutor has a scheduling conflict or has reached their hour limit"
 // @Failure      500 {object} ErrorResponse "Failed to update tutoria estado"
 // @Router       /v1/tutorias/{id}/estado [patch]
-func UpdateTutoriaEstadoEndpoint(queries *db.Queries) http.HandlerFunc {
+func UpdateTutoriaEstadoEndpoint(pool *pgxpool.Pool, queries *db.Queries) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Get tutoria ID from path parameter using Go 1.22
 		idStr := r.PathValue("id")
@@ -838,12 +1052,17 @@ func UpdateTutoriaEstadoEndpoint(queries *db.Queries) http.HandlerFunc {
 			TemasTratados:        existingTutoria.TemasTratados,
 		}
 
-		updatedTutoria, err := queries.UpdateTutoria(r.Context(), params)
+		updatedTutoria, err := actualizarTutoria(r.Context(), pool, queries, existingTutoria, params)
 		if err != nil {
-			http.Error(w, "Failed to update tutoria estado: "+err.Error(), http.StatusInternalServerError)
+			writeTutoriaUpdateError(w, err, "update tutoria estado")
 			return
 		}
 
+		if updatedTutoria.Estado != existingTutoria.Estado {
+			emitWebhookEvent(r.Context(), queries, tutoriaEstadoEvento(updatedTutoria.Estado), updatedTutoria)
+			sendTutoriaInvitacion(r.Context(), queries, updatedTutoria)
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(updatedTutoria)
 	}
@@ -912,6 +1131,8 @@ func UpdateTutoriaAsistenciaEndpoint(queries *db.Queries) http.HandlerFunc {
 			return
 		}
 
+		emitWebhookEvent(r.Context(), queries, EventoTutoriaAsistencia, updatedTutoria)
+
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(updatedTutoria)
 	}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// withTx runs fn with queries bound to a new transaction. The transaction is committed
+// when fn returns nil and rolled back otherwise.
+func withTx(ctx context.Context, pool *pgxpool.Pool, queries *db.Queries, fn func(*db.Queries) error) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(queries.WithTx(tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
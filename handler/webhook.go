@@ -0,0 +1,739 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/matwate/proyecto-datos/db"
+)
+
+// Webhook event names emitted by the API.
+const (
+	EventoTutoriaCreada           = "tutoria.creada"
+	EventoTutoriaActualizada      = "tutoria.actualizada"
+	EventoTutoriaConfirmada       = "tutoria.confirmada"
+	EventoTutoriaCancelada        = "tutoria.cancelada"
+	EventoTutoriaCompletada       = "tutoria.completada"
+	EventoTutoriaAsistencia       = "tutoria.asistencia"
+	EventoTutoriaEliminada        = "tutoria.eliminada"
+	EventoTutorMateriaAsignada    = "tutor_materia.asignada"
+	EventoTutorMateriaActualizada = "tutor_materia.actualizada"
+	EventoTutorMateriaEliminada   = "tutor_materia.eliminada"
+)
+
+// webhookEventos lists every event a webhook can subscribe to.
+var webhookEventos = []string{
+	EventoTutoriaCreada,
+	EventoTutoriaActualizada,
+	EventoTutoriaConfirmada,
+	EventoTutoriaCancelada,
+	EventoTutoriaCompletada,
+	EventoTutoriaAsistencia,
+	EventoTutoriaEliminada,
+	EventoTutorMateriaAsignada,
+	EventoTutorMateriaActualizada,
+	EventoTutorMateriaEliminada,
+}
+
+// Headers sent with every webhook delivery.
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookEventoHeader    = "X-Webhook-Evento"
+	WebhookEntregaHeader   = "X-Webhook-Entrega"
+)
+
+// CreateWebhookRequest represents the request body for creating a webhook subscription.
+// Eventos may contain exact event names, a prefix wildcard such as "tutoria.*", or "*";
+// an empty list subscribes to every event. If Secreto is empty one is generated.
+type CreateWebhookRequest struct {
+	URL     string   `json:"url" example:"https://sistemas.urosario.edu.co/hooks/tutorias"`
+	Secreto string   `json:"secreto,omitempty" example:"s3cr3t"`
+	Eventos []string `json:"eventos" example:"tutoria.confirmada,tutoria.completada"`
+	Activo  *bool    `json:"activo,omitempty" example:"true"`
+}
+
+// UpdateWebhookRequest represents the request body for updating a webhook subscription.
+// Secreto is kept unchanged when empty.
+type UpdateWebhookRequest struct {
+	URL     string   `json:"url" example:"https://sistemas.urosario.edu.co/hooks/tutorias"`
+	Secreto string   `json:"secreto,omitempty" example:"n3w-s3cr3t"`
+	Eventos []string `json:"eventos" example:"tutoria.*"`
+	Activo  bool     `json:"activo" example:"true"`
+}
+
+// WebhookResponse is a webhook subscription. Secreto is only returned when the webhook is created.
+type WebhookResponse struct {
+	WebhookID     int32            `json:"webhook_id" example:"1"`
+	URL           string           `json:"url" example:"https://sistemas.urosario.edu.co/hooks/tutorias"`
+	Secreto       string           `json:"secreto,omitempty" example:"s3cr3t"`
+	Eventos       []string         `json:"eventos" example:"tutoria.confirmada,tutoria.completada"`
+	Activo        bool             `json:"activo" example:"true"`
+	CreadoPor     pgtype.Int4      `json:"creado_por" swaggertype:"integer" example:"1"`
+	FechaCreacion pgtype.Timestamp `json:"fecha_creacion" swaggertype:"string" example:"2025-03-01T10:00:00Z"`
+}
+
+// WebhookPayload is the JSON body delivered to webhook receivers.
+type WebhookPayload struct {
+	Evento string      `json:"evento" example:"tutoria.confirmada"`
+	Fecha  time.Time   `json:"fecha"`
+	Datos  interface{} `json:"datos"`
+}
+
+// WebhookHandlers handles all webhook subscription endpoints using Go 1.24 routing patterns.
+// @Summary      Handle Webhook Operations
+// @Description  Admin-managed CRUD operations for outbound webhook subscriptions.
+// @Tags         Webhooks
+func WebhookHandlers(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			createWebhookHandler(w, r, queries)
+		case http.MethodGet:
+			handleWebhookGET(w, r, queries)
+		case http.MethodPut:
+			updateWebhookHandler(w, r, queries)
+		case http.MethodDelete:
+			deleteWebhookHandler(w, r, queries)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// validateWebhookRequest checks the target URL and the subscribed events.
+func validateWebhookRequest(rawURL string, eventos []string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("url must be an absolute http(s) URL")
+	}
+
+	for _, evento := range eventos {
+		if evento == "*" {
+			continue
+		}
+		if prefix, found := strings.CutSuffix(evento, ".*"); found {
+			if !webhookEventoHasPrefix(prefix + ".") {
+				return fmt.Errorf("unknown event wildcard: %s", evento)
+			}
+			continue
+		}
+		if !isKnownWebhookEvento(evento) {
+			return fmt.Errorf("unknown event: %s", evento)
+		}
+	}
+
+	return nil
+}
+
+func isKnownWebhookEvento(evento string) bool {
+	for _, known := range webhookEventos {
+		if known == evento {
+			return true
+		}
+	}
+	return false
+}
+
+func webhookEventoHasPrefix(prefix string) bool {
+	for _, known := range webhookEventos {
+		if strings.HasPrefix(known, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookMatchesEvento reports whether a subscription's event filter includes evento.
+func webhookMatchesEvento(eventos []string, evento string) bool {
+	if len(eventos) == 0 {
+		return true
+	}
+	for _, filtro := range eventos {
+		if filtro == "*" || filtro == evento {
+			return true
+		}
+		if prefix, found := strings.CutSuffix(filtro, "*"); found && strings.HasPrefix(evento, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// newWebhookResponse removes the signing secret from a webhook.
+func newWebhookResponse(webhook db.Webhook) WebhookResponse {
+	return WebhookResponse{
+		WebhookID:     webhook.WebhookID,
+		URL:           webhook.Url,
+		Eventos:       webhook.Eventos,
+		Activo:        webhook.Activo,
+		CreadoPor:     webhook.CreadoPor,
+		FechaCreacion: webhook.FechaCreacion,
+	}
+}
+
+// generateWebhookSecret returns a random hex-encoded signing secret.
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// SignWebhookPayload computes the value of the X-Webhook-Signature header: the hex HMAC-SHA256
+// of "<timestamp>.<body>" keyed with the webhook secret, prefixed with "sha256=".
+// Receivers recompute it with their copy of the secret to verify a delivery.
+func SignWebhookPayload(secreto string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secreto))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// createWebhookHandler handles POST /v1/webhooks
+// @Summary      Create Webhook
+// @Description  Creates a webhook subscription. The response is the only one that includes the secreto, so
+// @Description  receivers must store it now. Requires an admin bearer token.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        webhook body CreateWebhookRequest true "Webhook Data"
+// @Success      201 {object} WebhookResponse "Successfully created webhook"
+// @Failure      400 {object} ErrorResponse "Invalid request body"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to create webhook"
+// @Router       /v1/webhooks [post]
+func createWebhookHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	var req CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validateWebhookRequest(req.URL, req.Eventos); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	secreto := req.Secreto
+	if secreto == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			http.Error(w, "Failed to generate webhook secret", http.StatusInternalServerError)
+			return
+		}
+		secreto = generated
+	}
+
+	eventos := req.Eventos
+	if eventos == nil {
+		eventos = []string{}
+	}
+
+	params := db.CreateWebhookParams{
+		Url:     req.URL,
+		Secreto: secreto,
+		Eventos: eventos,
+		Activo:  req.Activo == nil || *req.Activo,
+	}
+	if admin, ok := adminFromContext(r.Context()); ok {
+		params.CreadoPor = pgtype.Int4{Int32: admin.AdminID, Valid: true}
+	}
+
+	webhook, err := queries.CreateWebhook(r.Context(), params)
+	if err != nil {
+		http.Error(w, "Failed to create webhook: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := newWebhookResponse(webhook)
+	resp.Secreto = webhook.Secreto
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// handleWebhookGET handles GET requests for webhooks
+func handleWebhookGET(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/webhooks")
+
+	if path == "" || path == "/" {
+		// GET /v1/webhooks - List all webhooks
+		listWebhooksHandler(w, r, queries)
+		return
+	}
+
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	// Handle /v1/webhooks/entregas/{id}
+	if len(pathParts) == 2 && pathParts[0] == "entregas" {
+		getWebhookEntregaByIDHandler(w, r, queries, pathParts[1])
+		return
+	}
+
+	// Parse ID from path: /v1/webhooks/{id}
+	if len(pathParts) == 1 && pathParts[0] != "" {
+		getWebhookByIDHandler(w, r, queries, pathParts[0])
+		return
+	}
+
+	// Handle /v1/webhooks/{id}/entregas
+	if len(pathParts) == 2 && pathParts[1] == "entregas" {
+		listWebhookEntregasHandler(w, r, queries, pathParts[0])
+		return
+	}
+
+	http.Error(w, "Invalid path", http.StatusBadRequest)
+}
+
+// listWebhooksHandler handles GET /v1/webhooks
+// @Summary      List All Webhooks
+// @Description  Retrieves every webhook subscription. Requires an admin bearer token.
+// @Tags         Webhooks
+// @Produce      json
+// @Security     AdminBearer
+// @Success      200 {array} WebhookResponse "Successfully retrieved webhooks"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve webhooks"
+// @Router       /v1/webhooks [get]
+func listWebhooksHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	webhooks, err := queries.ListWebhooks(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to retrieve webhooks: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		resp[i] = newWebhookResponse(webhook)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// getWebhookByIDHandler handles GET /v1/webhooks/{id}
+// @Summary      Get Webhook by ID
+// @Description  Retrieves a specific webhook subscription. Requires an admin bearer token.
+// @Tags         Webhooks
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Webhook ID"
+// @Success      200 {object} WebhookResponse "Successfully retrieved webhook"
+// @Failure      400 {object} ErrorResponse "Invalid webhook ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Webhook not found"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve webhook"
+// @Router       /v1/webhooks/{id} [get]
+func getWebhookByIDHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, idStr string) {
+	id, err := strconv.ParseInt(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := queries.SelectWebhookById(r.Context(), int32(id))
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			http.Error(w, "Webhook not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to retrieve webhook: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newWebhookResponse(webhook))
+}
+
+// listWebhookEntregasHandler handles GET /v1/webhooks/{id}/entregas
+// @Summary      List Webhook Deliveries
+// @Description  Retrieves the delivery log of a webhook, newest first. Requires an admin bearer token.
+// @Tags         Webhooks
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Webhook ID"
+// @Param        limit query int false "Maximum number of deliveries (default 50)"
+// @Success      200 {array} db.WebhookEntrega "Successfully retrieved deliveries"
+// @Failure      400 {object} ErrorResponse "Invalid webhook ID or limit"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve deliveries"
+// @Router       /v1/webhooks/{id}/entregas [get]
+func listWebhookEntregasHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, idStr string) {
+	id, err := strconv.ParseInt(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	limit := int64(50)
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		limit, err = strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || limit <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	entregas, err := queries.ListWebhookEntregasByWebhook(r.Context(), db.ListWebhookEntregasByWebhookParams{
+		WebhookID: int32(id),
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		http.Error(w, "Failed to retrieve deliveries: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entregas)
+}
+
+// getWebhookEntregaByIDHandler handles GET /v1/webhooks/entregas/{id}
+// @Summary      Get Webhook Delivery by ID
+// @Description  Retrieves a single webhook delivery with its payload and last attempt. Requires an admin bearer token.
+// @Tags         Webhooks
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Delivery ID"
+// @Success      200 {object} db.WebhookEntrega "Successfully retrieved delivery"
+// @Failure      400 {object} ErrorResponse "Invalid delivery ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Delivery not found"
+// @Failure      500 {object} ErrorResponse "Failed to retrieve delivery"
+// @Router       /v1/webhooks/entregas/{id} [get]
+func getWebhookEntregaByIDHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries, idStr string) {
+	id, err := strconv.ParseInt(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid delivery ID", http.StatusBadRequest)
+		return
+	}
+
+	entrega, err := queries.SelectWebhookEntregaById(r.Context(), int32(id))
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			http.Error(w, "Delivery not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to retrieve delivery: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entrega)
+}
+
+// updateWebhookHandler handles PUT /v1/webhooks/{id}
+// @Summary      Update Webhook
+// @Description  Updates a webhook subscription. An empty secreto keeps the current one. Requires an admin bearer token.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Webhook ID"
+// @Param        webhook body UpdateWebhookRequest true "Updated Webhook Data"
+// @Success      200 {object} WebhookResponse "Successfully updated webhook"
+// @Failure      400 {object} ErrorResponse "Invalid request body or webhook ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Webhook not found"
+// @Failure      500 {object} ErrorResponse "Failed to update webhook"
+// @Router       /v1/webhooks/{id} [put]
+func updateWebhookHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/webhooks/")
+	id, err := strconv.ParseInt(path, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := validateWebhookRequest(req.URL, req.Eventos); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	existingWebhook, err := queries.SelectWebhookById(r.Context(), int32(id))
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			http.Error(w, "Webhook not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to get webhook: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	secreto := req.Secreto
+	if secreto == "" {
+		secreto = existingWebhook.Secreto
+	}
+
+	eventos := req.Eventos
+	if eventos == nil {
+		eventos = []string{}
+	}
+
+	webhook, err := queries.UpdateWebhook(r.Context(), db.UpdateWebhookParams{
+		WebhookID: int32(id),
+		Url:       req.URL,
+		Secreto:   secreto,
+		Eventos:   eventos,
+		Activo:    req.Activo,
+	})
+	if err != nil {
+		http.Error(w, "Failed to update webhook: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newWebhookResponse(webhook))
+}
+
+// deleteWebhookHandler handles DELETE /v1/webhooks/{id}
+// @Summary      Delete Webhook
+// @Description  Deletes a webhook subscription and its delivery log. Requires an admin bearer token.
+// @Tags         Webhooks
+// @Security     AdminBearer
+// @Param        id path int true "Webhook ID"
+// @Success      204 "Successfully deleted webhook"
+// @Failure      400 {object} ErrorResponse "Invalid webhook ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      500 {object} ErrorResponse "Failed to delete webhook"
+// @Router       /v1/webhooks/{id} [delete]
+func deleteWebhookHandler(w http.ResponseWriter, r *http.Request, queries *db.Queries) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/webhooks/")
+	id, err := strconv.ParseInt(path, 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	err = queries.DeleteWebhook(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, "Failed to delete webhook: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RedeliverWebhookEntregaEndpoint handles POST /v1/webhooks/entregas/{id}/reenviar using Go 1.22 routing
+// @Summary      Redeliver Webhook Delivery
+// @Description  Queues a delivery to be sent again on the next dispatcher run, resetting its attempt count. Requires an admin bearer token.
+// @Tags         Webhooks
+// @Produce      json
+// @Security     AdminBearer
+// @Param        id path int true "Delivery ID"
+// @Success      202 {object} db.WebhookEntrega "Delivery queued for redelivery"
+// @Failure      400 {object} ErrorResponse "Invalid delivery ID"
+// @Failure      401 {object} ErrorResponse "Admin authentication required"
+// @Failure      404 {object} ErrorResponse "Delivery not found"
+// @Failure      500 {object} ErrorResponse "Failed to queue redelivery"
+// @Router       /v1/webhooks/entregas/{id}/reenviar [post]
+func RedeliverWebhookEntregaEndpoint(queries *db.Queries) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid delivery ID", http.StatusBadRequest)
+			return
+		}
+
+		entrega, err := queries.ReenviarWebhookEntrega(r.Context(), int32(id))
+		if err != nil {
+			if err.Error() == "no rows in result set" {
+				http.Error(w, "Delivery not found", http.StatusNotFound)
+				return
+			}
+			http.Error(w, "Failed to queue redelivery: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(entrega)
+	}
+}
+
+// emitWebhookEvent queues a delivery of evento for every active webhook subscribed to it.
+// Failures are logged and never interrupt the request that triggered the event.
+func emitWebhookEvent(ctx context.Context, queries *db.Queries, evento string, datos interface{}) {
+	webhooks, err := queries.ListWebhooksActivos(ctx)
+	if err != nil {
+		log.Printf("webhooks: could not list subscriptions for %s: %v", evento, err)
+		return
+	}
+
+	var payload []byte
+	for _, webhook := range webhooks {
+		if !webhookMatchesEvento(webhook.Eventos, evento) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(WebhookPayload{
+				Evento: evento,
+				Fecha:  time.Now().UTC(),
+				Datos:  datos,
+			})
+			if err != nil {
+				log.Printf("webhooks: could not encode %s payload: %v", evento, err)
+				return
+			}
+		}
+
+		_, err := queries.CreateWebhookEntrega(ctx, db.CreateWebhookEntregaParams{
+			WebhookID: webhook.WebhookID,
+			Evento:    evento,
+			Payload:   payload,
+		})
+		if err != nil {
+			log.Printf("webhooks: could not queue %s for webhook %d: %v", evento, webhook.WebhookID, err)
+		}
+	}
+}
+
+// tutoriaEstadoEvento maps a tutoria estado to the event emitted when a tutoria enters it.
+func tutoriaEstadoEvento(estado string) string {
+	switch estado {
+	case "confirmada":
+		return EventoTutoriaConfirmada
+	case "cancelada":
+		return EventoTutoriaCancelada
+	case "completada":
+		return EventoTutoriaCompletada
+	default:
+		return EventoTutoriaActualizada
+	}
+}
+
+// WebhookDispatcher delivers queued webhook events, retrying failures with exponential backoff.
+type WebhookDispatcher struct {
+	Queries      *db.Queries
+	Client       *http.Client
+	MaxIntentos  int32         // Attempts before a delivery is marked 'fallida'
+	BaseBackoff  time.Duration // Delay before the first retry, doubled on every further attempt
+	PollInterval time.Duration // How often pending deliveries are looked up
+	BatchSize    int32         // Maximum deliveries sent per poll
+}
+
+// NewWebhookDispatcher creates a WebhookDispatcher with the default retry policy.
+func NewWebhookDispatcher(queries *db.Queries) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		Queries:      queries,
+		Client:       &http.Client{Timeout: 10 * time.Second},
+		MaxIntentos:  6,
+		BaseBackoff:  30 * time.Second,
+		PollInterval: 5 * time.Second,
+		BatchSize:    50,
+	}
+}
+
+// Run polls for pending deliveries until ctx is cancelled.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.DeliverPending(ctx); err != nil {
+			log.Printf("webhooks: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverPending sends every delivery that is due and records the outcome of each attempt.
+func (d *WebhookDispatcher) DeliverPending(ctx context.Context) error {
+	entregas, err := d.Queries.ListWebhookEntregasPendientes(ctx, d.BatchSize)
+	if err != nil {
+		return fmt.Errorf("could not list pending deliveries: %w", err)
+	}
+
+	for _, entrega := range entregas {
+		codigo, err := d.send(ctx, entrega)
+		params := d.intento(entrega, codigo, err)
+		if _, err := d.Queries.UpdateWebhookEntregaIntento(ctx, params); err != nil {
+			log.Printf("webhooks: could not record attempt for delivery %d: %v", entrega.EntregaID, err)
+		}
+	}
+
+	return nil
+}
+
+// intento returns the outcome of a delivery attempt: 'entregada' on success, otherwise 'pendiente'
+// with the next attempt after an exponential backoff, or 'fallida' once the attempts are exhausted.
+// The backoff is relative to the database clock, which also decides when the delivery is due.
+func (d *WebhookDispatcher) intento(entrega db.ListWebhookEntregasPendientesRow, codigo int, err error) db.UpdateWebhookEntregaIntentoParams {
+	params := db.UpdateWebhookEntregaIntentoParams{
+		EntregaID:    entrega.EntregaID,
+		Estado:       "entregada",
+		UltimoCodigo: pgtype.Int4{Int32: int32(codigo), Valid: codigo != 0},
+	}
+
+	if err != nil {
+		params.UltimoError = pgtype.Text{String: err.Error(), Valid: true}
+		intentos := entrega.Intentos + 1
+		if intentos >= d.MaxIntentos {
+			params.Estado = "fallida"
+		} else {
+			params.Estado = "pendiente"
+			backoff := d.BaseBackoff << (intentos - 1)
+			params.EsperaSegundos = pgtype.Float8{Float64: backoff.Seconds(), Valid: true}
+		}
+	}
+
+	return params
+}
+
+// send POSTs a single signed delivery. Any non-2xx response is treated as a failure.
+func (d *WebhookDispatcher) send(ctx context.Context, entrega db.ListWebhookEntregasPendientesRow) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, entrega.Url, bytes.NewReader(entrega.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventoHeader, entrega.Evento)
+	req.Header.Set(WebhookEntregaHeader, strconv.Itoa(int(entrega.EntregaID)))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(entrega.Secreto, timestamp, entrega.Payload))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/matwate/proyecto-datos/db"
+)
+
+func newTestEntrega(url string) db.ListWebhookEntregasPendientesRow {
+	return db.ListWebhookEntregasPendientesRow{
+		EntregaID: 7,
+		WebhookID: 3,
+		Evento:    EventoTutoriaConfirmada,
+		Payload:   []byte(`{"evento":"tutoria.confirmada","datos":{"tutoria_id":42}}`),
+		Estado:    "pendiente",
+		Url:       url,
+		Secreto:   "s3cr3t",
+	}
+}
+
+func TestWebhookSendSignsPayload(t *testing.T) {
+	var (
+		gotSignature, gotTimestamp, gotEvento, gotEntrega string
+		gotBody                                           []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(WebhookSignatureHeader)
+		gotTimestamp = r.Header.Get(WebhookTimestampHeader)
+		gotEvento = r.Header.Get(WebhookEventoHeader)
+		gotEntrega = r.Header.Get(WebhookEntregaHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	d := NewWebhookDispatcher(nil)
+	entrega := newTestEntrega(server.URL)
+
+	codigo, err := d.send(context.Background(), entrega)
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if codigo != http.StatusNoContent {
+		t.Errorf("codigo = %d, want %d", codigo, http.StatusNoContent)
+	}
+	if string(gotBody) != string(entrega.Payload) {
+		t.Errorf("body = %s, want %s", gotBody, entrega.Payload)
+	}
+	if gotEvento != entrega.Evento {
+		t.Errorf("%s = %q, want %q", WebhookEventoHeader, gotEvento, entrega.Evento)
+	}
+	if gotEntrega != "7" {
+		t.Errorf("%s = %q, want %q", WebhookEntregaHeader, gotEntrega, "7")
+	}
+
+	timestamp, err := strconv.ParseInt(gotTimestamp, 10, 64)
+	if err != nil {
+		t.Fatalf("invalid %s %q: %v", WebhookTimestampHeader, gotTimestamp, err)
+	}
+	if want := SignWebhookPayload(entrega.Secreto, timestamp, gotBody); gotSignature != want {
+		t.Errorf("%s = %q, want %q", WebhookSignatureHeader, gotSignature, want)
+	}
+	if other := SignWebhookPayload("otro", timestamp, gotBody); gotSignature == other {
+		t.Errorf("signature does not depend on the secret")
+	}
+}
+
+func TestWebhookSendFailsOnNon2xx(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	codigo, err := NewWebhookDispatcher(nil).send(context.Background(), newTestEntrega(server.URL))
+	if err == nil {
+		t.Fatal("send succeeded on a 503 response")
+	}
+	if codigo != http.StatusServiceUnavailable {
+		t.Errorf("codigo = %d, want %d", codigo, http.StatusServiceUnavailable)
+	}
+}
+
+func TestWebhookIntentoBackoff(t *testing.T) {
+	d := NewWebhookDispatcher(nil)
+	d.MaxIntentos = 4
+	d.BaseBackoff = time.Minute
+	fallo := errors.New("receiver responded with status 503")
+
+	tests := []struct {
+		intentos int32
+		estado   string
+		espera   time.Duration
+	}{
+		{intentos: 0, estado: "pendiente", espera: time.Minute},
+		{intentos: 1, estado: "pendiente", espera: 2 * time.Minute},
+		{intentos: 2, estado: "pendiente", espera: 4 * time.Minute},
+		{intentos: 3, estado: "fallida"},
+	}
+	for _, tt := range tests {
+		entrega := newTestEntrega("http://example.invalid")
+		entrega.Intentos = tt.intentos
+
+		params := d.intento(entrega, http.StatusServiceUnavailable, fallo)
+		if params.Estado != tt.estado {
+			t.Errorf("intentos=%d: estado = %q, want %q", tt.intentos, params.Estado, tt.estado)
+		}
+		if !params.UltimoError.Valid || params.UltimoError.String != fallo.Error() {
+			t.Errorf("intentos=%d: ultimo_error = %+v, want %q", tt.intentos, params.UltimoError, fallo)
+		}
+		if params.UltimoCodigo.Int32 != http.StatusServiceUnavailable {
+			t.Errorf("intentos=%d: ultimo_codigo = %d, want %d", tt.intentos, params.UltimoCodigo.Int32, http.StatusServiceUnavailable)
+		}
+		got := time.Duration(params.EsperaSegundos.Float64 * float64(time.Second))
+		if params.EsperaSegundos.Valid != (tt.estado == "pendiente") || got != tt.espera {
+			t.Errorf("intentos=%d: next attempt after %v (valid %v), want %v", tt.intentos, got, params.EsperaSegundos.Valid, tt.espera)
+		}
+	}
+}
+
+func TestWebhookIntentoEntregada(t *testing.T) {
+	params := NewWebhookDispatcher(nil).intento(newTestEntrega("http://example.invalid"), http.StatusOK, nil)
+	if params.Estado != "entregada" {
+		t.Errorf("estado = %q, want %q", params.Estado, "entregada")
+	}
+	if params.UltimoError.Valid {
+		t.Errorf("ultimo_error = %q, want none", params.UltimoError.String)
+	}
+}
+
+func TestNewWebhookResponseRedactsSecreto(t *testing.T) {
+	resp := newWebhookResponse(db.Webhook{WebhookID: 1, Url: "https://example.com/hook", Secreto: "s3cr3t"})
+	if resp.Secreto != "" {
+		t.Errorf("secreto = %q, want it redacted", resp.Secreto)
+	}
+}
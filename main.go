@@ -22,6 +22,10 @@ import (
 // @host      matwa.tail013c29.ts.net
 // @BasePath  /api/
 // @schemes   https
+// @securityDefinitions.apikey AdminBearer
+// @in header
+// @name Authorization
+// @description Admin token from POST /v1/login/admin, sent as "Bearer <token>".
 func main() {
 	// Load environment variables from .env file
 	err := godotenv.Load()
@@ -84,12 +88,12 @@ func main() {
 	mux.Handle("/v1/disponibilidad", disponibilidadHandlers)
 	mux.Handle("/v1/disponibilidad/", disponibilidadHandlers)
 
-	tutoriaHandlers := handler.TutoriaHandlers(queries)
+	tutoriaHandlers := handler.TutoriaHandlers(pool, queries)
 	mux.Handle("/v1/tutorias", tutoriaHandlers)
 	mux.Handle("/v1/tutorias/", tutoriaHandlers)
 
 	// Specific endpoints for tutoria updates using Go 1.22 routing patterns
-	mux.HandleFunc("PATCH /v1/tutorias/{id}/estado", handler.UpdateTutoriaEstadoEndpoint(queries))
+	mux.HandleFunc("PATCH /v1/tutorias/{id}/estado", handler.UpdateTutoriaEstadoEndpoint(pool, queries))
 	mux.HandleFunc("PATCH /v1/tutorias/{id}/asistencia", handler.UpdateTutoriaAsistenciaEndpoint(queries))
 
 	// Specific endpoints for selecting tutorias by tutor or estudiante ID
@@ -104,6 +108,19 @@ func main() {
 	mux.HandleFunc("GET /v1/tutores/{id}/materias", func(w http.ResponseWriter, r *http.Request) {
 		handler.GetTutorMateriasHandler(w, r, queries)
 	})
+	mux.HandleFunc("GET /v1/tutores/{id}/horas", handler.TutorHorasEndpoint(queries))
+
+	// Hours certificates, verifiable by anyone holding the code printed on them
+	mux.HandleFunc("GET /v1/tutores/{id}/certificado", handler.TutorCertificadoEndpoint(queries))
+	mux.HandleFunc("GET /v1/certificados/{codigo}", handler.VerificarCertificadoEndpoint(queries))
+
+	// iCalendar feeds, secured by a per-user feed token
+	mux.HandleFunc("POST /v1/calendario/token/{mode}", handler.CalendarioTokenEndpoint(queries))
+	mux.HandleFunc("GET /v1/estudiantes/{id}/calendario.ics", handler.EstudianteCalendarioEndpoint(queries))
+	mux.HandleFunc("GET /v1/tutores/{id}/calendario.ics", handler.TutorCalendarioEndpoint(queries))
+
+	// Accent-insensitive, typo-tolerant search over materias and tutores
+	mux.HandleFunc("GET /v1/buscar", handler.BuscarEndpoint(queries))
 
 	reporteHandlers := handler.ReporteHandlers(queries)
 	mux.Handle("/v1/reportes", reporteHandlers)
@@ -113,6 +130,107 @@ func main() {
 	mux.Handle("/v1/tutor-materias", tutorMateriaHandlers)
 	mux.Handle("/v1/tutor-materias/", tutorMateriaHandlers)
 
+	// Materias a student is enrolled in, the only ones they can book tutorias for
+	mux.HandleFunc("GET /v1/estudiantes/{id}/materias", handler.EstudianteMateriasEndpoint(queries))
+
+	// Tutors apply to teach a materia; admins review the postulaciones below
+	mux.HandleFunc("GET /v1/materias/{id}/requisitos", handler.MateriaRequisitosEndpoint(queries))
+	mux.HandleFunc("POST /v1/tutor-postulaciones", handler.CreateTutorPostulacionEndpoint(pool, queries))
+	mux.HandleFunc("GET /v1/tutor-postulaciones/{id}", handler.GetTutorPostulacionEndpoint(queries))
+
+	// Students apply to become tutors; admins review the solicitudes below
+	mux.HandleFunc("POST /v1/solicitudes-tutor", handler.CreateSolicitudTutorEndpoint(queries))
+	mux.HandleFunc("GET /v1/solicitudes-tutor/{id}", handler.GetSolicitudTutorEndpoint(queries))
+
+	// Admin-only endpoints require a bearer token issued by /v1/login/admin
+	requireAdmin := handler.AdminAuthMiddleware(queries)
+
+	webhookHandlers := requireAdmin(handler.WebhookHandlers(queries))
+	mux.Handle("/v1/webhooks", webhookHandlers)
+	mux.Handle("/v1/webhooks/", webhookHandlers)
+	mux.Handle("POST /v1/webhooks/entregas/{id}/reenviar", requireAdmin(handler.RedeliverWebhookEntregaEndpoint(queries)))
+
+	// Students become tutors through a reviewed solicitud, or directly by an admin
+	mux.Handle("POST /v1/tutores", requireAdmin(tutorHandlers))
+
+	// Tutors get materias through a reviewed postulacion, or directly by an admin
+	mux.Handle("POST /v1/tutor-materias", requireAdmin(tutorMateriaHandlers))
+	mux.Handle("PUT /v1/tutor-materias/", requireAdmin(tutorMateriaHandlers))
+	mux.Handle("DELETE /v1/tutor-materias/", requireAdmin(tutorMateriaHandlers))
+
+	// Reports are queued on behalf of the authenticated admin
+	mux.Handle("POST /v1/reportes", requireAdmin(reporteHandlers))
+	mux.Handle("DELETE /v1/reportes/", requireAdmin(reporteHandlers))
+	mux.Handle("POST /v1/reportes/{id}/cancelar", requireAdmin(handler.CancelReporteEndpoint(queries)))
+	mux.Handle("POST /v1/reportes/{id}/reintentar", requireAdmin(handler.RetryReporteEndpoint(queries)))
+
+	reporteProgramacionHandlers := requireAdmin(handler.ReporteProgramacionHandlers(queries))
+	mux.Handle("/v1/reporte-programaciones", reporteProgramacionHandlers)
+	mux.Handle("/v1/reporte-programaciones/", reporteProgramacionHandlers)
+
+	// Live metrics for the admin dashboard
+	mux.Handle("GET /v1/analytics/sesiones", requireAdmin(handler.AnalyticsSesionesEndpoint(queries)))
+	mux.Handle("GET /v1/analytics/demanda-oferta", requireAdmin(handler.AnalyticsDemandaOfertaEndpoint(queries)))
+	mux.Handle("GET /v1/analytics/horas-pico", requireAdmin(handler.AnalyticsHorasPicoEndpoint(queries)))
+	mux.Handle("GET /v1/analytics/anticipacion", requireAdmin(handler.AnalyticsAnticipacionEndpoint(queries)))
+	mux.Handle("GET /v1/analytics/latencia-confirmacion", requireAdmin(handler.AnalyticsLatenciaConfirmacionEndpoint(queries)))
+	mux.Handle("GET /v1/solicitudes-fallidas", requireAdmin(handler.ListSolicitudesFallidasEndpoint(queries)))
+
+	// Bulk imports
+	mux.Handle("POST /v1/estudiantes/import", requireAdmin(handler.ImportEstudiantesEndpoint(pool, queries)))
+	mux.Handle("POST /v1/materias/import", requireAdmin(handler.ImportMateriasEndpoint(pool, queries)))
+	mux.Handle("POST /v1/tutores/onboard", requireAdmin(handler.OnboardTutorEndpoint(pool, queries)))
+	mux.Handle("POST /v1/tutores/{id}/disponibilidad/import", requireAdmin(handler.ImportDisponibilidadEndpoint(pool, queries)))
+
+	// Student enrollments per academic period
+	mux.Handle("POST /v1/inscripciones", requireAdmin(handler.CreateInscripcionEndpoint(queries)))
+	mux.Handle("GET /v1/inscripciones", requireAdmin(handler.ListInscripcionesEndpoint(queries)))
+	mux.Handle("GET /v1/inscripciones/{id}", requireAdmin(handler.GetInscripcionEndpoint(queries)))
+	mux.Handle("DELETE /v1/inscripciones/{id}", requireAdmin(handler.DeleteInscripcionEndpoint(queries)))
+	mux.Handle("POST /v1/inscripciones/import", requireAdmin(handler.ImportInscripcionesEndpoint(pool, queries)))
+
+	// Deleted estudiantes, tutores and materias wait in the papelera until an admin restores or purges them
+	mux.Handle("GET /v1/admin/papelera", requireAdmin(handler.ListPapeleraEndpoint(queries)))
+	mux.Handle("POST /v1/admin/papelera/purgar", requireAdmin(handler.PurgePapeleraEndpoint(queries)))
+	mux.Handle("POST /v1/estudiantes/{id}/restaurar", requireAdmin(handler.RestoreEstudianteEndpoint(queries)))
+	mux.Handle("POST /v1/tutores/{id}/restaurar", requireAdmin(handler.RestoreTutorEndpoint(queries)))
+	mux.Handle("POST /v1/materias/{id}/restaurar", requireAdmin(handler.RestoreMateriaEndpoint(queries)))
+
+	// Admin accounts are managed by superadmins; every admin can change their own password
+	requireSuperadmin := func(next http.Handler) http.Handler {
+		return requireAdmin(handler.SuperadminMiddleware(next))
+	}
+	mux.Handle("GET /v1/admins", requireSuperadmin(handler.ListAdminsEndpoint(queries)))
+	mux.Handle("POST /v1/admins", requireSuperadmin(handler.CreateAdminEndpoint(queries)))
+	mux.Handle("GET /v1/admins/{id}", requireSuperadmin(handler.GetAdminEndpoint(queries)))
+	mux.Handle("PUT /v1/admins/{id}", requireSuperadmin(handler.UpdateAdminEndpoint(pool, queries)))
+	mux.Handle("DELETE /v1/admins/{id}", requireSuperadmin(handler.DeleteAdminEndpoint(pool, queries)))
+	mux.Handle("PUT /v1/admins/{id}/password", requireAdmin(handler.UpdateAdminPasswordEndpoint(queries)))
+
+	// Append-only trail of every POST, PUT, PATCH and DELETE call, recorded by AuditoriaMiddleware
+	mux.Handle("GET /v1/admin/auditoria", requireAdmin(handler.ListAuditoriaEndpoint(queries)))
+
+	// Workload limits enforced when booking tutorias
+	mux.Handle("PUT /v1/tutores/{id}/limites", requireAdmin(handler.UpdateTutorLimitesEndpoint(queries)))
+
+	// Tutor eligibility rules and review of postulaciones and solicitudes
+	mux.Handle("PUT /v1/materias/{id}/requisitos", requireAdmin(handler.UpdateMateriaRequisitosEndpoint(queries)))
+	mux.Handle("GET /v1/tutor-postulaciones", requireAdmin(handler.ListTutorPostulacionesEndpoint(queries)))
+	mux.Handle("POST /v1/tutor-postulaciones/{id}/aprobar", requireAdmin(handler.ApproveTutorPostulacionEndpoint(pool, queries)))
+	mux.Handle("POST /v1/tutor-postulaciones/{id}/rechazar", requireAdmin(handler.RejectTutorPostulacionEndpoint(queries)))
+	mux.Handle("GET /v1/admin/solicitudes-tutor", requireAdmin(handler.ListSolicitudesTutorEndpoint(queries)))
+	mux.Handle("POST /v1/admin/solicitudes-tutor/{id}/aprobar", requireAdmin(handler.ApproveSolicitudTutorEndpoint(pool, queries)))
+	mux.Handle("POST /v1/admin/solicitudes-tutor/{id}/rechazar", requireAdmin(handler.RejectSolicitudTutorEndpoint(queries)))
+
+	// Deliver queued webhook events in the background
+	go handler.NewWebhookDispatcher(queries).Run(context.Background())
+
+	// Generate queued reports in the background
+	go handler.NewReporteWorker(queries).Run(context.Background())
+
+	// Queue scheduled reports when they are due
+	go handler.NewReporteScheduler(queries).Run(context.Background())
+
 	mux.HandleFunc("/v1/docs/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		html := `<!DOCTYPE html>
@@ -166,7 +284,7 @@ func main() {
 	}
 
 	// Apply global middleware
-	wrappedMux := use(mux, handler.LoggingMiddleware, handler.CORSMiddleware) // Apply LoggingMiddleware and CORSMiddleware globally
+	wrappedMux := use(mux, handler.AuditoriaMiddleware(queries), handler.LoggingMiddleware, handler.CORSMiddleware) // Apply AuditoriaMiddleware, LoggingMiddleware and CORSMiddleware globally
 
 	log.Printf("Starting server on port %s...\n", port)
 	if err := http.ListenAndServe(":"+port, wrappedMux); err != nil { // Use wrappedMux
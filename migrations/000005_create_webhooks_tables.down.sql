@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS WEBHOOK_ENTREGAS;
+DROP TABLE IF EXISTS WEBHOOKS;
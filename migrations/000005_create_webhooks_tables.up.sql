@@ -0,0 +1,28 @@
+-- Suscripciones de webhooks salientes gestionadas por administradores
+CREATE TABLE WEBHOOKS (
+    webhook_id SERIAL PRIMARY KEY,
+    url VARCHAR(500) NOT NULL,
+    secreto VARCHAR(255) NOT NULL, -- Clave para firmar los envíos con HMAC-SHA256
+    eventos TEXT[] NOT NULL DEFAULT '{}', -- Vacío = todos los eventos
+    activo BOOLEAN NOT NULL DEFAULT TRUE,
+    creado_por INTEGER REFERENCES ADMINS(admin_id) ON DELETE SET NULL,
+    fecha_creacion TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Registro de entregas de cada evento a cada webhook
+CREATE TABLE WEBHOOK_ENTREGAS (
+    entrega_id SERIAL PRIMARY KEY,
+    webhook_id INTEGER NOT NULL REFERENCES WEBHOOKS(webhook_id) ON DELETE CASCADE,
+    evento VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    estado VARCHAR(20) NOT NULL DEFAULT 'pendiente' CHECK (estado IN ('pendiente', 'entregada', 'fallida')),
+    intentos INTEGER NOT NULL DEFAULT 0,
+    ultimo_codigo INTEGER, -- Último código HTTP recibido
+    ultimo_error TEXT,
+    fecha_creacion TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    fecha_ultimo_intento TIMESTAMP,
+    proximo_intento TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_entregas_pendientes ON WEBHOOK_ENTREGAS(estado, proximo_intento);
+CREATE INDEX idx_webhook_entregas_por_webhook ON WEBHOOK_ENTREGAS(webhook_id, fecha_creacion);
@@ -0,0 +1,8 @@
+-- Tokens secretos para las suscripciones de calendario (.ics) de estudiantes y tutores
+CREATE TABLE CALENDARIO_TOKENS (
+    tipo_usuario VARCHAR(20) NOT NULL CHECK (tipo_usuario IN ('estudiante', 'tutor')),
+    usuario_id INTEGER NOT NULL, -- estudiante_id o tutor_id según tipo_usuario
+    token VARCHAR(64) NOT NULL UNIQUE,
+    fecha_creacion TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tipo_usuario, usuario_id)
+);
@@ -0,0 +1,8 @@
+-- Última invitación de calendario (iTIP) enviada por tutoría. La secuencia se incrementa en
+-- cada envío para que los clientes de correo actualicen el evento en lugar de duplicarlo.
+CREATE TABLE TUTORIA_INVITACIONES (
+    tutoria_id INTEGER PRIMARY KEY REFERENCES TUTORIAS(tutoria_id) ON DELETE CASCADE,
+    secuencia INTEGER NOT NULL DEFAULT 0,
+    metodo VARCHAR(10) NOT NULL CHECK (metodo IN ('REQUEST', 'CANCEL')),
+    fecha_envio TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1 @@
+ALTER TABLE MATERIAS DROP COLUMN activo;
@@ -0,0 +1,2 @@
+-- Las materias retiradas del catálogo se desactivan en lugar de borrarse, para conservar sus tutorías
+ALTER TABLE MATERIAS ADD COLUMN activo BOOLEAN NOT NULL DEFAULT TRUE;
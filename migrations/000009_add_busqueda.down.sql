@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tutores_busqueda;
+DROP INDEX IF EXISTS idx_materias_busqueda;
+DROP FUNCTION IF EXISTS f_unaccent(text);
+DROP EXTENSION IF EXISTS pg_trgm;
+DROP EXTENSION IF EXISTS unaccent;
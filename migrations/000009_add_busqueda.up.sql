@@ -0,0 +1,14 @@
+-- Búsqueda de materias y tutores insensible a tildes y tolerante a errores de escritura
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- unaccent() es STABLE; esta envoltura IMMUTABLE permite usarla en índices
+CREATE OR REPLACE FUNCTION f_unaccent(text) RETURNS text
+LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+AS $$ SELECT public.unaccent('public.unaccent', $1) $$;
+
+CREATE INDEX idx_materias_busqueda ON MATERIAS
+USING gin (f_unaccent(lower(nombre || ' ' || codigo || ' ' || facultad || ' ' || COALESCE(descripcion, ''))) gin_trgm_ops);
+
+CREATE INDEX idx_tutores_busqueda ON TUTORES
+USING gin (f_unaccent(lower(nombre || ' ' || apellido)) gin_trgm_ops);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_reportes_pendientes;
+
+ALTER TABLE REPORTES
+    DROP COLUMN fecha_finalizacion,
+    DROP COLUMN fecha_inicio,
+    DROP COLUMN proximo_intento,
+    DROP COLUMN ultimo_error,
+    DROP COLUMN intentos,
+    DROP COLUMN progreso,
+    DROP COLUMN estado;
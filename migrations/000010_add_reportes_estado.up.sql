@@ -0,0 +1,13 @@
+-- Los reportes se generan en segundo plano: cada fila es también un trabajo en cola.
+-- Los reportes existentes ya están generados, de ahí los valores por defecto.
+ALTER TABLE REPORTES
+    ADD COLUMN estado VARCHAR(20) NOT NULL DEFAULT 'listo'
+        CHECK (estado IN ('pendiente', 'en_progreso', 'listo', 'fallido', 'cancelado')),
+    ADD COLUMN progreso INTEGER NOT NULL DEFAULT 100 CHECK (progreso BETWEEN 0 AND 100),
+    ADD COLUMN intentos INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN ultimo_error TEXT,
+    ADD COLUMN proximo_intento TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN fecha_inicio TIMESTAMP, -- Inicio del último intento
+    ADD COLUMN fecha_finalizacion TIMESTAMP;
+
+CREATE INDEX idx_reportes_pendientes ON REPORTES(estado, proximo_intento);
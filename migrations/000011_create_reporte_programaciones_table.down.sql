@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS uq_reportes_programacion_periodo;
+ALTER TABLE REPORTES DROP COLUMN programacion_id;
+DROP TABLE IF EXISTS REPORTE_PROGRAMACIONES;
@@ -0,0 +1,25 @@
+-- Programaciones de reportes recurrentes definidas por administradores
+CREATE TABLE REPORTE_PROGRAMACIONES (
+    programacion_id SERIAL PRIMARY KEY,
+    nombre VARCHAR(100) NOT NULL,
+    tipo_reporte VARCHAR(50) NOT NULL,
+    cron VARCHAR(100) NOT NULL, -- Expresión cron de 5 campos, p. ej. '0 7 * * 1'
+    periodo VARCHAR(20) NOT NULL CHECK (periodo IN ('dia_anterior', 'semana_anterior', 'mes_anterior')),
+    destinatarios TEXT[] NOT NULL DEFAULT '{}', -- Correos que reciben el reporte generado
+    adjuntar_pdf BOOLEAN NOT NULL DEFAULT FALSE, -- Adjuntar el PDF en lugar de solo enviar el enlace
+    activo BOOLEAN NOT NULL DEFAULT TRUE,
+    creado_por INTEGER NOT NULL REFERENCES ADMINS(admin_id),
+    proxima_ejecucion TIMESTAMP NOT NULL,
+    ultima_ejecucion TIMESTAMP,
+    fecha_creacion TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_reporte_programaciones_pendientes ON REPORTE_PROGRAMACIONES(activo, proxima_ejecucion);
+
+-- Reportes generados por una programación; cada periodo se genera una sola vez
+ALTER TABLE REPORTES
+    ADD COLUMN programacion_id INTEGER REFERENCES REPORTE_PROGRAMACIONES(programacion_id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX uq_reportes_programacion_periodo
+    ON REPORTES(programacion_id, periodo_inicio, periodo_fin)
+    WHERE programacion_id IS NOT NULL;
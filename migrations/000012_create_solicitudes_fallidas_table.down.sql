@@ -0,0 +1 @@
+DROP TABLE IF EXISTS SOLICITUDES_FALLIDAS;
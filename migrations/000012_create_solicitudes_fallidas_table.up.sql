@@ -0,0 +1,18 @@
+-- Intentos de reserva de tutorías que no se pudieron atender (demanda no satisfecha)
+CREATE TABLE SOLICITUDES_FALLIDAS (
+    solicitud_id SERIAL PRIMARY KEY,
+    estudiante_id INTEGER REFERENCES ESTUDIANTES(estudiante_id) ON DELETE SET NULL,
+    materia_id INTEGER NOT NULL REFERENCES MATERIAS(materia_id) ON DELETE CASCADE,
+    tutor_id INTEGER REFERENCES TUTORES(tutor_id) ON DELETE SET NULL, -- Tutor pedido explícitamente, si lo hubo
+    fecha DATE NOT NULL,
+    hora_inicio TIME NOT NULL,
+    hora_fin TIME NOT NULL,
+    motivo VARCHAR(30) NOT NULL CHECK (motivo IN (
+        'materia_inactiva', 'sin_tutores', 'sin_horario',
+        'tutor_no_calificado', 'tutor_ocupado', 'tutor_no_disponible'
+    )),
+    fecha_intento TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_solicitudes_fallidas_por_materia ON SOLICITUDES_FALLIDAS(materia_id, fecha);
+CREATE INDEX idx_solicitudes_fallidas_por_intento ON SOLICITUDES_FALLIDAS(fecha_intento);
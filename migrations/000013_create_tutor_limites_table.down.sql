@@ -0,0 +1,8 @@
+DELETE FROM SOLICITUDES_FALLIDAS WHERE motivo = 'tutor_sin_cupo';
+ALTER TABLE SOLICITUDES_FALLIDAS DROP CONSTRAINT solicitudes_fallidas_motivo_check;
+ALTER TABLE SOLICITUDES_FALLIDAS ADD CONSTRAINT solicitudes_fallidas_motivo_check CHECK (motivo IN (
+    'materia_inactiva', 'sin_tutores', 'sin_horario',
+    'tutor_no_calificado', 'tutor_ocupado', 'tutor_no_disponible'
+));
+
+DROP TABLE IF EXISTS TUTOR_LIMITES;
@@ -0,0 +1,14 @@
+-- Límites de carga horaria por tutor; un límite NULL significa que no hay tope en ese periodo
+CREATE TABLE TUTOR_LIMITES (
+    tutor_id INTEGER PRIMARY KEY REFERENCES TUTORES(tutor_id) ON DELETE CASCADE,
+    horas_semana INTEGER CHECK (horas_semana > 0),
+    horas_mes INTEGER CHECK (horas_mes > 0),
+    fecha_actualizacion TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Las reservas rechazadas porque el tutor alcanzó su límite también son demanda no satisfecha
+ALTER TABLE SOLICITUDES_FALLIDAS DROP CONSTRAINT solicitudes_fallidas_motivo_check;
+ALTER TABLE SOLICITUDES_FALLIDAS ADD CONSTRAINT solicitudes_fallidas_motivo_check CHECK (motivo IN (
+    'materia_inactiva', 'sin_tutores', 'sin_horario',
+    'tutor_no_calificado', 'tutor_ocupado', 'tutor_no_disponible', 'tutor_sin_cupo'
+));
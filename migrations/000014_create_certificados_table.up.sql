@@ -0,0 +1,17 @@
+-- Certificados de horas de tutoría emitidos a los tutores, verificables por su código
+CREATE TABLE CERTIFICADOS (
+    certificado_id SERIAL PRIMARY KEY,
+    codigo VARCHAR(20) NOT NULL UNIQUE, -- Código de verificación impreso en el PDF
+    tutor_id INTEGER REFERENCES TUTORES(tutor_id) ON DELETE SET NULL,
+    tutor_nombre VARCHAR(200) NOT NULL, -- Nombre del tutor al momento de la emisión
+    desde DATE NOT NULL,
+    hasta DATE NOT NULL,
+    total_tutorias INTEGER NOT NULL,
+    total_minutos INTEGER NOT NULL,
+    detalle JSONB NOT NULL, -- Tutorías y minutos por materia
+    firma VARCHAR(64) NOT NULL, -- HMAC-SHA256 del contenido certificado
+    fecha_emision TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (desde <= hasta)
+);
+
+CREATE INDEX idx_certificados_por_tutor ON CERTIFICADOS(tutor_id, desde, hasta);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS TUTOR_POSTULACIONES;
+DROP TABLE IF EXISTS MATERIA_REQUISITOS;
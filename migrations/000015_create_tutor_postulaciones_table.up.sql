@@ -0,0 +1,27 @@
+-- Reglas de elegibilidad para ser tutor de una materia; las materias sin fila no tienen requisitos
+-- de semestre ni de programa, pero sus postulaciones requieren aprobación
+CREATE TABLE MATERIA_REQUISITOS (
+    materia_id INTEGER PRIMARY KEY REFERENCES MATERIAS(materia_id) ON DELETE CASCADE,
+    semestre_minimo INTEGER CHECK (semestre_minimo > 0 AND semestre_minimo <= 12), -- NULL: cualquier semestre
+    programas TEXT[] NOT NULL DEFAULT '{}', -- Programas académicos admitidos; vacío: cualquiera
+    requiere_aprobacion BOOLEAN NOT NULL DEFAULT TRUE, -- Si es falso, las postulaciones elegibles se aprueban solas
+    fecha_actualizacion TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Postulaciones de tutores para impartir una materia; al aprobarse se crea la fila de TUTOR_MATERIAS
+CREATE TABLE TUTOR_POSTULACIONES (
+    postulacion_id SERIAL PRIMARY KEY,
+    tutor_id INTEGER NOT NULL REFERENCES TUTORES(tutor_id) ON DELETE CASCADE,
+    materia_id INTEGER NOT NULL REFERENCES MATERIAS(materia_id) ON DELETE CASCADE,
+    comentario TEXT,
+    estado VARCHAR(20) NOT NULL DEFAULT 'pendiente' CHECK (estado IN ('pendiente', 'aprobada', 'rechazada')),
+    motivo_rechazo TEXT,
+    revisado_por INTEGER REFERENCES ADMINS(admin_id) ON DELETE SET NULL, -- NULL si se aprobó automáticamente
+    fecha_postulacion TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    fecha_revision TIMESTAMP,
+    CHECK (estado != 'rechazada' OR motivo_rechazo IS NOT NULL)
+);
+
+-- Un tutor solo puede tener una postulación pendiente por materia
+CREATE UNIQUE INDEX uq_tutor_postulaciones_pendiente ON TUTOR_POSTULACIONES(tutor_id, materia_id) WHERE estado = 'pendiente';
+CREATE INDEX idx_tutor_postulaciones_por_estado ON TUTOR_POSTULACIONES(estado, fecha_postulacion);
@@ -0,0 +1,18 @@
+-- Solicitudes de estudiantes para convertirse en tutores; al aprobarse se crea la fila de TUTORES
+CREATE TABLE SOLICITUDES_TUTOR (
+    solicitud_id SERIAL PRIMARY KEY,
+    estudiante_id INTEGER NOT NULL REFERENCES ESTUDIANTES(estudiante_id) ON DELETE CASCADE,
+    motivacion TEXT NOT NULL,
+    materias INTEGER[] NOT NULL DEFAULT '{}', -- Materias que el estudiante quiere impartir
+    estado VARCHAR(20) NOT NULL DEFAULT 'pendiente' CHECK (estado IN ('pendiente', 'aprobada', 'rechazada')),
+    motivo_rechazo TEXT,
+    revisado_por INTEGER REFERENCES ADMINS(admin_id) ON DELETE SET NULL,
+    tutor_id INTEGER REFERENCES TUTORES(tutor_id) ON DELETE SET NULL, -- Tutor creado al aprobarse
+    fecha_solicitud TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    fecha_revision TIMESTAMP,
+    CHECK (estado != 'rechazada' OR motivo_rechazo IS NOT NULL)
+);
+
+-- Un estudiante solo puede tener una solicitud pendiente
+CREATE UNIQUE INDEX uq_solicitudes_tutor_pendiente ON SOLICITUDES_TUTOR(estudiante_id) WHERE estado = 'pendiente';
+CREATE INDEX idx_solicitudes_tutor_por_estado ON SOLICITUDES_TUTOR(estado, fecha_solicitud);
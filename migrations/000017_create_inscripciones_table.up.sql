@@ -0,0 +1,12 @@
+-- Inscripciones de estudiantes en materias por periodo académico (YYYY-1 o YYYY-2); un
+-- estudiante solo puede solicitar tutorías de las materias en que está inscrito en el periodo
+CREATE TABLE INSCRIPCIONES (
+    inscripcion_id SERIAL PRIMARY KEY,
+    estudiante_id INTEGER NOT NULL REFERENCES ESTUDIANTES(estudiante_id) ON DELETE CASCADE,
+    materia_id INTEGER NOT NULL REFERENCES MATERIAS(materia_id) ON DELETE CASCADE,
+    periodo VARCHAR(6) NOT NULL CHECK (periodo ~ '^[0-9]{4}-[12]$'),
+    fecha_inscripcion TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_inscripcion UNIQUE (estudiante_id, materia_id, periodo)
+);
+
+CREATE INDEX idx_inscripciones_por_materia ON INSCRIPCIONES(materia_id, periodo);
@@ -0,0 +1,15 @@
+-- Los registros que siguen en la papelera se eliminan, ya que no cabrían en las restricciones originales
+DELETE FROM ESTUDIANTES WHERE deleted_at IS NOT NULL;
+DELETE FROM TUTORES WHERE deleted_at IS NOT NULL;
+DELETE FROM MATERIAS WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS uq_estudiantes_correo;
+DROP INDEX IF EXISTS uq_tutores_correo;
+DROP INDEX IF EXISTS uq_materias_codigo;
+ALTER TABLE ESTUDIANTES ADD CONSTRAINT estudiantes_correo_key UNIQUE (correo);
+ALTER TABLE TUTORES ADD CONSTRAINT tutores_correo_key UNIQUE (correo);
+ALTER TABLE MATERIAS ADD CONSTRAINT materias_codigo_key UNIQUE (codigo);
+
+ALTER TABLE ESTUDIANTES DROP COLUMN deleted_at;
+ALTER TABLE TUTORES DROP COLUMN deleted_at;
+ALTER TABLE MATERIAS DROP COLUMN deleted_at;
@@ -0,0 +1,18 @@
+-- Los estudiantes, tutores y materias borrados pasan a la papelera en lugar de eliminarse, para
+-- conservar sus tutorías; se eliminan definitivamente al cumplirse el periodo de retención
+ALTER TABLE ESTUDIANTES ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE TUTORES ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE MATERIAS ADD COLUMN deleted_at TIMESTAMP;
+
+-- El correo y el código solo deben ser únicos entre los registros vigentes, de modo que uno
+-- borrado no impida volver a registrarlo
+ALTER TABLE ESTUDIANTES DROP CONSTRAINT estudiantes_correo_key;
+CREATE UNIQUE INDEX uq_estudiantes_correo ON ESTUDIANTES(correo) WHERE deleted_at IS NULL;
+ALTER TABLE TUTORES DROP CONSTRAINT tutores_correo_key;
+CREATE UNIQUE INDEX uq_tutores_correo ON TUTORES(correo) WHERE deleted_at IS NULL;
+ALTER TABLE MATERIAS DROP CONSTRAINT materias_codigo_key;
+CREATE UNIQUE INDEX uq_materias_codigo ON MATERIAS(codigo) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_estudiantes_papelera ON ESTUDIANTES(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_tutores_papelera ON TUTORES(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_materias_papelera ON MATERIAS(deleted_at) WHERE deleted_at IS NOT NULL;
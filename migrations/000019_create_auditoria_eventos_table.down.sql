@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS AUDITORIA_EVENTOS;
+DROP FUNCTION IF EXISTS f_auditoria_inmutable();
@@ -0,0 +1,35 @@
+-- Registro de auditoría de todas las llamadas que modifican datos (POST, PUT, PATCH y DELETE)
+CREATE TABLE AUDITORIA_EVENTOS (
+    evento_id SERIAL PRIMARY KEY,
+    admin_id INTEGER, -- Sin llave foránea: el registro sobrevive a la eliminación del admin
+    actor VARCHAR(100) NOT NULL, -- Correo del admin, o 'anonimo' si la llamada no traía token
+    metodo VARCHAR(10) NOT NULL,
+    ruta VARCHAR(255) NOT NULL,
+    entidad VARCHAR(50) NOT NULL,
+    entidad_id INTEGER,
+    estado_http INTEGER NOT NULL,
+    antes JSONB, -- Solo los campos que cambiaron, con su valor previo
+    despues JSONB, -- Solo los campos que cambiaron, con su valor nuevo
+    ip VARCHAR(45) NOT NULL,
+    fecha TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_auditoria_eventos_por_entidad ON AUDITORIA_EVENTOS(entidad, entidad_id, fecha);
+CREATE INDEX idx_auditoria_eventos_por_fecha ON AUDITORIA_EVENTOS(fecha);
+
+-- La tabla es de solo inserción: los eventos no se pueden modificar ni borrar
+CREATE OR REPLACE FUNCTION f_auditoria_inmutable() RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    RAISE EXCEPTION 'AUDITORIA_EVENTOS es de solo inserción';
+END;
+$$;
+
+CREATE TRIGGER trg_auditoria_eventos_inmutable
+BEFORE UPDATE OR DELETE ON AUDITORIA_EVENTOS
+FOR EACH ROW EXECUTE FUNCTION f_auditoria_inmutable();
+
+CREATE TRIGGER trg_auditoria_eventos_sin_truncate
+BEFORE TRUNCATE ON AUDITORIA_EVENTOS
+FOR EACH STATEMENT EXECUTE FUNCTION f_auditoria_inmutable();
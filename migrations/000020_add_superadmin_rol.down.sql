@@ -0,0 +1 @@
+UPDATE ADMINS SET rol = 'admin' WHERE rol = 'superadmin';
@@ -0,0 +1,5 @@
+-- Los admins se gestionan desde la API por superadmins; el admin más antiguo pasa a serlo
+-- si todavía no hay ninguno, para que alguien pueda administrar las cuentas
+UPDATE ADMINS SET rol = 'superadmin'
+WHERE admin_id = (SELECT MIN(admin_id) FROM ADMINS)
+  AND NOT EXISTS (SELECT 1 FROM ADMINS WHERE rol = 'superadmin');
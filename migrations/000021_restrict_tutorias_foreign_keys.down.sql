@@ -0,0 +1,7 @@
+ALTER TABLE TUTORIAS
+    DROP CONSTRAINT tutorias_estudiante_id_fkey,
+    ADD CONSTRAINT tutorias_estudiante_id_fkey FOREIGN KEY (estudiante_id) REFERENCES ESTUDIANTES(estudiante_id) ON DELETE CASCADE,
+    DROP CONSTRAINT tutorias_tutor_id_fkey,
+    ADD CONSTRAINT tutorias_tutor_id_fkey FOREIGN KEY (tutor_id) REFERENCES TUTORES(tutor_id) ON DELETE CASCADE,
+    DROP CONSTRAINT tutorias_materia_id_fkey,
+    ADD CONSTRAINT tutorias_materia_id_fkey FOREIGN KEY (materia_id) REFERENCES MATERIAS(materia_id) ON DELETE CASCADE;
@@ -0,0 +1,9 @@
+-- El historial de tutorías no se borra en cascada: un estudiante, tutor o materia con tutorías
+-- se queda en la papelera en lugar de eliminarse definitivamente
+ALTER TABLE TUTORIAS
+    DROP CONSTRAINT tutorias_estudiante_id_fkey,
+    ADD CONSTRAINT tutorias_estudiante_id_fkey FOREIGN KEY (estudiante_id) REFERENCES ESTUDIANTES(estudiante_id) ON DELETE RESTRICT,
+    DROP CONSTRAINT tutorias_tutor_id_fkey,
+    ADD CONSTRAINT tutorias_tutor_id_fkey FOREIGN KEY (tutor_id) REFERENCES TUTORES(tutor_id) ON DELETE RESTRICT,
+    DROP CONSTRAINT tutorias_materia_id_fkey,
+    ADD CONSTRAINT tutorias_materia_id_fkey FOREIGN KEY (materia_id) REFERENCES MATERIAS(materia_id) ON DELETE RESTRICT;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_materias_nombre_busqueda;
+DROP INDEX IF EXISTS idx_materias_busqueda_texto;
@@ -0,0 +1,7 @@
+-- Índices para las ramas de búsqueda que no cubría 000009: la búsqueda de texto completo
+-- en español sobre materias y la búsqueda de tutores por el nombre de las materias que dictan
+CREATE INDEX idx_materias_busqueda_texto ON MATERIAS
+USING gin (to_tsvector('spanish', f_unaccent(lower(nombre || ' ' || codigo || ' ' || facultad || ' ' || COALESCE(descripcion, '')))));
+
+CREATE INDEX idx_materias_nombre_busqueda ON MATERIAS
+USING gin (f_unaccent(lower(nombre)) gin_trgm_ops);
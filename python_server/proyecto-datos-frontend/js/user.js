@@ -38,12 +38,27 @@ function getUserData() {
     return userSession.user.data;
 }
 
+// Function to fetch every page of a paginated list, following the X-Next-Cursor header
+async function fetchAllPages(url) {
+    const items = [];
+    let cursor = null;
+    do {
+        const pageUrl = new URL(url, window.location.href);
+        pageUrl.searchParams.set('limit', '500');
+        if (cursor) pageUrl.searchParams.set('after', cursor);
+
+        const response = await fetch(pageUrl);
+        if (!response.ok) throw new Error(`Request failed with status ${response.status}`);
+        items.push(...await response.json());
+        cursor = response.headers.get('X-Next-Cursor');
+    } while (cursor);
+    return items;
+}
+
 // Function to load upcoming tutoring sessions from API
 async function loadUpcomingTutoringSessions(userId) {
     try {
-        const response = await fetch(`${API_BASE_URL}/tutorias?proximas_estudiante_id=${userId}`);
-        if (!response.ok) throw new Error('Failed to load upcoming tutoring sessions');
-        return await response.json();
+        return await fetchAllPages(`${API_BASE_URL}/tutorias?proximas_estudiante_id=${userId}`);
     } catch (error) {
         console.error('Error loading upcoming tutoring sessions:', error);
         return [];
@@ -53,19 +68,17 @@ async function loadUpcomingTutoringSessions(userId) {
 // Function to load all tutoring sessions for a student
 async function loadAllTutoringSessions(userId) {
     try {
-        const response = await fetch(`${API_BASE_URL}/tutorias?estudiante_id=${userId}`);
-        if (!response.ok) throw new Error('Failed to load tutoring sessions');
-        return await response.json();
+        return await fetchAllPages(`${API_BASE_URL}/tutorias?estudiante_id=${userId}`);
     } catch (error) {
         console.error('Error loading all tutoring sessions:', error);
         return [];
     }
 }
 
-// Function to load available subjects from API
-async function loadSubjects() {
+// Function to load the subjects the student is enrolled in this period from API
+async function loadSubjects(userId) {
     try {
-        const response = await fetch(`${API_BASE_URL}/materias?nombres=true`);
+        const response = await fetch(`${API_BASE_URL}/estudiantes/${userId}/materias`);
         if (!response.ok) throw new Error('Failed to load subjects');
         return await response.json();
     } catch (error) {
@@ -192,7 +205,7 @@ async function initializeUserData() {
 
     // Load subjects for the request form
     try {
-        const subjects = await loadSubjects();
+        const subjects = await loadSubjects(sessionData.currentUser.id);
         sessionData.subjects = subjects || [];
         console.log('Loaded subjects:', sessionData.subjects);
     } catch (error) {
@@ -663,6 +676,15 @@ function populateSolicitudMateriasDropdown() {
     dropdown.innerHTML = '';
     dropdown.appendChild(firstOption);
 
+    // Students can only request tutorias for the materias they are enrolled in
+    if (sessionData.subjects.length === 0) {
+        const option = document.createElement('option');
+        option.disabled = true;
+        option.textContent = 'No tienes materias inscritas en este periodo';
+        dropdown.appendChild(option);
+        return;
+    }
+
     // Add options for each materia from API
     sessionData.subjects.forEach(subject => {
         const option = document.createElement('option');
@@ -1293,8 +1315,8 @@ async function getTutorName(tutorId) {
 function GetIdFromName(materiaId) {
     if (!sessionData.subjects || !materiaId) return 'N/A';
     
-    const materia = sessionData.subjects.find(m => m.materia_id === materiaId || m.id === materiaId);
-    return materia ? (materia.nombre || materia.name || 'N/A') : 'N/A';
+    const materia = sessionData.subjects.find(m => m.MateriaID === materiaId || m.materia_id === materiaId || m.id === materiaId);
+    return materia ? (materia.Nombre || materia.nombre || materia.name || 'N/A') : 'N/A';
 }
 
 // Function to update user interface
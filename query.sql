@@ -8,31 +8,92 @@ VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING estudiante_id, nombre, apellido, correo, programa_academico, semestre, ti, fecha_registro;
 
 -- name: SelectEstudianteById :one
-SELECT * FROM ESTUDIANTES WHERE estudiante_id = $1;
+-- Deleted estudiantes are only reachable through the papelera queries.
+SELECT * FROM ESTUDIANTES WHERE estudiante_id = $1 AND deleted_at IS NULL;
 
 -- name: SelectEstudianteByCorreo :one
-SELECT * FROM ESTUDIANTES WHERE correo = $1;
+SELECT * FROM ESTUDIANTES WHERE correo = $1 AND deleted_at IS NULL;
 
 -- name: SelectEstudianteByTI :one
-SELECT * FROM ESTUDIANTES WHERE ti = $1;
+SELECT * FROM ESTUDIANTES WHERE ti = $1 AND deleted_at IS NULL;
 
 -- name: UpdateEstudiante :one
 UPDATE ESTUDIANTES 
 SET nombre = $2, apellido = $3, correo = $4, programa_academico = $5, semestre = $6, ti = $7
-WHERE estudiante_id = $1
+WHERE estudiante_id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: SoftDeleteEstudiante :execrows
+-- Moves the estudiante to the papelera, keeping their tutorias and inscripciones.
+UPDATE ESTUDIANTES SET deleted_at = CURRENT_TIMESTAMP
+WHERE estudiante_id = $1 AND deleted_at IS NULL;
+
+-- name: RestoreEstudiante :one
+UPDATE ESTUDIANTES SET deleted_at = NULL
+WHERE estudiante_id = $1 AND deleted_at IS NOT NULL
 RETURNING *;
 
--- name: DeleteEstudiante :exec
-DELETE FROM ESTUDIANTES WHERE estudiante_id = $1;
+-- name: SelectEstudianteEliminado :one
+SELECT * FROM ESTUDIANTES WHERE estudiante_id = $1 AND deleted_at IS NOT NULL;
+
+-- name: SelectEstudianteByIdConEliminados :one
+-- Includes estudiantes moved to the papelera, for records that must still name them.
+SELECT * FROM ESTUDIANTES WHERE estudiante_id = $1;
+
+-- name: PurgeEstudiantes :execrows
+-- Permanently deletes the estudiantes moved to the papelera before the cutoff. Estudiantes with
+-- tutorias stay in the papelera, so that the tutoria history is never lost.
+DELETE FROM ESTUDIANTES e
+WHERE e.deleted_at < sqlc.arg('antes')::timestamp
+  AND NOT EXISTS (SELECT 1 FROM TUTORIAS t WHERE t.estudiante_id = e.estudiante_id);
 
 -- name: ListEstudiantes :many
-SELECT * FROM ESTUDIANTES ORDER BY apellido, nombre;
+-- Keyset pagination: k.valor is the sort key as text, and rows come after the
+-- (after_valor, after_id) cursor in the requested direction.
+SELECT e.* FROM ESTUDIANTES e
+CROSS JOIN LATERAL (
+    SELECT CASE sqlc.arg('sort')::text
+        WHEN 'nombre' THEN e.nombre || ' ' || e.apellido
+        WHEN 'correo' THEN e.correo
+        WHEN 'programa_academico' THEN e.programa_academico
+        WHEN 'semestre' THEN lpad(COALESCE(e.semestre, 0)::text, 10, '0')
+        WHEN 'fecha_registro' THEN COALESCE(to_char(e.fecha_registro, 'YYYY-MM-DD HH24:MI:SS.US'), '')
+        WHEN 'id' THEN lpad(e.estudiante_id::text, 10, '0')
+        ELSE e.apellido || ' ' || e.nombre
+    END AS valor
+) k
+WHERE e.deleted_at IS NULL AND (sqlc.narg('after_id')::int IS NULL
+    OR (NOT sqlc.arg('descending')::bool AND (k.valor, e.estudiante_id) > (sqlc.narg('after_valor')::text, sqlc.narg('after_id')::int))
+    OR (sqlc.arg('descending')::bool AND (k.valor, e.estudiante_id) < (sqlc.narg('after_valor')::text, sqlc.narg('after_id')::int)))
+ORDER BY
+    CASE WHEN NOT sqlc.arg('descending')::bool THEN k.valor END,
+    CASE WHEN sqlc.arg('descending')::bool THEN k.valor END DESC,
+    CASE WHEN NOT sqlc.arg('descending')::bool THEN e.estudiante_id END,
+    e.estudiante_id DESC
+LIMIT sqlc.arg('row_limit');
+
+-- name: CountEstudiantes :one
+SELECT COUNT(*) FROM ESTUDIANTES WHERE deleted_at IS NULL;
+
+-- name: ListEstudianteCorreos :many
+SELECT estudiante_id, correo FROM ESTUDIANTES WHERE deleted_at IS NULL;
 
 -- name: ListEstudiantesByPrograma :many
-SELECT * FROM ESTUDIANTES WHERE programa_academico = $1 ORDER BY apellido, nombre;
+SELECT * FROM ESTUDIANTES WHERE programa_academico = $1 AND deleted_at IS NULL ORDER BY apellido, nombre;
 
 -- name: LoginEstudiante :one
-SELECT * FROM ESTUDIANTES WHERE correo = $1 AND ti = $2;
+SELECT * FROM ESTUDIANTES WHERE correo = $1 AND ti = $2 AND deleted_at IS NULL;
+
+-- name: UpsertEstudianteByCorreo :one
+INSERT INTO ESTUDIANTES (nombre, apellido, correo, programa_academico, semestre, ti)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (correo) WHERE deleted_at IS NULL DO UPDATE SET
+    nombre = EXCLUDED.nombre,
+    apellido = EXCLUDED.apellido,
+    programa_academico = EXCLUDED.programa_academico,
+    semestre = EXCLUDED.semestre,
+    ti = EXCLUDED.ti
+RETURNING estudiante_id, (xmax = 0) AS creado;
 
 -- ========================================
 -- TUTORES QUERIES  
@@ -41,28 +102,72 @@ SELECT * FROM ESTUDIANTES WHERE correo = $1 AND ti = $2;
 -- name: CreateTutor :one
 INSERT INTO TUTORES (nombre, apellido, correo, programa_academico)
 VALUES ($1, $2, $3, $4)
-RETURNING tutor_id, nombre, apellido, correo, programa_academico, fecha_registro;
+RETURNING tutor_id, nombre, apellido, correo, programa_academico, fecha_registro, deleted_at;
 
 -- name: SelectTutorById :one
-SELECT * FROM TUTORES WHERE tutor_id = $1;
+-- Deleted tutores are only reachable through the papelera queries.
+SELECT * FROM TUTORES WHERE tutor_id = $1 AND deleted_at IS NULL;
 
 -- name: SelectTutorByCorreo :one
-SELECT * FROM TUTORES WHERE correo = $1;
+SELECT * FROM TUTORES WHERE correo = $1 AND deleted_at IS NULL;
 
 -- name: UpdateTutor :one
 UPDATE TUTORES 
 SET nombre = $2, apellido = $3, correo = $4, programa_academico = $5
-WHERE tutor_id = $1
+WHERE tutor_id = $1 AND deleted_at IS NULL
 RETURNING *;
 
--- name: DeleteTutor :exec
-DELETE FROM TUTORES WHERE tutor_id = $1;
+-- name: SoftDeleteTutor :execrows
+-- Moves the tutor to the papelera, keeping their tutorias, availability and materias.
+UPDATE TUTORES SET deleted_at = CURRENT_TIMESTAMP
+WHERE tutor_id = $1 AND deleted_at IS NULL;
+
+-- name: RestoreTutor :one
+UPDATE TUTORES SET deleted_at = NULL
+WHERE tutor_id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: SelectTutorEliminado :one
+SELECT * FROM TUTORES WHERE tutor_id = $1 AND deleted_at IS NOT NULL;
+
+-- name: SelectTutorByIdConEliminados :one
+-- Includes tutores moved to the papelera, for records that must still name them.
+SELECT * FROM TUTORES WHERE tutor_id = $1;
+
+-- name: PurgeTutores :execrows
+-- Permanently deletes the tutores moved to the papelera before the cutoff, along with their
+-- availability and materias. Tutores with tutorias stay in the papelera.
+DELETE FROM TUTORES tu
+WHERE tu.deleted_at < sqlc.arg('antes')::timestamp
+  AND NOT EXISTS (SELECT 1 FROM TUTORIAS t WHERE t.tutor_id = tu.tutor_id);
 
 -- name: ListTutores :many
-SELECT * FROM TUTORES ORDER BY apellido, nombre;
+SELECT t.* FROM TUTORES t
+CROSS JOIN LATERAL (
+    SELECT CASE sqlc.arg('sort')::text
+        WHEN 'nombre' THEN t.nombre || ' ' || t.apellido
+        WHEN 'correo' THEN t.correo
+        WHEN 'programa_academico' THEN COALESCE(t.programa_academico, '')
+        WHEN 'fecha_registro' THEN COALESCE(to_char(t.fecha_registro, 'YYYY-MM-DD HH24:MI:SS.US'), '')
+        WHEN 'id' THEN lpad(t.tutor_id::text, 10, '0')
+        ELSE t.apellido || ' ' || t.nombre
+    END AS valor
+) k
+WHERE t.deleted_at IS NULL AND (sqlc.narg('after_id')::int IS NULL
+    OR (NOT sqlc.arg('descending')::bool AND (k.valor, t.tutor_id) > (sqlc.narg('after_valor')::text, sqlc.narg('after_id')::int))
+    OR (sqlc.arg('descending')::bool AND (k.valor, t.tutor_id) < (sqlc.narg('after_valor')::text, sqlc.narg('after_id')::int)))
+ORDER BY
+    CASE WHEN NOT sqlc.arg('descending')::bool THEN k.valor END,
+    CASE WHEN sqlc.arg('descending')::bool THEN k.valor END DESC,
+    CASE WHEN NOT sqlc.arg('descending')::bool THEN t.tutor_id END,
+    t.tutor_id DESC
+LIMIT sqlc.arg('row_limit');
+
+-- name: CountTutores :one
+SELECT COUNT(*) FROM TUTORES WHERE deleted_at IS NULL;
 
 -- name: LoginTutor :one
-SELECT * FROM TUTORES WHERE correo = $1;
+SELECT * FROM TUTORES WHERE correo = $1 AND deleted_at IS NULL;
 
 -- ========================================
 -- ADMINS QUERIES
@@ -101,6 +206,14 @@ SELECT admin_id, nombre, apellido, correo, rol, activo, fecha_registro FROM ADMI
 SELECT admin_id, nombre, apellido, correo, password_hash, rol, activo, fecha_registro FROM ADMINS
 WHERE correo = $1;
 
+-- name: LockSuperadminsActivos :many
+-- Locks the active superadmins until the end of the transaction, so that concurrent changes
+-- cannot remove the last one.
+SELECT admin_id FROM ADMINS
+WHERE rol = 'superadmin' AND activo IS NOT FALSE
+ORDER BY admin_id
+FOR UPDATE;
+
 -- ========================================
 -- MATERIAS QUERIES
 -- ========================================
@@ -111,31 +224,90 @@ VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: SelectMateriaById :one
-SELECT * FROM MATERIAS WHERE materia_id = $1;
+-- Deleted materias are only reachable through the papelera queries.
+SELECT * FROM MATERIAS WHERE materia_id = $1 AND deleted_at IS NULL;
 
 -- name: SelectMateriaByCodigo :one
-SELECT * FROM MATERIAS WHERE codigo = $1;
+SELECT * FROM MATERIAS WHERE codigo = $1 AND deleted_at IS NULL;
 
 -- name: UpdateMateria :one
 UPDATE MATERIAS 
 SET nombre = $2, codigo = $3, facultad = $4, descripcion = $5, creditos = $6
-WHERE materia_id = $1
+WHERE materia_id = $1 AND deleted_at IS NULL
 RETURNING *;
 
--- name: DeleteMateria :exec
-DELETE FROM MATERIAS WHERE materia_id = $1;
+-- name: SoftDeleteMateria :execrows
+-- Moves the materia to the papelera, keeping its tutorias and tutor assignments.
+UPDATE MATERIAS SET deleted_at = CURRENT_TIMESTAMP
+WHERE materia_id = $1 AND deleted_at IS NULL;
+
+-- name: RestoreMateria :one
+UPDATE MATERIAS SET deleted_at = NULL
+WHERE materia_id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: SelectMateriaEliminada :one
+SELECT * FROM MATERIAS WHERE materia_id = $1 AND deleted_at IS NOT NULL;
+
+-- name: SelectMateriaByIdConEliminadas :one
+-- Includes materias moved to the papelera, for records that must still name them.
+SELECT * FROM MATERIAS WHERE materia_id = $1;
+
+-- name: PurgeMaterias :execrows
+-- Permanently deletes the materias moved to the papelera before the cutoff, along with their
+-- tutor assignments. Materias with tutorias or failed requests stay in the papelera, so the
+-- demanda insatisfecha history is not lost with them.
+DELETE FROM MATERIAS m
+WHERE m.deleted_at < sqlc.arg('antes')::timestamp
+  AND NOT EXISTS (SELECT 1 FROM TUTORIAS t WHERE t.materia_id = m.materia_id)
+  AND NOT EXISTS (SELECT 1 FROM SOLICITUDES_FALLIDAS sf WHERE sf.materia_id = m.materia_id);
 
 -- name: ListMaterias :many
-SELECT * FROM MATERIAS ORDER BY codigo;
+SELECT m.* FROM MATERIAS m
+CROSS JOIN LATERAL (
+    SELECT CASE sqlc.arg('sort')::text
+        WHEN 'nombre' THEN m.nombre
+        WHEN 'facultad' THEN m.facultad
+        WHEN 'creditos' THEN lpad(m.creditos::text, 10, '0')
+        WHEN 'id' THEN lpad(m.materia_id::text, 10, '0')
+        ELSE m.codigo
+    END AS valor
+) k
+WHERE m.deleted_at IS NULL AND (sqlc.narg('after_id')::int IS NULL
+    OR (NOT sqlc.arg('descending')::bool AND (k.valor, m.materia_id) > (sqlc.narg('after_valor')::text, sqlc.narg('after_id')::int))
+    OR (sqlc.arg('descending')::bool AND (k.valor, m.materia_id) < (sqlc.narg('after_valor')::text, sqlc.narg('after_id')::int)))
+ORDER BY
+    CASE WHEN NOT sqlc.arg('descending')::bool THEN k.valor END,
+    CASE WHEN sqlc.arg('descending')::bool THEN k.valor END DESC,
+    CASE WHEN NOT sqlc.arg('descending')::bool THEN m.materia_id END,
+    m.materia_id DESC
+LIMIT sqlc.arg('row_limit');
+
+-- name: CountMaterias :one
+SELECT COUNT(*) FROM MATERIAS WHERE deleted_at IS NULL;
+
+-- name: ListMateriasCatalogo :many
+-- Whole catalog, active or not, for imports and onboarding. Deleted materias are left out.
+SELECT * FROM MATERIAS WHERE deleted_at IS NULL ORDER BY codigo;
 
 -- name: ListMateriasByFacultad :many
-SELECT * FROM MATERIAS WHERE facultad = $1 ORDER BY codigo;
+SELECT * FROM MATERIAS WHERE facultad = $1 AND deleted_at IS NULL ORDER BY codigo;
 
 -- name: ListMateriaNombres :many
-SELECT materia_id, nombre, codigo FROM MATERIAS ORDER BY codigo;
+SELECT materia_id, nombre, codigo FROM MATERIAS WHERE activo = true AND deleted_at IS NULL ORDER BY codigo;
 
 -- name: GetMateriaIdByName :one
-SELECT materia_id FROM MATERIAS WHERE nombre = $1;
+SELECT materia_id FROM MATERIAS WHERE nombre = $1 AND deleted_at IS NULL;
+
+-- name: UpdateMateriaByCodigo :one
+UPDATE MATERIAS
+SET nombre = $2, facultad = $3, descripcion = $4, creditos = $5, activo = true
+WHERE codigo = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: SetMateriaActivo :one
+UPDATE MATERIAS SET activo = $2 WHERE materia_id = $1 AND deleted_at IS NULL
+RETURNING *;
 
 -- ========================================
 -- TUTOR_MATERIAS QUERIES
@@ -162,16 +334,25 @@ DELETE FROM TUTOR_MATERIAS WHERE asignacion_id = $1;
 SELECT tm.*, m.nombre as materia_nombre, m.codigo as materia_codigo
 FROM TUTOR_MATERIAS tm
 JOIN MATERIAS m ON tm.materia_id = m.materia_id
-WHERE tm.tutor_id = $1 AND tm.activo = true
+WHERE tm.tutor_id = $1 AND tm.activo = true AND m.deleted_at IS NULL
 ORDER BY m.codigo;
 
 -- name: ListTutoresByMateria :many
 SELECT tm.*, t.nombre as tutor_nombre, t.apellido as tutor_apellido
 FROM TUTOR_MATERIAS tm
 JOIN TUTORES t ON tm.tutor_id = t.tutor_id
-WHERE tm.materia_id = $1 AND tm.activo = true
+WHERE tm.materia_id = $1 AND tm.activo = true AND t.deleted_at IS NULL
 ORDER BY t.apellido, t.nombre;
 
+-- name: UpsertTutorMateria :one
+-- Assigns a materia to a tutor, reactivating an inactive assignment. Returns no rows when
+-- the assignment is already active.
+INSERT INTO TUTOR_MATERIAS (tutor_id, materia_id, fecha_asignacion, activo)
+VALUES ($1, $2, CURRENT_DATE, true)
+ON CONFLICT (tutor_id, materia_id) DO UPDATE SET activo = true, fecha_asignacion = CURRENT_DATE
+WHERE TUTOR_MATERIAS.activo = false
+RETURNING asignacion_id, tutor_id, materia_id, fecha_asignacion, activo, (xmax = 0) AS creado;
+
 -- ========================================
 -- DISPONIBILIDAD QUERIES
 -- ========================================
@@ -249,15 +430,49 @@ JOIN MATERIAS m ON t.materia_id = m.materia_id
 WHERE t.tutor_id = $1
 ORDER BY t.fecha DESC, t.hora_inicio DESC;
 
--- name: ListTutoriasByEstado :many
+-- name: SearchTutorias :many
+-- Every filter is optional: a NULL argument matches all tutorias.
 SELECT t.*, e.nombre as estudiante_nombre, e.apellido as estudiante_apellido, 
        tu.nombre as tutor_nombre, tu.apellido as tutor_apellido, m.nombre as materia_nombre
 FROM TUTORIAS t
 JOIN ESTUDIANTES e ON t.estudiante_id = e.estudiante_id
 JOIN TUTORES tu ON t.tutor_id = tu.tutor_id
 JOIN MATERIAS m ON t.materia_id = m.materia_id
-WHERE t.estado = $1
-ORDER BY t.fecha DESC, t.hora_inicio DESC;
+CROSS JOIN LATERAL (
+    SELECT CASE sqlc.arg('sort')::text
+        WHEN 'fecha_solicitud' THEN COALESCE(to_char(t.fecha_solicitud, 'YYYY-MM-DD HH24:MI:SS.US'), '')
+        WHEN 'id' THEN lpad(t.tutoria_id::text, 10, '0')
+        ELSE COALESCE(to_char(t.fecha + t.hora_inicio, 'YYYY-MM-DD HH24:MI:SS'), '')
+    END AS valor
+) k
+WHERE (sqlc.narg('estudiante_id')::int IS NULL OR t.estudiante_id = sqlc.narg('estudiante_id')::int)
+  AND (sqlc.narg('tutor_id')::int IS NULL OR t.tutor_id = sqlc.narg('tutor_id')::int)
+  AND (sqlc.narg('materia_id')::int IS NULL OR t.materia_id = sqlc.narg('materia_id')::int)
+  AND (sqlc.narg('estados')::text[] IS NULL OR t.estado = ANY(sqlc.narg('estados')::text[]))
+  AND (sqlc.narg('fecha_desde')::date IS NULL OR t.fecha >= sqlc.narg('fecha_desde')::date)
+  AND (sqlc.narg('fecha_hasta')::date IS NULL OR t.fecha <= sqlc.narg('fecha_hasta')::date)
+  AND (sqlc.narg('lugar')::text IS NULL OR t.lugar ILIKE '%' || replace(replace(replace(sqlc.narg('lugar')::text, '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND (sqlc.narg('asistencia')::bool IS NULL OR t.asistencia_confirmada = sqlc.narg('asistencia')::bool)
+  AND (sqlc.narg('after_id')::int IS NULL
+    OR (NOT sqlc.arg('descending')::bool AND (k.valor, t.tutoria_id) > (sqlc.narg('after_valor')::text, sqlc.narg('after_id')::int))
+    OR (sqlc.arg('descending')::bool AND (k.valor, t.tutoria_id) < (sqlc.narg('after_valor')::text, sqlc.narg('after_id')::int)))
+ORDER BY
+    CASE WHEN NOT sqlc.arg('descending')::bool THEN k.valor END,
+    CASE WHEN sqlc.arg('descending')::bool THEN k.valor END DESC,
+    CASE WHEN NOT sqlc.arg('descending')::bool THEN t.tutoria_id END,
+    t.tutoria_id DESC
+LIMIT sqlc.arg('row_limit');
+
+-- name: CountTutorias :one
+SELECT COUNT(*) FROM TUTORIAS t
+WHERE (sqlc.narg('estudiante_id')::int IS NULL OR t.estudiante_id = sqlc.narg('estudiante_id')::int)
+  AND (sqlc.narg('tutor_id')::int IS NULL OR t.tutor_id = sqlc.narg('tutor_id')::int)
+  AND (sqlc.narg('materia_id')::int IS NULL OR t.materia_id = sqlc.narg('materia_id')::int)
+  AND (sqlc.narg('estados')::text[] IS NULL OR t.estado = ANY(sqlc.narg('estados')::text[]))
+  AND (sqlc.narg('fecha_desde')::date IS NULL OR t.fecha >= sqlc.narg('fecha_desde')::date)
+  AND (sqlc.narg('fecha_hasta')::date IS NULL OR t.fecha <= sqlc.narg('fecha_hasta')::date)
+  AND (sqlc.narg('lugar')::text IS NULL OR t.lugar ILIKE '%' || replace(replace(replace(sqlc.narg('lugar')::text, '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND (sqlc.narg('asistencia')::bool IS NULL OR t.asistencia_confirmada = sqlc.narg('asistencia')::bool);
 
 -- name: ListTutoriasActivas :many
 SELECT * FROM tutoriasActivas ORDER BY fecha, hora_inicio;
@@ -274,17 +489,31 @@ RETURNING *;
 -- name: SelectReporteById :one
 SELECT * FROM REPORTES WHERE reporte_id = $1;
 
--- name: UpdateReporte :one
-UPDATE REPORTES 
-SET datos = $2
-WHERE reporte_id = $1
-RETURNING *;
-
 -- name: DeleteReporte :exec
 DELETE FROM REPORTES WHERE reporte_id = $1;
 
 -- name: ListReportes :many
-SELECT * FROM REPORTES ORDER BY fecha_generacion DESC;
+SELECT r.* FROM REPORTES r
+CROSS JOIN LATERAL (
+    SELECT CASE sqlc.arg('sort')::text
+        WHEN 'tipo_reporte' THEN r.tipo_reporte
+        WHEN 'periodo_inicio' THEN COALESCE(to_char(r.periodo_inicio, 'YYYY-MM-DD'), '')
+        WHEN 'id' THEN lpad(r.reporte_id::text, 10, '0')
+        ELSE COALESCE(to_char(r.fecha_generacion, 'YYYY-MM-DD HH24:MI:SS.US'), '')
+    END AS valor
+) k
+WHERE sqlc.narg('after_id')::int IS NULL
+    OR (NOT sqlc.arg('descending')::bool AND (k.valor, r.reporte_id) > (sqlc.narg('after_valor')::text, sqlc.narg('after_id')::int))
+    OR (sqlc.arg('descending')::bool AND (k.valor, r.reporte_id) < (sqlc.narg('after_valor')::text, sqlc.narg('after_id')::int))
+ORDER BY
+    CASE WHEN NOT sqlc.arg('descending')::bool THEN k.valor END,
+    CASE WHEN sqlc.arg('descending')::bool THEN k.valor END DESC,
+    CASE WHEN NOT sqlc.arg('descending')::bool THEN r.reporte_id END,
+    r.reporte_id DESC
+LIMIT sqlc.arg('row_limit');
+
+-- name: CountReportes :one
+SELECT COUNT(*) FROM REPORTES;
 
 -- name: ListReportesByTipo :many
 SELECT * FROM REPORTES WHERE tipo_reporte = $1 ORDER BY fecha_generacion DESC;
@@ -294,6 +523,172 @@ SELECT * FROM REPORTES
 WHERE periodo_inicio >= $1 AND periodo_fin <= $2 
 ORDER BY fecha_generacion DESC;
 
+-- name: EnqueueReporte :one
+INSERT INTO REPORTES (tipo_reporte, periodo_inicio, periodo_fin, generado_por, estado, progreso)
+VALUES ($1, $2, $3, $4, 'pendiente', 0)
+RETURNING *;
+
+-- name: EnqueueReporteProgramado :one
+-- Returns no rows when the programacion already has a reporte for the period.
+INSERT INTO REPORTES (tipo_reporte, periodo_inicio, periodo_fin, generado_por, programacion_id, estado, progreso)
+VALUES ($1, $2, $3, $4, $5, 'pendiente', 0)
+ON CONFLICT (programacion_id, periodo_inicio, periodo_fin) WHERE programacion_id IS NOT NULL DO NOTHING
+RETURNING *;
+
+-- name: ClaimReportePendiente :one
+-- Takes the next due job; SKIP LOCKED lets several workers (and servers) share the queue.
+UPDATE REPORTES
+SET estado = 'en_progreso', progreso = 0, intentos = intentos + 1, fecha_inicio = CURRENT_TIMESTAMP
+WHERE reporte_id = (
+    SELECT reporte_id FROM REPORTES
+    WHERE estado = 'pendiente' AND proximo_intento <= CURRENT_TIMESTAMP
+    ORDER BY proximo_intento, reporte_id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: SelectReporteEstado :one
+SELECT estado FROM REPORTES WHERE reporte_id = $1;
+
+-- name: UpdateReporteProgreso :exec
+UPDATE REPORTES SET progreso = $2
+WHERE reporte_id = $1 AND estado = 'en_progreso';
+
+-- name: CompleteReporte :execrows
+UPDATE REPORTES
+SET estado = 'listo', progreso = 100, datos = $2, ultimo_error = NULL, fecha_finalizacion = CURRENT_TIMESTAMP
+WHERE reporte_id = $1 AND estado = 'en_progreso';
+
+-- name: FailReporte :execrows
+-- estado is 'pendiente' to retry espera_segundos from now, on the database clock, or 'fallido'
+-- when attempts are exhausted.
+UPDATE REPORTES
+SET estado = sqlc.arg('estado'), ultimo_error = sqlc.arg('ultimo_error'),
+    proximo_intento = CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg('espera_segundos')::float8),
+    fecha_finalizacion = CASE WHEN sqlc.arg('estado') = 'fallido' THEN CURRENT_TIMESTAMP END
+WHERE reporte_id = sqlc.arg('reporte_id') AND estado = 'en_progreso';
+
+-- name: CancelReporte :one
+UPDATE REPORTES
+SET estado = 'cancelado', fecha_finalizacion = CURRENT_TIMESTAMP
+WHERE reporte_id = $1 AND estado IN ('pendiente', 'en_progreso')
+RETURNING *;
+
+-- name: RetryReporte :one
+UPDATE REPORTES
+SET estado = 'pendiente', progreso = 0, intentos = 0, ultimo_error = NULL,
+    proximo_intento = CURRENT_TIMESTAMP, fecha_finalizacion = NULL
+WHERE reporte_id = $1 AND estado IN ('fallido', 'cancelado')
+RETURNING *;
+
+-- name: RequeueReportesEstancados :execrows
+-- Jobs left en_progreso by a worker that stopped (e.g. a restart) go back to the queue.
+-- Their age is measured on the database clock, which also set fecha_inicio.
+UPDATE REPORTES
+SET estado = 'pendiente', proximo_intento = CURRENT_TIMESTAMP
+WHERE estado = 'en_progreso' AND fecha_inicio < CURRENT_TIMESTAMP - make_interval(secs => sqlc.arg('antiguedad_segundos')::float8);
+
+-- name: ReporteDesempenoTutores :many
+-- Same figures as the desempenoTutores view, restricted to tutorias of the period.
+SELECT 
+    tu.tutor_id,
+    (tu.nombre || ' ' || tu.apellido)::text AS tutor,
+    m.nombre AS materia,
+    COUNT(t.tutoria_id) AS total_tutorias,
+    SUM(CASE WHEN t.asistencia_confirmada THEN 1 ELSE 0 END) AS tutorias_completadas,
+    SUM(CASE WHEN t.estado = 'cancelada' THEN 1 ELSE 0 END) AS tutorias_canceladas,
+    COALESCE(ROUND(SUM(CASE WHEN t.asistencia_confirmada THEN 1 ELSE 0 END)::numeric / 
+          NULLIF(COUNT(t.tutoria_id), 0)::numeric * 100, 2), 0)::float8 AS porcentaje_asistencia
+FROM TUTORES tu
+JOIN TUTORIAS t ON tu.tutor_id = t.tutor_id
+JOIN MATERIAS m ON t.materia_id = m.materia_id
+WHERE t.fecha BETWEEN sqlc.arg('periodo_inicio') AND sqlc.arg('periodo_fin')
+GROUP BY tu.tutor_id, tu.nombre, tu.apellido, m.nombre
+ORDER BY tutor, materia;
+
+-- name: ReporteUsoMaterias :many
+-- Demand and hours of tutoring per active materia, including materias without tutorias.
+SELECT 
+    m.materia_id,
+    m.codigo,
+    m.nombre,
+    m.facultad,
+    COUNT(t.tutoria_id) AS total_tutorias,
+    COUNT(DISTINCT t.estudiante_id) AS estudiantes,
+    COUNT(DISTINCT t.tutor_id) AS tutores,
+    SUM(CASE WHEN t.estado = 'completada' THEN 1 ELSE 0 END) AS tutorias_completadas,
+    SUM(CASE WHEN t.estado = 'cancelada' THEN 1 ELSE 0 END) AS tutorias_canceladas,
+    COALESCE(SUM(EXTRACT(EPOCH FROM (t.hora_fin - t.hora_inicio)) / 3600)
+        FILTER (WHERE t.estado <> 'cancelada'), 0)::float8 AS horas
+FROM MATERIAS m
+LEFT JOIN TUTORIAS t ON t.materia_id = m.materia_id
+    AND t.fecha BETWEEN sqlc.arg('periodo_inicio') AND sqlc.arg('periodo_fin')
+WHERE m.activo = true
+GROUP BY m.materia_id, m.codigo, m.nombre, m.facultad
+ORDER BY total_tutorias DESC, m.codigo;
+
+-- name: ReporteAsistenciaProgramas :many
+-- Attendance of non-cancelled tutorias per academic program of the estudiante.
+SELECT 
+    e.programa_academico,
+    COUNT(DISTINCT e.estudiante_id) AS estudiantes,
+    COUNT(t.tutoria_id) AS total_tutorias,
+    SUM(CASE WHEN t.asistencia_confirmada THEN 1 ELSE 0 END) AS asistencias,
+    SUM(CASE WHEN NOT t.asistencia_confirmada THEN 1 ELSE 0 END) AS inasistencias,
+    SUM(CASE WHEN t.asistencia_confirmada IS NULL THEN 1 ELSE 0 END) AS sin_registro,
+    COALESCE(ROUND(SUM(CASE WHEN t.asistencia_confirmada THEN 1 ELSE 0 END)::numeric /
+          NULLIF(COUNT(t.asistencia_confirmada), 0)::numeric * 100, 2), 0)::float8 AS porcentaje_asistencia
+FROM TUTORIAS t
+JOIN ESTUDIANTES e ON t.estudiante_id = e.estudiante_id
+WHERE t.fecha BETWEEN sqlc.arg('periodo_inicio') AND sqlc.arg('periodo_fin')
+  AND t.estado <> 'cancelada'
+GROUP BY e.programa_academico
+ORDER BY e.programa_academico;
+
+-- name: ReporteCancelaciones :many
+-- Cancelled tutorias of the period, most recent first.
+SELECT 
+    t.tutoria_id,
+    t.fecha,
+    t.hora_inicio,
+    t.fecha_solicitud,
+    t.lugar,
+    (e.nombre || ' ' || e.apellido)::text AS estudiante,
+    e.programa_academico,
+    (tu.nombre || ' ' || tu.apellido)::text AS tutor,
+    m.codigo AS materia_codigo,
+    m.nombre AS materia
+FROM TUTORIAS t
+JOIN ESTUDIANTES e ON t.estudiante_id = e.estudiante_id
+JOIN TUTORES tu ON t.tutor_id = tu.tutor_id
+JOIN MATERIAS m ON t.materia_id = m.materia_id
+WHERE t.fecha BETWEEN sqlc.arg('periodo_inicio') AND sqlc.arg('periodo_fin')
+  AND t.estado = 'cancelada'
+ORDER BY t.fecha DESC, t.hora_inicio DESC;
+
+-- name: ReporteDemandaInsatisfecha :many
+-- Failed booking attempts per materia for the dates within the period, most unmet demand first.
+SELECT
+    m.materia_id,
+    m.codigo,
+    m.nombre,
+    m.facultad,
+    COUNT(*) AS intentos,
+    COUNT(DISTINCT s.estudiante_id) AS estudiantes,
+    COALESCE(SUM(EXTRACT(EPOCH FROM (s.hora_fin - s.hora_inicio)) / 3600), 0)::float8 AS horas_solicitadas,
+    COUNT(*) FILTER (WHERE s.motivo = 'sin_tutores') AS sin_tutores,
+    COUNT(*) FILTER (WHERE s.motivo = 'sin_horario') AS sin_horario,
+    COUNT(*) FILTER (WHERE s.motivo NOT IN ('sin_tutores', 'sin_horario')) AS otros_motivos,
+    (SELECT COUNT(*) FROM TUTOR_MATERIAS tm WHERE tm.materia_id = m.materia_id AND tm.activo = true) AS tutores_activos,
+    MAX(s.fecha_intento)::timestamp AS ultimo_intento
+FROM SOLICITUDES_FALLIDAS s
+JOIN MATERIAS m ON m.materia_id = s.materia_id
+WHERE s.fecha BETWEEN sqlc.arg('periodo_inicio') AND sqlc.arg('periodo_fin')
+GROUP BY m.materia_id, m.codigo, m.nombre, m.facultad
+ORDER BY intentos DESC, m.codigo;
+
+
 
 -- ========================================
 -- ADDITIONAL USEFUL QUERIES FOR URTUTORIAS
@@ -309,7 +704,8 @@ SELECT t.*,
        COUNT(tm.materia_id) as total_materias
 FROM TUTORES t
 LEFT JOIN TUTOR_MATERIAS tm ON t.tutor_id = tm.tutor_id AND tm.activo = true
-LEFT JOIN MATERIAS m ON tm.materia_id = m.materia_id
+LEFT JOIN MATERIAS m ON tm.materia_id = m.materia_id AND m.deleted_at IS NULL
+WHERE t.deleted_at IS NULL
 GROUP BY t.tutor_id, t.nombre, t.apellido, t.correo, t.programa_academico, t.fecha_registro
 ORDER BY t.apellido, t.nombre;
 
@@ -318,7 +714,7 @@ SELECT DISTINCT t.*, d.dia_semana, d.hora_inicio, d.hora_fin
 FROM TUTORES t
 JOIN TUTOR_MATERIAS tm ON t.tutor_id = tm.tutor_id
 JOIN DISPONIBILIDAD d ON t.tutor_id = d.tutor_id
-WHERE tm.materia_id = $1 AND tm.activo = true AND d.dia_semana = $2
+WHERE tm.materia_id = $1 AND tm.activo = true AND d.dia_semana = $2 AND t.deleted_at IS NULL
 ORDER BY d.hora_inicio;
 
 -- ========================================
@@ -328,20 +724,21 @@ ORDER BY d.hora_inicio;
 -- name: CountEstudiantesByPrograma :many
 SELECT programa_academico, COUNT(*) as total_estudiantes
 FROM ESTUDIANTES
+WHERE deleted_at IS NULL
 GROUP BY programa_academico
 ORDER BY total_estudiantes DESC;
 
 -- name: ListEstudiantesBySemestre :many
 SELECT * FROM ESTUDIANTES 
-WHERE semestre = $1 
+WHERE semestre = $1 AND deleted_at IS NULL
 ORDER BY apellido, nombre;
 
 -- name: SelectMateriasByEstudiante :many
--- Get materias corresponding to a specific estudiante's semester
+-- Active materias the estudiante is enrolled in for the academic period.
 SELECT m.*
 FROM MATERIAS m
-JOIN ESTUDIANTES e ON e.programa_academico = m.facultad
-WHERE e.estudiante_id = $1 AND e.semestre = $2
+JOIN INSCRIPCIONES i ON i.materia_id = m.materia_id
+WHERE i.estudiante_id = $1 AND i.periodo = $2 AND m.activo = true AND m.deleted_at IS NULL
 ORDER BY m.codigo;
 
 -- name: GetProximasTutoriasByEstudiante :many
@@ -357,13 +754,14 @@ ORDER BY fecha, hora_inicio;
 -- name: ListMateriaNames :many
 SELECT nombre 
 FROM MATERIAS
+WHERE activo = true AND deleted_at IS NULL
 ORDER BY nombre;
 
 -- name: GetTutorMaterias :many
-SELECT m.materia_id, m.nombre, m.codigo, m.facultad, m.descripcion, m.creditos
+SELECT m.materia_id, m.nombre, m.codigo, m.facultad, m.descripcion, m.creditos, m.activo, m.deleted_at
 FROM MATERIAS m
 JOIN TUTOR_MATERIAS tm ON m.materia_id = tm.materia_id
-WHERE tm.tutor_id = $1 AND tm.activo = true
+WHERE tm.tutor_id = $1 AND tm.activo = true AND m.deleted_at IS NULL
 ORDER BY m.codigo;
 
 -- name: GetTutorNameById :one
@@ -372,5 +770,580 @@ SELECT nombre, apellido FROM TUTORES WHERE tutor_id = $1;
 -- name: CountTutorsWithMaterias :one
 SELECT COUNT(DISTINCT tm.tutor_id) as count
 FROM TUTOR_MATERIAS tm
-WHERE tm.activo = true;
+JOIN TUTORES t ON t.tutor_id = tm.tutor_id
+WHERE tm.activo = true AND t.deleted_at IS NULL;
+
+
+-- ========================================
+-- WEBHOOKS QUERIES
+-- ========================================
+
+-- name: CreateWebhook :one
+INSERT INTO WEBHOOKS (url, secreto, eventos, activo, creado_por)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: SelectWebhookById :one
+SELECT * FROM WEBHOOKS WHERE webhook_id = $1;
+
+-- name: UpdateWebhook :one
+UPDATE WEBHOOKS
+SET url = $2, secreto = $3, eventos = $4, activo = $5
+WHERE webhook_id = $1
+RETURNING *;
+
+-- name: DeleteWebhook :exec
+DELETE FROM WEBHOOKS WHERE webhook_id = $1;
+
+-- name: ListWebhooks :many
+SELECT * FROM WEBHOOKS ORDER BY webhook_id;
+
+-- name: ListWebhooksActivos :many
+SELECT * FROM WEBHOOKS WHERE activo = true ORDER BY webhook_id;
+
+-- name: CreateWebhookEntrega :one
+INSERT INTO WEBHOOK_ENTREGAS (webhook_id, evento, payload)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: SelectWebhookEntregaById :one
+SELECT * FROM WEBHOOK_ENTREGAS WHERE entrega_id = $1;
+
+-- name: ListWebhookEntregasByWebhook :many
+SELECT * FROM WEBHOOK_ENTREGAS
+WHERE webhook_id = $1
+ORDER BY fecha_creacion DESC
+LIMIT $2;
+
+-- name: ListWebhookEntregasPendientes :many
+SELECT e.*, w.url, w.secreto
+FROM WEBHOOK_ENTREGAS e
+JOIN WEBHOOKS w ON e.webhook_id = w.webhook_id
+WHERE e.estado = 'pendiente' AND e.proximo_intento <= CURRENT_TIMESTAMP AND w.activo = true
+ORDER BY e.proximo_intento
+LIMIT $1;
+
+-- name: UpdateWebhookEntregaIntento :one
+-- The next attempt is scheduled on the database clock, the one ListWebhookEntregasPendientes
+-- compares against. A NULL espera_segundos keeps the current proximo_intento.
+UPDATE WEBHOOK_ENTREGAS
+SET estado = sqlc.arg('estado'), intentos = intentos + 1, ultimo_codigo = sqlc.arg('ultimo_codigo'), ultimo_error = sqlc.arg('ultimo_error'),
+    fecha_ultimo_intento = CURRENT_TIMESTAMP,
+    proximo_intento = COALESCE(CURRENT_TIMESTAMP + make_interval(secs => sqlc.narg('espera_segundos')::float8), proximo_intento)
+WHERE entrega_id = sqlc.arg('entrega_id')
+RETURNING *;
+
+-- name: ReenviarWebhookEntrega :one
+UPDATE WEBHOOK_ENTREGAS
+SET estado = 'pendiente', intentos = 0, proximo_intento = CURRENT_TIMESTAMP
+WHERE entrega_id = $1
+RETURNING *;
+
+-- ========================================
+-- CALENDARIO QUERIES
+-- ========================================
+
+-- name: SelectCalendarioToken :one
+SELECT * FROM CALENDARIO_TOKENS WHERE tipo_usuario = $1 AND usuario_id = $2;
+
+-- name: UpsertCalendarioToken :one
+INSERT INTO CALENDARIO_TOKENS (tipo_usuario, usuario_id, token)
+VALUES ($1, $2, $3)
+ON CONFLICT (tipo_usuario, usuario_id) DO UPDATE SET token = EXCLUDED.token, fecha_creacion = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- ========================================
+-- TUTORIA INVITACIONES QUERIES
+-- ========================================
+
+-- name: SelectTutoriaInvitacion :one
+SELECT * FROM TUTORIA_INVITACIONES WHERE tutoria_id = $1;
+
+-- name: UpsertTutoriaInvitacion :one
+INSERT INTO TUTORIA_INVITACIONES (tutoria_id, metodo)
+VALUES ($1, $2)
+ON CONFLICT (tutoria_id) DO UPDATE SET
+    secuencia = TUTORIA_INVITACIONES.secuencia + 1,
+    metodo = EXCLUDED.metodo,
+    fecha_envio = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- ========================================
+-- BUSQUEDA QUERIES
+-- ========================================
+
+-- name: BuscarMaterias :many
+-- Ranks active materias by trigram word similarity of q against nombre, codigo, facultad and
+-- descripcion, ignoring case and accents, with a bonus for Spanish full-text matches.
+-- Candidates are the union of a trigram branch and a full-text branch, each written to match
+-- its index (idx_materias_busqueda and idx_materias_busqueda_texto).
+WITH candidatas AS (
+    SELECT materia_id FROM MATERIAS
+    WHERE f_unaccent(lower(sqlc.arg('q')::text)) <% f_unaccent(lower(nombre || ' ' || codigo || ' ' || facultad || ' ' || COALESCE(descripcion, '')))
+    UNION
+    SELECT materia_id FROM MATERIAS
+    WHERE to_tsvector('spanish', f_unaccent(lower(nombre || ' ' || codigo || ' ' || facultad || ' ' || COALESCE(descripcion, ''))))
+          @@ plainto_tsquery('spanish', f_unaccent(lower(sqlc.arg('q')::text)))
+)
+SELECT m.materia_id, m.nombre, m.codigo, m.facultad, m.descripcion, m.creditos,
+       (GREATEST(
+           word_similarity(b.q, f_unaccent(lower(m.nombre))),
+           word_similarity(b.q, lower(m.codigo)),
+           word_similarity(b.q, f_unaccent(lower(m.facultad))) * 0.8,
+           word_similarity(b.q, f_unaccent(lower(COALESCE(m.descripcion, '')))) * 0.6
+       ) + CASE WHEN to_tsvector('spanish', b.doc) @@ plainto_tsquery('spanish', b.q) THEN 0.5 ELSE 0 END)::real AS rank
+FROM candidatas c
+JOIN MATERIAS m ON m.materia_id = c.materia_id
+CROSS JOIN LATERAL (
+    SELECT f_unaccent(lower(sqlc.arg('q')::text)) AS q,
+           f_unaccent(lower(m.nombre || ' ' || m.codigo || ' ' || m.facultad || ' ' || COALESCE(m.descripcion, ''))) AS doc
+) b
+WHERE m.activo AND m.deleted_at IS NULL
+ORDER BY rank DESC, m.nombre
+LIMIT sqlc.arg('row_limit');
+
+-- name: BuscarTutores :many
+-- Ranks tutors by word similarity of q against their name and the materias they teach,
+-- ignoring case and accents. Candidates are the union of the tutors whose name matches
+-- (idx_tutores_busqueda) and those teaching a materia whose nombre matches (idx_materias_nombre_busqueda).
+WITH candidatos AS (
+    SELECT tutor_id FROM TUTORES
+    WHERE f_unaccent(lower(sqlc.arg('q')::text)) <% f_unaccent(lower(nombre || ' ' || apellido))
+    UNION
+    SELECT tm.tutor_id
+    FROM MATERIAS m
+    JOIN TUTOR_MATERIAS tm ON tm.materia_id = m.materia_id
+    WHERE f_unaccent(lower(sqlc.arg('q')::text)) <% f_unaccent(lower(m.nombre))
+      AND tm.activo AND m.activo AND m.deleted_at IS NULL
+)
+SELECT t.tutor_id, t.nombre, t.apellido, t.correo, t.programa_academico,
+       COALESCE(mt.materias, '{}')::text[] AS materias,
+       GREATEST(
+           word_similarity(b.q, f_unaccent(lower(t.nombre || ' ' || t.apellido))),
+           word_similarity(b.q, f_unaccent(lower(COALESCE(array_to_string(mt.materias, ' '), '')))) * 0.8
+       )::real AS rank
+FROM candidatos c
+JOIN TUTORES t ON t.tutor_id = c.tutor_id
+LEFT JOIN LATERAL (
+    SELECT array_agg(m.nombre ORDER BY m.nombre) AS materias
+    FROM TUTOR_MATERIAS tm
+    JOIN MATERIAS m ON tm.materia_id = m.materia_id
+    WHERE tm.tutor_id = t.tutor_id AND tm.activo AND m.activo AND m.deleted_at IS NULL
+) mt ON true
+CROSS JOIN LATERAL (
+    SELECT f_unaccent(lower(sqlc.arg('q')::text)) AS q
+) b
+WHERE t.deleted_at IS NULL
+ORDER BY rank DESC, t.apellido, t.nombre
+LIMIT sqlc.arg('row_limit');
+
+-- ========================================
+-- REPORTE PROGRAMACIONES QUERIES
+-- ========================================
+
+-- name: CreateReporteProgramacion :one
+INSERT INTO REPORTE_PROGRAMACIONES (nombre, tipo_reporte, cron, periodo, destinatarios, adjuntar_pdf, activo, creado_por, proxima_ejecucion)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: SelectReporteProgramacionById :one
+SELECT * FROM REPORTE_PROGRAMACIONES WHERE programacion_id = $1;
+
+-- name: UpdateReporteProgramacion :one
+UPDATE REPORTE_PROGRAMACIONES
+SET nombre = $2, tipo_reporte = $3, cron = $4, periodo = $5, destinatarios = $6,
+    adjuntar_pdf = $7, activo = $8, proxima_ejecucion = $9
+WHERE programacion_id = $1
+RETURNING *;
+
+-- name: DeleteReporteProgramacion :exec
+DELETE FROM REPORTE_PROGRAMACIONES WHERE programacion_id = $1;
+
+-- name: ListReporteProgramaciones :many
+SELECT * FROM REPORTE_PROGRAMACIONES ORDER BY programacion_id;
+
+-- name: ListReporteProgramacionesPendientes :many
+SELECT * FROM REPORTE_PROGRAMACIONES
+WHERE activo = true AND proxima_ejecucion <= $1
+ORDER BY proxima_ejecucion, programacion_id;
+
+-- name: AdvanceReporteProgramacion :exec
+UPDATE REPORTE_PROGRAMACIONES
+SET ultima_ejecucion = $2, proxima_ejecucion = $3
+WHERE programacion_id = $1;
+
+-- ========================================
+-- ANALYTICS QUERIES
+-- ========================================
+
+-- name: AnalyticsSesiones :many
+-- Tutorias per day or week (agrupacion is 'day' or 'week'), including periods without tutorias.
+-- Weeks start on Monday.
+SELECT
+    s.periodo::date AS periodo,
+    COUNT(t.tutoria_id) AS total,
+    COUNT(t.tutoria_id) FILTER (WHERE t.estado = 'solicitada') AS solicitadas,
+    COUNT(t.tutoria_id) FILTER (WHERE t.estado = 'confirmada') AS confirmadas,
+    COUNT(t.tutoria_id) FILTER (WHERE t.estado = 'cancelada') AS canceladas,
+    COUNT(t.tutoria_id) FILTER (WHERE t.estado = 'completada') AS completadas
+FROM generate_series(
+    date_trunc(sqlc.arg('agrupacion')::text, sqlc.arg('desde')::date::timestamp),
+    sqlc.arg('hasta')::date::timestamp,
+    ('1 ' || sqlc.arg('agrupacion')::text)::interval
+) AS s(periodo)
+LEFT JOIN TUTORIAS t ON date_trunc(sqlc.arg('agrupacion')::text, t.fecha::timestamp) = s.periodo
+    AND t.fecha BETWEEN sqlc.arg('desde')::date AND sqlc.arg('hasta')::date
+GROUP BY s.periodo
+ORDER BY s.periodo;
+
+-- name: AnalyticsDemandaOferta :many
+-- Requested hours of tutoring per active materia against the hours its active tutors are
+-- available on the days of the period. A tutor's availability counts for every materia they teach.
+WITH dias AS (
+    SELECT EXTRACT(ISODOW FROM d)::int AS dia_semana, COUNT(*) AS n
+    FROM generate_series(sqlc.arg('desde')::date, sqlc.arg('hasta')::date, '1 day'::interval) AS d
+    GROUP BY 1
+), oferta AS (
+    SELECT
+        tm.materia_id,
+        COUNT(DISTINCT tm.tutor_id) AS tutores,
+        SUM(EXTRACT(EPOCH FROM (dp.hora_fin - dp.hora_inicio)) / 3600 * dias.n) AS horas
+    FROM TUTOR_MATERIAS tm
+    JOIN TUTORES t ON t.tutor_id = tm.tutor_id
+    LEFT JOIN DISPONIBILIDAD dp ON dp.tutor_id = tm.tutor_id
+    LEFT JOIN dias ON dias.dia_semana = dp.dia_semana
+    WHERE tm.activo = true AND t.deleted_at IS NULL
+    GROUP BY tm.materia_id
+), demanda AS (
+    SELECT
+        materia_id,
+        COUNT(*) AS solicitudes,
+        SUM(EXTRACT(EPOCH FROM (hora_fin - hora_inicio)) / 3600) AS horas
+    FROM TUTORIAS
+    WHERE fecha BETWEEN sqlc.arg('desde')::date AND sqlc.arg('hasta')::date
+    GROUP BY materia_id
+)
+SELECT
+    m.materia_id,
+    m.codigo,
+    m.nombre,
+    m.facultad,
+    COALESCE(d.solicitudes, 0)::bigint AS solicitudes,
+    COALESCE(d.horas, 0)::float8 AS horas_solicitadas,
+    COALESCE(o.tutores, 0)::bigint AS tutores,
+    COALESCE(o.horas, 0)::float8 AS horas_disponibles
+FROM MATERIAS m
+LEFT JOIN demanda d ON d.materia_id = m.materia_id
+LEFT JOIN oferta o ON o.materia_id = m.materia_id
+WHERE m.activo = true AND m.deleted_at IS NULL
+ORDER BY COALESCE(d.horas, 0) - COALESCE(o.horas, 0) DESC, m.codigo;
+
+-- name: AnalyticsHorasPico :many
+-- Non-cancelled tutorias per day of the week (1 = Monday) and starting hour, every cell included.
+SELECT
+    d.dia_semana::int AS dia_semana,
+    h.hora::int AS hora,
+    COUNT(t.tutoria_id) AS tutorias
+FROM generate_series(1, 7) AS d(dia_semana)
+CROSS JOIN generate_series(0, 23) AS h(hora)
+LEFT JOIN TUTORIAS t ON EXTRACT(ISODOW FROM t.fecha) = d.dia_semana
+    AND EXTRACT(HOUR FROM t.hora_inicio) = h.hora
+    AND t.fecha BETWEEN sqlc.arg('desde')::date AND sqlc.arg('hasta')::date
+    AND t.estado <> 'cancelada'
+GROUP BY d.dia_semana, h.hora
+ORDER BY d.dia_semana, h.hora;
+
+-- name: AnalyticsAnticipacion :one
+-- Hours between the request of a tutoria and its start, for the tutorias dated within the period.
+SELECT
+    COUNT(*) AS tutorias,
+    COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY x.horas), 0)::float8 AS mediana_horas,
+    COALESCE(percentile_cont(0.25) WITHIN GROUP (ORDER BY x.horas), 0)::float8 AS p25_horas,
+    COALESCE(percentile_cont(0.75) WITHIN GROUP (ORDER BY x.horas), 0)::float8 AS p75_horas,
+    COALESCE(AVG(x.horas), 0)::float8 AS promedio_horas
+FROM (
+    SELECT (EXTRACT(EPOCH FROM (t.fecha + t.hora_inicio - t.fecha_solicitud)) / 3600)::float8 AS horas
+    FROM TUTORIAS t
+    WHERE t.fecha BETWEEN sqlc.arg('desde')::date AND sqlc.arg('hasta')::date
+      AND (sqlc.narg('materia_id')::int IS NULL OR t.materia_id = sqlc.narg('materia_id'))
+) x;
+
+-- name: AnalyticsLatenciaConfirmacion :one
+-- Hours between the request of a tutoria and its confirmation by the tutor, for the confirmed
+-- tutorias dated within the period.
+SELECT
+    COUNT(*) AS tutorias,
+    COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY x.horas), 0)::float8 AS mediana_horas,
+    COALESCE(percentile_cont(0.25) WITHIN GROUP (ORDER BY x.horas), 0)::float8 AS p25_horas,
+    COALESCE(percentile_cont(0.75) WITHIN GROUP (ORDER BY x.horas), 0)::float8 AS p75_horas,
+    COALESCE(AVG(x.horas), 0)::float8 AS promedio_horas
+FROM (
+    SELECT (EXTRACT(EPOCH FROM (t.fecha_confirmacion - t.fecha_solicitud)) / 3600)::float8 AS horas
+    FROM TUTORIAS t
+    WHERE t.fecha BETWEEN sqlc.arg('desde')::date AND sqlc.arg('hasta')::date
+      AND t.fecha_confirmacion IS NOT NULL
+      AND (sqlc.narg('materia_id')::int IS NULL OR t.materia_id = sqlc.narg('materia_id'))
+) x;
+
+-- ========================================
+-- SOLICITUDES FALLIDAS QUERIES
+-- ========================================
+
+-- name: CreateSolicitudFallida :exec
+-- Unknown estudiante and tutor IDs are stored as NULL so that the attempt is always recorded.
+INSERT INTO SOLICITUDES_FALLIDAS (estudiante_id, materia_id, tutor_id, fecha, hora_inicio, hora_fin, motivo)
+VALUES (
+    (SELECT estudiante_id FROM ESTUDIANTES WHERE estudiante_id = sqlc.arg('estudiante_id')),
+    sqlc.arg('materia_id'),
+    (SELECT tutor_id FROM TUTORES WHERE tutor_id = sqlc.narg('tutor_id')),
+    sqlc.arg('fecha'),
+    sqlc.arg('hora_inicio'),
+    sqlc.arg('hora_fin'),
+    sqlc.arg('motivo')
+);
+
+-- name: ListSolicitudesFallidas :many
+SELECT s.*, m.codigo AS materia_codigo, m.nombre AS materia
+FROM SOLICITUDES_FALLIDAS s
+JOIN MATERIAS m ON m.materia_id = s.materia_id
+WHERE (sqlc.narg('materia_id')::int IS NULL OR s.materia_id = sqlc.narg('materia_id'))
+  AND (sqlc.narg('motivo')::text IS NULL OR s.motivo = sqlc.narg('motivo'))
+  AND (sqlc.narg('desde')::date IS NULL OR s.fecha_intento >= sqlc.narg('desde'))
+  AND (sqlc.narg('hasta')::date IS NULL OR s.fecha_intento < sqlc.narg('hasta')::date + 1)
+ORDER BY s.fecha_intento DESC, s.solicitud_id DESC
+LIMIT sqlc.arg('row_limit');
+
+-- ========================================
+-- TUTOR LIMITES QUERIES
+-- ========================================
+
+-- name: SelectTutorLimites :one
+SELECT * FROM TUTOR_LIMITES WHERE tutor_id = $1;
+
+-- name: LockTutor :one
+-- Locks the tutor until the end of the transaction, so that concurrent bookings are checked
+-- against their hour limits one at a time.
+SELECT tutor_id FROM TUTORES WHERE tutor_id = $1 FOR UPDATE;
+
+-- name: UpsertTutorLimites :one
+INSERT INTO TUTOR_LIMITES (tutor_id, horas_semana, horas_mes)
+VALUES ($1, $2, $3)
+ON CONFLICT (tutor_id) DO UPDATE SET
+    horas_semana = EXCLUDED.horas_semana,
+    horas_mes = EXCLUDED.horas_mes,
+    fecha_actualizacion = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: SelectTutorHoras :one
+-- Hours of the tutor's tutorias dated within the period, by estado. Cancelled tutorias are not counted.
+SELECT
+    COALESCE(SUM(EXTRACT(EPOCH FROM (hora_fin - hora_inicio)) / 3600) FILTER (WHERE estado = 'completada'), 0)::float8 AS horas_completadas,
+    COALESCE(SUM(EXTRACT(EPOCH FROM (hora_fin - hora_inicio)) / 3600) FILTER (WHERE estado = 'confirmada'), 0)::float8 AS horas_confirmadas,
+    COALESCE(SUM(EXTRACT(EPOCH FROM (hora_fin - hora_inicio)) / 3600) FILTER (WHERE estado = 'solicitada'), 0)::float8 AS horas_pendientes,
+    COUNT(*) FILTER (WHERE estado = 'completada') AS tutorias_completadas,
+    COUNT(*) FILTER (WHERE estado = 'confirmada') AS tutorias_confirmadas,
+    COUNT(*) FILTER (WHERE estado = 'solicitada') AS tutorias_pendientes
+FROM TUTORIAS
+WHERE tutor_id = sqlc.arg('tutor_id')
+  AND fecha BETWEEN sqlc.arg('desde')::date AND sqlc.arg('hasta')::date;
+
+-- ========================================
+-- CERTIFICADOS QUERIES
+-- ========================================
+
+-- name: ListTutoriasCertificables :many
+-- Completed tutorias of the tutor with confirmed attendance, dated within the period.
+SELECT
+    t.tutoria_id,
+    t.fecha,
+    t.hora_inicio,
+    t.hora_fin,
+    m.materia_id,
+    m.codigo AS materia_codigo,
+    m.nombre AS materia,
+    (EXTRACT(EPOCH FROM (t.hora_fin - t.hora_inicio)) / 60)::int AS minutos
+FROM TUTORIAS t
+JOIN MATERIAS m ON m.materia_id = t.materia_id
+WHERE t.tutor_id = sqlc.arg('tutor_id')
+  AND t.estado = 'completada'
+  AND t.asistencia_confirmada
+  AND t.fecha BETWEEN sqlc.arg('desde')::date AND sqlc.arg('hasta')::date
+ORDER BY m.nombre, t.fecha, t.hora_inicio;
+
+-- name: CreateCertificado :one
+INSERT INTO CERTIFICADOS (codigo, tutor_id, tutor_nombre, desde, hasta, total_tutorias, total_minutos, detalle, firma)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: SelectCertificadoByCodigo :one
+SELECT * FROM CERTIFICADOS WHERE codigo = $1;
+
+-- name: SelectUltimoCertificado :one
+-- Most recent certificate issued to the tutor for exactly this period.
+SELECT * FROM CERTIFICADOS
+WHERE tutor_id = $1 AND desde = $2 AND hasta = $3
+ORDER BY fecha_emision DESC, certificado_id DESC
+LIMIT 1;
+
+-- ========================================
+-- TUTOR POSTULACIONES QUERIES
+-- ========================================
+
+-- name: SelectMateriaRequisitos :one
+SELECT * FROM MATERIA_REQUISITOS WHERE materia_id = $1;
+
+-- name: UpsertMateriaRequisitos :one
+INSERT INTO MATERIA_REQUISITOS (materia_id, semestre_minimo, programas, requiere_aprobacion)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (materia_id) DO UPDATE SET
+    semestre_minimo = EXCLUDED.semestre_minimo,
+    programas = EXCLUDED.programas,
+    requiere_aprobacion = EXCLUDED.requiere_aprobacion,
+    fecha_actualizacion = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: CreateTutorPostulacion :one
+INSERT INTO TUTOR_POSTULACIONES (tutor_id, materia_id, comentario)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: SelectTutorPostulacionById :one
+SELECT * FROM TUTOR_POSTULACIONES WHERE postulacion_id = $1;
+
+-- name: ListTutorPostulaciones :many
+SELECT p.*,
+    (t.nombre || ' ' || t.apellido)::text AS tutor,
+    m.codigo AS materia_codigo,
+    m.nombre AS materia
+FROM TUTOR_POSTULACIONES p
+JOIN TUTORES t ON t.tutor_id = p.tutor_id
+JOIN MATERIAS m ON m.materia_id = p.materia_id
+WHERE (sqlc.narg('estado')::text IS NULL OR p.estado = sqlc.narg('estado'))
+  AND (sqlc.narg('tutor_id')::int IS NULL OR p.tutor_id = sqlc.narg('tutor_id'))
+  AND (sqlc.narg('materia_id')::int IS NULL OR p.materia_id = sqlc.narg('materia_id'))
+ORDER BY p.fecha_postulacion DESC, p.postulacion_id DESC
+LIMIT sqlc.arg('row_limit');
+
+-- name: ReviewTutorPostulacion :one
+-- Returns no rows when the postulacion was already reviewed.
+UPDATE TUTOR_POSTULACIONES
+SET estado = sqlc.arg('estado'),
+    motivo_rechazo = sqlc.narg('motivo_rechazo'),
+    revisado_por = sqlc.narg('revisado_por'),
+    fecha_revision = CURRENT_TIMESTAMP
+WHERE postulacion_id = sqlc.arg('postulacion_id') AND estado = 'pendiente'
+RETURNING *;
+
+-- ========================================
+-- SOLICITUDES TUTOR QUERIES
+-- ========================================
+
+-- name: CreateSolicitudTutor :one
+INSERT INTO SOLICITUDES_TUTOR (estudiante_id, motivacion, materias)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: SelectSolicitudTutorById :one
+SELECT * FROM SOLICITUDES_TUTOR WHERE solicitud_id = $1;
+
+-- name: ListSolicitudesTutor :many
+SELECT s.*,
+    (e.nombre || ' ' || e.apellido)::text AS estudiante,
+    e.correo,
+    e.programa_academico,
+    e.semestre
+FROM SOLICITUDES_TUTOR s
+JOIN ESTUDIANTES e ON e.estudiante_id = s.estudiante_id
+WHERE (sqlc.narg('estado')::text IS NULL OR s.estado = sqlc.narg('estado'))
+  AND (sqlc.narg('estudiante_id')::int IS NULL OR s.estudiante_id = sqlc.narg('estudiante_id'))
+ORDER BY s.fecha_solicitud DESC, s.solicitud_id DESC
+LIMIT sqlc.arg('row_limit');
+
+-- name: ReviewSolicitudTutor :one
+-- Returns no rows when the solicitud was already reviewed.
+UPDATE SOLICITUDES_TUTOR
+SET estado = sqlc.arg('estado'),
+    motivo_rechazo = sqlc.narg('motivo_rechazo'),
+    revisado_por = sqlc.narg('revisado_por'),
+    tutor_id = sqlc.narg('tutor_id'),
+    fecha_revision = CURRENT_TIMESTAMP
+WHERE solicitud_id = sqlc.arg('solicitud_id') AND estado = 'pendiente'
+RETURNING *;
+
+-- ========================================
+-- INSCRIPCIONES QUERIES
+-- ========================================
+
+-- name: CreateInscripcion :one
+INSERT INTO INSCRIPCIONES (estudiante_id, materia_id, periodo)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: SelectInscripcionById :one
+SELECT * FROM INSCRIPCIONES WHERE inscripcion_id = $1;
+
+-- name: DeleteInscripcion :execrows
+DELETE FROM INSCRIPCIONES WHERE inscripcion_id = $1;
+
+-- name: ListInscripciones :many
+SELECT i.*,
+    (e.nombre || ' ' || e.apellido)::text AS estudiante,
+    e.correo,
+    m.codigo AS materia_codigo,
+    m.nombre AS materia
+FROM INSCRIPCIONES i
+JOIN ESTUDIANTES e ON e.estudiante_id = i.estudiante_id
+JOIN MATERIAS m ON m.materia_id = i.materia_id
+WHERE (sqlc.narg('estudiante_id')::int IS NULL OR i.estudiante_id = sqlc.narg('estudiante_id'))
+  AND (sqlc.narg('materia_id')::int IS NULL OR i.materia_id = sqlc.narg('materia_id'))
+  AND (sqlc.narg('periodo')::text IS NULL OR i.periodo = sqlc.narg('periodo'))
+ORDER BY i.periodo DESC, m.codigo, e.apellido, e.nombre
+LIMIT sqlc.arg('row_limit');
+
+-- name: EstudianteInscrito :one
+SELECT EXISTS (
+    SELECT 1 FROM INSCRIPCIONES
+    WHERE estudiante_id = $1 AND materia_id = $2 AND periodo = $3
+);
+
+-- name: UpsertInscripcion :one
+-- Imports are idempotent: an existing inscripcion is returned unchanged.
+INSERT INTO INSCRIPCIONES (estudiante_id, materia_id, periodo)
+VALUES ($1, $2, $3)
+ON CONFLICT (estudiante_id, materia_id, periodo) DO UPDATE SET periodo = EXCLUDED.periodo
+RETURNING inscripcion_id, (xmax = 0) AS creado;
+
+-- ========================================
+-- PAPELERA QUERIES
+-- ========================================
+
+-- name: ListPapelera :many
+-- Estudiantes, tutores and materias moved to the papelera, most recently deleted first.
+SELECT 'estudiante'::text AS tipo, estudiante_id AS id, (nombre || ' ' || apellido)::text AS nombre, correo AS detalle, deleted_at
+FROM ESTUDIANTES WHERE deleted_at IS NOT NULL
+UNION ALL
+SELECT 'tutor'::text, tutor_id, (nombre || ' ' || apellido)::text, correo, deleted_at
+FROM TUTORES WHERE deleted_at IS NOT NULL
+UNION ALL
+SELECT 'materia'::text, materia_id, nombre, codigo, deleted_at
+FROM MATERIAS WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, tipo, id;
+
+-- ========================================
+-- AUDITORIA QUERIES
+-- ========================================
 
+-- name: CreateAuditoriaEvento :exec
+INSERT INTO AUDITORIA_EVENTOS (admin_id, actor, metodo, ruta, entidad, entidad_id, estado_http, antes, despues, ip)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: ListAuditoriaEventos :many
+SELECT * FROM AUDITORIA_EVENTOS
+WHERE (sqlc.narg('entidad')::text IS NULL OR entidad = sqlc.narg('entidad'))
+  AND (sqlc.narg('entidad_id')::int IS NULL OR entidad_id = sqlc.narg('entidad_id'))
+  AND (sqlc.narg('admin_id')::int IS NULL OR admin_id = sqlc.narg('admin_id'))
+  AND (sqlc.narg('metodo')::text IS NULL OR metodo = sqlc.narg('metodo'))
+  AND (sqlc.narg('desde')::date IS NULL OR fecha >= sqlc.narg('desde'))
+  AND (sqlc.narg('hasta')::date IS NULL OR fecha < sqlc.narg('hasta')::date + 1)
+ORDER BY fecha DESC, evento_id DESC
+LIMIT sqlc.arg('row_limit');